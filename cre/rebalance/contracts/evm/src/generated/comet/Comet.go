@@ -51,7 +51,7 @@ var (
 )
 
 var CometMetaData = &bind.MetaData{
-	ABI: "[{\"inputs\":[],\"name\":\"totalSupply\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"totalBorrow\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"utilization\",\"type\":\"uint256\"}],\"name\":\"getSupplyRate\",\"outputs\":[{\"internalType\":\"uint64\",\"name\":\"\",\"type\":\"uint64\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
+	ABI: "[{\"inputs\":[],\"name\":\"totalSupply\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"totalBorrow\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"utilization\",\"type\":\"uint256\"}],\"name\":\"getSupplyRate\",\"outputs\":[{\"internalType\":\"uint64\",\"name\":\"\",\"type\":\"uint64\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"baseToken\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"baseTrackingSupplySpeed\",\"outputs\":[{\"internalType\":\"uint64\",\"name\":\"\",\"type\":\"uint64\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"trackingIndexScale\",\"outputs\":[{\"internalType\":\"uint64\",\"name\":\"\",\"type\":\"uint64\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"decimals\",\"outputs\":[{\"internalType\":\"uint8\",\"name\":\"\",\"type\":\"uint8\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"isSupplyPaused\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"isWithdrawPaused\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"supplyKink\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"supplyPerSecondInterestRateBase\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"supplyPerSecondInterestRateSlopeLow\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"supplyPerSecondInterestRateSlopeHigh\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
 }
 
 // Structs
@@ -85,12 +85,32 @@ type Comet struct {
 }
 
 type CometCodec interface {
+	EncodeBaseTokenMethodCall() ([]byte, error)
+	DecodeBaseTokenMethodOutput(data []byte) (common.Address, error)
+	EncodeBaseTrackingSupplySpeedMethodCall() ([]byte, error)
+	DecodeBaseTrackingSupplySpeedMethodOutput(data []byte) (uint64, error)
+	EncodeDecimalsMethodCall() ([]byte, error)
+	DecodeDecimalsMethodOutput(data []byte) (uint8, error)
 	EncodeGetSupplyRateMethodCall(in GetSupplyRateInput) ([]byte, error)
 	DecodeGetSupplyRateMethodOutput(data []byte) (uint64, error)
+	EncodeIsSupplyPausedMethodCall() ([]byte, error)
+	DecodeIsSupplyPausedMethodOutput(data []byte) (bool, error)
+	EncodeIsWithdrawPausedMethodCall() ([]byte, error)
+	DecodeIsWithdrawPausedMethodOutput(data []byte) (bool, error)
+	EncodeSupplyKinkMethodCall() ([]byte, error)
+	DecodeSupplyKinkMethodOutput(data []byte) (*big.Int, error)
+	EncodeSupplyPerSecondInterestRateBaseMethodCall() ([]byte, error)
+	DecodeSupplyPerSecondInterestRateBaseMethodOutput(data []byte) (*big.Int, error)
+	EncodeSupplyPerSecondInterestRateSlopeHighMethodCall() ([]byte, error)
+	DecodeSupplyPerSecondInterestRateSlopeHighMethodOutput(data []byte) (*big.Int, error)
+	EncodeSupplyPerSecondInterestRateSlopeLowMethodCall() ([]byte, error)
+	DecodeSupplyPerSecondInterestRateSlopeLowMethodOutput(data []byte) (*big.Int, error)
 	EncodeTotalBorrowMethodCall() ([]byte, error)
 	DecodeTotalBorrowMethodOutput(data []byte) (*big.Int, error)
 	EncodeTotalSupplyMethodCall() ([]byte, error)
 	DecodeTotalSupplyMethodOutput(data []byte) (*big.Int, error)
+	EncodeTrackingIndexScaleMethodCall() ([]byte, error)
+	DecodeTrackingIndexScaleMethodOutput(data []byte) (uint64, error)
 }
 
 func NewComet(
@@ -127,6 +147,72 @@ func NewCodec() (CometCodec, error) {
 	return &Codec{abi: &parsed}, nil
 }
 
+func (c *Codec) EncodeBaseTokenMethodCall() ([]byte, error) {
+	return c.abi.Pack("baseToken")
+}
+
+func (c *Codec) DecodeBaseTokenMethodOutput(data []byte) (common.Address, error) {
+	vals, err := c.abi.Methods["baseToken"].Outputs.Unpack(data)
+	if err != nil {
+		return *new(common.Address), err
+	}
+	jsonData, err := json.Marshal(vals[0])
+	if err != nil {
+		return *new(common.Address), fmt.Errorf("failed to marshal ABI result: %w", err)
+	}
+
+	var result common.Address
+	if err := json.Unmarshal(jsonData, &result); err != nil {
+		return *new(common.Address), fmt.Errorf("failed to unmarshal to common.Address: %w", err)
+	}
+
+	return result, nil
+}
+
+func (c *Codec) EncodeBaseTrackingSupplySpeedMethodCall() ([]byte, error) {
+	return c.abi.Pack("baseTrackingSupplySpeed")
+}
+
+func (c *Codec) DecodeBaseTrackingSupplySpeedMethodOutput(data []byte) (uint64, error) {
+	vals, err := c.abi.Methods["baseTrackingSupplySpeed"].Outputs.Unpack(data)
+	if err != nil {
+		return *new(uint64), err
+	}
+	jsonData, err := json.Marshal(vals[0])
+	if err != nil {
+		return *new(uint64), fmt.Errorf("failed to marshal ABI result: %w", err)
+	}
+
+	var result uint64
+	if err := json.Unmarshal(jsonData, &result); err != nil {
+		return *new(uint64), fmt.Errorf("failed to unmarshal to uint64: %w", err)
+	}
+
+	return result, nil
+}
+
+func (c *Codec) EncodeDecimalsMethodCall() ([]byte, error) {
+	return c.abi.Pack("decimals")
+}
+
+func (c *Codec) DecodeDecimalsMethodOutput(data []byte) (uint8, error) {
+	vals, err := c.abi.Methods["decimals"].Outputs.Unpack(data)
+	if err != nil {
+		return *new(uint8), err
+	}
+	jsonData, err := json.Marshal(vals[0])
+	if err != nil {
+		return *new(uint8), fmt.Errorf("failed to marshal ABI result: %w", err)
+	}
+
+	var result uint8
+	if err := json.Unmarshal(jsonData, &result); err != nil {
+		return *new(uint8), fmt.Errorf("failed to unmarshal to uint8: %w", err)
+	}
+
+	return result, nil
+}
+
 func (c *Codec) EncodeGetSupplyRateMethodCall(in GetSupplyRateInput) ([]byte, error) {
 	return c.abi.Pack("getSupplyRate", in.Utilization)
 }
@@ -149,6 +235,138 @@ func (c *Codec) DecodeGetSupplyRateMethodOutput(data []byte) (uint64, error) {
 	return result, nil
 }
 
+func (c *Codec) EncodeIsSupplyPausedMethodCall() ([]byte, error) {
+	return c.abi.Pack("isSupplyPaused")
+}
+
+func (c *Codec) DecodeIsSupplyPausedMethodOutput(data []byte) (bool, error) {
+	vals, err := c.abi.Methods["isSupplyPaused"].Outputs.Unpack(data)
+	if err != nil {
+		return *new(bool), err
+	}
+	jsonData, err := json.Marshal(vals[0])
+	if err != nil {
+		return *new(bool), fmt.Errorf("failed to marshal ABI result: %w", err)
+	}
+
+	var result bool
+	if err := json.Unmarshal(jsonData, &result); err != nil {
+		return *new(bool), fmt.Errorf("failed to unmarshal to bool: %w", err)
+	}
+
+	return result, nil
+}
+
+func (c *Codec) EncodeIsWithdrawPausedMethodCall() ([]byte, error) {
+	return c.abi.Pack("isWithdrawPaused")
+}
+
+func (c *Codec) DecodeIsWithdrawPausedMethodOutput(data []byte) (bool, error) {
+	vals, err := c.abi.Methods["isWithdrawPaused"].Outputs.Unpack(data)
+	if err != nil {
+		return *new(bool), err
+	}
+	jsonData, err := json.Marshal(vals[0])
+	if err != nil {
+		return *new(bool), fmt.Errorf("failed to marshal ABI result: %w", err)
+	}
+
+	var result bool
+	if err := json.Unmarshal(jsonData, &result); err != nil {
+		return *new(bool), fmt.Errorf("failed to unmarshal to bool: %w", err)
+	}
+
+	return result, nil
+}
+
+func (c *Codec) EncodeSupplyKinkMethodCall() ([]byte, error) {
+	return c.abi.Pack("supplyKink")
+}
+
+func (c *Codec) DecodeSupplyKinkMethodOutput(data []byte) (*big.Int, error) {
+	vals, err := c.abi.Methods["supplyKink"].Outputs.Unpack(data)
+	if err != nil {
+		return *new(*big.Int), err
+	}
+	jsonData, err := json.Marshal(vals[0])
+	if err != nil {
+		return *new(*big.Int), fmt.Errorf("failed to marshal ABI result: %w", err)
+	}
+
+	var result *big.Int
+	if err := json.Unmarshal(jsonData, &result); err != nil {
+		return *new(*big.Int), fmt.Errorf("failed to unmarshal to *big.Int: %w", err)
+	}
+
+	return result, nil
+}
+
+func (c *Codec) EncodeSupplyPerSecondInterestRateBaseMethodCall() ([]byte, error) {
+	return c.abi.Pack("supplyPerSecondInterestRateBase")
+}
+
+func (c *Codec) DecodeSupplyPerSecondInterestRateBaseMethodOutput(data []byte) (*big.Int, error) {
+	vals, err := c.abi.Methods["supplyPerSecondInterestRateBase"].Outputs.Unpack(data)
+	if err != nil {
+		return *new(*big.Int), err
+	}
+	jsonData, err := json.Marshal(vals[0])
+	if err != nil {
+		return *new(*big.Int), fmt.Errorf("failed to marshal ABI result: %w", err)
+	}
+
+	var result *big.Int
+	if err := json.Unmarshal(jsonData, &result); err != nil {
+		return *new(*big.Int), fmt.Errorf("failed to unmarshal to *big.Int: %w", err)
+	}
+
+	return result, nil
+}
+
+func (c *Codec) EncodeSupplyPerSecondInterestRateSlopeHighMethodCall() ([]byte, error) {
+	return c.abi.Pack("supplyPerSecondInterestRateSlopeHigh")
+}
+
+func (c *Codec) DecodeSupplyPerSecondInterestRateSlopeHighMethodOutput(data []byte) (*big.Int, error) {
+	vals, err := c.abi.Methods["supplyPerSecondInterestRateSlopeHigh"].Outputs.Unpack(data)
+	if err != nil {
+		return *new(*big.Int), err
+	}
+	jsonData, err := json.Marshal(vals[0])
+	if err != nil {
+		return *new(*big.Int), fmt.Errorf("failed to marshal ABI result: %w", err)
+	}
+
+	var result *big.Int
+	if err := json.Unmarshal(jsonData, &result); err != nil {
+		return *new(*big.Int), fmt.Errorf("failed to unmarshal to *big.Int: %w", err)
+	}
+
+	return result, nil
+}
+
+func (c *Codec) EncodeSupplyPerSecondInterestRateSlopeLowMethodCall() ([]byte, error) {
+	return c.abi.Pack("supplyPerSecondInterestRateSlopeLow")
+}
+
+func (c *Codec) DecodeSupplyPerSecondInterestRateSlopeLowMethodOutput(data []byte) (*big.Int, error) {
+	vals, err := c.abi.Methods["supplyPerSecondInterestRateSlopeLow"].Outputs.Unpack(data)
+	if err != nil {
+		return *new(*big.Int), err
+	}
+	jsonData, err := json.Marshal(vals[0])
+	if err != nil {
+		return *new(*big.Int), fmt.Errorf("failed to marshal ABI result: %w", err)
+	}
+
+	var result *big.Int
+	if err := json.Unmarshal(jsonData, &result); err != nil {
+		return *new(*big.Int), fmt.Errorf("failed to unmarshal to *big.Int: %w", err)
+	}
+
+	return result, nil
+}
+
 func (c *Codec) EncodeTotalBorrowMethodCall() ([]byte, error) {
 	return c.abi.Pack("totalBorrow")
 }
@@ -193,6 +411,139 @@ func (c *Codec) DecodeTotalSupplyMethodOutput(data []byte) (*big.Int, error) {
 	return result, nil
 }
 
+func (c *Codec) EncodeTrackingIndexScaleMethodCall() ([]byte, error) {
+	return c.abi.Pack("trackingIndexScale")
+}
+
+func (c *Codec) DecodeTrackingIndexScaleMethodOutput(data []byte) (uint64, error) {
+	vals, err := c.abi.Methods["trackingIndexScale"].Outputs.Unpack(data)
+	if err != nil {
+		return *new(uint64), err
+	}
+	jsonData, err := json.Marshal(vals[0])
+	if err != nil {
+		return *new(uint64), fmt.Errorf("failed to marshal ABI result: %w", err)
+	}
+
+	var result uint64
+	if err := json.Unmarshal(jsonData, &result); err != nil {
+		return *new(uint64), fmt.Errorf("failed to unmarshal to uint64: %w", err)
+	}
+
+	return result, nil
+}
+
+func (c Comet) BaseToken(
+	runtime cre.Runtime,
+	blockNumber *big.Int,
+) cre.Promise[common.Address] {
+	calldata, err := c.Codec.EncodeBaseTokenMethodCall()
+	if err != nil {
+		return cre.PromiseFromResult[common.Address](*new(common.Address), err)
+	}
+
+	var bn cre.Promise[*pb.BigInt]
+	if blockNumber == nil {
+		promise := c.client.HeaderByNumber(runtime, &evm.HeaderByNumberRequest{
+			BlockNumber: bindings.FinalizedBlockNumber,
+		})
+
+		bn = cre.Then(promise, func(finalizedBlock *evm.HeaderByNumberReply) (*pb.BigInt, error) {
+			if finalizedBlock == nil || finalizedBlock.Header == nil {
+				return nil, errors.New("failed to get finalized block header")
+			}
+			return finalizedBlock.Header.BlockNumber, nil
+		})
+	} else {
+		bn = cre.PromiseFromResult(pb.NewBigIntFromInt(blockNumber), nil)
+	}
+
+	promise := cre.ThenPromise(bn, func(bn *pb.BigInt) cre.Promise[*evm.CallContractReply] {
+		return c.client.CallContract(runtime, &evm.CallContractRequest{
+			Call:        &evm.CallMsg{To: c.Address.Bytes(), Data: calldata},
+			BlockNumber: bn,
+		})
+	})
+	return cre.Then(promise, func(response *evm.CallContractReply) (common.Address, error) {
+		return c.Codec.DecodeBaseTokenMethodOutput(response.Data)
+	})
+
+}
+
+func (c Comet) BaseTrackingSupplySpeed(
+	runtime cre.Runtime,
+	blockNumber *big.Int,
+) cre.Promise[uint64] {
+	calldata, err := c.Codec.EncodeBaseTrackingSupplySpeedMethodCall()
+	if err != nil {
+		return cre.PromiseFromResult[uint64](*new(uint64), err)
+	}
+
+	var bn cre.Promise[*pb.BigInt]
+	if blockNumber == nil {
+		promise := c.client.HeaderByNumber(runtime, &evm.HeaderByNumberRequest{
+			BlockNumber: bindings.FinalizedBlockNumber,
+		})
+
+		bn = cre.Then(promise, func(finalizedBlock *evm.HeaderByNumberReply) (*pb.BigInt, error) {
+			if finalizedBlock == nil || finalizedBlock.Header == nil {
+				return nil, errors.New("failed to get finalized block header")
+			}
+			return finalizedBlock.Header.BlockNumber, nil
+		})
+	} else {
+		bn = cre.PromiseFromResult(pb.NewBigIntFromInt(blockNumber), nil)
+	}
+
+	promise := cre.ThenPromise(bn, func(bn *pb.BigInt) cre.Promise[*evm.CallContractReply] {
+		return c.client.CallContract(runtime, &evm.CallContractRequest{
+			Call:        &evm.CallMsg{To: c.Address.Bytes(), Data: calldata},
+			BlockNumber: bn,
+		})
+	})
+	return cre.Then(promise, func(response *evm.CallContractReply) (uint64, error) {
+		return c.Codec.DecodeBaseTrackingSupplySpeedMethodOutput(response.Data)
+	})
+
+}
+
+func (c Comet) Decimals(
+	runtime cre.Runtime,
+	blockNumber *big.Int,
+) cre.Promise[uint8] {
+	calldata, err := c.Codec.EncodeDecimalsMethodCall()
+	if err != nil {
+		return cre.PromiseFromResult[uint8](*new(uint8), err)
+	}
+
+	var bn cre.Promise[*pb.BigInt]
+	if blockNumber == nil {
+		promise := c.client.HeaderByNumber(runtime, &evm.HeaderByNumberRequest{
+			BlockNumber: bindings.FinalizedBlockNumber,
+		})
+
+		bn = cre.Then(promise, func(finalizedBlock *evm.HeaderByNumberReply) (*pb.BigInt, error) {
+			if finalizedBlock == nil || finalizedBlock.Header == nil {
+				return nil, errors.New("failed to get finalized block header")
+			}
+			return finalizedBlock.Header.BlockNumber, nil
+		})
+	} else {
+		bn = cre.PromiseFromResult(pb.NewBigIntFromInt(blockNumber), nil)
+	}
+
+	promise := cre.ThenPromise(bn, func(bn *pb.BigInt) cre.Promise[*evm.CallContractReply] {
+		return c.client.CallContract(runtime, &evm.CallContractRequest{
+			Call:        &evm.CallMsg{To: c.Address.Bytes(), Data: calldata},
+			BlockNumber: bn,
+		})
+	})
+	return cre.Then(promise, func(response *evm.CallContractReply) (uint8, error) {
+		return c.Codec.DecodeDecimalsMethodOutput(response.Data)
+	})
+
+}
+
 func (c Comet) GetSupplyRate(
 	runtime cre.Runtime,
 	args GetSupplyRateInput,
@@ -231,6 +582,228 @@ func (c Comet) GetSupplyRate(
 
 }
 
+func (c Comet) IsSupplyPaused(
+	runtime cre.Runtime,
+	blockNumber *big.Int,
+) cre.Promise[bool] {
+	calldata, err := c.Codec.EncodeIsSupplyPausedMethodCall()
+	if err != nil {
+		return cre.PromiseFromResult[bool](*new(bool), err)
+	}
+
+	var bn cre.Promise[*pb.BigInt]
+	if blockNumber == nil {
+		promise := c.client.HeaderByNumber(runtime, &evm.HeaderByNumberRequest{
+			BlockNumber: bindings.FinalizedBlockNumber,
+		})
+
+		bn = cre.Then(promise, func(finalizedBlock *evm.HeaderByNumberReply) (*pb.BigInt, error) {
+			if finalizedBlock == nil || finalizedBlock.Header == nil {
+				return nil, errors.New("failed to get finalized block header")
+			}
+			return finalizedBlock.Header.BlockNumber, nil
+		})
+	} else {
+		bn = cre.PromiseFromResult(pb.NewBigIntFromInt(blockNumber), nil)
+	}
+
+	promise := cre.ThenPromise(bn, func(bn *pb.BigInt) cre.Promise[*evm.CallContractReply] {
+		return c.client.CallContract(runtime, &evm.CallContractRequest{
+			Call:        &evm.CallMsg{To: c.Address.Bytes(), Data: calldata},
+			BlockNumber: bn,
+		})
+	})
+	return cre.Then(promise, func(response *evm.CallContractReply) (bool, error) {
+		return c.Codec.DecodeIsSupplyPausedMethodOutput(response.Data)
+	})
+
+}
+
+func (c Comet) IsWithdrawPaused(
+	runtime cre.Runtime,
+	blockNumber *big.Int,
+) cre.Promise[bool] {
+	calldata, err := c.Codec.EncodeIsWithdrawPausedMethodCall()
+	if err != nil {
+		return cre.PromiseFromResult[bool](*new(bool), err)
+	}
+
+	var bn cre.Promise[*pb.BigInt]
+	if blockNumber == nil {
+		promise := c.client.HeaderByNumber(runtime, &evm.HeaderByNumberRequest{
+			BlockNumber: bindings.FinalizedBlockNumber,
+		})
+
+		bn = cre.Then(promise, func(finalizedBlock *evm.HeaderByNumberReply) (*pb.BigInt, error) {
+			if finalizedBlock == nil || finalizedBlock.Header == nil {
+				return nil, errors.New("failed to get finalized block header")
+			}
+			return finalizedBlock.Header.BlockNumber, nil
+		})
+	} else {
+		bn = cre.PromiseFromResult(pb.NewBigIntFromInt(blockNumber), nil)
+	}
+
+	promise := cre.ThenPromise(bn, func(bn *pb.BigInt) cre.Promise[*evm.CallContractReply] {
+		return c.client.CallContract(runtime, &evm.CallContractRequest{
+			Call:        &evm.CallMsg{To: c.Address.Bytes(), Data: calldata},
+			BlockNumber: bn,
+		})
+	})
+	return cre.Then(promise, func(response *evm.CallContractReply) (bool, error) {
+		return c.Codec.DecodeIsWithdrawPausedMethodOutput(response.Data)
+	})
+
+}
+
+func (c Comet) SupplyKink(
+	runtime cre.Runtime,
+	blockNumber *big.Int,
+) cre.Promise[*big.Int] {
+	calldata, err := c.Codec.EncodeSupplyKinkMethodCall()
+	if err != nil {
+		return cre.PromiseFromResult[*big.Int](*new(*big.Int), err)
+	}
+
+	var bn cre.Promise[*pb.BigInt]
+	if blockNumber == nil {
+		promise := c.client.HeaderByNumber(runtime, &evm.HeaderByNumberRequest{
+			BlockNumber: bindings.FinalizedBlockNumber,
+		})
+
+		bn = cre.Then(promise, func(finalizedBlock *evm.HeaderByNumberReply) (*pb.BigInt, error) {
+			if finalizedBlock == nil || finalizedBlock.Header == nil {
+				return nil, errors.New("failed to get finalized block header")
+			}
+			return finalizedBlock.Header.BlockNumber, nil
+		})
+	} else {
+		bn = cre.PromiseFromResult(pb.NewBigIntFromInt(blockNumber), nil)
+	}
+
+	promise := cre.ThenPromise(bn, func(bn *pb.BigInt) cre.Promise[*evm.CallContractReply] {
+		return c.client.CallContract(runtime, &evm.CallContractRequest{
+			Call:        &evm.CallMsg{To: c.Address.Bytes(), Data: calldata},
+			BlockNumber: bn,
+		})
+	})
+	return cre.Then(promise, func(response *evm.CallContractReply) (*big.Int, error) {
+		return c.Codec.DecodeSupplyKinkMethodOutput(response.Data)
+	})
+
+}
+
+func (c Comet) SupplyPerSecondInterestRateBase(
+	runtime cre.Runtime,
+	blockNumber *big.Int,
+) cre.Promise[*big.Int] {
+	calldata, err := c.Codec.EncodeSupplyPerSecondInterestRateBaseMethodCall()
+	if err != nil {
+		return cre.PromiseFromResult[*big.Int](*new(*big.Int), err)
+	}
+
+	var bn cre.Promise[*pb.BigInt]
+	if blockNumber == nil {
+		promise := c.client.HeaderByNumber(runtime, &evm.HeaderByNumberRequest{
+			BlockNumber: bindings.FinalizedBlockNumber,
+		})
+
+		bn = cre.Then(promise, func(finalizedBlock *evm.HeaderByNumberReply) (*pb.BigInt, error) {
+			if finalizedBlock == nil || finalizedBlock.Header == nil {
+				return nil, errors.New("failed to get finalized block header")
+			}
+			return finalizedBlock.Header.BlockNumber, nil
+		})
+	} else {
+		bn = cre.PromiseFromResult(pb.NewBigIntFromInt(blockNumber), nil)
+	}
+
+	promise := cre.ThenPromise(bn, func(bn *pb.BigInt) cre.Promise[*evm.CallContractReply] {
+		return c.client.CallContract(runtime, &evm.CallContractRequest{
+			Call:        &evm.CallMsg{To: c.Address.Bytes(), Data: calldata},
+			BlockNumber: bn,
+		})
+	})
+	return cre.Then(promise, func(response *evm.CallContractReply) (*big.Int, error) {
+		return c.Codec.DecodeSupplyPerSecondInterestRateBaseMethodOutput(response.Data)
+	})
+
+}
+
+func (c Comet) SupplyPerSecondInterestRateSlopeHigh(
+	runtime cre.Runtime,
+	blockNumber *big.Int,
+) cre.Promise[*big.Int] {
+	calldata, err := c.Codec.EncodeSupplyPerSecondInterestRateSlopeHighMethodCall()
+	if err != nil {
+		return cre.PromiseFromResult[*big.Int](*new(*big.Int), err)
+	}
+
+	var bn cre.Promise[*pb.BigInt]
+	if blockNumber == nil {
+		promise := c.client.HeaderByNumber(runtime, &evm.HeaderByNumberRequest{
+			BlockNumber: bindings.FinalizedBlockNumber,
+		})
+
+		bn = cre.Then(promise, func(finalizedBlock *evm.HeaderByNumberReply) (*pb.BigInt, error) {
+			if finalizedBlock == nil || finalizedBlock.Header == nil {
+				return nil, errors.New("failed to get finalized block header")
+			}
+			return finalizedBlock.Header.BlockNumber, nil
+		})
+	} else {
+		bn = cre.PromiseFromResult(pb.NewBigIntFromInt(blockNumber), nil)
+	}
+
+	promise := cre.ThenPromise(bn, func(bn *pb.BigInt) cre.Promise[*evm.CallContractReply] {
+		return c.client.CallContract(runtime, &evm.CallContractRequest{
+			Call:        &evm.CallMsg{To: c.Address.Bytes(), Data: calldata},
+			BlockNumber: bn,
+		})
+	})
+	return cre.Then(promise, func(response *evm.CallContractReply) (*big.Int, error) {
+		return c.Codec.DecodeSupplyPerSecondInterestRateSlopeHighMethodOutput(response.Data)
+	})
+
+}
+
+func (c Comet) SupplyPerSecondInterestRateSlopeLow(
+	runtime cre.Runtime,
+	blockNumber *big.Int,
+) cre.Promise[*big.Int] {
+	calldata, err := c.Codec.EncodeSupplyPerSecondInterestRateSlopeLowMethodCall()
+	if err != nil {
+		return cre.PromiseFromResult[*big.Int](*new(*big.Int), err)
+	}
+
+	var bn cre.Promise[*pb.BigInt]
+	if blockNumber == nil {
+		promise := c.client.HeaderByNumber(runtime, &evm.HeaderByNumberRequest{
+			BlockNumber: bindings.FinalizedBlockNumber,
+		})
+
+		bn = cre.Then(promise, func(finalizedBlock *evm.HeaderByNumberReply) (*pb.BigInt, error) {
+			if finalizedBlock == nil || finalizedBlock.Header == nil {
+				return nil, errors.New("failed to get finalized block header")
+			}
+			return finalizedBlock.Header.BlockNumber, nil
+		})
+	} else {
+		bn = cre.PromiseFromResult(pb.NewBigIntFromInt(blockNumber), nil)
+	}
+
+	promise := cre.ThenPromise(bn, func(bn *pb.BigInt) cre.Promise[*evm.CallContractReply] {
+		return c.client.CallContract(runtime, &evm.CallContractRequest{
+			Call:        &evm.CallMsg{To: c.Address.Bytes(), Data: calldata},
+			BlockNumber: bn,
+		})
+	})
+	return cre.Then(promise, func(response *evm.CallContractReply) (*big.Int, error) {
+		return c.Codec.DecodeSupplyPerSecondInterestRateSlopeLowMethodOutput(response.Data)
+	})
+
+}
+
 func (c Comet) TotalBorrow(
 	runtime cre.Runtime,
 	blockNumber *big.Int,
@@ -305,6 +878,43 @@ func (c Comet) TotalSupply(
 
 }
 
+func (c Comet) TrackingIndexScale(
+	runtime cre.Runtime,
+	blockNumber *big.Int,
+) cre.Promise[uint64] {
+	calldata, err := c.Codec.EncodeTrackingIndexScaleMethodCall()
+	if err != nil {
+		return cre.PromiseFromResult[uint64](*new(uint64), err)
+	}
+
+	var bn cre.Promise[*pb.BigInt]
+	if blockNumber == nil {
+		promise := c.client.HeaderByNumber(runtime, &evm.HeaderByNumberRequest{
+			BlockNumber: bindings.FinalizedBlockNumber,
+		})
+
+		bn = cre.Then(promise, func(finalizedBlock *evm.HeaderByNumberReply) (*pb.BigInt, error) {
+			if finalizedBlock == nil || finalizedBlock.Header == nil {
+				return nil, errors.New("failed to get finalized block header")
+			}
+			return finalizedBlock.Header.BlockNumber, nil
+		})
+	} else {
+		bn = cre.PromiseFromResult(pb.NewBigIntFromInt(blockNumber), nil)
+	}
+
+	promise := cre.ThenPromise(bn, func(bn *pb.BigInt) cre.Promise[*evm.CallContractReply] {
+		return c.client.CallContract(runtime, &evm.CallContractRequest{
+			Call:        &evm.CallMsg{To: c.Address.Bytes(), Data: calldata},
+			BlockNumber: bn,
+		})
+	})
+	return cre.Then(promise, func(response *evm.CallContractReply) (uint64, error) {
+		return c.Codec.DecodeTrackingIndexScaleMethodOutput(response.Data)
+	})
+
+}
+
 func (c Comet) WriteReport(
 	runtime cre.Runtime,
 	report *cre.Report,
@@ -22,9 +22,19 @@ var (
 
 // CometMock is a mock implementation of Comet for testing.
 type CometMock struct {
-	GetSupplyRate func(GetSupplyRateInput) (uint64, error)
-	TotalBorrow   func() (*big.Int, error)
-	TotalSupply   func() (*big.Int, error)
+	BaseToken                            func() (common.Address, error)
+	BaseTrackingSupplySpeed              func() (uint64, error)
+	Decimals                             func() (uint8, error)
+	GetSupplyRate                        func(GetSupplyRateInput) (uint64, error)
+	IsSupplyPaused                       func() (bool, error)
+	IsWithdrawPaused                     func() (bool, error)
+	SupplyKink                           func() (*big.Int, error)
+	SupplyPerSecondInterestRateBase      func() (*big.Int, error)
+	SupplyPerSecondInterestRateSlopeHigh func() (*big.Int, error)
+	SupplyPerSecondInterestRateSlopeLow  func() (*big.Int, error)
+	TotalBorrow                          func() (*big.Int, error)
+	TotalSupply                          func() (*big.Int, error)
+	TrackingIndexScale                   func() (uint64, error)
 }
 
 // NewCometMock creates a new CometMock for testing.
@@ -40,6 +50,36 @@ func NewCometMock(address common.Address, clientMock *evmmock.ClientCapability)
 	_ = abi
 
 	funcMap := map[string]func([]byte) ([]byte, error){
+		string(abi.Methods["baseToken"].ID[:4]): func(payload []byte) ([]byte, error) {
+			if mock.BaseToken == nil {
+				return nil, errors.New("baseToken method not mocked")
+			}
+			result, err := mock.BaseToken()
+			if err != nil {
+				return nil, err
+			}
+			return abi.Methods["baseToken"].Outputs.Pack(result)
+		},
+		string(abi.Methods["baseTrackingSupplySpeed"].ID[:4]): func(payload []byte) ([]byte, error) {
+			if mock.BaseTrackingSupplySpeed == nil {
+				return nil, errors.New("baseTrackingSupplySpeed method not mocked")
+			}
+			result, err := mock.BaseTrackingSupplySpeed()
+			if err != nil {
+				return nil, err
+			}
+			return abi.Methods["baseTrackingSupplySpeed"].Outputs.Pack(result)
+		},
+		string(abi.Methods["decimals"].ID[:4]): func(payload []byte) ([]byte, error) {
+			if mock.Decimals == nil {
+				return nil, errors.New("decimals method not mocked")
+			}
+			result, err := mock.Decimals()
+			if err != nil {
+				return nil, err
+			}
+			return abi.Methods["decimals"].Outputs.Pack(result)
+		},
 		string(abi.Methods["getSupplyRate"].ID[:4]): func(payload []byte) ([]byte, error) {
 			if mock.GetSupplyRate == nil {
 				return nil, errors.New("getSupplyRate method not mocked")
@@ -64,6 +104,66 @@ func NewCometMock(address common.Address, clientMock *evmmock.ClientCapability)
 			}
 			return abi.Methods["getSupplyRate"].Outputs.Pack(result)
 		},
+		string(abi.Methods["isSupplyPaused"].ID[:4]): func(payload []byte) ([]byte, error) {
+			if mock.IsSupplyPaused == nil {
+				return nil, errors.New("isSupplyPaused method not mocked")
+			}
+			result, err := mock.IsSupplyPaused()
+			if err != nil {
+				return nil, err
+			}
+			return abi.Methods["isSupplyPaused"].Outputs.Pack(result)
+		},
+		string(abi.Methods["isWithdrawPaused"].ID[:4]): func(payload []byte) ([]byte, error) {
+			if mock.IsWithdrawPaused == nil {
+				return nil, errors.New("isWithdrawPaused method not mocked")
+			}
+			result, err := mock.IsWithdrawPaused()
+			if err != nil {
+				return nil, err
+			}
+			return abi.Methods["isWithdrawPaused"].Outputs.Pack(result)
+		},
+		string(abi.Methods["supplyKink"].ID[:4]): func(payload []byte) ([]byte, error) {
+			if mock.SupplyKink == nil {
+				return nil, errors.New("supplyKink method not mocked")
+			}
+			result, err := mock.SupplyKink()
+			if err != nil {
+				return nil, err
+			}
+			return abi.Methods["supplyKink"].Outputs.Pack(result)
+		},
+		string(abi.Methods["supplyPerSecondInterestRateBase"].ID[:4]): func(payload []byte) ([]byte, error) {
+			if mock.SupplyPerSecondInterestRateBase == nil {
+				return nil, errors.New("supplyPerSecondInterestRateBase method not mocked")
+			}
+			result, err := mock.SupplyPerSecondInterestRateBase()
+			if err != nil {
+				return nil, err
+			}
+			return abi.Methods["supplyPerSecondInterestRateBase"].Outputs.Pack(result)
+		},
+		string(abi.Methods["supplyPerSecondInterestRateSlopeHigh"].ID[:4]): func(payload []byte) ([]byte, error) {
+			if mock.SupplyPerSecondInterestRateSlopeHigh == nil {
+				return nil, errors.New("supplyPerSecondInterestRateSlopeHigh method not mocked")
+			}
+			result, err := mock.SupplyPerSecondInterestRateSlopeHigh()
+			if err != nil {
+				return nil, err
+			}
+			return abi.Methods["supplyPerSecondInterestRateSlopeHigh"].Outputs.Pack(result)
+		},
+		string(abi.Methods["supplyPerSecondInterestRateSlopeLow"].ID[:4]): func(payload []byte) ([]byte, error) {
+			if mock.SupplyPerSecondInterestRateSlopeLow == nil {
+				return nil, errors.New("supplyPerSecondInterestRateSlopeLow method not mocked")
+			}
+			result, err := mock.SupplyPerSecondInterestRateSlopeLow()
+			if err != nil {
+				return nil, err
+			}
+			return abi.Methods["supplyPerSecondInterestRateSlopeLow"].Outputs.Pack(result)
+		},
 		string(abi.Methods["totalBorrow"].ID[:4]): func(payload []byte) ([]byte, error) {
 			if mock.TotalBorrow == nil {
 				return nil, errors.New("totalBorrow method not mocked")
@@ -84,6 +184,16 @@ func NewCometMock(address common.Address, clientMock *evmmock.ClientCapability)
 			}
 			return abi.Methods["totalSupply"].Outputs.Pack(result)
 		},
+		string(abi.Methods["trackingIndexScale"].ID[:4]): func(payload []byte) ([]byte, error) {
+			if mock.TrackingIndexScale == nil {
+				return nil, errors.New("trackingIndexScale method not mocked")
+			}
+			result, err := mock.TrackingIndexScale()
+			if err != nil {
+				return nil, err
+			}
+			return abi.Methods["trackingIndexScale"].Outputs.Pack(result)
+		},
 	}
 
 	evmmock.AddContractMock(address, clientMock, funcMap, nil)
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+
+	"rebalance/contracts/evm/src/generated/child_peer"
+	"rebalance/contracts/evm/src/generated/parent_peer"
+	"rebalance/workflow/internal/helper"
+	"rebalance/workflow/internal/onchain"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/smartcontractkit/cre-sdk-go/capabilities/blockchain/evm"
+	"github.com/smartcontractkit/cre-sdk-go/capabilities/blockchain/evm/bindings"
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+)
+
+/*//////////////////////////////////////////////////////////////
+                          FLOW EVENTS
+//////////////////////////////////////////////////////////////*/
+
+// FlowKind is the direction of a user flow.
+type FlowKind string
+
+const (
+	FlowDeposit  FlowKind = "deposit"
+	FlowWithdraw FlowKind = "withdraw"
+)
+
+// flowConfidence is the confidence level the flow log triggers fire at.
+const flowConfidence = evm.ConfidenceLevel_CONFIDENCE_LEVEL_SAFE
+
+// FlowEvent is the DepositInitiated / WithdrawInitiated log that triggered a run.
+type FlowEvent struct {
+	Kind          FlowKind      `json:"kind"`
+	ChainSelector uint64        `json:"chainSelector"`
+	Amount        *big.Int      `json:"amount"` // vault asset for deposits, shares for withdrawals
+	TxHash        hexutil.Bytes `json:"txHash"`
+	TVLFraction   float64       `json:"tvlFraction"` // share of the vault the flow moves
+}
+
+// newFlowEvent builds a FlowEvent from a decoded peer log.
+func newFlowEvent(kind FlowKind, chainSelector uint64, amount *big.Int, log *evm.Log) *FlowEvent {
+	event := &FlowEvent{
+		Kind:          kind,
+		ChainSelector: chainSelector,
+		Amount:        amount,
+	}
+	if log != nil {
+		event.TxHash = log.TxHash
+	}
+	return event
+}
+
+// measureFlow sets flow.TVLFraction: deposits relative to the TVL, withdrawals
+// (denominated in shares) relative to the total share supply. An empty vault
+// counts as fully moved. Deposits are rescaled from the vault asset's decimals
+// on their chain to tvlDecimals.
+func measureFlow(
+	config *helper.Config,
+	runtime cre.Runtime,
+	deps OnCronDeps,
+	parentPeer onchain.ParentPeerInterface,
+	flow *FlowEvent,
+	tvl *big.Int,
+	tvlDecimals uint8,
+) error {
+	var total *big.Int
+	switch flow.Kind {
+	case FlowDeposit:
+		total = tvl
+	case FlowWithdraw:
+		shares, err := deps.ReadTotalShares(config, runtime, parentPeer)
+		if err != nil {
+			return fmt.Errorf("failed to read total shares from ParentPeer: %w", err)
+		}
+		total = shares
+	default:
+		return fmt.Errorf("unknown flow kind %q", flow.Kind)
+	}
+
+	if flow.Amount == nil {
+		return fmt.Errorf("flow amount must not be nil")
+	}
+	if total == nil || total.Sign() == 0 {
+		flow.TVLFraction = 1
+		return nil
+	}
+
+	amount := flow.Amount
+	if flow.Kind == FlowDeposit {
+		decimals, err := config.VaultAssetDecimals(flow.ChainSelector)
+		if err != nil {
+			return fmt.Errorf("failed to resolve deposit asset decimals: %w", err)
+		}
+		amount = helper.ConvertDecimals(amount, decimals, tvlDecimals)
+	}
+
+	flow.TVLFraction, _ = new(big.Rat).SetFrac(amount, total).Float64()
+	return nil
+}
+
+/*//////////////////////////////////////////////////////////////
+                        FLOW LOG TRIGGERS
+//////////////////////////////////////////////////////////////*/
+
+// flowHandlers registers DepositInitiated / WithdrawInitiated log triggers on
+// ParentPeer (evms[0]) and every ChildPeer.
+func flowHandlers(config *helper.Config) ([]cre.ExecutionHandler[*helper.Config, cre.Runtime], error) {
+	var handlers []cre.ExecutionHandler[*helper.Config, cre.Runtime]
+
+	for i, evmCfg := range config.Evms {
+		if !common.IsHexAddress(evmCfg.YieldPeerAddress) {
+			return nil, fmt.Errorf("invalid YieldPeer address for %s: %s", evmCfg.ChainName, evmCfg.YieldPeerAddress)
+		}
+		client := &evm.Client{ChainSelector: evmCfg.ChainSelector}
+		peerAddr := common.HexToAddress(evmCfg.YieldPeerAddress)
+
+		if i == 0 {
+			peer, err := parent_peer.NewParentPeer(client, peerAddr, nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create ParentPeer binding: %w", err)
+			}
+			deposits, err := peer.LogTriggerDepositInitiatedLog(evmCfg.ChainSelector, flowConfidence, nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create ParentPeer DepositInitiated trigger: %w", err)
+			}
+			withdrawals, err := peer.LogTriggerWithdrawInitiatedLog(evmCfg.ChainSelector, flowConfidence, nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create ParentPeer WithdrawInitiated trigger: %w", err)
+			}
+			handlers = append(handlers,
+				cre.Handler(deposits, onParentDepositInitiated),
+				cre.Handler(withdrawals, onParentWithdrawInitiated),
+			)
+			continue
+		}
+
+		peer, err := child_peer.NewChildPeer(client, peerAddr, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create ChildPeer binding for %s: %w", evmCfg.ChainName, err)
+		}
+		deposits, err := peer.LogTriggerDepositInitiatedLog(evmCfg.ChainSelector, flowConfidence, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create ChildPeer DepositInitiated trigger for %s: %w", evmCfg.ChainName, err)
+		}
+		withdrawals, err := peer.LogTriggerWithdrawInitiatedLog(evmCfg.ChainSelector, flowConfidence, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create ChildPeer WithdrawInitiated trigger for %s: %w", evmCfg.ChainName, err)
+		}
+		handlers = append(handlers,
+			cre.Handler(deposits, onChildDepositInitiated),
+			cre.Handler(withdrawals, onChildWithdrawInitiated),
+		)
+	}
+
+	return handlers, nil
+}
+
+func onParentDepositInitiated(config *helper.Config, runtime cre.Runtime, log *bindings.DecodedLog[parent_peer.DepositInitiatedDecoded]) (*StrategyResult, error) {
+	return onFlowWithDeps(config, runtime, newFlowEvent(FlowDeposit, log.Data.ThisChainSelector, log.Data.Amount, log.Log), defaultOnCronDeps)
+}
+
+func onParentWithdrawInitiated(config *helper.Config, runtime cre.Runtime, log *bindings.DecodedLog[parent_peer.WithdrawInitiatedDecoded]) (*StrategyResult, error) {
+	return onFlowWithDeps(config, runtime, newFlowEvent(FlowWithdraw, log.Data.ThisChainSelector, log.Data.Amount, log.Log), defaultOnCronDeps)
+}
+
+func onChildDepositInitiated(config *helper.Config, runtime cre.Runtime, log *bindings.DecodedLog[child_peer.DepositInitiatedDecoded]) (*StrategyResult, error) {
+	return onFlowWithDeps(config, runtime, newFlowEvent(FlowDeposit, log.Data.ThisChainSelector, log.Data.Amount, log.Log), defaultOnCronDeps)
+}
+
+func onChildWithdrawInitiated(config *helper.Config, runtime cre.Runtime, log *bindings.DecodedLog[child_peer.WithdrawInitiatedDecoded]) (*StrategyResult, error) {
+	return onFlowWithDeps(config, runtime, newFlowEvent(FlowWithdraw, log.Data.ThisChainSelector, log.Data.Amount, log.Log), defaultOnCronDeps)
+}
+
+// onFlowWithDeps re-runs the strategy evaluation for a deposit or withdrawal.
+func onFlowWithDeps(config *helper.Config, runtime cre.Runtime, flow *FlowEvent, deps OnCronDeps) (*StrategyResult, error) {
+	runtime.Logger().Info(
+		"Flow log received",
+		"kind", flow.Kind,
+		"chainSelector", flow.ChainSelector,
+		"amount", flow.Amount.String(),
+		"txHash", flow.TxHash.String(),
+	)
+	return evaluateStrategy(config, runtime, flow, deps)
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"rebalance/workflow/internal/helper"
+	"rebalance/workflow/internal/onchain"
+
+	"github.com/smartcontractkit/cre-sdk-go/capabilities/blockchain/evm"
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+	"github.com/smartcontractkit/cre-sdk-go/cre/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+/*//////////////////////////////////////////////////////////////
+                        SETUP / UTILITY
+//////////////////////////////////////////////////////////////*/
+
+func newFlowTestConfig(minTVLFraction float64) *helper.Config {
+	return &helper.Config{
+		Evms: []helper.EvmConfig{{
+			ChainName:        "parent-chain",
+			ChainSelector:    1,
+			YieldPeerAddress: "0xparent",
+			GasLimit:         500000,
+		}},
+		FlowTrigger: helper.FlowTriggerConfig{Enabled: true, MinTVLFraction: minTVLFraction},
+	}
+}
+
+// newFlowTestDeps returns deps with a 1,000 USDC TVL held by cur, where opt
+// is 5pp better; GetOptimalAndCurrentStrategyWithAPY counts its calls.
+func newFlowTestDeps(evaluations *int) OnCronDeps {
+	cur := onchain.Strategy{ProtocolId: [32]byte{1}, ChainSelector: 1}
+	opt := onchain.Strategy{ProtocolId: [32]byte{2}, ChainSelector: 1}
+
+	return OnCronDeps{
+		ReadBlock:                       noopReadBlock,
+		NewStrategyAdapterReaderBinding: noopNewStrategyAdapterReaderBinding,
+		ReadPreflightState:              passingReadPreflightState,
+		NewStrategySet:                  noopNewStrategySet,
+		NewParentPeerBinding: func(_ *evm.Client, _ string) (onchain.ParentPeerInterface, error) {
+			return nil, nil
+		},
+		ReadCurrentStrategy: func(_ *helper.Config, _ cre.Runtime, _ onchain.ParentPeerInterface) (onchain.Strategy, error) {
+			return cur, nil
+		},
+		ReadTVL: func(_ *helper.Config, _ cre.Runtime, _ onchain.YieldPeerInterface) (*big.Int, error) {
+			return big.NewInt(1_000_000_000), nil
+		},
+		GetOptimalAndCurrentStrategyWithAPY: func(_ *helper.Config, _ cre.Runtime, _ onchain.StrategySet, _ onchain.Strategy, _ *big.Int) (onchain.StrategyWithAPY, onchain.StrategyWithAPY, []onchain.StrategyWithAPY, error) {
+			*evaluations++
+			return onchain.StrategyWithAPY{Strategy: opt, APY: 0.08}, onchain.StrategyWithAPY{Strategy: cur, APY: 0.03}, nil, nil
+		},
+		NewRebalancerBinding: func(_ *evm.Client, _ string) (onchain.RebalancerInterface, error) {
+			return nil, nil
+		},
+		WriteRebalance: func(_ onchain.RebalancerInterface, _ cre.Runtime, _ uint64, _ onchain.Strategy) ([]byte, error) {
+			return []byte{0x01}, nil
+		},
+	}
+}
+
+/*//////////////////////////////////////////////////////////////
+                             TESTS
+//////////////////////////////////////////////////////////////*/
+
+func Test_onFlowWithDeps_smallDepositSkipsEvaluation(t *testing.T) {
+	config := newFlowTestConfig(0.1)
+	runtime := testutils.NewRuntime(t, nil)
+
+	evaluations := 0
+	deps := newFlowTestDeps(&evaluations)
+
+	// 50 USDC into a 1,000 USDC vault = 5%.
+	flow := newFlowEvent(FlowDeposit, 1, big.NewInt(50_000_000), nil)
+	res, err := onFlowWithDeps(config, runtime, flow, deps)
+
+	require.NoError(t, err)
+	require.Equal(t, ReasonSmallFlow, res.Reason)
+	require.False(t, res.Updated)
+	require.Zero(t, evaluations, "strategies should not be evaluated for a small flow")
+	require.InDelta(t, 0.05, res.Flow.TVLFraction, 1e-12)
+	require.Equal(t, res.Current, res.Optimal)
+}
+
+func Test_onFlowWithDeps_largeDepositRunsPipeline(t *testing.T) {
+	config := newFlowTestConfig(0.1)
+	runtime := testutils.NewRuntime(t, nil)
+
+	evaluations := 0
+	deps := newFlowTestDeps(&evaluations)
+
+	// 250 USDC into a 1,000 USDC vault = 25%.
+	flow := newFlowEvent(FlowDeposit, 1, big.NewInt(250_000_000), &evm.Log{TxHash: []byte{0xaa}})
+	res, err := onFlowWithDeps(config, runtime, flow, deps)
+
+	require.NoError(t, err)
+	require.Equal(t, 1, evaluations)
+	require.Equal(t, ReasonRebalanced, res.Reason)
+	require.True(t, res.Updated)
+	require.Equal(t, []byte{0xaa}, []byte(res.Flow.TxHash))
+	require.InDelta(t, 0.25, res.Flow.TVLFraction, 1e-12)
+}
+
+func Test_onFlowWithDeps_withdrawalMeasuredAgainstShares(t *testing.T) {
+	config := newFlowTestConfig(0.1)
+	runtime := testutils.NewRuntime(t, nil)
+
+	evaluations := 0
+	deps := newFlowTestDeps(&evaluations)
+	deps.ReadTotalShares = func(_ *helper.Config, _ cre.Runtime, _ onchain.ParentPeerInterface) (*big.Int, error) {
+		return new(big.Int).Mul(big.NewInt(1_000), big.NewInt(1e18)), nil
+	}
+
+	// 200 of 1,000 shares = 20%.
+	shares := new(big.Int).Mul(big.NewInt(200), big.NewInt(1e18))
+	res, err := onFlowWithDeps(config, runtime, newFlowEvent(FlowWithdraw, 1, shares, nil), deps)
+
+	require.NoError(t, err)
+	require.Equal(t, 1, evaluations)
+	require.InDelta(t, 0.2, res.Flow.TVLFraction, 1e-12)
+}
+
+func Test_onFlowWithDeps_errorWhen_ReadTotalSharesFails(t *testing.T) {
+	config := newFlowTestConfig(0.1)
+	runtime := testutils.NewRuntime(t, nil)
+
+	evaluations := 0
+	deps := newFlowTestDeps(&evaluations)
+	deps.ReadTotalShares = func(_ *helper.Config, _ cre.Runtime, _ onchain.ParentPeerInterface) (*big.Int, error) {
+		return nil, fmt.Errorf("shares-failed")
+	}
+
+	res, err := onFlowWithDeps(config, runtime, newFlowEvent(FlowWithdraw, 1, big.NewInt(1), nil), deps)
+
+	require.Error(t, err)
+	require.Nil(t, res)
+	require.Contains(t, err.Error(), "failed to measure withdraw")
+	require.Contains(t, err.Error(), "shares-failed")
+}
+
+func Test_measureFlow_emptyVaultCountsAsFullyMoved(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+	flow := newFlowEvent(FlowDeposit, 1, big.NewInt(1), nil)
+
+	err := measureFlow(&helper.Config{}, runtime, OnCronDeps{}, nil, flow, big.NewInt(0), 6)
+
+	require.NoError(t, err)
+	require.Equal(t, 1.0, flow.TVLFraction)
+}
+
+func Test_measureFlow_depositRescaledToTVLDecimals(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+	config := &helper.Config{
+		Asset: "USDT",
+		Evms: []helper.EvmConfig{
+			{ChainSelector: 1, Assets: []helper.AssetConfig{{Symbol: "USDT", Decimals: 6}}},
+			{ChainSelector: 2, Assets: []helper.AssetConfig{{Symbol: "USDT", Decimals: 18}}},
+		},
+	}
+	// 1 USDT deposited on chain 2 against 10 USDT held on chain 1.
+	flow := newFlowEvent(FlowDeposit, 2, new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil), nil)
+
+	err := measureFlow(config, runtime, OnCronDeps{}, nil, flow, big.NewInt(10_000_000), 6)
+
+	require.NoError(t, err)
+	require.Equal(t, 0.1, flow.TVLFraction)
+}
+
+func Test_InitWorkflow_registersFlowHandlers(t *testing.T) {
+	config := &helper.Config{
+		Schedule: "0 */1 * * * *",
+		Evms: []helper.EvmConfig{
+			{ChainName: "parent-chain", ChainSelector: 1, YieldPeerAddress: "0x0000000000000000000000000000000000000001"},
+			{ChainName: "child-chain", ChainSelector: 2, YieldPeerAddress: "0x0000000000000000000000000000000000000002"},
+		},
+		FlowTrigger: helper.FlowTriggerConfig{Enabled: true, MinTVLFraction: 0.1},
+	}
+	logger := testutils.NewRuntime(t, nil).Logger()
+
+	wf, err := InitWorkflow(config, logger, nil)
+
+	require.NoError(t, err)
+	// cron + deposit/withdraw on each peer
+	require.Len(t, wf, 1+2*len(config.Evms))
+}
+
+func Test_InitWorkflow_errorWhen_flowPeerAddressInvalid(t *testing.T) {
+	config := &helper.Config{
+		Schedule: "0 */1 * * * *",
+		Evms: []helper.EvmConfig{
+			{ChainName: "parent-chain", ChainSelector: 1, YieldPeerAddress: "0xparent"},
+		},
+		FlowTrigger: helper.FlowTriggerConfig{Enabled: true},
+	}
+	logger := testutils.NewRuntime(t, nil).Logger()
+
+	wf, err := InitWorkflow(config, logger, nil)
+
+	require.Error(t, err)
+	require.Nil(t, wf)
+	require.Contains(t, err.Error(), "invalid YieldPeer address for parent-chain")
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"rebalance/workflow/internal/helper"
+	"rebalance/workflow/internal/onchain"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/cre-sdk-go/capabilities/blockchain/evm"
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+	"github.com/smartcontractkit/cre-sdk-go/cre/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+/*//////////////////////////////////////////////////////////////
+                        SETUP / UTILITY
+//////////////////////////////////////////////////////////////*/
+
+// newInFlightTestConfig has a parent and a child peer and the in-flight check
+// enabled.
+func newInFlightTestConfig() *helper.Config {
+	config := newFlowTestConfig(0)
+	config.Evms = append(config.Evms, helper.EvmConfig{
+		ChainName:              "child-chain",
+		ChainSelector:          2,
+		YieldPeerAddress:       "0xchild",
+		InFlightLookbackBlocks: 50,
+	})
+	config.InFlight = helper.InFlightConfig{Enabled: true, LookbackBlocks: 100}
+	return config
+}
+
+// withCCIPMessages serves logs per chain selector and records the lookback
+// each chain was read with.
+func withCCIPMessages(deps OnCronDeps, logs map[uint64]onchain.CCIPMessageLogs, lookbacks map[uint64]uint64) OnCronDeps {
+	deps.NewCCIPLogReaderBinding = func(_ *evm.Client, _ string) (onchain.CCIPLogReaderInterface, error) {
+		return nil, nil
+	}
+	deps.ReadCCIPMessages = func(_ cre.Runtime, chainSelector uint64, lookbackBlocks uint64, _ onchain.HeaderReaderInterface, _ onchain.CCIPLogReaderInterface) (onchain.CCIPMessageLogs, error) {
+		lookbacks[chainSelector] = lookbackBlocks
+		return logs[chainSelector], nil
+	}
+	return deps
+}
+
+// rebalanceSentByParent is a RebalanceOldStrategy message sent from the parent
+// to the child.
+var rebalanceSentByParent = onchain.CCIPMessage{
+	MessageId:     common.HexToHash("0x01"),
+	TxType:        onchain.CcipTxTypeRebalanceOldStrategy,
+	ChainSelector: 1,
+}
+
+/*//////////////////////////////////////////////////////////////
+                             TESTS
+//////////////////////////////////////////////////////////////*/
+
+func Test_onCronTriggerWithDeps_inFlightRebalanceSkipsEvaluation(t *testing.T) {
+	config := newInFlightTestConfig()
+	runtime := testutils.NewRuntime(t, nil)
+
+	evaluations := 0
+	lookbacks := map[uint64]uint64{}
+	deps := withCCIPMessages(newFlowTestDeps(&evaluations), map[uint64]onchain.CCIPMessageLogs{
+		1: {ChainSelector: 1, Sent: []onchain.CCIPMessage{rebalanceSentByParent}},
+	}, lookbacks)
+	deps.WriteRebalance = func(_ onchain.RebalancerInterface, _ cre.Runtime, _ uint64, _ onchain.Strategy) ([]byte, error) {
+		require.FailNow(t, "WriteRebalance should not be called while a rebalance is in flight")
+		return nil, nil
+	}
+
+	res, err := onCronTriggerWithDeps(config, runtime, newPayloadNow(), deps)
+
+	require.NoError(t, err)
+	require.Equal(t, ReasonInFlight, res.Reason)
+	require.Zero(t, evaluations)
+	require.False(t, res.Updated)
+	require.Equal(t, res.Current, res.Optimal)
+	require.Nil(t, res.TVL, "TVL is not read while funds are moving")
+	require.NotNil(t, res.InFlight)
+	require.True(t, res.InFlight.InFlight)
+	require.Equal(t, []onchain.CCIPMessage{rebalanceSentByParent}, res.InFlight.Open)
+	require.Equal(t, map[uint64]uint64{1: 100, 2: 50}, lookbacks)
+}
+
+func Test_onCronTriggerWithDeps_deliveredRebalanceProceeds(t *testing.T) {
+	config := newInFlightTestConfig()
+	runtime := testutils.NewRuntime(t, nil)
+
+	received := rebalanceSentByParent
+	received.ChainSelector = 2
+
+	evaluations := 0
+	deps := withCCIPMessages(newFlowTestDeps(&evaluations), map[uint64]onchain.CCIPMessageLogs{
+		1: {ChainSelector: 1, Sent: []onchain.CCIPMessage{rebalanceSentByParent}},
+		2: {ChainSelector: 2, Received: []onchain.CCIPMessage{received}},
+	}, map[uint64]uint64{})
+
+	res, err := onCronTriggerWithDeps(config, runtime, newPayloadNow(), deps)
+
+	require.NoError(t, err)
+	require.Equal(t, ReasonRebalanced, res.Reason)
+	require.Equal(t, 1, evaluations)
+	require.NotNil(t, res.InFlight)
+	require.False(t, res.InFlight.InFlight)
+}
+
+func Test_onCronTriggerWithDeps_errorWhen_ReadCCIPMessagesFails(t *testing.T) {
+	config := newInFlightTestConfig()
+	runtime := testutils.NewRuntime(t, nil)
+
+	evaluations := 0
+	deps := withCCIPMessages(newFlowTestDeps(&evaluations), nil, map[uint64]uint64{})
+	deps.ReadCCIPMessages = func(_ cre.Runtime, _ uint64, _ uint64, _ onchain.HeaderReaderInterface, _ onchain.CCIPLogReaderInterface) (onchain.CCIPMessageLogs, error) {
+		return onchain.CCIPMessageLogs{}, fmt.Errorf("filter-failed")
+	}
+
+	res, err := onCronTriggerWithDeps(config, runtime, newPayloadNow(), deps)
+
+	require.Error(t, err)
+	require.Nil(t, res)
+	require.Contains(t, err.Error(), "failed to read CCIP messages on parent-chain: filter-failed")
+}
+
+func Test_onOperatorRequestWithDeps_overrideBlockedWhileInFlight(t *testing.T) {
+	config := newInFlightTestConfig()
+	config.Operator = newOperatorTestConfig().Operator
+	runtime := testutils.NewRuntime(t, nil)
+
+	evaluations := 0
+	var written onchain.Strategy
+	deps := withCCIPMessages(newOperatorTestDeps(&evaluations, &written), map[uint64]onchain.CCIPMessageLogs{
+		1: {ChainSelector: 1, Sent: []onchain.CCIPMessage{rebalanceSentByParent}},
+	}, map[uint64]uint64{})
+
+	res, err := onOperatorRequestWithDeps(config, runtime, operatorPayload(operatorKey, overrideBody), deps)
+
+	require.NoError(t, err)
+	require.Equal(t, ReasonInFlight, res.Reason)
+	require.Zero(t, written, "override must not be written while a rebalance is in flight")
+	require.True(t, res.InFlight.InFlight)
+}
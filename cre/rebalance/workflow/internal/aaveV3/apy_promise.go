@@ -5,6 +5,7 @@ import (
 	"math/big"
 
 	"rebalance/workflow/internal/helper"
+	"rebalance/workflow/internal/protocol"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/smartcontractkit/cre-sdk-go/capabilities/blockchain/evm"
@@ -16,13 +17,14 @@ import (
 // Parameters:
 //   - config: The helper.Config containing all chain configurations
 //   - runtime: CRE runtime for contract calls
+//   - cache: The run's cache of shared reads (nil caches nothing)
 //   - liquidityAdded: Amount of liquidity being added (use big.NewInt(0) for current APY)
 //   - chainSelector: Chain selector to identify which chain config to use
 //
 // Returns:
 //   - Promise of APY as float64 (e.g., 0.0523 = 5.23%)
 //   - Error will be returned when Promise is awaited if chain not found or APY calculation fails
-func GetAPYPromise(config *helper.Config, runtime cre.Runtime, liquidityAdded *big.Int, chainSelector uint64) cre.Promise[float64] {
+func GetAPYPromise(config *helper.Config, runtime cre.Runtime, cache *protocol.Cache, liquidityAdded *big.Int, chainSelector uint64) cre.Promise[float64] {
 	// logger := runtime.Logger()
 
 	// Find the chain config by chainSelector
@@ -35,8 +37,53 @@ func GetAPYPromise(config *helper.Config, runtime cre.Runtime, liquidityAdded *b
 	if evmCfg.AaveV3PoolAddressesProviderAddress == "" {
 		return cre.PromiseFromResult(0.0, fmt.Errorf("AaveV3PoolAddressesProviderAddress not configured for chain %s", evmCfg.ChainName))
 	}
-	if evmCfg.USDCAddress == "" {
-		return cre.PromiseFromResult(0.0, fmt.Errorf("USDCAddress not configured for chain %s", evmCfg.ChainName))
+
+	return getAPYPromiseForProvider(config, runtime, cache, evmCfg, evmCfg.AaveV3PoolAddressesProviderAddress, vaultAsset(config, evmCfg), liquidityAdded)
+}
+
+// GetMarketAPYPromise is GetAPYPromise for the Aave-compatible market named
+// marketName (see helper.AaveMarketConfig) on a specific chain.
+func GetMarketAPYPromise(config *helper.Config, runtime cre.Runtime, cache *protocol.Cache, marketName string, liquidityAdded *big.Int, chainSelector uint64) cre.Promise[float64] {
+	evmCfg, err := helper.FindEvmConfigByChainSelector(config.Evms, chainSelector)
+	if err != nil {
+		return cre.PromiseFromResult(0.0, fmt.Errorf("chain config not found for chainSelector %d: %w", chainSelector, err))
+	}
+
+	market, ok := evmCfg.FindAaveMarket(marketName)
+	if !ok || market.PoolAddressesProviderAddress == "" {
+		return cre.PromiseFromResult(0.0, fmt.Errorf("Aave market %s not configured for chain %s", marketName, evmCfg.ChainName))
+	}
+
+	return getAPYPromiseForProvider(config, runtime, cache, evmCfg, market.PoolAddressesProviderAddress, vaultAsset(config, evmCfg), liquidityAdded)
+}
+
+// vaultAsset returns the vault asset on the chain; its zero value (no address)
+// if the chain does not configure it.
+func vaultAsset(config *helper.Config, evmCfg *helper.EvmConfig) helper.AssetConfig {
+	asset, _ := config.VaultAsset(*evmCfg)
+	if asset.Symbol == "" {
+		asset.Symbol = config.AssetSymbol()
+	}
+	return asset
+}
+
+// requireAssetAddress fails if the vault asset has no address on the chain.
+func requireAssetAddress(evmCfg *helper.EvmConfig, asset helper.AssetConfig) error {
+	if asset.Address != "" {
+		return nil
+	}
+	if asset.Symbol == helper.DefaultAsset {
+		return fmt.Errorf("USDCAddress not configured for chain %s", evmCfg.ChainName)
+	}
+	return fmt.Errorf("%s address not configured for chain %s", asset.Symbol, evmCfg.ChainName)
+}
+
+// getAPYPromiseForProvider runs the Aave v3 APY pipeline for the asset reserve
+// of the market behind the PoolAddressesProvider at providerAddress. The
+// deposit is first fitted to the reserve's supply cap (see fitToSupplyCap).
+func getAPYPromiseForProvider(config *helper.Config, runtime cre.Runtime, cache *protocol.Cache, evmCfg *helper.EvmConfig, providerAddress string, asset helper.AssetConfig, liquidityAdded *big.Int) cre.Promise[float64] {
+	if err := requireAssetAddress(evmCfg, asset); err != nil {
+		return cre.PromiseFromResult(0.0, err)
 	}
 
 	// Validate liquidityAdded is not nil (can be nil if contract call returns nil)
@@ -44,10 +91,16 @@ func GetAPYPromise(config *helper.Config, runtime cre.Runtime, liquidityAdded *b
 		return cre.PromiseFromResult(0.0, fmt.Errorf("liquidityAdded cannot be nil (use big.NewInt(0) for zero value)"))
 	}
 
+	switch config.RateModel.Mode {
+	case "", helper.RateModelContract, helper.RateModelLocal, helper.RateModelVerify:
+	default:
+		return cre.PromiseFromResult(0.0, fmt.Errorf("unknown rate model mode %q", config.RateModel.Mode))
+	}
+
 	// logger.Info("GetAPYPromise: Starting APY calculation",
 	// 	"chain", evmCfg.ChainName,
 	// 	"chainSelector", chainSelector,
-	// 	"asset", evmCfg.USDCAddress,
+	// 	"asset", asset.Address,
 	// 	"liquidityAdded", liquidityAdded.String())
 
 	// Step 1: Create EVM client for this chain
@@ -56,40 +109,68 @@ func GetAPYPromise(config *helper.Config, runtime cre.Runtime, liquidityAdded *b
 	}
 
 	// Step 2: Create PoolAddressesProvider binding
-	poolAddressesProvider, err := newPoolAddressesProviderBindingFunc(evmClient, evmCfg.AaveV3PoolAddressesProviderAddress)
+	poolAddressesProvider, err := newPoolAddressesProviderBindingFunc(evmClient, providerAddress)
 	if err != nil {
 		return cre.PromiseFromResult(0.0, fmt.Errorf("failed to create PoolAddressesProvider binding for chain %s: %w", evmCfg.ChainName, err))
 	}
 
+	// Every read is made at the block the run evaluates, the same one the
+	// other per-candidate checks read at
+	blockNumber := big.NewInt(config.BlockNumber)
+
 	// Step 3: Get ProtocolDataProvider binding
-	protocolDataProviderPromise := getProtocolDataProviderBindingFunc(runtime, evmClient, poolAddressesProvider, evmCfg.ChainName)
+	protocolDataProviderPromise := getProtocolDataProviderBindingFunc(runtime, evmClient, poolAddressesProvider, blockNumber, evmCfg.ChainName)
 
 	// Step 4: Chain promises to build the full calculation pipeline
 	return cre.ThenPromise(protocolDataProviderPromise, func(protocolDataProvider AaveProtocolDataProviderInterface) cre.Promise[float64] {
-		// Get USDC address
-		usdcAddress := common.HexToAddress(evmCfg.USDCAddress)
+		// Get the reserve asset address
+		assetAddress := common.HexToAddress(asset.Address)
+
+		// Step 5: Fit the deposit to the supply cap
+		liquidityPromise := fitToSupplyCapFunc(runtime, protocolDataProvider, asset, liquidityAdded, blockNumber, config.Evaluation.SupplyCapPolicy, evmCfg.ChainName)
+
+		// Step 6: In local and verify mode, price the deposit with the
+		// reserve's rate model, read once per run (see helper.RateModelConfig)
+		switch config.RateModel.Mode {
+		case helper.RateModelLocal, helper.RateModelVerify:
+			key := rateModelKey{chainSelector: evmCfg.ChainSelector, provider: common.HexToAddress(providerAddress), asset: assetAddress}
+			modelPromise := protocol.Cached(cache, key, func() cre.Promise[*reserveRateModel] {
+				strategyPromise := getStrategyBindingFunc(runtime, evmClient, protocolDataProvider, assetAddress, blockNumber, evmCfg.ChainName)
+				return cre.ThenPromise(strategyPromise, func(strategyV2 DefaultReserveInterestRateStrategyV2Interface) cre.Promise[*reserveRateModel] {
+					return readReserveRateModelFunc(runtime, protocolDataProvider, strategyV2, assetAddress, blockNumber)
+				})
+			})
+			return cre.ThenPromise(liquidityPromise, func(liquidity *big.Int) cre.Promise[float64] {
+				return cre.ThenPromise(modelPromise, func(model *reserveRateModel) cre.Promise[float64] {
+					return calculateAPYFromModel(runtime, model, liquidity, config.RateModel.Mode == helper.RateModelVerify)
+				})
+			})
+		}
 
-		// Step 5: Get Strategy binding
-		strategyPromise := getStrategyBindingFunc(runtime, evmClient, protocolDataProvider, usdcAddress, evmCfg.ChainName)
+		// Step 7: Get Strategy binding
+		strategyPromise := getStrategyBindingFunc(runtime, evmClient, protocolDataProvider, assetAddress, blockNumber, evmCfg.ChainName)
 
-		// Step 6: Fetch params and calculate APY
+		// Step 8: Fetch params and calculate APY
 		return cre.ThenPromise(strategyPromise, func(strategyV2 DefaultReserveInterestRateStrategyV2Interface) cre.Promise[float64] {
-			// Step 7: Fetch CalculateInterestRatesParams
-			paramsPromise := getCalculateInterestRatesParamsFunc(
-				runtime,
-				protocolDataProvider,
-				usdcAddress,
-				liquidityAdded,
-			)
-
-			// Step 8: Calculate APY using the strategy contract
-			return cre.ThenPromise(paramsPromise, func(params *CalculateInterestRatesParams) cre.Promise[float64] {
-				// logger.Info("GetAPYPromise: Got CalculateInterestRatesParams",
-				// 	"chain", evmCfg.ChainName,
-				// 	"totalDebt", params.TotalDebt.String(),
-				// 	"virtualUnderlyingBalance", params.VirtualUnderlyingBalance.String())
-
-				return calculateAPYFromContractFunc(runtime, strategyV2, params)
+			return cre.ThenPromise(liquidityPromise, func(liquidity *big.Int) cre.Promise[float64] {
+				// Step 9: Fetch CalculateInterestRatesParams
+				paramsPromise := getCalculateInterestRatesParamsFunc(
+					runtime,
+					protocolDataProvider,
+					assetAddress,
+					liquidity,
+					blockNumber,
+				)
+
+				// Step 10: Calculate APY using the strategy contract
+				return cre.ThenPromise(paramsPromise, func(params *CalculateInterestRatesParams) cre.Promise[float64] {
+					// logger.Info("GetAPYPromise: Got CalculateInterestRatesParams",
+					// 	"chain", evmCfg.ChainName,
+					// 	"totalDebt", params.TotalDebt.String(),
+					// 	"virtualUnderlyingBalance", params.VirtualUnderlyingBalance.String())
+
+					return calculateAPYFromContractFunc(runtime, strategyV2, params, blockNumber)
+				})
 			})
 		})
 	})
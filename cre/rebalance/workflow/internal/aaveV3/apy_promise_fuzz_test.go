@@ -32,6 +32,7 @@ func Fuzz_GetAPYPromise_LiquidityAndAPR(f *testing.F) {
 	origGetStrategy := getStrategyBindingFunc
 	origGetParams := getCalculateInterestRatesParamsFunc
 	origCalc := calculateAPYFromContractFunc
+	origFitToSupplyCap := fitToSupplyCapFunc
 
 	defer func() {
 		newPoolAddressesProviderBindingFunc = origProvider
@@ -39,6 +40,7 @@ func Fuzz_GetAPYPromise_LiquidityAndAPR(f *testing.F) {
 		getStrategyBindingFunc = origGetStrategy
 		getCalculateInterestRatesParamsFunc = origGetParams
 		calculateAPYFromContractFunc = origCalc
+		fitToSupplyCapFunc = origFitToSupplyCap
 	}()
 
 	const (
@@ -61,17 +63,22 @@ func Fuzz_GetAPYPromise_LiquidityAndAPR(f *testing.F) {
 	}
 
 	// Hook: protocol data provider is unused in this fuzz; return nil, nil.
-	getProtocolDataProviderBindingFunc = func(_ cre.Runtime, _ *evm.Client, _ PoolAddressesProviderInterface, _ string) cre.Promise[AaveProtocolDataProviderInterface] {
+	getProtocolDataProviderBindingFunc = func(_ cre.Runtime, _ *evm.Client, _ PoolAddressesProviderInterface, _ *big.Int, _ string) cre.Promise[AaveProtocolDataProviderInterface] {
 		return cre.PromiseFromResult[AaveProtocolDataProviderInterface](nil, nil)
 	}
 
 	// Hook: strategy binding is also unused here; return nil, nil.
-	getStrategyBindingFunc = func(_ cre.Runtime, _ *evm.Client, _ AaveProtocolDataProviderInterface, _ common.Address, _ string) cre.Promise[DefaultReserveInterestRateStrategyV2Interface] {
+	getStrategyBindingFunc = func(_ cre.Runtime, _ *evm.Client, _ AaveProtocolDataProviderInterface, _ common.Address, _ *big.Int, _ string) cre.Promise[DefaultReserveInterestRateStrategyV2Interface] {
 		return cre.PromiseFromResult[DefaultReserveInterestRateStrategyV2Interface](nil, nil)
 	}
 
+	// Hook: the reserve is uncapped; the deposit passes through unchanged.
+	fitToSupplyCapFunc = func(_ cre.Runtime, _ AaveProtocolDataProviderInterface, _ helper.AssetConfig, liq *big.Int, _ *big.Int, _ string, _ string) cre.Promise[*big.Int] {
+		return cre.PromiseFromResult(liq, nil)
+	}
+
 	// Hook: capture asset and liquidity passed into params and return dummy params.
-	getCalculateInterestRatesParamsFunc = func(_ cre.Runtime, _ AaveProtocolDataProviderInterface, asset common.Address, liq *big.Int) cre.Promise[*CalculateInterestRatesParams] {
+	getCalculateInterestRatesParamsFunc = func(_ cre.Runtime, _ AaveProtocolDataProviderInterface, asset common.Address, liq *big.Int, _ *big.Int) cre.Promise[*CalculateInterestRatesParams] {
 		lastParamsAsset = asset
 		if liq != nil {
 			lastParamsLiquidity = new(big.Int).Set(liq)
@@ -87,7 +94,7 @@ func Fuzz_GetAPYPromise_LiquidityAndAPR(f *testing.F) {
 	}
 
 	// Hook: compute APY directly from currentAPR using the same helper as the real code.
-	calculateAPYFromContractFunc = func(_ cre.Runtime, _ DefaultReserveInterestRateStrategyV2Interface, _ *CalculateInterestRatesParams) cre.Promise[float64] {
+	calculateAPYFromContractFunc = func(_ cre.Runtime, _ DefaultReserveInterestRateStrategyV2Interface, _ *CalculateInterestRatesParams, _ *big.Int) cre.Promise[float64] {
 		perSecond := currentAPR / float64(constants.SecondsPerYear)
 		apy := helper.APYFromPerSecondRate(perSecond)
 		return cre.PromiseFromResult(apy, nil)
@@ -122,7 +129,7 @@ func Fuzz_GetAPYPromise_LiquidityAndAPR(f *testing.F) {
 			},
 		}
 
-		promise := GetAPYPromise(cfg, runtime, liquidityAdded, fuzzChainSelector)
+		promise := GetAPYPromise(cfg, runtime, nil, liquidityAdded, fuzzChainSelector)
 		apy, err := promise.Await()
 
 		require.NoError(t, err, "GetAPYPromise should not error for valid config and non-nil liquidityAdded")
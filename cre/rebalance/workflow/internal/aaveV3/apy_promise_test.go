@@ -22,7 +22,7 @@ func TestGetAPYPromise_error_chainConfigNotFound(t *testing.T) {
 	cfg := &helper.Config{Evms: []helper.EvmConfig{}}
 	runtime := testutils.NewRuntime(t, nil)
 
-	p := GetAPYPromise(cfg, runtime, big.NewInt(0), 999)
+	p := GetAPYPromise(cfg, runtime, nil, big.NewInt(0), 999)
 	apy, err := p.Await()
 
 	require.Error(t, err)
@@ -43,7 +43,7 @@ func TestGetAPYPromise_error_missingPoolAddressesProvider(t *testing.T) {
 	}
 	runtime := testutils.NewRuntime(t, nil)
 
-	p := GetAPYPromise(cfg, runtime, big.NewInt(0), 1)
+	p := GetAPYPromise(cfg, runtime, nil, big.NewInt(0), 1)
 	apy, err := p.Await()
 
 	require.Error(t, err)
@@ -64,7 +64,7 @@ func TestGetAPYPromise_error_missingUSDCAddress(t *testing.T) {
 	}
 	runtime := testutils.NewRuntime(t, nil)
 
-	p := GetAPYPromise(cfg, runtime, big.NewInt(0), 1)
+	p := GetAPYPromise(cfg, runtime, nil, big.NewInt(0), 1)
 	apy, err := p.Await()
 
 	require.Error(t, err)
@@ -85,7 +85,7 @@ func TestGetAPYPromise_error_liquidityNil(t *testing.T) {
 	}
 	runtime := testutils.NewRuntime(t, nil)
 
-	p := GetAPYPromise(cfg, runtime, nil, 1)
+	p := GetAPYPromise(cfg, runtime, nil, nil, 1)
 	apy, err := p.Await()
 
 	require.Error(t, err)
@@ -112,7 +112,7 @@ func TestGetAPYPromise_error_poolAddressesProviderBindingFails(t *testing.T) {
 	}
 	defer func() { newPoolAddressesProviderBindingFunc = orig }()
 
-	p := GetAPYPromise(cfg, runtime, big.NewInt(0), 1)
+	p := GetAPYPromise(cfg, runtime, nil, big.NewInt(0), 1)
 	apy, err := p.Await()
 
 	require.Error(t, err)
@@ -135,6 +135,7 @@ func TestGetAPYPromise_success_happyPath(t *testing.T) {
 				USDCAddress:                     "0x0000000000000000000000000000000000000002",
 			},
 		},
+		BlockNumber: 19000000,
 	}
 	runtime := testutils.NewRuntime(t, nil)
 
@@ -152,6 +153,7 @@ func TestGetAPYPromise_success_happyPath(t *testing.T) {
 		gotParamsLiquidity  *big.Int
 		gotCalcStrategy     DefaultReserveInterestRateStrategyV2Interface
 		gotCalcParams       *CalculateInterestRatesParams
+		gotBlocks           []*big.Int
 		expectedStrategy    DefaultReserveInterestRateStrategyV2Interface = nil
 		expectedParams                                      = &CalculateInterestRatesParams{}
 		expectedAPY        float64                         = 0.123
@@ -162,6 +164,7 @@ func TestGetAPYPromise_success_happyPath(t *testing.T) {
 	origGetStrategy := getStrategyBindingFunc
 	origGetParams := getCalculateInterestRatesParamsFunc
 	origCalcAPY := calculateAPYFromContractFunc
+	origFitToSupplyCap := fitToSupplyCapFunc
 
 	newPoolAddressesProviderBindingFunc = func(client *evm.Client, _ string) (PoolAddressesProviderInterface, error) {
 		gotClientChainSelector = client.ChainSelector
@@ -169,39 +172,49 @@ func TestGetAPYPromise_success_happyPath(t *testing.T) {
 		return nil, nil
 	}
 
-	getProtocolDataProviderBindingFunc = func(_ cre.Runtime, _ *evm.Client, _ PoolAddressesProviderInterface, chainName string) cre.Promise[AaveProtocolDataProviderInterface] {
+	getProtocolDataProviderBindingFunc = func(_ cre.Runtime, _ *evm.Client, _ PoolAddressesProviderInterface, blockNumber *big.Int, chainName string) cre.Promise[AaveProtocolDataProviderInterface] {
+		gotBlocks = append(gotBlocks, blockNumber)
 		gotProviderChainName = chainName
 		// We don't need a concrete implementation; nil interface is fine, as we stub strategy next.
 		return cre.PromiseFromResult[AaveProtocolDataProviderInterface](nil, nil)
 	}
 
-	getStrategyBindingFunc = func(_ cre.Runtime, _ *evm.Client, _ AaveProtocolDataProviderInterface, asset common.Address, chainName string) cre.Promise[DefaultReserveInterestRateStrategyV2Interface] {
+	getStrategyBindingFunc = func(_ cre.Runtime, _ *evm.Client, _ AaveProtocolDataProviderInterface, asset common.Address, blockNumber *big.Int, chainName string) cre.Promise[DefaultReserveInterestRateStrategyV2Interface] {
+		gotBlocks = append(gotBlocks, blockNumber)
 		gotStrategyAsset = asset
 		gotStrategyChain = chainName
 		return cre.PromiseFromResult[DefaultReserveInterestRateStrategyV2Interface](expectedStrategy, nil)
 	}
 
-	getCalculateInterestRatesParamsFunc = func(_ cre.Runtime, _ AaveProtocolDataProviderInterface, asset common.Address, liq *big.Int) cre.Promise[*CalculateInterestRatesParams] {
+	getCalculateInterestRatesParamsFunc = func(_ cre.Runtime, _ AaveProtocolDataProviderInterface, asset common.Address, liq *big.Int, blockNumber *big.Int) cre.Promise[*CalculateInterestRatesParams] {
+		gotBlocks = append(gotBlocks, blockNumber)
 		gotParamsAsset = asset
 		gotParamsLiquidity = new(big.Int).Set(liq)
 		return cre.PromiseFromResult(expectedParams, nil)
 	}
 
-	calculateAPYFromContractFunc = func(_ cre.Runtime, strategy DefaultReserveInterestRateStrategyV2Interface, params *CalculateInterestRatesParams) cre.Promise[float64] {
+	calculateAPYFromContractFunc = func(_ cre.Runtime, strategy DefaultReserveInterestRateStrategyV2Interface, params *CalculateInterestRatesParams, blockNumber *big.Int) cre.Promise[float64] {
+		gotBlocks = append(gotBlocks, blockNumber)
 		gotCalcStrategy = strategy
 		gotCalcParams = params
 		return cre.PromiseFromResult(expectedAPY, nil)
 	}
 
+	fitToSupplyCapFunc = func(_ cre.Runtime, _ AaveProtocolDataProviderInterface, _ helper.AssetConfig, liq *big.Int, blockNumber *big.Int, _ string, _ string) cre.Promise[*big.Int] {
+		gotBlocks = append(gotBlocks, blockNumber)
+		return cre.PromiseFromResult(liq, nil)
+	}
+
 	defer func() {
 		newPoolAddressesProviderBindingFunc = origProvider
 		getProtocolDataProviderBindingFunc = origGetProvider
 		getStrategyBindingFunc = origGetStrategy
 		getCalculateInterestRatesParamsFunc = origGetParams
 		calculateAPYFromContractFunc = origCalcAPY
+		fitToSupplyCapFunc = origFitToSupplyCap
 	}()
 
-	p := GetAPYPromise(cfg, runtime, liquidity, 42)
+	p := GetAPYPromise(cfg, runtime, nil, liquidity, 42)
 	apy, err := p.Await()
 
 	require.NoError(t, err)
@@ -225,4 +238,161 @@ func TestGetAPYPromise_success_happyPath(t *testing.T) {
 	// Validate CalculateAPYFromContract receives the same strategy and params produced upstream.
 	require.Equal(t, expectedStrategy, gotCalcStrategy)
 	require.Equal(t, expectedParams, gotCalcParams)
+
+	// Validate every read is made at the evaluated block.
+	require.Len(t, gotBlocks, 5)
+	for _, blockNumber := range gotBlocks {
+		require.Equal(t, big.NewInt(cfg.BlockNumber), blockNumber)
+	}
+}
+
+/*//////////////////////////////////////////////////////////////
+                         AAVE MARKETS
+//////////////////////////////////////////////////////////////*/
+
+func TestGetMarketAPYPromise_error_marketNotConfigured(t *testing.T) {
+	cfg := &helper.Config{
+		Evms: []helper.EvmConfig{
+			{
+				ChainName:     "test-chain",
+				ChainSelector: 1,
+				USDCAddress:   "0x0000000000000000000000000000000000000002",
+			},
+		},
+	}
+	runtime := testutils.NewRuntime(t, nil)
+
+	apy, err := GetMarketAPYPromise(cfg, runtime, nil, "spark", big.NewInt(0), 1).Await()
+
+	require.ErrorContains(t, err, "Aave market spark not configured for chain test-chain")
+	require.Equal(t, 0.0, apy)
+}
+
+func TestGetMarketAPYPromise_success_usesMarketProvider(t *testing.T) {
+	cfg := &helper.Config{
+		Evms: []helper.EvmConfig{
+			{
+				ChainName:                          "test-chain",
+				ChainSelector:                      42,
+				AaveV3PoolAddressesProviderAddress: "0x0000000000000000000000000000000000000001",
+				USDCAddress:                        "0x0000000000000000000000000000000000000002",
+				AaveMarkets: []helper.AaveMarketConfig{
+					{Name: "spark", PoolAddressesProviderAddress: "0x0000000000000000000000000000000000000003"},
+				},
+			},
+		},
+	}
+	runtime := testutils.NewRuntime(t, nil)
+
+	var gotProviderAddress string
+	origProvider := newPoolAddressesProviderBindingFunc
+	origGetProvider := getProtocolDataProviderBindingFunc
+	origGetStrategy := getStrategyBindingFunc
+	origGetParams := getCalculateInterestRatesParamsFunc
+	origCalcAPY := calculateAPYFromContractFunc
+	defer func() {
+		newPoolAddressesProviderBindingFunc = origProvider
+		getProtocolDataProviderBindingFunc = origGetProvider
+		getStrategyBindingFunc = origGetStrategy
+		getCalculateInterestRatesParamsFunc = origGetParams
+		calculateAPYFromContractFunc = origCalcAPY
+	}()
+
+	newPoolAddressesProviderBindingFunc = func(_ *evm.Client, address string) (PoolAddressesProviderInterface, error) {
+		gotProviderAddress = address
+		return nil, nil
+	}
+	getProtocolDataProviderBindingFunc = func(cre.Runtime, *evm.Client, PoolAddressesProviderInterface, *big.Int, string) cre.Promise[AaveProtocolDataProviderInterface] {
+		return cre.PromiseFromResult[AaveProtocolDataProviderInterface](nil, nil)
+	}
+	getStrategyBindingFunc = func(cre.Runtime, *evm.Client, AaveProtocolDataProviderInterface, common.Address, *big.Int, string) cre.Promise[DefaultReserveInterestRateStrategyV2Interface] {
+		return cre.PromiseFromResult[DefaultReserveInterestRateStrategyV2Interface](nil, nil)
+	}
+	getCalculateInterestRatesParamsFunc = func(cre.Runtime, AaveProtocolDataProviderInterface, common.Address, *big.Int, *big.Int) cre.Promise[*CalculateInterestRatesParams] {
+		return cre.PromiseFromResult(&CalculateInterestRatesParams{}, nil)
+	}
+	calculateAPYFromContractFunc = func(cre.Runtime, DefaultReserveInterestRateStrategyV2Interface, *CalculateInterestRatesParams, *big.Int) cre.Promise[float64] {
+		return cre.PromiseFromResult(0.045, nil)
+	}
+
+	apy, err := GetMarketAPYPromise(cfg, runtime, nil, "spark", big.NewInt(0), 42).Await()
+
+	require.NoError(t, err)
+	require.Equal(t, 0.045, apy)
+	require.Equal(t, "0x0000000000000000000000000000000000000003", gotProviderAddress)
+}
+
+func TestGetAPYPromise_success_usesVaultAssetReserve(t *testing.T) {
+	cfg := &helper.Config{
+		Asset: "USDT",
+		Evms: []helper.EvmConfig{
+			{
+				ChainName:                          "test-chain",
+				ChainSelector:                      42,
+				AaveV3PoolAddressesProviderAddress: "0x0000000000000000000000000000000000000001",
+				USDCAddress:                        "0x0000000000000000000000000000000000000002",
+				Assets: []helper.AssetConfig{
+					{Symbol: "USDT", Address: "0x0000000000000000000000000000000000000004", Decimals: 6},
+				},
+			},
+		},
+	}
+	runtime := testutils.NewRuntime(t, nil)
+
+	var gotStrategyAsset, gotParamsAsset common.Address
+	origProvider := newPoolAddressesProviderBindingFunc
+	origGetProvider := getProtocolDataProviderBindingFunc
+	origGetStrategy := getStrategyBindingFunc
+	origGetParams := getCalculateInterestRatesParamsFunc
+	origCalcAPY := calculateAPYFromContractFunc
+	defer func() {
+		newPoolAddressesProviderBindingFunc = origProvider
+		getProtocolDataProviderBindingFunc = origGetProvider
+		getStrategyBindingFunc = origGetStrategy
+		getCalculateInterestRatesParamsFunc = origGetParams
+		calculateAPYFromContractFunc = origCalcAPY
+	}()
+
+	newPoolAddressesProviderBindingFunc = func(*evm.Client, string) (PoolAddressesProviderInterface, error) {
+		return nil, nil
+	}
+	getProtocolDataProviderBindingFunc = func(cre.Runtime, *evm.Client, PoolAddressesProviderInterface, *big.Int, string) cre.Promise[AaveProtocolDataProviderInterface] {
+		return cre.PromiseFromResult[AaveProtocolDataProviderInterface](nil, nil)
+	}
+	getStrategyBindingFunc = func(_ cre.Runtime, _ *evm.Client, _ AaveProtocolDataProviderInterface, asset common.Address, _ *big.Int, _ string) cre.Promise[DefaultReserveInterestRateStrategyV2Interface] {
+		gotStrategyAsset = asset
+		return cre.PromiseFromResult[DefaultReserveInterestRateStrategyV2Interface](nil, nil)
+	}
+	getCalculateInterestRatesParamsFunc = func(_ cre.Runtime, _ AaveProtocolDataProviderInterface, asset common.Address, _ *big.Int, _ *big.Int) cre.Promise[*CalculateInterestRatesParams] {
+		gotParamsAsset = asset
+		return cre.PromiseFromResult(&CalculateInterestRatesParams{}, nil)
+	}
+	calculateAPYFromContractFunc = func(cre.Runtime, DefaultReserveInterestRateStrategyV2Interface, *CalculateInterestRatesParams, *big.Int) cre.Promise[float64] {
+		return cre.PromiseFromResult(0.05, nil)
+	}
+
+	_, err := GetAPYPromise(cfg, runtime, nil, big.NewInt(0), 42).Await()
+
+	require.NoError(t, err)
+	usdt := common.HexToAddress("0x0000000000000000000000000000000000000004")
+	require.Equal(t, usdt, gotStrategyAsset)
+	require.Equal(t, usdt, gotParamsAsset)
+}
+
+func TestGetAPYPromise_error_vaultAssetNotConfigured(t *testing.T) {
+	cfg := &helper.Config{
+		Asset: "DAI",
+		Evms: []helper.EvmConfig{
+			{
+				ChainName:                          "test-chain",
+				ChainSelector:                      1,
+				AaveV3PoolAddressesProviderAddress: "0x0000000000000000000000000000000000000001",
+			},
+		},
+	}
+	runtime := testutils.NewRuntime(t, nil)
+
+	_, err := GetAPYPromise(cfg, runtime, nil, big.NewInt(0), 1).Await()
+
+	require.ErrorContains(t, err, "DAI address not configured for chain test-chain")
 }
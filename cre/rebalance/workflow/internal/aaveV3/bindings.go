@@ -6,6 +6,7 @@ import (
 	"rebalance/contracts/evm/src/generated/aave_protocol_data_provider"
 	"rebalance/contracts/evm/src/generated/default_reserve_interest_rate_strategy_v2"
 	"rebalance/contracts/evm/src/generated/pool_addresses_provider"
+	"rebalance/contracts/evm/src/generated/rewards_controller"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/smartcontractkit/cre-sdk-go/capabilities/blockchain/evm"
@@ -55,3 +56,18 @@ func newDefaultReserveInterestRateStrategyV2Binding(client *evm.Client, addr str
 		nil,
 	)
 }
+
+// newRewardsControllerBinding constructs the RewardsController binding.
+// It validates the address and returns an interface for testability.
+func newRewardsControllerBinding(client *evm.Client, addr string) (RewardsControllerInterface, error) {
+	if !common.IsHexAddress(addr) {
+		return nil, fmt.Errorf("invalid RewardsController address: %s", addr)
+	}
+	controllerAddr := common.HexToAddress(addr)
+
+	return rewards_controller.NewRewardsController(
+		client,
+		controllerAddr,
+		nil,
+	)
+}
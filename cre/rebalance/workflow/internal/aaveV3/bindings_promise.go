@@ -2,6 +2,7 @@ package aaveV3
 
 import (
 	"fmt"
+	"math/big"
 
 	"rebalance/contracts/evm/src/generated/aave_protocol_data_provider"
 
@@ -17,6 +18,7 @@ import (
 //   - runtime: CRE runtime for contract calls
 //   - evmClient: EVM client for the chain
 //   - poolProvider: PoolAddressesProvider binding
+//   - blockNumber: Block to read the address at (nil for latest)
 //   - chainName: Chain name for error messages
 //
 // Returns:
@@ -25,12 +27,13 @@ func getProtocolDataProviderBinding(
 	runtime cre.Runtime,
 	evmClient *evm.Client,
 	poolProvider PoolAddressesProviderInterface,
+	blockNumber *big.Int,
 	chainName string,
 ) cre.Promise[AaveProtocolDataProviderInterface] {
 	// logger := runtime.Logger()
 
 	// Fetch ProtocolDataProvider address
-	protocolDataProviderAddrPromise := poolProvider.GetPoolDataProvider(runtime, nil)
+	protocolDataProviderAddrPromise := poolProvider.GetPoolDataProvider(runtime, blockNumber)
 
 	return cre.Then(protocolDataProviderAddrPromise, func(protocolDataProviderAddr common.Address) (AaveProtocolDataProviderInterface, error) {
 		// Validate address
@@ -57,6 +60,7 @@ func getProtocolDataProviderBinding(
 //   - evmClient: EVM client for the chain
 //   - protocolProvider: AaveProtocolDataProvider binding
 //   - assetAddress: The reserve asset address (e.g., USDC address)
+//   - blockNumber: Block to read the strategy address at
 //   - chainName: Chain name for error messages
 //
 // Returns:
@@ -66,6 +70,7 @@ func getStrategyBinding(
 	evmClient *evm.Client,
 	protocolProvider AaveProtocolDataProviderInterface,
 	assetAddress common.Address,
+	blockNumber *big.Int,
 	chainName string,
 ) cre.Promise[DefaultReserveInterestRateStrategyV2Interface] {
 	// logger := runtime.Logger()
@@ -74,7 +79,7 @@ func getStrategyBinding(
 	strategyAddrPromise := protocolProvider.GetInterestRateStrategyAddress(
 		runtime,
 		aave_protocol_data_provider.GetInterestRateStrategyAddressInput{Arg0: assetAddress},
-		nil,
+		blockNumber,
 	)
 
 	return cre.Then(strategyAddrPromise, func(strategyAddr common.Address) (DefaultReserveInterestRateStrategyV2Interface, error) {
@@ -56,6 +56,26 @@ func (m *mockAaveProtocolDataProvider) GetVirtualUnderlyingBalance(runtime cre.R
 	return cre.PromiseFromResult[*big.Int](nil, errors.New("not implemented"))
 }
 
+func (m *mockAaveProtocolDataProvider) GetReserveTokensAddresses(runtime cre.Runtime, input aave_protocol_data_provider.GetReserveTokensAddressesInput, blockNumber *big.Int) cre.Promise[aave_protocol_data_provider.GetReserveTokensAddressesOutput] {
+	return cre.PromiseFromResult(aave_protocol_data_provider.GetReserveTokensAddressesOutput{}, errors.New("not implemented"))
+}
+
+func (m *mockAaveProtocolDataProvider) GetATokenTotalSupply(runtime cre.Runtime, input aave_protocol_data_provider.GetATokenTotalSupplyInput, blockNumber *big.Int) cre.Promise[*big.Int] {
+	return cre.PromiseFromResult[*big.Int](nil, errors.New("not implemented"))
+}
+
+func (m *mockAaveProtocolDataProvider) GetReserveCaps(runtime cre.Runtime, input aave_protocol_data_provider.GetReserveCapsInput, blockNumber *big.Int) cre.Promise[aave_protocol_data_provider.GetReserveCapsOutput] {
+	return cre.PromiseFromResult(aave_protocol_data_provider.GetReserveCapsOutput{}, errors.New("not implemented"))
+}
+
+func (m *mockAaveProtocolDataProvider) GetPaused(runtime cre.Runtime, input aave_protocol_data_provider.GetPausedInput, blockNumber *big.Int) cre.Promise[bool] {
+	return cre.PromiseFromResult(false, errors.New("not implemented"))
+}
+
+func (m *mockAaveProtocolDataProvider) GetReserveDeficit(runtime cre.Runtime, input aave_protocol_data_provider.GetReserveDeficitInput, blockNumber *big.Int) cre.Promise[*big.Int] {
+	return cre.PromiseFromResult[*big.Int](nil, errors.New("not implemented"))
+}
+
 /*//////////////////////////////////////////////////////////////
          GET PROTOCOL DATA PROVIDER BINDING TESTS
 //////////////////////////////////////////////////////////////*/
@@ -66,19 +86,23 @@ func Test_getProtocolDataProviderBinding_success(t *testing.T) {
 	chainName := "ethereum-mainnet"
 
 	validAddress := common.HexToAddress("0x7B4EB56E7CD4b454BA8ff71E4518426369a138a3")
+	blockNumber := big.NewInt(19000000)
 
+	var gotBlock *big.Int
 	mockPoolProvider := &mockPoolAddressesProvider{
-		getPoolDataProviderFunc: func(_ cre.Runtime, _ *big.Int) cre.Promise[common.Address] {
+		getPoolDataProviderFunc: func(_ cre.Runtime, block *big.Int) cre.Promise[common.Address] {
+			gotBlock = block
 			return cre.PromiseFromResult(validAddress, nil)
 		},
 	}
 
 	// We need to mock NewAaveProtocolDataProviderBinding to return our mock
-	promise := getProtocolDataProviderBinding(runtime, evmClient, mockPoolProvider, chainName)
+	promise := getProtocolDataProviderBinding(runtime, evmClient, mockPoolProvider, blockNumber, chainName)
 	result, err := promise.Await()
 
 	require.NoError(t, err)
 	require.NotNil(t, result)
+	require.Equal(t, blockNumber, gotBlock)
 	// result is already AaveProtocolDataProviderInterface (returned from getProtocolDataProviderBinding)
 }
 
@@ -93,7 +117,7 @@ func Test_getProtocolDataProviderBinding_zeroAddress(t *testing.T) {
 		},
 	}
 
-	promise := getProtocolDataProviderBinding(runtime, evmClient, mockPoolProvider, chainName)
+	promise := getProtocolDataProviderBinding(runtime, evmClient, mockPoolProvider, nil, chainName)
 	result, err := promise.Await()
 
 	require.Error(t, err)
@@ -113,7 +137,7 @@ func Test_getProtocolDataProviderBinding_contractCallError(t *testing.T) {
 		},
 	}
 
-	promise := getProtocolDataProviderBinding(runtime, evmClient, mockPoolProvider, chainName)
+	promise := getProtocolDataProviderBinding(runtime, evmClient, mockPoolProvider, nil, chainName)
 	result, err := promise.Await()
 
 	require.Error(t, err)
@@ -140,7 +164,7 @@ func Test_getStrategyBinding_success(t *testing.T) {
 		},
 	}
 
-	promise := getStrategyBinding(runtime, evmClient, mockProtocolProvider, assetAddress, chainName)
+	promise := getStrategyBinding(runtime, evmClient, mockProtocolProvider, assetAddress, nil, chainName)
 	result, err := promise.Await()
 
 	require.NoError(t, err)
@@ -160,7 +184,7 @@ func Test_getStrategyBinding_zeroAddress(t *testing.T) {
 		},
 	}
 
-	promise := getStrategyBinding(runtime, evmClient, mockProtocolProvider, assetAddress, chainName)
+	promise := getStrategyBinding(runtime, evmClient, mockProtocolProvider, assetAddress, nil, chainName)
 	result, err := promise.Await()
 
 	require.Error(t, err)
@@ -181,7 +205,7 @@ func Test_getStrategyBinding_contractCallError(t *testing.T) {
 		},
 	}
 
-	promise := getStrategyBinding(runtime, evmClient, mockProtocolProvider, assetAddress, chainName)
+	promise := getStrategyBinding(runtime, evmClient, mockProtocolProvider, assetAddress, nil, chainName)
 	result, err := promise.Await()
 
 	require.Error(t, err)
@@ -22,6 +22,7 @@ var RAYBigInt = new(big.Int).Exp(big.NewInt(10), big.NewInt(27), nil)
 //   - runtime: CRE runtime for contract calls
 //   - strategyContract: The DefaultReserveInterestRateStrategyV2 contract interface
 //   - params: Parameters for CalculateInterestRates (fetched by read.go)
+//   - blockNumber: Block to call CalculateInterestRates at
 //
 // Returns:
 //   - APY as float64 (e.g., 0.0523 = 5.23%)
@@ -36,6 +37,7 @@ func calculateAPYFromContract(
 	runtime cre.Runtime,
 	strategyContract DefaultReserveInterestRateStrategyV2Interface,
 	params *CalculateInterestRatesParams,
+	blockNumber *big.Int,
 ) cre.Promise[float64] {
 	// logger := runtime.Logger()
 	// logger.Info("Calculating APY using contract CalculateInterestRates",
@@ -63,7 +65,7 @@ func calculateAPYFromContract(
 	}
 
 	// Call CalculateInterestRates on the contract
-	resultPromise := strategyContract.CalculateInterestRates(runtime, input, nil)
+	resultPromise := strategyContract.CalculateInterestRates(runtime, input, blockNumber)
 
 	// Process the result
 	return cre.Then(resultPromise, func(result default_reserve_interest_rate_strategy_v2.CalculateInterestRatesOutput) (float64, error) {
@@ -73,25 +75,30 @@ func calculateAPYFromContract(
 
 		// logger.Info("Got liquidity rate from contract", "liquidityRateRAY", liquidityRateRAY.String())
 
-		// Handle zero liquidity rate (underutilized pool) - return 0 APY
-		if liquidityRateRAY.Sign() == 0 {
-			return 0.0, nil
-		}
+		return apyFromLiquidityRate(liquidityRateRAY)
+	})
+}
+
+// apyFromLiquidityRate converts a liquidity rate (supply APR in RAY) to APY.
+func apyFromLiquidityRate(liquidityRateRAY *big.Int) (float64, error) {
+	// Handle zero liquidity rate (underutilized pool) - return 0 APY
+	if liquidityRateRAY.Sign() == 0 {
+		return 0.0, nil
+	}
 
-		// Note: RAYBigInt is a constant (10^27), so it can never be zero
-		aprRat := new(big.Rat).Quo(
-			new(big.Rat).SetInt(liquidityRateRAY),
-			new(big.Rat).SetInt(RAYBigInt),
-		)
+	// Note: RAYBigInt is a constant (10^27), so it can never be zero
+	aprRat := new(big.Rat).Quo(
+		new(big.Rat).SetInt(liquidityRateRAY),
+		new(big.Rat).SetInt(RAYBigInt),
+	)
 
-		// Convert APR to APY using discrete compounding helper
-		apyFloat, err := convertAPRToAPY(aprRat)
-		if err != nil {
-			return 0.0, fmt.Errorf("failed to convert APR to APY: %w", err)
-		}
+	// Convert APR to APY using discrete compounding helper
+	apyFloat, err := convertAPRToAPY(aprRat)
+	if err != nil {
+		return 0.0, fmt.Errorf("failed to convert APR to APY: %w", err)
+	}
 
-		return apyFloat, nil
-	})
+	return apyFloat, nil
 }
 
 // convertAPRToAPY converts APR (as a big.Rat in decimal form, e.g. 0.05 for 5%)
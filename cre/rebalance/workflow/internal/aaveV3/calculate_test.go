@@ -124,6 +124,21 @@ func Test_convertAPRToAPY_veryLargeButValidAPR(t *testing.T) {
 
 type mockStrategyContract struct {
 	calculateInterestRatesFunc func(cre.Runtime, default_reserve_interest_rate_strategy_v2.CalculateInterestRatesInput, *big.Int) cre.Promise[default_reserve_interest_rate_strategy_v2.CalculateInterestRatesOutput]
+	getInterestRateDataFunc    func(cre.Runtime, default_reserve_interest_rate_strategy_v2.GetInterestRateDataInput, *big.Int) cre.Promise[default_reserve_interest_rate_strategy_v2.IDefaultInterestRateStrategyV2InterestRateDataRay]
+}
+
+func (m *mockStrategyContract) GetInterestRateData(
+	runtime cre.Runtime,
+	input default_reserve_interest_rate_strategy_v2.GetInterestRateDataInput,
+	blockNumber *big.Int,
+) cre.Promise[default_reserve_interest_rate_strategy_v2.IDefaultInterestRateStrategyV2InterestRateDataRay] {
+	if m.getInterestRateDataFunc != nil {
+		return m.getInterestRateDataFunc(runtime, input, blockNumber)
+	}
+	return cre.PromiseFromResult(
+		default_reserve_interest_rate_strategy_v2.IDefaultInterestRateStrategyV2InterestRateDataRay{},
+		nil,
+	)
 }
 
 func (m *mockStrategyContract) CalculateInterestRates(
@@ -167,7 +182,7 @@ func Test_calculateAPYFromContract_success(t *testing.T) {
 		VirtualUnderlyingBalance: big.NewInt(1000000),
 	}
 
-	apyPromise := calculateAPYFromContract(runtime, mockStrategy, params)
+	apyPromise := calculateAPYFromContract(runtime, mockStrategy, params, nil)
 	apy, err := apyPromise.Await()
 
 	require.NoError(t, err)
@@ -198,7 +213,7 @@ func Test_calculateAPYFromContract_zeroLiquidityRate(t *testing.T) {
 		VirtualUnderlyingBalance: big.NewInt(1000000),
 	}
 
-	apyPromise := calculateAPYFromContract(runtime, mockStrategy, params)
+	apyPromise := calculateAPYFromContract(runtime, mockStrategy, params, nil)
 	apy, err := apyPromise.Await()
 
 	require.NoError(t, err)
@@ -231,7 +246,7 @@ func Test_calculateAPYFromContract_veryHighAPR(t *testing.T) {
 		VirtualUnderlyingBalance: big.NewInt(1000000),
 	}
 
-	apyPromise := calculateAPYFromContract(runtime, mockStrategy, params)
+	apyPromise := calculateAPYFromContract(runtime, mockStrategy, params, nil)
 	apy, err := apyPromise.Await()
 
 	require.Error(t, err)
@@ -262,7 +277,7 @@ func Test_calculateAPYFromContract_contractError(t *testing.T) {
 		VirtualUnderlyingBalance: big.NewInt(1000000),
 	}
 
-	apyPromise := calculateAPYFromContract(runtime, mockStrategy, params)
+	apyPromise := calculateAPYFromContract(runtime, mockStrategy, params, nil)
 	apy, err := apyPromise.Await()
 
 	require.Error(t, err)
@@ -295,7 +310,7 @@ func Test_calculateAPYFromContract_conversionError(t *testing.T) {
 		VirtualUnderlyingBalance: big.NewInt(1000000),
 	}
 
-	apyPromise := calculateAPYFromContract(runtime, mockStrategy, params)
+	apyPromise := calculateAPYFromContract(runtime, mockStrategy, params, nil)
 	apy, err := apyPromise.Await()
 
 	require.Error(t, err)
@@ -0,0 +1,73 @@
+package aaveV3
+
+import (
+	"fmt"
+	"math/big"
+
+	"rebalance/contracts/evm/src/generated/aave_protocol_data_provider"
+	"rebalance/workflow/internal/helper"
+	"rebalance/workflow/internal/protocol"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+)
+
+// fitToSupplyCap checks liquidityAdded against the supply cap headroom of the
+// asset reserve at blockNumber and returns the amount to evaluate the APY
+// with.
+//
+// A deposit that fits is returned as is. One that does not fails with
+// protocol.ErrInfeasible, unless capPolicy is helper.SupplyCapPolicyClamp and
+// there is some headroom, in which case the headroom is returned instead.
+// A zero deposit (the current strategy) is never checked.
+func fitToSupplyCap(
+	runtime cre.Runtime,
+	protocolDataProvider AaveProtocolDataProviderInterface,
+	asset helper.AssetConfig,
+	liquidityAdded *big.Int,
+	blockNumber *big.Int,
+	capPolicy string,
+	chainName string,
+) cre.Promise[*big.Int] {
+	if liquidityAdded.Sign() == 0 {
+		return cre.PromiseFromResult(liquidityAdded, nil)
+	}
+
+	assetAddress := common.HexToAddress(asset.Address)
+	capsPromise := protocolDataProvider.GetReserveCaps(runtime, aave_protocol_data_provider.GetReserveCapsInput{Asset: assetAddress}, blockNumber)
+	supplyPromise := protocolDataProvider.GetATokenTotalSupply(runtime, aave_protocol_data_provider.GetATokenTotalSupplyInput{Asset: assetAddress}, blockNumber)
+
+	return cre.ThenPromise(capsPromise, func(caps aave_protocol_data_provider.GetReserveCapsOutput) cre.Promise[*big.Int] {
+		return cre.Then(supplyPromise, func(aTokenSupply *big.Int) (*big.Int, error) {
+			headroom := supplyCapHeadroom(caps.SupplyCap, asset.Decimals, aTokenSupply)
+			if headroom == nil || headroom.Cmp(liquidityAdded) >= 0 {
+				return liquidityAdded, nil
+			}
+			if capPolicy == helper.SupplyCapPolicyClamp && headroom.Sign() > 0 {
+				return headroom, nil
+			}
+			return nil, fmt.Errorf("%w: Aave %s supply cap on chain %s has %s of headroom, deposit is %s",
+				protocol.ErrInfeasible, asset.Symbol, chainName, headroom, liquidityAdded)
+		})
+	})
+}
+
+// supplyCapHeadroom returns how much more of the asset the reserve accepts, in
+// raw units: supplyCap (whole tokens) * 10^decimals - aTokenSupply, floored at
+// 0. It returns nil for an uncapped reserve (a supply cap of 0).
+//
+// The aToken supply excludes treasury accruals not yet minted, which Aave also
+// counts against the cap, so the headroom is a slight overestimate.
+func supplyCapHeadroom(supplyCap *big.Int, decimals uint8, aTokenSupply *big.Int) *big.Int {
+	if supplyCap == nil || supplyCap.Sign() == 0 {
+		return nil
+	}
+
+	unit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	headroom := new(big.Int).Mul(supplyCap, unit)
+	headroom.Sub(headroom, aTokenSupply)
+	if headroom.Sign() < 0 {
+		return new(big.Int)
+	}
+	return headroom
+}
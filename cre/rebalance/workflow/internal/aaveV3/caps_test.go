@@ -0,0 +1,115 @@
+package aaveV3
+
+import (
+	"math/big"
+	"testing"
+
+	"rebalance/contracts/evm/src/generated/aave_protocol_data_provider"
+	"rebalance/workflow/internal/helper"
+	"rebalance/workflow/internal/protocol"
+
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+	"github.com/smartcontractkit/cre-sdk-go/cre/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+/*//////////////////////////////////////////////////////////////
+                    TEST HELPERS / MOCKS
+//////////////////////////////////////////////////////////////*/
+
+var capsTestAsset = helper.AssetConfig{Symbol: "USDC", Address: "0x0000000000000000000000000000000000000002", Decimals: 6}
+
+// cappedReserve is a data provider whose reserve has a supply cap of
+// supplyCap whole tokens and aTokenSupply raw units supplied.
+func cappedReserve(supplyCap int64, aTokenSupply *big.Int) *mockProtocolDataProviderForRead {
+	return &mockProtocolDataProviderForRead{
+		getReserveCapsFunc: func(_ cre.Runtime, _ aave_protocol_data_provider.GetReserveCapsInput, _ *big.Int) cre.Promise[aave_protocol_data_provider.GetReserveCapsOutput] {
+			return cre.PromiseFromResult(aave_protocol_data_provider.GetReserveCapsOutput{BorrowCap: big.NewInt(0), SupplyCap: big.NewInt(supplyCap)}, nil)
+		},
+		getATokenTotalSupplyFunc: func(_ cre.Runtime, _ aave_protocol_data_provider.GetATokenTotalSupplyInput, _ *big.Int) cre.Promise[*big.Int] {
+			return cre.PromiseFromResult(aTokenSupply, nil)
+		},
+	}
+}
+
+/*//////////////////////////////////////////////////////////////
+                       SUPPLY CAP HEADROOM
+//////////////////////////////////////////////////////////////*/
+
+func Test_supplyCapHeadroom(t *testing.T) {
+	require.Nil(t, supplyCapHeadroom(big.NewInt(0), 6, big.NewInt(1)), "a zero cap means uncapped")
+	require.Equal(t, big.NewInt(250_000), supplyCapHeadroom(big.NewInt(1), 6, big.NewInt(750_000)))
+	require.Equal(t, big.NewInt(0), supplyCapHeadroom(big.NewInt(1), 6, big.NewInt(2_000_000)), "an over-full reserve has no headroom")
+}
+
+/*//////////////////////////////////////////////////////////////
+                        FIT TO SUPPLY CAP
+//////////////////////////////////////////////////////////////*/
+
+func Test_fitToSupplyCap_depositFits(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+	// 10 USDC cap, 4 supplied.
+	provider := cappedReserve(10, big.NewInt(4_000_000))
+
+	liq, err := fitToSupplyCap(runtime, provider, capsTestAsset, big.NewInt(6_000_000), nil, "", "test-chain").Await()
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(6_000_000), liq)
+}
+
+func Test_fitToSupplyCap_uncapped(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+
+	liq, err := fitToSupplyCap(runtime, cappedReserve(0, big.NewInt(4_000_000)), capsTestAsset, big.NewInt(1_000_000_000_000), nil, "", "test-chain").Await()
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1_000_000_000_000), liq)
+}
+
+func Test_fitToSupplyCap_excludeMarksInfeasible(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+	provider := cappedReserve(10, big.NewInt(4_000_000))
+
+	_, err := fitToSupplyCap(runtime, provider, capsTestAsset, big.NewInt(7_000_000), nil, helper.SupplyCapPolicyExclude, "test-chain").Await()
+	require.ErrorIs(t, err, protocol.ErrInfeasible)
+	require.ErrorContains(t, err, "Aave USDC supply cap on chain test-chain has 6000000 of headroom, deposit is 7000000")
+}
+
+func Test_fitToSupplyCap_clampEvaluatesAtHeadroom(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+
+	liq, err := fitToSupplyCap(runtime, cappedReserve(10, big.NewInt(4_000_000)), capsTestAsset, big.NewInt(7_000_000), nil, helper.SupplyCapPolicyClamp, "test-chain").Await()
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(6_000_000), liq)
+
+	// A full reserve cannot take anything, even when clamping.
+	_, err = fitToSupplyCap(runtime, cappedReserve(10, big.NewInt(10_000_000)), capsTestAsset, big.NewInt(1), nil, helper.SupplyCapPolicyClamp, "test-chain").Await()
+	require.ErrorIs(t, err, protocol.ErrInfeasible)
+}
+
+func Test_fitToSupplyCap_zeroDepositNotChecked(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+
+	// No caps or supply mocked: reading them would fail.
+	liq, err := fitToSupplyCap(runtime, &mockProtocolDataProviderForRead{}, capsTestAsset, big.NewInt(0), nil, "", "test-chain").Await()
+	require.NoError(t, err)
+	require.Zero(t, liq.Sign())
+}
+
+func Test_fitToSupplyCap_readsAtBlockNumber(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+	blockNumber := big.NewInt(12_345)
+	var blocks []*big.Int
+	provider := &mockProtocolDataProviderForRead{
+		getReserveCapsFunc: func(_ cre.Runtime, _ aave_protocol_data_provider.GetReserveCapsInput, block *big.Int) cre.Promise[aave_protocol_data_provider.GetReserveCapsOutput] {
+			blocks = append(blocks, block)
+			return cre.PromiseFromResult(aave_protocol_data_provider.GetReserveCapsOutput{BorrowCap: big.NewInt(0), SupplyCap: big.NewInt(10)}, nil)
+		},
+		getATokenTotalSupplyFunc: func(_ cre.Runtime, _ aave_protocol_data_provider.GetATokenTotalSupplyInput, block *big.Int) cre.Promise[*big.Int] {
+			blocks = append(blocks, block)
+			return cre.PromiseFromResult(big.NewInt(4_000_000), nil)
+		},
+	}
+
+	_, err := fitToSupplyCap(runtime, provider, capsTestAsset, big.NewInt(1), blockNumber, "", "test-chain").Await()
+	require.NoError(t, err)
+	require.Equal(t, []*big.Int{blockNumber, blockNumber}, blocks)
+}
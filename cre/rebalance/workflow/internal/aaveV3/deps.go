@@ -7,4 +7,7 @@ var (
 	getStrategyBindingFunc              = getStrategyBinding
 	getCalculateInterestRatesParamsFunc = getCalculateInterestRatesParams
 	calculateAPYFromContractFunc        = calculateAPYFromContract
+	readReserveRateModelFunc            = readReserveRateModel
+	newRewardsControllerBindingFunc     = newRewardsControllerBinding
+	fitToSupplyCapFunc                  = fitToSupplyCap
 )
\ No newline at end of file
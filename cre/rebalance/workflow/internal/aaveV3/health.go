@@ -0,0 +1,98 @@
+package aaveV3
+
+import (
+	"fmt"
+	"math/big"
+
+	"rebalance/contracts/evm/src/generated/aave_protocol_data_provider"
+	"rebalance/workflow/internal/helper"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/cre-sdk-go/capabilities/blockchain/evm"
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+)
+
+// GetHealthPromise reports the health issues of the vault asset reserve of
+// AaveV3 on a specific chain and returns a Promise. [Needs .Await() after this is called]
+func GetHealthPromise(config *helper.Config, runtime cre.Runtime, chainSelector uint64) cre.Promise[[]string] {
+	evmCfg, err := helper.FindEvmConfigByChainSelector(config.Evms, chainSelector)
+	if err != nil {
+		return cre.PromiseFromResult[[]string](nil, fmt.Errorf("chain config not found for chainSelector %d: %w", chainSelector, err))
+	}
+	if evmCfg.AaveV3PoolAddressesProviderAddress == "" {
+		return cre.PromiseFromResult[[]string](nil, fmt.Errorf("AaveV3PoolAddressesProviderAddress not configured for chain %s", evmCfg.ChainName))
+	}
+
+	return healthPromiseForProvider(config, runtime, evmCfg, evmCfg.AaveV3PoolAddressesProviderAddress, vaultAsset(config, evmCfg))
+}
+
+// GetMarketHealthPromise is GetHealthPromise for the Aave-compatible market
+// named marketName (see helper.AaveMarketConfig) on a specific chain.
+func GetMarketHealthPromise(config *helper.Config, runtime cre.Runtime, marketName string, chainSelector uint64) cre.Promise[[]string] {
+	evmCfg, err := helper.FindEvmConfigByChainSelector(config.Evms, chainSelector)
+	if err != nil {
+		return cre.PromiseFromResult[[]string](nil, fmt.Errorf("chain config not found for chainSelector %d: %w", chainSelector, err))
+	}
+
+	market, ok := evmCfg.FindAaveMarket(marketName)
+	if !ok || market.PoolAddressesProviderAddress == "" {
+		return cre.PromiseFromResult[[]string](nil, fmt.Errorf("Aave market %s not configured for chain %s", marketName, evmCfg.ChainName))
+	}
+
+	return healthPromiseForProvider(config, runtime, evmCfg, market.PoolAddressesProviderAddress, vaultAsset(config, evmCfg))
+}
+
+// healthPromiseForProvider reads the paused, active and frozen flags and the
+// deficit of the asset reserve of the market behind providerAddress.
+func healthPromiseForProvider(config *helper.Config, runtime cre.Runtime, evmCfg *helper.EvmConfig, providerAddress string, asset helper.AssetConfig) cre.Promise[[]string] {
+	if err := requireAssetAddress(evmCfg, asset); err != nil {
+		return cre.PromiseFromResult[[]string](nil, err)
+	}
+
+	evmClient := &evm.Client{ChainSelector: evmCfg.ChainSelector}
+
+	poolAddressesProvider, err := newPoolAddressesProviderBindingFunc(evmClient, providerAddress)
+	if err != nil {
+		return cre.PromiseFromResult[[]string](nil, fmt.Errorf("failed to create PoolAddressesProvider binding for chain %s: %w", evmCfg.ChainName, err))
+	}
+
+	blockNumber := big.NewInt(config.BlockNumber)
+	protocolDataProviderPromise := getProtocolDataProviderBindingFunc(runtime, evmClient, poolAddressesProvider, blockNumber, evmCfg.ChainName)
+
+	return cre.ThenPromise(protocolDataProviderPromise, func(protocolDataProvider AaveProtocolDataProviderInterface) cre.Promise[[]string] {
+		assetAddress := common.HexToAddress(asset.Address)
+
+		// Issue every read before awaiting any of them.
+		pausedPromise := protocolDataProvider.GetPaused(runtime, aave_protocol_data_provider.GetPausedInput{Asset: assetAddress}, blockNumber)
+		configPromise := protocolDataProvider.GetReserveConfigurationData(runtime, aave_protocol_data_provider.GetReserveConfigurationDataInput{Asset: assetAddress}, blockNumber)
+		deficitPromise := protocolDataProvider.GetReserveDeficit(runtime, aave_protocol_data_provider.GetReserveDeficitInput{Asset: assetAddress}, blockNumber)
+
+		return cre.ThenPromise(pausedPromise, func(paused bool) cre.Promise[[]string] {
+			return cre.ThenPromise(configPromise, func(reserveConfig aave_protocol_data_provider.GetReserveConfigurationDataOutput) cre.Promise[[]string] {
+				return cre.Then(deficitPromise, func(deficit *big.Int) ([]string, error) {
+					return reserveHealthIssues(paused, reserveConfig.IsActive, reserveConfig.IsFrozen, deficit), nil
+				})
+			})
+		})
+	})
+}
+
+// reserveHealthIssues lists what makes a reserve unfit to hold funds: paused
+// or inactive (no supply or withdraw), frozen (no new supply; governance is
+// winding it down) or carrying a deficit (bad debt not yet covered).
+func reserveHealthIssues(paused, isActive, isFrozen bool, deficit *big.Int) []string {
+	var issues []string
+	if paused {
+		issues = append(issues, "reserve paused")
+	}
+	if !isActive {
+		issues = append(issues, "reserve inactive")
+	}
+	if isFrozen {
+		issues = append(issues, "reserve frozen")
+	}
+	if deficit != nil && deficit.Sign() > 0 {
+		issues = append(issues, fmt.Sprintf("reserve deficit of %s", deficit))
+	}
+	return issues
+}
@@ -0,0 +1,134 @@
+package aaveV3
+
+import (
+	"math/big"
+	"testing"
+
+	"rebalance/contracts/evm/src/generated/aave_protocol_data_provider"
+	"rebalance/workflow/internal/helper"
+
+	"github.com/smartcontractkit/cre-sdk-go/capabilities/blockchain/evm"
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+	"github.com/smartcontractkit/cre-sdk-go/cre/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+/*//////////////////////////////////////////////////////////////
+                    TEST HELPERS / MOCKS
+//////////////////////////////////////////////////////////////*/
+
+func healthTestConfig() *helper.Config {
+	return &helper.Config{
+		Evms: []helper.EvmConfig{
+			{
+				ChainName:                          "test-chain",
+				ChainSelector:                      1,
+				AaveV3PoolAddressesProviderAddress: "0x0000000000000000000000000000000000000001",
+				USDCAddress:                        "0x0000000000000000000000000000000000000002",
+			},
+		},
+	}
+}
+
+// useReserveHealth wires a data provider whose reserve reports the given
+// paused, active and frozen flags and deficit.
+func useReserveHealth(t *testing.T, paused, isActive, isFrozen bool, deficit *big.Int) {
+	origProvider := newPoolAddressesProviderBindingFunc
+	origGetProvider := getProtocolDataProviderBindingFunc
+	t.Cleanup(func() {
+		newPoolAddressesProviderBindingFunc = origProvider
+		getProtocolDataProviderBindingFunc = origGetProvider
+	})
+
+	dataProvider := &mockProtocolDataProviderForRead{
+		getPausedFunc: func(_ cre.Runtime, _ aave_protocol_data_provider.GetPausedInput, _ *big.Int) cre.Promise[bool] {
+			return cre.PromiseFromResult(paused, nil)
+		},
+		getReserveConfigurationDataFunc: func(_ cre.Runtime, _ aave_protocol_data_provider.GetReserveConfigurationDataInput, _ *big.Int) cre.Promise[aave_protocol_data_provider.GetReserveConfigurationDataOutput] {
+			return cre.PromiseFromResult(aave_protocol_data_provider.GetReserveConfigurationDataOutput{IsActive: isActive, IsFrozen: isFrozen}, nil)
+		},
+		getReserveDeficitFunc: func(_ cre.Runtime, _ aave_protocol_data_provider.GetReserveDeficitInput, _ *big.Int) cre.Promise[*big.Int] {
+			return cre.PromiseFromResult(deficit, nil)
+		},
+	}
+	newPoolAddressesProviderBindingFunc = func(_ *evm.Client, _ string) (PoolAddressesProviderInterface, error) {
+		return &mockPoolAddressesProvider{}, nil
+	}
+	getProtocolDataProviderBindingFunc = func(_ cre.Runtime, _ *evm.Client, _ PoolAddressesProviderInterface, _ *big.Int, _ string) cre.Promise[AaveProtocolDataProviderInterface] {
+		return cre.PromiseFromResult[AaveProtocolDataProviderInterface](dataProvider, nil)
+	}
+}
+
+/*//////////////////////////////////////////////////////////////
+                            HEALTH
+//////////////////////////////////////////////////////////////*/
+
+func Test_reserveHealthIssues(t *testing.T) {
+	require.Empty(t, reserveHealthIssues(false, true, false, big.NewInt(0)))
+	require.Equal(t,
+		[]string{"reserve paused", "reserve inactive", "reserve frozen", "reserve deficit of 5"},
+		reserveHealthIssues(true, false, true, big.NewInt(5)),
+	)
+}
+
+func TestGetHealthPromise_healthy(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+	useReserveHealth(t, false, true, false, big.NewInt(0))
+
+	issues, err := GetHealthPromise(healthTestConfig(), runtime, 1).Await()
+
+	require.NoError(t, err)
+	require.Empty(t, issues)
+}
+
+func TestGetHealthPromise_reportsFrozenReserveWithDeficit(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+	useReserveHealth(t, false, true, true, big.NewInt(1_000_000))
+
+	issues, err := GetHealthPromise(healthTestConfig(), runtime, 1).Await()
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"reserve frozen", "reserve deficit of 1000000"}, issues)
+}
+
+func TestGetHealthPromise_readsAtConfiguredBlock(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+	useReserveHealth(t, false, true, false, big.NewInt(0))
+	var blocks []*big.Int
+	dataProvider := &mockProtocolDataProviderForRead{
+		getPausedFunc: func(_ cre.Runtime, _ aave_protocol_data_provider.GetPausedInput, block *big.Int) cre.Promise[bool] {
+			blocks = append(blocks, block)
+			return cre.PromiseFromResult(false, nil)
+		},
+		getReserveConfigurationDataFunc: func(_ cre.Runtime, _ aave_protocol_data_provider.GetReserveConfigurationDataInput, block *big.Int) cre.Promise[aave_protocol_data_provider.GetReserveConfigurationDataOutput] {
+			blocks = append(blocks, block)
+			return cre.PromiseFromResult(aave_protocol_data_provider.GetReserveConfigurationDataOutput{IsActive: true}, nil)
+		},
+		getReserveDeficitFunc: func(_ cre.Runtime, _ aave_protocol_data_provider.GetReserveDeficitInput, block *big.Int) cre.Promise[*big.Int] {
+			blocks = append(blocks, block)
+			return cre.PromiseFromResult(big.NewInt(0), nil)
+		},
+	}
+	getProtocolDataProviderBindingFunc = func(_ cre.Runtime, _ *evm.Client, _ PoolAddressesProviderInterface, block *big.Int, _ string) cre.Promise[AaveProtocolDataProviderInterface] {
+		blocks = append(blocks, block)
+		return cre.PromiseFromResult[AaveProtocolDataProviderInterface](dataProvider, nil)
+	}
+	cfg := healthTestConfig()
+	cfg.BlockNumber = -2
+
+	_, err := GetHealthPromise(cfg, runtime, 1).Await()
+
+	require.NoError(t, err)
+	require.Len(t, blocks, 4)
+	for _, block := range blocks {
+		require.Equal(t, big.NewInt(-2), block)
+	}
+}
+
+func TestGetMarketHealthPromise_error_whenMarketNotConfigured(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+
+	_, err := GetMarketHealthPromise(healthTestConfig(), runtime, "spark", 1).Await()
+
+	require.ErrorContains(t, err, "Aave market spark not configured for chain test-chain")
+}
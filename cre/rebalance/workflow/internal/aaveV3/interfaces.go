@@ -6,6 +6,7 @@ import (
 	"rebalance/contracts/evm/src/generated/aave_protocol_data_provider"
 	"rebalance/contracts/evm/src/generated/default_reserve_interest_rate_strategy_v2"
 	"rebalance/contracts/evm/src/generated/pool"
+	"rebalance/contracts/evm/src/generated/rewards_controller"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/smartcontractkit/cre-sdk-go/cre"
@@ -45,6 +46,45 @@ type AaveProtocolDataProviderInterface interface {
 		input aave_protocol_data_provider.GetVirtualUnderlyingBalanceInput,
 		blockNumber *big.Int,
 	) cre.Promise[*big.Int]
+
+	GetReserveTokensAddresses(
+		runtime cre.Runtime,
+		input aave_protocol_data_provider.GetReserveTokensAddressesInput,
+		blockNumber *big.Int,
+	) cre.Promise[aave_protocol_data_provider.GetReserveTokensAddressesOutput]
+
+	GetATokenTotalSupply(
+		runtime cre.Runtime,
+		input aave_protocol_data_provider.GetATokenTotalSupplyInput,
+		blockNumber *big.Int,
+	) cre.Promise[*big.Int]
+
+	GetReserveCaps(
+		runtime cre.Runtime,
+		input aave_protocol_data_provider.GetReserveCapsInput,
+		blockNumber *big.Int,
+	) cre.Promise[aave_protocol_data_provider.GetReserveCapsOutput]
+
+	GetPaused(
+		runtime cre.Runtime,
+		input aave_protocol_data_provider.GetPausedInput,
+		blockNumber *big.Int,
+	) cre.Promise[bool]
+
+	GetReserveDeficit(
+		runtime cre.Runtime,
+		input aave_protocol_data_provider.GetReserveDeficitInput,
+		blockNumber *big.Int,
+	) cre.Promise[*big.Int]
+}
+
+// RewardsControllerInterface abstracts the Aave v3 RewardsController contract
+type RewardsControllerInterface interface {
+	GetRewardsData(
+		runtime cre.Runtime,
+		input rewards_controller.GetRewardsDataInput,
+		blockNumber *big.Int,
+	) cre.Promise[rewards_controller.GetRewardsDataOutput]
 }
 
 // DefaultReserveInterestRateStrategyV2Interface abstracts the Interest Rate Strategy contract
@@ -54,4 +94,10 @@ type DefaultReserveInterestRateStrategyV2Interface interface {
 		input default_reserve_interest_rate_strategy_v2.CalculateInterestRatesInput,
 		blockNumber *big.Int,
 	) cre.Promise[default_reserve_interest_rate_strategy_v2.CalculateInterestRatesOutput]
+
+	GetInterestRateData(
+		runtime cre.Runtime,
+		input default_reserve_interest_rate_strategy_v2.GetInterestRateDataInput,
+		blockNumber *big.Int,
+	) cre.Promise[default_reserve_interest_rate_strategy_v2.IDefaultInterestRateStrategyV2InterestRateDataRay]
 }
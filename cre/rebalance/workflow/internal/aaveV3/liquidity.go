@@ -0,0 +1,81 @@
+package aaveV3
+
+import (
+	"fmt"
+	"math/big"
+
+	"rebalance/contracts/evm/src/generated/aave_protocol_data_provider"
+	"rebalance/workflow/internal/helper"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/cre-sdk-go/capabilities/blockchain/evm"
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+)
+
+// GetAvailableLiquidityPromise returns how much of the vault asset could be
+// withdrawn from AaveV3 on a specific chain after liquidityAdded is supplied.
+// [Needs .Await() after this is called]
+func GetAvailableLiquidityPromise(config *helper.Config, runtime cre.Runtime, liquidityAdded *big.Int, chainSelector uint64) cre.Promise[*big.Int] {
+	evmCfg, err := helper.FindEvmConfigByChainSelector(config.Evms, chainSelector)
+	if err != nil {
+		return cre.PromiseFromResult[*big.Int](nil, fmt.Errorf("chain config not found for chainSelector %d: %w", chainSelector, err))
+	}
+	if evmCfg.AaveV3PoolAddressesProviderAddress == "" {
+		return cre.PromiseFromResult[*big.Int](nil, fmt.Errorf("AaveV3PoolAddressesProviderAddress not configured for chain %s", evmCfg.ChainName))
+	}
+
+	return liquidityPromiseForProvider(config, runtime, evmCfg, evmCfg.AaveV3PoolAddressesProviderAddress, vaultAsset(config, evmCfg), liquidityAdded)
+}
+
+// GetMarketAvailableLiquidityPromise is GetAvailableLiquidityPromise for the
+// Aave-compatible market named marketName on a specific chain.
+func GetMarketAvailableLiquidityPromise(config *helper.Config, runtime cre.Runtime, marketName string, liquidityAdded *big.Int, chainSelector uint64) cre.Promise[*big.Int] {
+	evmCfg, err := helper.FindEvmConfigByChainSelector(config.Evms, chainSelector)
+	if err != nil {
+		return cre.PromiseFromResult[*big.Int](nil, fmt.Errorf("chain config not found for chainSelector %d: %w", chainSelector, err))
+	}
+
+	market, ok := evmCfg.FindAaveMarket(marketName)
+	if !ok || market.PoolAddressesProviderAddress == "" {
+		return cre.PromiseFromResult[*big.Int](nil, fmt.Errorf("Aave market %s not configured for chain %s", marketName, evmCfg.ChainName))
+	}
+
+	return liquidityPromiseForProvider(config, runtime, evmCfg, market.PoolAddressesProviderAddress, vaultAsset(config, evmCfg), liquidityAdded)
+}
+
+// liquidityPromiseForProvider reads the virtual underlying balance of the asset
+// reserve of the market behind providerAddress: the cash the pool holds for
+// the reserve, which is what withdrawals are paid from, excluding donations.
+// A deposit adds to it one for one.
+func liquidityPromiseForProvider(
+	config *helper.Config,
+	runtime cre.Runtime,
+	evmCfg *helper.EvmConfig,
+	providerAddress string,
+	asset helper.AssetConfig,
+	liquidityAdded *big.Int,
+) cre.Promise[*big.Int] {
+	if err := requireAssetAddress(evmCfg, asset); err != nil {
+		return cre.PromiseFromResult[*big.Int](nil, err)
+	}
+	if liquidityAdded == nil {
+		return cre.PromiseFromResult[*big.Int](nil, fmt.Errorf("liquidityAdded cannot be nil (use big.NewInt(0) for zero value)"))
+	}
+
+	evmClient := &evm.Client{ChainSelector: evmCfg.ChainSelector}
+
+	poolAddressesProvider, err := newPoolAddressesProviderBindingFunc(evmClient, providerAddress)
+	if err != nil {
+		return cre.PromiseFromResult[*big.Int](nil, fmt.Errorf("failed to create PoolAddressesProvider binding for chain %s: %w", evmCfg.ChainName, err))
+	}
+
+	blockNumber := big.NewInt(config.BlockNumber)
+	protocolDataProviderPromise := getProtocolDataProviderBindingFunc(runtime, evmClient, poolAddressesProvider, blockNumber, evmCfg.ChainName)
+
+	return cre.ThenPromise(protocolDataProviderPromise, func(protocolDataProvider AaveProtocolDataProviderInterface) cre.Promise[*big.Int] {
+		balancePromise := protocolDataProvider.GetVirtualUnderlyingBalance(runtime, aave_protocol_data_provider.GetVirtualUnderlyingBalanceInput{Asset: common.HexToAddress(asset.Address)}, blockNumber)
+		return cre.Then(balancePromise, func(virtualBalance *big.Int) (*big.Int, error) {
+			return new(big.Int).Add(virtualBalance, liquidityAdded), nil
+		})
+	})
+}
@@ -0,0 +1,66 @@
+package aaveV3
+
+import (
+	"math/big"
+	"testing"
+
+	"rebalance/contracts/evm/src/generated/aave_protocol_data_provider"
+	"rebalance/workflow/internal/helper"
+
+	"github.com/smartcontractkit/cre-sdk-go/capabilities/blockchain/evm"
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+	"github.com/smartcontractkit/cre-sdk-go/cre/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+// useVirtualBalance wires a data provider whose reserve holds virtualBalance.
+func useVirtualBalance(t *testing.T, virtualBalance *big.Int) {
+	origProvider := newPoolAddressesProviderBindingFunc
+	origGetProvider := getProtocolDataProviderBindingFunc
+	t.Cleanup(func() {
+		newPoolAddressesProviderBindingFunc = origProvider
+		getProtocolDataProviderBindingFunc = origGetProvider
+	})
+
+	dataProvider := &mockProtocolDataProviderForRead{
+		getVirtualUnderlyingBalanceFunc: func(_ cre.Runtime, _ aave_protocol_data_provider.GetVirtualUnderlyingBalanceInput, _ *big.Int) cre.Promise[*big.Int] {
+			return cre.PromiseFromResult(virtualBalance, nil)
+		},
+	}
+	newPoolAddressesProviderBindingFunc = func(_ *evm.Client, _ string) (PoolAddressesProviderInterface, error) {
+		return &mockPoolAddressesProvider{}, nil
+	}
+	getProtocolDataProviderBindingFunc = func(_ cre.Runtime, _ *evm.Client, _ PoolAddressesProviderInterface, _ *big.Int, _ string) cre.Promise[AaveProtocolDataProviderInterface] {
+		return cre.PromiseFromResult[AaveProtocolDataProviderInterface](dataProvider, nil)
+	}
+}
+
+func TestGetAvailableLiquidityPromise_includesDeposit(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+	useVirtualBalance(t, big.NewInt(2_000_000))
+
+	available, err := GetAvailableLiquidityPromise(healthTestConfig(), runtime, big.NewInt(500_000), 1).Await()
+
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(2_500_000), available)
+}
+
+func TestGetAvailableLiquidityPromise_fullyUtilisedReserveHasNothingToWithdraw(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+	// At 100% utilisation every unit of cash is lent out.
+	useVirtualBalance(t, big.NewInt(0))
+
+	available, err := GetAvailableLiquidityPromise(healthTestConfig(), runtime, big.NewInt(0), 1).Await()
+
+	require.NoError(t, err)
+	require.Zero(t, available.Sign())
+}
+
+func TestGetMarketAvailableLiquidityPromise_error_whenMarketNotConfigured(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+	cfg := &helper.Config{Evms: []helper.EvmConfig{{ChainName: "test-chain", ChainSelector: 1}}}
+
+	_, err := GetMarketAvailableLiquidityPromise(cfg, runtime, "spark", big.NewInt(0), 1).Await()
+
+	require.ErrorContains(t, err, "Aave market spark not configured for chain test-chain")
+}
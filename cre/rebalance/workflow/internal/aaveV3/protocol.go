@@ -0,0 +1,122 @@
+package aaveV3
+
+import (
+	"fmt"
+	"math/big"
+
+	"rebalance/workflow/internal/helper"
+	"rebalance/workflow/internal/protocol"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+)
+
+// Name is the AaveV3 protocol name; its protocol ID is keccak256(Name).
+const Name = "aave-v3"
+
+// ProtocolId is the AaveV3 protocol ID.
+var ProtocolId = protocol.IDFromName(Name)
+
+func init() {
+	protocol.Register(aaveV3Protocol{})
+	protocol.RegisterFamily(marketFamily{})
+}
+
+// aaveV3Protocol registers AaveV3 as a yield source.
+type aaveV3Protocol struct{}
+
+func (aaveV3Protocol) ID() [32]byte { return ProtocolId }
+
+func (aaveV3Protocol) Name() string { return Name }
+
+// IsConfigured does not depend on asset: the pool is looked up for a reserve
+// of the vault asset when the APY is calculated.
+func (aaveV3Protocol) IsConfigured(evm helper.EvmConfig, _ helper.AssetConfig) bool {
+	return evm.AaveV3PoolAddressesProviderAddress != ""
+}
+
+func (aaveV3Protocol) GetAPYPromise(config *helper.Config, runtime cre.Runtime, cache *protocol.Cache, liquidityAdded *big.Int, chainSelector uint64) cre.Promise[float64] {
+	return GetAPYPromise(config, runtime, cache, liquidityAdded, chainSelector)
+}
+
+func (aaveV3Protocol) GetRewardAPRPromise(config *helper.Config, runtime cre.Runtime, liquidityAdded *big.Int, chainSelector uint64) cre.Promise[float64] {
+	return GetRewardAPRPromise(config, runtime, liquidityAdded, chainSelector)
+}
+
+func (aaveV3Protocol) GetHealthPromise(config *helper.Config, runtime cre.Runtime, chainSelector uint64) cre.Promise[[]string] {
+	return GetHealthPromise(config, runtime, chainSelector)
+}
+
+func (aaveV3Protocol) GetAvailableLiquidityPromise(config *helper.Config, runtime cre.Runtime, liquidityAdded *big.Int, chainSelector uint64) cre.Promise[*big.Int] {
+	return GetAvailableLiquidityPromise(config, runtime, liquidityAdded, chainSelector)
+}
+
+// MarketFamilyName names the family of configured Aave-compatible markets
+// (Spark, Aave v3 Prime, ...).
+const MarketFamilyName = "aave-v3-markets"
+
+// marketFamily contributes one protocol per Aave market name in config.
+type marketFamily struct{}
+
+func (marketFamily) Name() string { return MarketFamilyName }
+
+// Protocols returns a protocol for every distinct market name across chains, in
+// first-seen order. A name twice on one chain, or an invalid market, is an error.
+func (marketFamily) Protocols(config *helper.Config) ([]protocol.Protocol, error) {
+	var protocols []protocol.Protocol
+	seen := make(map[string]bool)
+
+	for _, evm := range config.Evms {
+		onChain := make(map[string]bool, len(evm.AaveMarkets))
+		for _, m := range evm.AaveMarkets {
+			if m.Name == "" {
+				return nil, fmt.Errorf("Aave market %s on chain %s has no name", m.PoolAddressesProviderAddress, evm.ChainName)
+			}
+			if onChain[m.Name] {
+				return nil, fmt.Errorf("Aave market %s is configured more than once on chain %s", m.Name, evm.ChainName)
+			}
+			onChain[m.Name] = true
+			if !common.IsHexAddress(m.PoolAddressesProviderAddress) {
+				return nil, fmt.Errorf("Aave market %s on chain %s has invalid PoolAddressesProvider address %q", m.Name, evm.ChainName, m.PoolAddressesProviderAddress)
+			}
+
+			if !seen[m.Name] {
+				seen[m.Name] = true
+				protocols = append(protocols, marketProtocol{name: m.Name, id: protocol.IDFromName(m.Name)})
+			}
+		}
+	}
+	return protocols, nil
+}
+
+// marketProtocol is the protocol of the Aave-compatible markets configured
+// under name. It shares the AaveV3 pipeline; only the provider differs.
+type marketProtocol struct {
+	name string
+	id   [32]byte
+}
+
+func (p marketProtocol) ID() [32]byte { return p.id }
+
+func (p marketProtocol) Name() string { return p.name }
+
+func (p marketProtocol) IsConfigured(evm helper.EvmConfig, _ helper.AssetConfig) bool {
+	_, ok := evm.FindAaveMarket(p.name)
+	return ok
+}
+
+func (p marketProtocol) GetAPYPromise(config *helper.Config, runtime cre.Runtime, cache *protocol.Cache, liquidityAdded *big.Int, chainSelector uint64) cre.Promise[float64] {
+	return GetMarketAPYPromise(config, runtime, cache, p.name, liquidityAdded, chainSelector)
+}
+
+func (p marketProtocol) GetRewardAPRPromise(config *helper.Config, runtime cre.Runtime, liquidityAdded *big.Int, chainSelector uint64) cre.Promise[float64] {
+	return GetMarketRewardAPRPromise(config, runtime, p.name, liquidityAdded, chainSelector)
+}
+
+func (p marketProtocol) GetHealthPromise(config *helper.Config, runtime cre.Runtime, chainSelector uint64) cre.Promise[[]string] {
+	return GetMarketHealthPromise(config, runtime, p.name, chainSelector)
+}
+
+func (p marketProtocol) GetAvailableLiquidityPromise(config *helper.Config, runtime cre.Runtime, liquidityAdded *big.Int, chainSelector uint64) cre.Promise[*big.Int] {
+	return GetMarketAvailableLiquidityPromise(config, runtime, p.name, liquidityAdded, chainSelector)
+}
@@ -0,0 +1,71 @@
+package aaveV3
+
+import (
+	"testing"
+
+	"rebalance/workflow/internal/helper"
+	"rebalance/workflow/internal/protocol"
+
+	"github.com/stretchr/testify/require"
+)
+
+func sparkMarket(provider string) helper.AaveMarketConfig {
+	return helper.AaveMarketConfig{Name: "spark", PoolAddressesProviderAddress: provider}
+}
+
+func Test_marketFamily_oneProtocolPerMarketName(t *testing.T) {
+	cfg := &helper.Config{
+		Evms: []helper.EvmConfig{
+			{ChainName: "a", AaveMarkets: []helper.AaveMarketConfig{
+				sparkMarket("0x0000000000000000000000000000000000000001"),
+				{Name: "aave-v3-prime", PoolAddressesProviderAddress: "0x0000000000000000000000000000000000000002"},
+			}},
+			{ChainName: "b", AaveMarkets: []helper.AaveMarketConfig{
+				sparkMarket("0x0000000000000000000000000000000000000003"),
+			}},
+		},
+	}
+
+	protocols, err := marketFamily{}.Protocols(cfg)
+	require.NoError(t, err)
+	require.Len(t, protocols, 2)
+
+	require.Equal(t, "spark", protocols[0].Name())
+	require.Equal(t, protocol.IDFromName("spark"), protocols[0].ID())
+	require.Equal(t, protocol.IDFromName("aave-v3-prime"), protocols[1].ID())
+
+	usdc := helper.AssetConfig{Symbol: helper.DefaultAsset}
+	require.True(t, protocols[1].IsConfigured(cfg.Evms[0], usdc))
+	require.False(t, protocols[1].IsConfigured(cfg.Evms[1], usdc))
+}
+
+func Test_marketFamily_errors(t *testing.T) {
+	cases := map[string]struct {
+		markets []helper.AaveMarketConfig
+		err     string
+	}{
+		"no name": {
+			markets: []helper.AaveMarketConfig{{PoolAddressesProviderAddress: "0x0000000000000000000000000000000000000001"}},
+			err:     "has no name",
+		},
+		"duplicate": {
+			markets: []helper.AaveMarketConfig{
+				sparkMarket("0x0000000000000000000000000000000000000001"),
+				sparkMarket("0x0000000000000000000000000000000000000002"),
+			},
+			err: "Aave market spark is configured more than once on chain a",
+		},
+		"invalid address": {
+			markets: []helper.AaveMarketConfig{sparkMarket("0x01")},
+			err:     `invalid PoolAddressesProvider address "0x01"`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cfg := &helper.Config{Evms: []helper.EvmConfig{{ChainName: "a", AaveMarkets: tc.markets}}}
+			_, err := marketFamily{}.Protocols(cfg)
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package aaveV3
+
+import (
+	"fmt"
+	"math/big"
+
+	"rebalance/contracts/evm/src/generated/default_reserve_interest_rate_strategy_v2"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+)
+
+// InterestRateDataRay is a reserve's rate parameters as returned by
+// DefaultReserveInterestRateStrategyV2.getInterestRateData, all in RAY.
+type InterestRateDataRay = default_reserve_interest_rate_strategy_v2.IDefaultInterestRateStrategyV2InterestRateDataRay
+
+var (
+	halfRAY = new(big.Int).Rsh(RAYBigInt, 1)
+
+	// PercentageMath: 100.00% is 1e4.
+	percentageFactor     = big.NewInt(1e4)
+	halfPercentageFactor = big.NewInt(5e3)
+)
+
+// reserveRateModel is an Aave reserve's interest rate strategy, its rate
+// parameters and the reserve state they apply to, read at BlockNumber once per
+// market per run (see getAPYPromiseForProvider). SupplyRateAt prices any
+// deposit without further reads.
+type reserveRateModel struct {
+	Strategy    DefaultReserveInterestRateStrategyV2Interface
+	Params      CalculateInterestRatesParams // with nothing added
+	RateData    InterestRateDataRay
+	BlockNumber *big.Int
+}
+
+// readReserveRateModel reads the rate parameters of reserve from
+// strategyContract and the reserve state from protocolDataProvider, at
+// blockNumber.
+func readReserveRateModel(
+	runtime cre.Runtime,
+	protocolDataProvider AaveProtocolDataProviderInterface,
+	strategyContract DefaultReserveInterestRateStrategyV2Interface,
+	reserve common.Address,
+	blockNumber *big.Int,
+) cre.Promise[*reserveRateModel] {
+	// Issue both reads before awaiting either.
+	paramsPromise := getCalculateInterestRatesParamsFunc(runtime, protocolDataProvider, reserve, big.NewInt(0), blockNumber)
+	rateDataPromise := strategyContract.GetInterestRateData(
+		runtime,
+		default_reserve_interest_rate_strategy_v2.GetInterestRateDataInput{Reserve: reserve},
+		blockNumber,
+	)
+
+	return cre.ThenPromise(paramsPromise, func(params *CalculateInterestRatesParams) cre.Promise[*reserveRateModel] {
+		return cre.Then(rateDataPromise, func(rateData InterestRateDataRay) (*reserveRateModel, error) {
+			if err := validateInterestRateData(rateData); err != nil {
+				return nil, fmt.Errorf("invalid interest rate data for reserve %s: %w", reserve.Hex(), err)
+			}
+			return &reserveRateModel{Strategy: strategyContract, Params: *params, RateData: rateData, BlockNumber: blockNumber}, nil
+		})
+	})
+}
+
+// paramsAt returns the calculateInterestRates parameters after liquidityAdded
+// is supplied.
+func (m *reserveRateModel) paramsAt(liquidityAdded *big.Int) *CalculateInterestRatesParams {
+	params := m.Params
+	params.LiquidityAdded = liquidityAdded
+	return &params
+}
+
+// SupplyRateAt returns the liquidity rate (supply APR, in RAY) after
+// liquidityAdded is supplied.
+func (m *reserveRateModel) SupplyRateAt(liquidityAdded *big.Int) *big.Int {
+	liquidityRate, _ := calculateInterestRates(m.paramsAt(liquidityAdded), m.RateData)
+	return liquidityRate
+}
+
+// calculateAPYFromModel calculates the APY after liquidityAdded is supplied
+// with model. With verify, the liquidity rate is cross-checked with the
+// strategy contract's own CalculateInterestRates, at the block the model was
+// read at, and a mismatch is an error.
+func calculateAPYFromModel(runtime cre.Runtime, model *reserveRateModel, liquidityAdded *big.Int, verify bool) cre.Promise[float64] {
+	liquidityRate := model.SupplyRateAt(liquidityAdded)
+	if !verify {
+		return cre.PromiseFromResult(apyFromLiquidityRate(liquidityRate))
+	}
+
+	params := model.paramsAt(liquidityAdded)
+	input := default_reserve_interest_rate_strategy_v2.CalculateInterestRatesInput{
+		Params: default_reserve_interest_rate_strategy_v2.DataTypesCalculateInterestRatesParams{
+			Unbacked:                 params.Unbacked,
+			LiquidityAdded:           params.LiquidityAdded,
+			LiquidityTaken:           params.LiquidityTaken,
+			TotalDebt:                params.TotalDebt,
+			ReserveFactor:            params.ReserveFactor,
+			Reserve:                  params.Reserve,
+			UsingVirtualBalance:      params.UsingVirtualBalance,
+			VirtualUnderlyingBalance: params.VirtualUnderlyingBalance,
+		},
+	}
+	return cre.Then(model.Strategy.CalculateInterestRates(runtime, input, model.BlockNumber), func(result default_reserve_interest_rate_strategy_v2.CalculateInterestRatesOutput) (float64, error) {
+		if result.Arg0 == nil || result.Arg0.Cmp(liquidityRate) != 0 {
+			return 0.0, fmt.Errorf("local Aave rate model disagrees with the contract for reserve %s: liquidity rate %s, contract %s",
+				params.Reserve.Hex(), liquidityRate, result.Arg0)
+		}
+		return apyFromLiquidityRate(liquidityRate)
+	})
+}
+
+// rateModelKey identifies a reserve: the asset in the market behind a
+// PoolAddressesProvider on a chain.
+type rateModelKey struct {
+	chainSelector uint64
+	provider      common.Address
+	asset         common.Address
+}
+
+// validateInterestRateData rejects parameters calculateInterestRates cannot
+// use: missing values, or an optimal usage ratio of 0 or 100%, which the
+// contract never sets and the model divides by.
+func validateInterestRateData(rateData InterestRateDataRay) error {
+	if rateData.OptimalUsageRatio == nil || rateData.BaseVariableBorrowRate == nil ||
+		rateData.VariableRateSlope1 == nil || rateData.VariableRateSlope2 == nil {
+		return fmt.Errorf("missing rate parameter")
+	}
+	if rateData.OptimalUsageRatio.Sign() <= 0 || rateData.OptimalUsageRatio.Cmp(RAYBigInt) >= 0 {
+		return fmt.Errorf("optimal usage ratio %s out of range", rateData.OptimalUsageRatio)
+	}
+	return nil
+}
+
+// calculateInterestRates is DefaultReserveInterestRateStrategyV2
+// .calculateInterestRates (Aave v3.2) in Go, with the same rounding. It returns
+// the liquidity rate (supply APR) and the variable borrow rate, in RAY.
+//
+// https://github.com/aave-dao/aave-v3-origin/blob/main/src/contracts/misc/DefaultReserveInterestRateStrategyV2.sol
+func calculateInterestRates(params *CalculateInterestRatesParams, rateData InterestRateDataRay) (*big.Int, *big.Int) {
+	// Mintable assets (e.g. GHO), which have no virtual balance, and reserves
+	// without debt pay suppliers nothing.
+	if !params.UsingVirtualBalance || params.TotalDebt.Sign() == 0 {
+		return new(big.Int), new(big.Int).Set(rateData.BaseVariableBorrowRate)
+	}
+
+	availableLiquidity := new(big.Int).Add(params.VirtualUnderlyingBalance, params.LiquidityAdded)
+	availableLiquidity.Sub(availableLiquidity, params.LiquidityTaken)
+	availableLiquidityPlusDebt := new(big.Int).Add(availableLiquidity, params.TotalDebt)
+
+	borrowUsageRatio := rayDiv(params.TotalDebt, availableLiquidityPlusDebt)
+	supplyUsageRatio := rayDiv(params.TotalDebt, new(big.Int).Add(availableLiquidityPlusDebt, params.Unbacked))
+
+	variableBorrowRate := new(big.Int).Set(rateData.BaseVariableBorrowRate)
+	if borrowUsageRatio.Cmp(rateData.OptimalUsageRatio) > 0 {
+		excessBorrowUsageRatio := rayDiv(
+			new(big.Int).Sub(borrowUsageRatio, rateData.OptimalUsageRatio),
+			new(big.Int).Sub(RAYBigInt, rateData.OptimalUsageRatio),
+		)
+		variableBorrowRate.Add(variableBorrowRate, rateData.VariableRateSlope1)
+		variableBorrowRate.Add(variableBorrowRate, rayMul(rateData.VariableRateSlope2, excessBorrowUsageRatio))
+	} else {
+		variableBorrowRate.Add(variableBorrowRate, rayDiv(rayMul(rateData.VariableRateSlope1, borrowUsageRatio), rateData.OptimalUsageRatio))
+	}
+
+	liquidityRate := percentMul(
+		rayMul(variableBorrowRate, supplyUsageRatio),
+		new(big.Int).Sub(percentageFactor, params.ReserveFactor),
+	)
+	return liquidityRate, variableBorrowRate
+}
+
+// rayMul is WadRayMath.rayMul: a * b / RAY, rounded half up.
+func rayMul(a, b *big.Int) *big.Int {
+	out := new(big.Int).Mul(a, b)
+	out.Add(out, halfRAY)
+	return out.Quo(out, RAYBigInt)
+}
+
+// rayDiv is WadRayMath.rayDiv: a * RAY / b, rounded half up.
+func rayDiv(a, b *big.Int) *big.Int {
+	out := new(big.Int).Mul(a, RAYBigInt)
+	out.Add(out, new(big.Int).Rsh(b, 1))
+	return out.Quo(out, b)
+}
+
+// percentMul is PercentageMath.percentMul: value * percentage / 1e4, rounded
+// half up, with percentage in basis points.
+func percentMul(value, percentage *big.Int) *big.Int {
+	out := new(big.Int).Mul(value, percentage)
+	out.Add(out, halfPercentageFactor)
+	return out.Quo(out, percentageFactor)
+}
@@ -0,0 +1,283 @@
+package aaveV3
+
+import (
+	"math/big"
+	"testing"
+
+	"rebalance/contracts/evm/src/generated/default_reserve_interest_rate_strategy_v2"
+	"rebalance/workflow/internal/helper"
+	"rebalance/workflow/internal/protocol"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/cre-sdk-go/capabilities/blockchain/evm"
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+	"github.com/smartcontractkit/cre-sdk-go/cre/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+/*//////////////////////////////////////////////////////////////
+                    TEST HELPERS / MOCKS
+//////////////////////////////////////////////////////////////*/
+
+// rayFromPercent returns percent% in RAY.
+func rayFromPercent(percent int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(percent), new(big.Int).Exp(big.NewInt(10), big.NewInt(25), nil))
+}
+
+// testRateData is a kinked curve: 90% optimal usage, 0% base, 4% slope1 and
+// 60% slope2.
+func testRateData() InterestRateDataRay {
+	return InterestRateDataRay{
+		OptimalUsageRatio:      rayFromPercent(90),
+		BaseVariableBorrowRate: big.NewInt(0),
+		VariableRateSlope1:     rayFromPercent(4),
+		VariableRateSlope2:     rayFromPercent(60),
+	}
+}
+
+// testRateParams is a reserve with totalDebt borrowed out of virtualBalance +
+// totalDebt supplied and a 10% reserve factor, after liquidityAdded.
+func testRateParams(virtualBalance, totalDebt, liquidityAdded int64) *CalculateInterestRatesParams {
+	return &CalculateInterestRatesParams{
+		Unbacked:                 big.NewInt(0),
+		LiquidityAdded:           big.NewInt(liquidityAdded),
+		LiquidityTaken:           big.NewInt(0),
+		TotalDebt:                big.NewInt(totalDebt),
+		ReserveFactor:            big.NewInt(1000),
+		Reserve:                  common.HexToAddress("0x0000000000000000000000000000000000000002"),
+		UsingVirtualBalance:      true,
+		VirtualUnderlyingBalance: big.NewInt(virtualBalance),
+	}
+}
+
+func rayToFloat(ray *big.Int) float64 {
+	f, _ := new(big.Rat).SetFrac(ray, RAYBigInt).Float64()
+	return f
+}
+
+/*//////////////////////////////////////////////////////////////
+                  CALCULATE INTEREST RATES (PURE)
+//////////////////////////////////////////////////////////////*/
+
+func Test_calculateInterestRates_belowOptimalUsage(t *testing.T) {
+	// 80% usage: borrow rate 4% * 0.8 / 0.9, supply rate that * 0.8 * 0.9.
+	liquidityRate, borrowRate := calculateInterestRates(testRateParams(200e6, 800e6, 0), testRateData())
+
+	require.InDelta(t, 0.04*0.8/0.9, rayToFloat(borrowRate), 1e-18)
+	require.InDelta(t, 0.0256, rayToFloat(liquidityRate), 1e-18)
+}
+
+func Test_calculateInterestRates_aboveOptimalUsage(t *testing.T) {
+	// 95% usage: half way up slope2, so 4% + 30%.
+	liquidityRate, borrowRate := calculateInterestRates(testRateParams(50e6, 950e6, 0), testRateData())
+
+	require.InDelta(t, 0.34, rayToFloat(borrowRate), 1e-18)
+	require.InDelta(t, 0.34*0.95*0.9, rayToFloat(liquidityRate), 1e-18)
+}
+
+func Test_calculateInterestRates_depositLowersUsage(t *testing.T) {
+	// Our 900 deposit takes usage from 95% to 50%.
+	liquidityRate, _ := calculateInterestRates(testRateParams(50e6, 950e6, 900e6), testRateData())
+
+	require.InDelta(t, 0.04*0.5/0.9*0.5*0.9, rayToFloat(liquidityRate), 1e-18)
+}
+
+func Test_calculateInterestRates_unbackedDilutesSupplyRate(t *testing.T) {
+	params := testRateParams(200e6, 800e6, 0)
+	params.Unbacked = big.NewInt(1000e6)
+
+	liquidityRate, borrowRate := calculateInterestRates(params, testRateData())
+
+	require.InDelta(t, 0.04*0.8/0.9, rayToFloat(borrowRate), 1e-18, "unbacked does not count towards borrow usage")
+	require.InDelta(t, 0.04*0.8/0.9*0.4*0.9, rayToFloat(liquidityRate), 1e-18)
+}
+
+func Test_calculateInterestRates_noDebtOrNoVirtualBalance(t *testing.T) {
+	rateData := testRateData()
+	rateData.BaseVariableBorrowRate = rayFromPercent(1)
+
+	liquidityRate, borrowRate := calculateInterestRates(testRateParams(1000e6, 0, 0), rateData)
+	require.Zero(t, liquidityRate.Sign())
+	requireBigEqual(t, rayFromPercent(1), borrowRate)
+
+	params := testRateParams(200e6, 800e6, 0)
+	params.UsingVirtualBalance = false
+	liquidityRate, borrowRate = calculateInterestRates(params, rateData)
+	require.Zero(t, liquidityRate.Sign())
+	requireBigEqual(t, rayFromPercent(1), borrowRate)
+}
+
+func Test_rayMath_roundsHalfUp(t *testing.T) {
+	requireBigEqual(t, big.NewInt(1), rayMul(big.NewInt(1), halfRAY))
+	requireBigEqual(t, big.NewInt(0), rayMul(big.NewInt(1), new(big.Int).Sub(halfRAY, big.NewInt(1))))
+	oneThird, _ := new(big.Int).SetString("333333333333333333333333333", 10)
+	twoThirds, _ := new(big.Int).SetString("666666666666666666666666667", 10)
+	requireBigEqual(t, oneThird, rayDiv(big.NewInt(1), big.NewInt(3)))
+	requireBigEqual(t, twoThirds, rayDiv(big.NewInt(2), big.NewInt(3)))
+	requireBigEqual(t, big.NewInt(1), percentMul(big.NewInt(1), big.NewInt(5000)))
+}
+
+func Test_validateInterestRateData(t *testing.T) {
+	require.NoError(t, validateInterestRateData(testRateData()))
+	require.ErrorContains(t, validateInterestRateData(InterestRateDataRay{}), "missing rate parameter")
+
+	rateData := testRateData()
+	rateData.OptimalUsageRatio = new(big.Int).Set(RAYBigInt)
+	require.ErrorContains(t, validateInterestRateData(rateData), "out of range")
+}
+
+/*//////////////////////////////////////////////////////////////
+                    CALCULATE APY FROM MODEL
+//////////////////////////////////////////////////////////////*/
+
+// modelStrategyContract serves testRateData and answers CalculateInterestRates
+// with contractRate, failing the test if callContract is false. reads counts
+// GetInterestRateData calls.
+func modelStrategyContract(t *testing.T, contractRate *big.Int, callContract bool, reads *int) *mockStrategyContract {
+	return &mockStrategyContract{
+		getInterestRateDataFunc: func(_ cre.Runtime, _ default_reserve_interest_rate_strategy_v2.GetInterestRateDataInput, _ *big.Int) cre.Promise[InterestRateDataRay] {
+			*reads++
+			return cre.PromiseFromResult(testRateData(), nil)
+		},
+		calculateInterestRatesFunc: func(_ cre.Runtime, _ default_reserve_interest_rate_strategy_v2.CalculateInterestRatesInput, _ *big.Int) cre.Promise[default_reserve_interest_rate_strategy_v2.CalculateInterestRatesOutput] {
+			require.True(t, callContract, "CalculateInterestRates must not be called")
+			return cre.PromiseFromResult(default_reserve_interest_rate_strategy_v2.CalculateInterestRatesOutput{Arg0: contractRate, Arg1: big.NewInt(0)}, nil)
+		},
+	}
+}
+
+// testRateModel is a model of testRateParams(200e6, 800e6, 0) and testRateData.
+func testRateModel(strategy DefaultReserveInterestRateStrategyV2Interface) *reserveRateModel {
+	return &reserveRateModel{Strategy: strategy, Params: *testRateParams(200e6, 800e6, 0), RateData: testRateData()}
+}
+
+func Test_reserveRateModel_SupplyRateAt(t *testing.T) {
+	model := testRateModel(nil)
+
+	for _, liquidityAdded := range []int64{0, 900e6} {
+		want, _ := calculateInterestRates(testRateParams(200e6, 800e6, liquidityAdded), testRateData())
+		requireBigEqual(t, want, model.SupplyRateAt(big.NewInt(liquidityAdded)))
+	}
+	require.Zero(t, model.Params.LiquidityAdded.Sign(), "a what-if query does not change the model")
+}
+
+func Test_readReserveRateModel_rejectsInvalidRateData(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+	origGetParams := getCalculateInterestRatesParamsFunc
+	t.Cleanup(func() { getCalculateInterestRatesParamsFunc = origGetParams })
+	getCalculateInterestRatesParamsFunc = func(cre.Runtime, AaveProtocolDataProviderInterface, common.Address, *big.Int, *big.Int) cre.Promise[*CalculateInterestRatesParams] {
+		return cre.PromiseFromResult(testRateParams(200e6, 800e6, 0), nil)
+	}
+	strategy := &mockStrategyContract{
+		getInterestRateDataFunc: func(cre.Runtime, default_reserve_interest_rate_strategy_v2.GetInterestRateDataInput, *big.Int) cre.Promise[InterestRateDataRay] {
+			return cre.PromiseFromResult(InterestRateDataRay{}, nil)
+		},
+	}
+
+	_, err := readReserveRateModel(runtime, nil, strategy, common.Address{}, nil).Await()
+
+	require.ErrorContains(t, err, "missing rate parameter")
+}
+
+func Test_calculateAPYFromModel_localSkipsContract(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+	reads := 0
+	model := testRateModel(modelStrategyContract(t, nil, false, &reads))
+	want, err := apyFromLiquidityRate(model.SupplyRateAt(big.NewInt(0)))
+	require.NoError(t, err)
+
+	apy, err := calculateAPYFromModel(runtime, model, big.NewInt(0), false).Await()
+
+	require.NoError(t, err)
+	require.Equal(t, want, apy)
+	require.Zero(t, reads)
+}
+
+func Test_calculateAPYFromModel_verifyAgrees(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+	reads := 0
+	liquidityRate, _ := calculateInterestRates(testRateParams(200e6, 800e6, 0), testRateData())
+	model := testRateModel(modelStrategyContract(t, liquidityRate, true, &reads))
+
+	apy, err := calculateAPYFromModel(runtime, model, big.NewInt(0), true).Await()
+
+	require.NoError(t, err)
+	require.Greater(t, apy, 0.0256)
+	require.Zero(t, reads, "verify reuses the model's rate parameters")
+}
+
+func Test_calculateAPYFromModel_verifyMismatch(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+	reads := 0
+	liquidityRate, _ := calculateInterestRates(testRateParams(200e6, 800e6, 0), testRateData())
+	off := new(big.Int).Add(liquidityRate, big.NewInt(1))
+	model := testRateModel(modelStrategyContract(t, off, true, &reads))
+
+	_, err := calculateAPYFromModel(runtime, model, big.NewInt(0), true).Await()
+
+	require.ErrorContains(t, err, "local Aave rate model disagrees with the contract")
+}
+
+func TestGetAPYPromise_localModeReadsRateModelOncePerRun(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+	cfg := healthTestConfig()
+	cfg.RateModel = helper.RateModelConfig{Mode: helper.RateModelLocal}
+	reads := 0
+	useRateModelReserve(t, modelStrategyContract(t, nil, false, &reads))
+
+	cache := protocol.NewCache()
+	low, err := GetAPYPromise(cfg, runtime, cache, big.NewInt(900e6), 1).Await()
+	require.NoError(t, err)
+	high, err := GetAPYPromise(cfg, runtime, cache, big.NewInt(0), 1).Await()
+	require.NoError(t, err)
+
+	require.Less(t, low, high)
+	require.Equal(t, 1, reads)
+
+	_, err = GetAPYPromise(cfg, runtime, protocol.NewCache(), big.NewInt(0), 1).Await()
+	require.NoError(t, err)
+	require.Equal(t, 2, reads, "a new run reads again")
+}
+
+// useRateModelReserve wires a reserve in the state testRateParams(200e6,
+// 800e6, 0) describes, whose interest rate strategy is strategy.
+func useRateModelReserve(t *testing.T, strategy DefaultReserveInterestRateStrategyV2Interface) {
+	origProvider := newPoolAddressesProviderBindingFunc
+	origGetProvider := getProtocolDataProviderBindingFunc
+	origGetStrategy := getStrategyBindingFunc
+	origGetParams := getCalculateInterestRatesParamsFunc
+	origFitToSupplyCap := fitToSupplyCapFunc
+	t.Cleanup(func() {
+		newPoolAddressesProviderBindingFunc = origProvider
+		getProtocolDataProviderBindingFunc = origGetProvider
+		getStrategyBindingFunc = origGetStrategy
+		getCalculateInterestRatesParamsFunc = origGetParams
+		fitToSupplyCapFunc = origFitToSupplyCap
+	})
+
+	newPoolAddressesProviderBindingFunc = func(*evm.Client, string) (PoolAddressesProviderInterface, error) {
+		return &mockPoolAddressesProvider{}, nil
+	}
+	getProtocolDataProviderBindingFunc = func(cre.Runtime, *evm.Client, PoolAddressesProviderInterface, *big.Int, string) cre.Promise[AaveProtocolDataProviderInterface] {
+		return cre.PromiseFromResult[AaveProtocolDataProviderInterface](&mockProtocolDataProviderForRead{}, nil)
+	}
+	getStrategyBindingFunc = func(cre.Runtime, *evm.Client, AaveProtocolDataProviderInterface, common.Address, *big.Int, string) cre.Promise[DefaultReserveInterestRateStrategyV2Interface] {
+		return cre.PromiseFromResult(strategy, nil)
+	}
+	getCalculateInterestRatesParamsFunc = func(cre.Runtime, AaveProtocolDataProviderInterface, common.Address, *big.Int, *big.Int) cre.Promise[*CalculateInterestRatesParams] {
+		return cre.PromiseFromResult(testRateParams(200e6, 800e6, 0), nil)
+	}
+	fitToSupplyCapFunc = func(_ cre.Runtime, _ AaveProtocolDataProviderInterface, _ helper.AssetConfig, liq *big.Int, _ *big.Int, _ string, _ string) cre.Promise[*big.Int] {
+		return cre.PromiseFromResult(liq, nil)
+	}
+}
+
+func TestGetAPYPromise_error_unknownRateModelMode(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+	cfg := healthTestConfig()
+	cfg.RateModel = helper.RateModelConfig{Mode: "oracle"}
+
+	_, err := GetAPYPromise(cfg, runtime, nil, big.NewInt(0), 1).Await()
+
+	require.ErrorContains(t, err, `unknown rate model mode "oracle"`)
+}
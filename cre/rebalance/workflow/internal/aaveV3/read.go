@@ -21,11 +21,13 @@ import (
 // liquidity internally as: unbacked + liquidityAdded - liquidityTaken.
 //
 // The liquidityAdded parameter is the deposit amount (0 for current APY, deposit amount for projected APY).
+// Every read is made at blockNumber.
 func getCalculateInterestRatesParams(
 	runtime cre.Runtime,
 	protocolDataProvider AaveProtocolDataProviderInterface,
 	reserveAddress common.Address,
 	liquidityAdded *big.Int,
+	blockNumber *big.Int,
 ) cre.Promise[*CalculateInterestRatesParams] {
 	// logger := runtime.Logger()
 	// logger.Info("Fetching CalculateInterestRatesParams", "reserve", reserveAddress.Hex(), "liquidityAdded", liquidityAdded.String())
@@ -35,7 +37,7 @@ func getCalculateInterestRatesParams(
 	reserveDataPromise := protocolDataProvider.GetReserveData(
 		runtime,
 		aave_protocol_data_provider.GetReserveDataInput{Asset: reserveAddress},
-		nil,
+		blockNumber,
 	)
 
 	return cre.ThenPromise(reserveDataPromise, func(reserveData aave_protocol_data_provider.GetReserveDataOutput) cre.Promise[*CalculateInterestRatesParams] {
@@ -54,7 +56,7 @@ func getCalculateInterestRatesParams(
 		virtualBalancePromise := protocolDataProvider.GetVirtualUnderlyingBalance(
 			runtime,
 			aave_protocol_data_provider.GetVirtualUnderlyingBalanceInput{Asset: reserveAddress},
-			nil,
+			blockNumber,
 		)
 
 		return cre.ThenPromise(virtualBalancePromise, func(virtualUnderlyingBalance *big.Int) cre.Promise[*CalculateInterestRatesParams] {
@@ -65,7 +67,7 @@ func getCalculateInterestRatesParams(
 			configPromise := protocolDataProvider.GetReserveConfigurationData(
 				runtime,
 				aave_protocol_data_provider.GetReserveConfigurationDataInput{Asset: reserveAddress},
-				nil,
+				blockNumber,
 			)
 
 			return cre.Then(configPromise, func(configResult aave_protocol_data_provider.GetReserveConfigurationDataOutput) (*CalculateInterestRatesParams, error) {
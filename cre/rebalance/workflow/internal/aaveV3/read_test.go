@@ -22,6 +22,11 @@ type mockProtocolDataProviderForRead struct {
 	getVirtualUnderlyingBalanceFunc    func(cre.Runtime, aave_protocol_data_provider.GetVirtualUnderlyingBalanceInput, *big.Int) cre.Promise[*big.Int]
 	getReserveConfigurationDataFunc    func(cre.Runtime, aave_protocol_data_provider.GetReserveConfigurationDataInput, *big.Int) cre.Promise[aave_protocol_data_provider.GetReserveConfigurationDataOutput]
 	getInterestRateStrategyAddressFunc func(cre.Runtime, aave_protocol_data_provider.GetInterestRateStrategyAddressInput, *big.Int) cre.Promise[common.Address]
+	getReserveTokensAddressesFunc      func(cre.Runtime, aave_protocol_data_provider.GetReserveTokensAddressesInput, *big.Int) cre.Promise[aave_protocol_data_provider.GetReserveTokensAddressesOutput]
+	getATokenTotalSupplyFunc           func(cre.Runtime, aave_protocol_data_provider.GetATokenTotalSupplyInput, *big.Int) cre.Promise[*big.Int]
+	getReserveCapsFunc                 func(cre.Runtime, aave_protocol_data_provider.GetReserveCapsInput, *big.Int) cre.Promise[aave_protocol_data_provider.GetReserveCapsOutput]
+	getPausedFunc                      func(cre.Runtime, aave_protocol_data_provider.GetPausedInput, *big.Int) cre.Promise[bool]
+	getReserveDeficitFunc              func(cre.Runtime, aave_protocol_data_provider.GetReserveDeficitInput, *big.Int) cre.Promise[*big.Int]
 }
 
 func (m *mockProtocolDataProviderForRead) GetReserveData(runtime cre.Runtime, input aave_protocol_data_provider.GetReserveDataInput, blockNumber *big.Int) cre.Promise[aave_protocol_data_provider.GetReserveDataOutput] {
@@ -52,6 +57,41 @@ func (m *mockProtocolDataProviderForRead) GetVirtualUnderlyingBalance(runtime cr
 	return cre.PromiseFromResult[*big.Int](nil, errors.New("not implemented"))
 }
 
+func (m *mockProtocolDataProviderForRead) GetReserveTokensAddresses(runtime cre.Runtime, input aave_protocol_data_provider.GetReserveTokensAddressesInput, blockNumber *big.Int) cre.Promise[aave_protocol_data_provider.GetReserveTokensAddressesOutput] {
+	if m.getReserveTokensAddressesFunc != nil {
+		return m.getReserveTokensAddressesFunc(runtime, input, blockNumber)
+	}
+	return cre.PromiseFromResult(aave_protocol_data_provider.GetReserveTokensAddressesOutput{}, errors.New("not implemented"))
+}
+
+func (m *mockProtocolDataProviderForRead) GetATokenTotalSupply(runtime cre.Runtime, input aave_protocol_data_provider.GetATokenTotalSupplyInput, blockNumber *big.Int) cre.Promise[*big.Int] {
+	if m.getATokenTotalSupplyFunc != nil {
+		return m.getATokenTotalSupplyFunc(runtime, input, blockNumber)
+	}
+	return cre.PromiseFromResult[*big.Int](nil, errors.New("not implemented"))
+}
+
+func (m *mockProtocolDataProviderForRead) GetReserveCaps(runtime cre.Runtime, input aave_protocol_data_provider.GetReserveCapsInput, blockNumber *big.Int) cre.Promise[aave_protocol_data_provider.GetReserveCapsOutput] {
+	if m.getReserveCapsFunc != nil {
+		return m.getReserveCapsFunc(runtime, input, blockNumber)
+	}
+	return cre.PromiseFromResult(aave_protocol_data_provider.GetReserveCapsOutput{}, errors.New("not implemented"))
+}
+
+func (m *mockProtocolDataProviderForRead) GetPaused(runtime cre.Runtime, input aave_protocol_data_provider.GetPausedInput, blockNumber *big.Int) cre.Promise[bool] {
+	if m.getPausedFunc != nil {
+		return m.getPausedFunc(runtime, input, blockNumber)
+	}
+	return cre.PromiseFromResult(false, errors.New("not implemented"))
+}
+
+func (m *mockProtocolDataProviderForRead) GetReserveDeficit(runtime cre.Runtime, input aave_protocol_data_provider.GetReserveDeficitInput, blockNumber *big.Int) cre.Promise[*big.Int] {
+	if m.getReserveDeficitFunc != nil {
+		return m.getReserveDeficitFunc(runtime, input, blockNumber)
+	}
+	return cre.PromiseFromResult[*big.Int](nil, errors.New("not implemented"))
+}
+
 /*//////////////////////////////////////////////////////////////
          FETCH CALCULATE INTEREST RATES PARAMS TESTS
 //////////////////////////////////////////////////////////////*/
@@ -88,7 +128,7 @@ func Test_getCalculateInterestRatesParams_success(t *testing.T) {
 		},
 	}
 
-	promise := getCalculateInterestRatesParams(runtime, mockProvider, reserveAddress, liquidityAdded)
+	promise := getCalculateInterestRatesParams(runtime, mockProvider, reserveAddress, liquidityAdded, nil)
 	params, err := promise.Await()
 
 	require.NoError(t, err)
@@ -132,7 +172,7 @@ func Test_getCalculateInterestRatesParams_zeroLiquidityAdded(t *testing.T) {
 		},
 	}
 
-	promise := getCalculateInterestRatesParams(runtime, mockProvider, reserveAddress, liquidityAdded)
+	promise := getCalculateInterestRatesParams(runtime, mockProvider, reserveAddress, liquidityAdded, nil)
 	params, err := promise.Await()
 
 	require.NoError(t, err)
@@ -167,7 +207,7 @@ func Test_getCalculateInterestRatesParams_zeroDebt(t *testing.T) {
 		},
 	}
 
-	promise := getCalculateInterestRatesParams(runtime, mockProvider, reserveAddress, liquidityAdded)
+	promise := getCalculateInterestRatesParams(runtime, mockProvider, reserveAddress, liquidityAdded, nil)
 	params, err := promise.Await()
 
 	require.NoError(t, err)
@@ -186,7 +226,7 @@ func Test_getCalculateInterestRatesParams_getReserveDataError(t *testing.T) {
 		},
 	}
 
-	promise := getCalculateInterestRatesParams(runtime, mockProvider, reserveAddress, liquidityAdded)
+	promise := getCalculateInterestRatesParams(runtime, mockProvider, reserveAddress, liquidityAdded, nil)
 	params, err := promise.Await()
 
 	require.Error(t, err)
@@ -216,7 +256,7 @@ func Test_getCalculateInterestRatesParams_getVirtualUnderlyingBalanceError(t *te
 		},
 	}
 
-	promise := getCalculateInterestRatesParams(runtime, mockProvider, reserveAddress, liquidityAdded)
+	promise := getCalculateInterestRatesParams(runtime, mockProvider, reserveAddress, liquidityAdded, nil)
 	params, err := promise.Await()
 
 	require.Error(t, err)
@@ -250,7 +290,7 @@ func Test_getCalculateInterestRatesParams_getReserveConfigurationDataError(t *te
 		},
 	}
 
-	promise := getCalculateInterestRatesParams(runtime, mockProvider, reserveAddress, liquidityAdded)
+	promise := getCalculateInterestRatesParams(runtime, mockProvider, reserveAddress, liquidityAdded, nil)
 	params, err := promise.Await()
 
 	require.Error(t, err)
@@ -0,0 +1,170 @@
+package aaveV3
+
+import (
+	"fmt"
+	"math/big"
+
+	"rebalance/contracts/evm/src/generated/aave_protocol_data_provider"
+	"rebalance/contracts/evm/src/generated/rewards_controller"
+	"rebalance/workflow/internal/constants"
+	"rebalance/workflow/internal/helper"
+	"rebalance/workflow/internal/price"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/cre-sdk-go/capabilities/blockchain/evm"
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+)
+
+// GetRewardAPRPromise calculates the RewardsController emission APR for
+// supplying the vault asset to AaveV3 on a specific chain after liquidityAdded
+// and returns a Promise. [Needs .Await() after this is called]
+//
+// Only the chain's RewardTokens are considered. A chain without a
+// RewardsController or reward tokens has a reward APR of 0.
+func GetRewardAPRPromise(config *helper.Config, runtime cre.Runtime, liquidityAdded *big.Int, chainSelector uint64) cre.Promise[float64] {
+	evmCfg, err := helper.FindEvmConfigByChainSelector(config.Evms, chainSelector)
+	if err != nil {
+		return cre.PromiseFromResult(0.0, fmt.Errorf("chain config not found for chainSelector %d: %w", chainSelector, err))
+	}
+	if evmCfg.AaveV3PoolAddressesProviderAddress == "" {
+		return cre.PromiseFromResult(0.0, fmt.Errorf("AaveV3PoolAddressesProviderAddress not configured for chain %s", evmCfg.ChainName))
+	}
+
+	return rewardAPRPromiseForProvider(
+		config, runtime, evmCfg,
+		evmCfg.AaveV3PoolAddressesProviderAddress, evmCfg.AaveV3RewardsControllerAddress,
+		vaultAsset(config, evmCfg), liquidityAdded,
+	)
+}
+
+// GetMarketRewardAPRPromise is GetRewardAPRPromise for the Aave-compatible
+// market named marketName, using the market's own RewardsController.
+func GetMarketRewardAPRPromise(config *helper.Config, runtime cre.Runtime, marketName string, liquidityAdded *big.Int, chainSelector uint64) cre.Promise[float64] {
+	evmCfg, err := helper.FindEvmConfigByChainSelector(config.Evms, chainSelector)
+	if err != nil {
+		return cre.PromiseFromResult(0.0, fmt.Errorf("chain config not found for chainSelector %d: %w", chainSelector, err))
+	}
+
+	market, ok := evmCfg.FindAaveMarket(marketName)
+	if !ok || market.PoolAddressesProviderAddress == "" {
+		return cre.PromiseFromResult(0.0, fmt.Errorf("Aave market %s not configured for chain %s", marketName, evmCfg.ChainName))
+	}
+
+	return rewardAPRPromiseForProvider(
+		config, runtime, evmCfg,
+		market.PoolAddressesProviderAddress, market.RewardsControllerAddress,
+		vaultAsset(config, evmCfg), liquidityAdded,
+	)
+}
+
+// rewardAPRPromiseForProvider sums the emission APRs the RewardsController at
+// controllerAddress pays on the aToken of the asset reserve of the market
+// behind providerAddress. Emissions are spread over the aToken supply plus
+// liquidityAdded; a reward token is only priced if it is currently emitting.
+func rewardAPRPromiseForProvider(
+	config *helper.Config,
+	runtime cre.Runtime,
+	evmCfg *helper.EvmConfig,
+	providerAddress string,
+	controllerAddress string,
+	asset helper.AssetConfig,
+	liquidityAdded *big.Int,
+) cre.Promise[float64] {
+	if controllerAddress == "" || len(evmCfg.RewardTokens) == 0 {
+		return cre.PromiseFromResult(0.0, nil)
+	}
+	if err := requireAssetAddress(evmCfg, asset); err != nil {
+		return cre.PromiseFromResult(0.0, err)
+	}
+	if liquidityAdded == nil {
+		return cre.PromiseFromResult(0.0, fmt.Errorf("liquidityAdded cannot be nil (use big.NewInt(0) for zero value)"))
+	}
+
+	evmClient := &evm.Client{ChainSelector: evmCfg.ChainSelector}
+
+	controller, err := newRewardsControllerBindingFunc(evmClient, controllerAddress)
+	if err != nil {
+		return cre.PromiseFromResult(0.0, fmt.Errorf("failed to create RewardsController binding for chain %s: %w", evmCfg.ChainName, err))
+	}
+	poolAddressesProvider, err := newPoolAddressesProviderBindingFunc(evmClient, providerAddress)
+	if err != nil {
+		return cre.PromiseFromResult(0.0, fmt.Errorf("failed to create PoolAddressesProvider binding for chain %s: %w", evmCfg.ChainName, err))
+	}
+
+	blockNumber := big.NewInt(config.BlockNumber)
+	now := runtime.Now().Unix()
+	protocolDataProviderPromise := getProtocolDataProviderBindingFunc(runtime, evmClient, poolAddressesProvider, blockNumber, evmCfg.ChainName)
+
+	return cre.ThenPromise(protocolDataProviderPromise, func(protocolDataProvider AaveProtocolDataProviderInterface) cre.Promise[float64] {
+		assetAddress := common.HexToAddress(asset.Address)
+		tokensPromise := protocolDataProvider.GetReserveTokensAddresses(runtime, aave_protocol_data_provider.GetReserveTokensAddressesInput{Asset: assetAddress}, blockNumber)
+		supplyPromise := protocolDataProvider.GetATokenTotalSupply(runtime, aave_protocol_data_provider.GetATokenTotalSupplyInput{Asset: assetAddress}, blockNumber)
+
+		return cre.ThenPromise(tokensPromise, func(tokens aave_protocol_data_provider.GetReserveTokensAddressesOutput) cre.Promise[float64] {
+			if tokens.ATokenAddress == (common.Address{}) {
+				return cre.PromiseFromResult(0.0, fmt.Errorf("no aToken for %s on chain %s", asset.Symbol, evmCfg.ChainName))
+			}
+
+			// Issue every getRewardsData read before awaiting any of them.
+			rewardsData := make([]cre.Promise[rewards_controller.GetRewardsDataOutput], len(evmCfg.RewardTokens))
+			for i, token := range evmCfg.RewardTokens {
+				if !common.IsHexAddress(token.Address) {
+					return cre.PromiseFromResult(0.0, fmt.Errorf("reward token %s on chain %s has invalid address %q", token.Symbol, evmCfg.ChainName, token.Address))
+				}
+				rewardsData[i] = controller.GetRewardsData(runtime, rewards_controller.GetRewardsDataInput{
+					Asset:  tokens.ATokenAddress,
+					Reward: common.HexToAddress(token.Address),
+				}, blockNumber)
+			}
+
+			return cre.ThenPromise(supplyPromise, func(aTokenSupply *big.Int) cre.Promise[float64] {
+				supply := new(big.Int).Add(aTokenSupply, liquidityAdded)
+
+				var next func(i int, total float64) cre.Promise[float64]
+				next = func(i int, total float64) cre.Promise[float64] {
+					if i == len(rewardsData) {
+						return cre.PromiseFromResult(total, nil)
+					}
+					return cre.ThenPromise(rewardsData[i], func(data rewards_controller.GetRewardsDataOutput) cre.Promise[float64] {
+						if !isEmitting(data, now) {
+							return next(i+1, total)
+						}
+						token := evmCfg.RewardTokens[i]
+						pricePromise := price.GetUSDPricePromise(config, runtime, evmCfg, token)
+						return cre.ThenPromise(pricePromise, func(rewardPriceUSD float64) cre.Promise[float64] {
+							apr := calculateRewardAPR(data.EmissionPerSecond, token.Decimals, rewardPriceUSD, supply, asset.Decimals)
+							return next(i+1, total+apr)
+						})
+					})
+				}
+				return next(0, 0)
+			})
+		})
+	})
+}
+
+// isEmitting reports whether a reward distribution pays out at unix time now.
+func isEmitting(data rewards_controller.GetRewardsDataOutput, now int64) bool {
+	if data.EmissionPerSecond == nil || data.EmissionPerSecond.Sign() <= 0 {
+		return false
+	}
+	return data.DistributionEnd != nil && data.DistributionEnd.Cmp(big.NewInt(now)) > 0
+}
+
+// calculateRewardAPR is the USD value of a year of emissions over the USD value
+// of supply, valuing the supplied asset at 1 USD:
+//
+//	emissionPerSecond / 10^rewardDecimals * SECONDS_PER_YEAR * rewardPrice / (supply / 10^assetDecimals)
+func calculateRewardAPR(emissionPerSecond *big.Int, rewardDecimals uint8, rewardPriceUSD float64, supply *big.Int, assetDecimals uint8) float64 {
+	if supply.Sign() == 0 {
+		return 0
+	}
+
+	rewardUnit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(rewardDecimals)), nil)
+	perSecond, _ := new(big.Rat).SetFrac(emissionPerSecond, rewardUnit).Float64()
+
+	assetUnit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(assetDecimals)), nil)
+	supplyUSD, _ := new(big.Rat).SetFrac(supply, assetUnit).Float64()
+
+	return perSecond * constants.SecondsPerYear * rewardPriceUSD / supplyUSD
+}
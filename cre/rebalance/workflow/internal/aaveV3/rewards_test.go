@@ -0,0 +1,184 @@
+package aaveV3
+
+import (
+	"math/big"
+	"testing"
+
+	"rebalance/contracts/evm/src/generated/aave_protocol_data_provider"
+	"rebalance/contracts/evm/src/generated/rewards_controller"
+	"rebalance/workflow/internal/constants"
+	"rebalance/workflow/internal/helper"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/cre-sdk-go/capabilities/blockchain/evm"
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+	"github.com/smartcontractkit/cre-sdk-go/cre/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+/*//////////////////////////////////////////////////////////////
+                    TEST HELPERS / MOCKS
+//////////////////////////////////////////////////////////////*/
+
+var (
+	testAToken = common.HexToAddress("0x00000000000000000000000000000000000000a1")
+	testAAVE   = common.HexToAddress("0x00000000000000000000000000000000000000b1")
+	testOP     = common.HexToAddress("0x00000000000000000000000000000000000000b2")
+)
+
+type mockRewardsController struct {
+	data map[common.Address]rewards_controller.GetRewardsDataOutput
+}
+
+func (m *mockRewardsController) GetRewardsData(runtime cre.Runtime, input rewards_controller.GetRewardsDataInput, blockNumber *big.Int) cre.Promise[rewards_controller.GetRewardsDataOutput] {
+	return cre.PromiseFromResult(m.data[input.Reward], nil)
+}
+
+func rewardsTestConfig(tokens ...helper.RewardTokenConfig) *helper.Config {
+	return &helper.Config{
+		BlockNumber: 123,
+		Evms: []helper.EvmConfig{
+			{
+				ChainName:                          "test-chain",
+				ChainSelector:                      1,
+				AaveV3PoolAddressesProviderAddress: "0x0000000000000000000000000000000000000001",
+				AaveV3RewardsControllerAddress:     "0x0000000000000000000000000000000000000003",
+				USDCAddress:                        "0x0000000000000000000000000000000000000002",
+				RewardTokens:                       tokens,
+			},
+		},
+	}
+}
+
+// useRewardsPipeline wires a data provider whose USDC reserve has aTokenSupply
+// supplied, and a RewardsController returning data per reward token.
+func useRewardsPipeline(t *testing.T, aTokenSupply *big.Int, data map[common.Address]rewards_controller.GetRewardsDataOutput) {
+	origProvider := newPoolAddressesProviderBindingFunc
+	origGetProvider := getProtocolDataProviderBindingFunc
+	origController := newRewardsControllerBindingFunc
+	t.Cleanup(func() {
+		newPoolAddressesProviderBindingFunc = origProvider
+		getProtocolDataProviderBindingFunc = origGetProvider
+		newRewardsControllerBindingFunc = origController
+	})
+
+	dataProvider := &mockProtocolDataProviderForRead{
+		getReserveTokensAddressesFunc: func(_ cre.Runtime, _ aave_protocol_data_provider.GetReserveTokensAddressesInput, _ *big.Int) cre.Promise[aave_protocol_data_provider.GetReserveTokensAddressesOutput] {
+			return cre.PromiseFromResult(aave_protocol_data_provider.GetReserveTokensAddressesOutput{ATokenAddress: testAToken}, nil)
+		},
+		getATokenTotalSupplyFunc: func(_ cre.Runtime, _ aave_protocol_data_provider.GetATokenTotalSupplyInput, _ *big.Int) cre.Promise[*big.Int] {
+			return cre.PromiseFromResult(aTokenSupply, nil)
+		},
+	}
+	newPoolAddressesProviderBindingFunc = func(_ *evm.Client, _ string) (PoolAddressesProviderInterface, error) {
+		return &mockPoolAddressesProvider{}, nil
+	}
+	getProtocolDataProviderBindingFunc = func(_ cre.Runtime, _ *evm.Client, _ PoolAddressesProviderInterface, _ *big.Int, _ string) cre.Promise[AaveProtocolDataProviderInterface] {
+		return cre.PromiseFromResult[AaveProtocolDataProviderInterface](dataProvider, nil)
+	}
+	newRewardsControllerBindingFunc = func(_ *evm.Client, _ string) (RewardsControllerInterface, error) {
+		return &mockRewardsController{data: data}, nil
+	}
+}
+
+// emitting is a distribution of emissionPerSecond that ends well after now.
+func emitting(emissionPerSecond *big.Int) rewards_controller.GetRewardsDataOutput {
+	return rewards_controller.GetRewardsDataOutput{
+		EmissionPerSecond: emissionPerSecond,
+		DistributionEnd:   big.NewInt(1 << 40),
+	}
+}
+
+/*//////////////////////////////////////////////////////////////
+                         REWARD APR
+//////////////////////////////////////////////////////////////*/
+
+func Test_calculateRewardAPR(t *testing.T) {
+	// 0.01 AAVE (18 decimals) per second over 10M USDC at 100 USD/AAVE.
+	emission := big.NewInt(10_000_000_000_000_000)
+	apr := calculateRewardAPR(emission, 18, 100, big.NewInt(10_000_000_000_000), 6)
+	require.InDelta(t, 0.01*constants.SecondsPerYear*100/10_000_000, apr, 1e-12)
+
+	require.Zero(t, calculateRewardAPR(emission, 18, 100, big.NewInt(0), 6))
+}
+
+func TestGetRewardAPRPromise_sumsEmittingTokens(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+	useRewardsPipeline(t, big.NewInt(9_000_000), map[common.Address]rewards_controller.GetRewardsDataOutput{
+		testAAVE: emitting(big.NewInt(1_000_000_000_000_000)), // 0.001 AAVE/s
+		testOP:   emitting(big.NewInt(2_000_000_000_000_000)), // 0.002 OP/s
+	})
+	cfg := rewardsTestConfig(
+		helper.RewardTokenConfig{Symbol: "AAVE", Address: testAAVE.Hex(), Decimals: 18, PriceUSD: 100},
+		helper.RewardTokenConfig{Symbol: "OP", Address: testOP.Hex(), Decimals: 18, PriceUSD: 2},
+	)
+
+	// Spread over 9 + 1 USDC of supply.
+	apr, err := GetRewardAPRPromise(cfg, runtime, big.NewInt(1_000_000), 1).Await()
+
+	require.NoError(t, err)
+	expected := (0.001*100 + 0.002*2) * constants.SecondsPerYear / 10
+	require.InDelta(t, expected, apr, 1e-6)
+}
+
+func TestGetRewardAPRPromise_skipsEndedAndIdleDistributions(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+	useRewardsPipeline(t, big.NewInt(1_000_000), map[common.Address]rewards_controller.GetRewardsDataOutput{
+		testAAVE: {EmissionPerSecond: big.NewInt(1), DistributionEnd: big.NewInt(1)},
+		testOP:   emitting(big.NewInt(0)),
+	})
+	// No prices: tokens that are not emitting must not be priced.
+	cfg := rewardsTestConfig(
+		helper.RewardTokenConfig{Symbol: "AAVE", Address: testAAVE.Hex(), Decimals: 18},
+		helper.RewardTokenConfig{Symbol: "OP", Address: testOP.Hex(), Decimals: 18},
+	)
+
+	apr, err := GetRewardAPRPromise(cfg, runtime, big.NewInt(0), 1).Await()
+
+	require.NoError(t, err)
+	require.Zero(t, apr)
+}
+
+func TestGetRewardAPRPromise_zeroWithoutController(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+	cfg := rewardsTestConfig(helper.RewardTokenConfig{Symbol: "AAVE", Address: testAAVE.Hex(), Decimals: 18, PriceUSD: 100})
+	cfg.Evms[0].AaveV3RewardsControllerAddress = ""
+
+	apr, err := GetRewardAPRPromise(cfg, runtime, big.NewInt(0), 1).Await()
+
+	require.NoError(t, err)
+	require.Zero(t, apr)
+}
+
+func TestGetRewardAPRPromise_error_whenPriceMissing(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+	useRewardsPipeline(t, big.NewInt(1_000_000), map[common.Address]rewards_controller.GetRewardsDataOutput{
+		testAAVE: emitting(big.NewInt(1)),
+	})
+	cfg := rewardsTestConfig(helper.RewardTokenConfig{Symbol: "AAVE", Address: testAAVE.Hex(), Decimals: 18})
+
+	_, err := GetRewardAPRPromise(cfg, runtime, big.NewInt(0), 1).Await()
+
+	require.ErrorContains(t, err, "no price source for reward token AAVE on chain test-chain")
+}
+
+func TestGetMarketRewardAPRPromise_usesMarketController(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+	useRewardsPipeline(t, big.NewInt(1_000_000), map[common.Address]rewards_controller.GetRewardsDataOutput{
+		testAAVE: emitting(big.NewInt(1_000_000_000_000_000)),
+	})
+	cfg := rewardsTestConfig(helper.RewardTokenConfig{Symbol: "AAVE", Address: testAAVE.Hex(), Decimals: 18, PriceUSD: 100})
+	cfg.Evms[0].AaveV3RewardsControllerAddress = ""
+	cfg.Evms[0].AaveMarkets = []helper.AaveMarketConfig{
+		{Name: "spark", PoolAddressesProviderAddress: "0x0000000000000000000000000000000000000004"},
+		{Name: "prime", PoolAddressesProviderAddress: "0x0000000000000000000000000000000000000005", RewardsControllerAddress: "0x0000000000000000000000000000000000000006"},
+	}
+
+	apr, err := GetMarketRewardAPRPromise(cfg, runtime, "spark", big.NewInt(0), 1).Await()
+	require.NoError(t, err)
+	require.Zero(t, apr, "spark has no RewardsController")
+
+	apr, err = GetMarketRewardAPRPromise(cfg, runtime, "prime", big.NewInt(0), 1).Await()
+	require.NoError(t, err)
+	require.InDelta(t, 0.001*constants.SecondsPerYear*100, apr, 1e-6)
+}
@@ -7,7 +7,9 @@ import (
 	"rebalance/contracts/evm/src/generated/comet"
 	"rebalance/workflow/internal/constants"
 	"rebalance/workflow/internal/helper"
+	"rebalance/workflow/internal/protocol"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/smartcontractkit/cre-sdk-go/capabilities/blockchain/evm"
 	"github.com/smartcontractkit/cre-sdk-go/cre"
 )
@@ -20,13 +22,14 @@ import (
 // Parameters:
 //   - config: The helper.Config containing all chain configurations
 //   - runtime: CRE runtime for contract calls
+//   - cache: The run's cache of shared reads (nil caches nothing)
 //   - liquidityAdded: Amount of liquidity being added (use big.NewInt(0) for current APY)
 //   - chainSelector: Chain selector to identify which chain config to use
 //
 // Returns:
 //   - Promise of APY as float64 (e.g., 0.0523 = 5.23%)
 //   - Error will be returned when Promise is awaited if chain not found or APY calculation fails
-func GetAPYPromise(config *helper.Config, runtime cre.Runtime, liquidityAdded *big.Int, chainSelector uint64) cre.Promise[float64] {
+func GetAPYPromise(config *helper.Config, runtime cre.Runtime, cache *protocol.Cache, liquidityAdded *big.Int, chainSelector uint64) cre.Promise[float64] {
 	// Find the chain config by chainSelector
 	evmCfg, err := helper.FindEvmConfigByChainSelector(config.Evms, chainSelector)
 	if err != nil {
@@ -49,15 +52,82 @@ func GetAPYPromise(config *helper.Config, runtime cre.Runtime, liquidityAdded *b
 	}
 
 	// Step 2: Create Comet binding
-	cometUSDC, err := newCometBindingFunc(evmClient, evmCfg.CompoundV3CometUSDCAddress) // @review CometAddr will depend on stablecoin
+	cometUSDC, err := newCometBindingFunc(evmClient, evmCfg.CompoundV3CometUSDCAddress)
 	if err != nil {
 		return cre.PromiseFromResult(0.0, fmt.Errorf("failed to create Comet binding for chain %s: %w", evmCfg.ChainName, err))
 	}
 
+	key := supplyRateModelKey{chainSelector: evmCfg.ChainSelector, comet: common.HexToAddress(evmCfg.CompoundV3CometUSDCAddress)}
+	return cometAPYPromise(runtime, cache, cometUSDC, key, big.NewInt(config.BlockNumber), liquidityAdded, config.RateModel.Mode)
+}
+
+// GetMarketAPYPromise is GetAPYPromise for the Comet market named marketName
+// (see helper.CompoundV3MarketConfig) on a specific chain. It fails if the
+// Comet's baseToken() is not the configured base asset.
+func GetMarketAPYPromise(config *helper.Config, runtime cre.Runtime, cache *protocol.Cache, marketName string, liquidityAdded *big.Int, chainSelector uint64) cre.Promise[float64] {
+	evmCfg, market, err := findMarket(config, marketName, chainSelector)
+	if err != nil {
+		return cre.PromiseFromResult(0.0, err)
+	}
+	if !common.IsHexAddress(market.BaseAsset) {
+		return cre.PromiseFromResult(0.0, fmt.Errorf("invalid base asset %q for Compound v3 market %s on chain %s", market.BaseAsset, marketName, evmCfg.ChainName))
+	}
+
+	if liquidityAdded == nil {
+		return cre.PromiseFromResult(0.0, fmt.Errorf("liquidityAdded cannot be nil (use big.NewInt(0) for zero value)"))
+	}
+
+	evmClient := &evm.Client{
+		ChainSelector: evmCfg.ChainSelector,
+	}
+
+	cometMarket, err := newCometBindingFunc(evmClient, market.CometAddress)
+	if err != nil {
+		return cre.PromiseFromResult(0.0, fmt.Errorf("failed to create Comet binding for market %s on chain %s: %w", marketName, evmCfg.ChainName, err))
+	}
+
+	// Issue the base token check alongside the rate reads; only the result
+	// waits on it.
 	blockNumber := big.NewInt(config.BlockNumber)
+	baseTokenPromise := cometMarket.BaseToken(runtime, blockNumber)
+	key := supplyRateModelKey{chainSelector: evmCfg.ChainSelector, comet: common.HexToAddress(market.CometAddress)}
+	apyPromise := cometAPYPromise(runtime, cache, cometMarket, key, blockNumber, liquidityAdded, config.RateModel.Mode)
+
+	return cre.ThenPromise(baseTokenPromise, func(baseToken common.Address) cre.Promise[float64] {
+		if baseToken != common.HexToAddress(market.BaseAsset) {
+			return cre.PromiseFromResult(0.0, fmt.Errorf("Compound v3 market %s on chain %s has base token %s, configured base asset is %s", marketName, evmCfg.ChainName, baseToken.Hex(), market.BaseAsset))
+		}
+		return apyPromise
+	})
+}
+
+// findMarket returns the chain config and the Comet market named marketName on it.
+func findMarket(config *helper.Config, marketName string, chainSelector uint64) (*helper.EvmConfig, helper.CompoundV3MarketConfig, error) {
+	evmCfg, err := helper.FindEvmConfigByChainSelector(config.Evms, chainSelector)
+	if err != nil {
+		return nil, helper.CompoundV3MarketConfig{}, fmt.Errorf("chain config not found for chainSelector %d: %w", chainSelector, err)
+	}
+
+	market, ok := evmCfg.FindCompoundV3Market(marketName)
+	if !ok || market.CometAddress == "" {
+		return nil, helper.CompoundV3MarketConfig{}, fmt.Errorf("Compound v3 market %s not configured for chain %s", marketName, evmCfg.ChainName)
+	}
+	return evmCfg, market, nil
+}
+
+// cometAPYPromise returns a promise of the supply APY of cometMarket at
+// blockNumber after supplying liquidityAdded. The supply rate comes from
+// getSupplyRate, or from the Comet's supplyRateModel as rateModelMode selects
+// (see helper.RateModelConfig). key identifies cometMarket.
+func cometAPYPromise(runtime cre.Runtime, cache *protocol.Cache, cometMarket CometInterface, key supplyRateModelKey, blockNumber *big.Int, liquidityAdded *big.Int, rateModelMode string) cre.Promise[float64] {
+	switch rateModelMode {
+	case "", helper.RateModelContract, helper.RateModelLocal, helper.RateModelVerify:
+	default:
+		return cre.PromiseFromResult(0.0, fmt.Errorf("unknown rate model mode %q", rateModelMode))
+	}
 
 	// Step 3: TotalSupply at the configured block
-	totalSupplyPromise := cometUSDC.TotalSupply(runtime, blockNumber)
+	totalSupplyPromise := cometMarket.TotalSupply(runtime, blockNumber)
 
 	// Step 4+: Chain the rest of the pipeline:
 	//   totalSupply -> (optionally + liquidityAdded)
@@ -76,19 +146,14 @@ func GetAPYPromise(config *helper.Config, runtime cre.Runtime, liquidityAdded *b
 		}
 
 		// Fetch total borrow
-		totalBorrowPromise := cometUSDC.TotalBorrow(runtime, blockNumber)
+		totalBorrowPromise := cometMarket.TotalBorrow(runtime, blockNumber)
 
 		return cre.ThenPromise(totalBorrowPromise, func(totalBorrow *big.Int) cre.Promise[float64] {
 			// utilization = (borrow * 1e18) / supply
 			utilization := new(big.Int).Mul(totalBorrow, big.NewInt(constants.WAD))
 			utilization.Div(utilization, totalSupply)
 
-			// Get supply rate from Comet
-			input := comet.GetSupplyRateInput{
-				Utilization: utilization,
-			}
-
-			supplyRatePromise := cometUSDC.GetSupplyRate(runtime, input, blockNumber)
+			supplyRatePromise := supplyRatePromise(runtime, cache, cometMarket, key, blockNumber, utilization, rateModelMode)
 
 			return cre.ThenPromise(supplyRatePromise, func(supplyRate uint64) cre.Promise[float64] {
 				apy := calculateAPYFromSupplyRate(supplyRate)
@@ -97,3 +162,35 @@ func GetAPYPromise(config *helper.Config, runtime cre.Runtime, liquidityAdded *b
 		})
 	})
 }
+
+// supplyRatePromise returns the per-second supply rate of cometMarket at
+// utilization: from getSupplyRate, from its supplyRateModel, or from the model
+// cross-checked with getSupplyRate, as rateModelMode selects. The model holds
+// Comet immutables, so it is read once per Comet into cache.
+func supplyRatePromise(runtime cre.Runtime, cache *protocol.Cache, cometMarket CometInterface, key supplyRateModelKey, blockNumber *big.Int, utilization *big.Int, rateModelMode string) cre.Promise[uint64] {
+	if rateModelMode != helper.RateModelLocal && rateModelMode != helper.RateModelVerify {
+		// Get supply rate from Comet
+		input := comet.GetSupplyRateInput{
+			Utilization: utilization,
+		}
+		return cometMarket.GetSupplyRate(runtime, input, blockNumber)
+	}
+
+	modelPromise := protocol.Cached(cache, key, func() cre.Promise[supplyRateModel] {
+		return readSupplyRateModel(runtime, cometMarket, blockNumber)
+	})
+	return cre.ThenPromise(modelPromise, func(model supplyRateModel) cre.Promise[uint64] {
+		rate, err := model.supplyRate(utilization)
+		if err != nil || rateModelMode != helper.RateModelVerify {
+			return cre.PromiseFromResult(rate, err)
+		}
+
+		onchainPromise := cometMarket.GetSupplyRate(runtime, comet.GetSupplyRateInput{Utilization: utilization}, blockNumber)
+		return cre.Then(onchainPromise, func(onchainRate uint64) (uint64, error) {
+			if onchainRate != rate {
+				return 0, fmt.Errorf("local Comet supply rate model disagrees with getSupplyRate at utilization %s: %d, contract %d", utilization, rate, onchainRate)
+			}
+			return rate, nil
+		})
+	})
+}
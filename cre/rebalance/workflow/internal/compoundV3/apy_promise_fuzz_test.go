@@ -110,7 +110,7 @@ func Fuzz_GetAPYPromise_LiquidityAndSupplyRate(f *testing.F) {
 		}
 
 		// Call the function under test.
-		promise := GetAPYPromise(cfg, runtime, liquidityAdded, cfg.Evms[0].ChainSelector)
+		promise := GetAPYPromise(cfg, runtime, nil, liquidityAdded, cfg.Evms[0].ChainSelector)
 
 		apy, err := promise.Await()
 		require.NoError(t, err, "GetAPYPromise should not error when baseSupply > 0 and liquidityAdded >= 0")
@@ -8,6 +8,7 @@ import (
 	"rebalance/workflow/internal/constants"
 	"rebalance/workflow/internal/helper"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/smartcontractkit/cre-sdk-go/capabilities/blockchain/evm"
 	"github.com/smartcontractkit/cre-sdk-go/cre"
 	"github.com/smartcontractkit/cre-sdk-go/cre/testutils"
@@ -23,6 +24,23 @@ type fakeComet struct {
 	totalSupply *big.Int
 	totalBorrow *big.Int
 	supplyRate  uint64
+	baseToken   common.Address
+
+	// reward emissions
+	trackingSpeed uint64
+	trackingScale uint64
+	decimals      uint8
+
+	// pause guardian flags
+	supplyPaused   bool
+	withdrawPaused bool
+
+	// supply rate curve; nil fields read as zero
+	supplyKink      *big.Int
+	supplyRateBase  *big.Int
+	supplySlopeLow  *big.Int
+	supplySlopeHigh *big.Int
+	curveReads      int // SupplyKink calls
 
 	// optional error injection for sync / promise tests
 	totalSupplyErr error
@@ -69,6 +87,54 @@ func (f *fakeComet) GetSupplyRate(runtime cre.Runtime, input comet.GetSupplyRate
 	return cre.PromiseFromResult(f.supplyRate, nil)
 }
 
+func (f *fakeComet) BaseToken(runtime cre.Runtime, blockNumber *big.Int) cre.Promise[common.Address] {
+	return cre.PromiseFromResult(f.baseToken, nil)
+}
+
+func (f *fakeComet) BaseTrackingSupplySpeed(runtime cre.Runtime, blockNumber *big.Int) cre.Promise[uint64] {
+	return cre.PromiseFromResult(f.trackingSpeed, nil)
+}
+
+func (f *fakeComet) TrackingIndexScale(runtime cre.Runtime, blockNumber *big.Int) cre.Promise[uint64] {
+	return cre.PromiseFromResult(f.trackingScale, nil)
+}
+
+func (f *fakeComet) Decimals(runtime cre.Runtime, blockNumber *big.Int) cre.Promise[uint8] {
+	return cre.PromiseFromResult(f.decimals, nil)
+}
+
+func (f *fakeComet) IsSupplyPaused(runtime cre.Runtime, blockNumber *big.Int) cre.Promise[bool] {
+	return cre.PromiseFromResult(f.supplyPaused, nil)
+}
+
+func (f *fakeComet) IsWithdrawPaused(runtime cre.Runtime, blockNumber *big.Int) cre.Promise[bool] {
+	return cre.PromiseFromResult(f.withdrawPaused, nil)
+}
+
+func (f *fakeComet) SupplyKink(runtime cre.Runtime, blockNumber *big.Int) cre.Promise[*big.Int] {
+	f.curveReads++
+	return cre.PromiseFromResult(orZero(f.supplyKink), nil)
+}
+
+func (f *fakeComet) SupplyPerSecondInterestRateBase(runtime cre.Runtime, blockNumber *big.Int) cre.Promise[*big.Int] {
+	return cre.PromiseFromResult(orZero(f.supplyRateBase), nil)
+}
+
+func (f *fakeComet) SupplyPerSecondInterestRateSlopeLow(runtime cre.Runtime, blockNumber *big.Int) cre.Promise[*big.Int] {
+	return cre.PromiseFromResult(orZero(f.supplySlopeLow), nil)
+}
+
+func (f *fakeComet) SupplyPerSecondInterestRateSlopeHigh(runtime cre.Runtime, blockNumber *big.Int) cre.Promise[*big.Int] {
+	return cre.PromiseFromResult(orZero(f.supplySlopeHigh), nil)
+}
+
+func orZero(x *big.Int) *big.Int {
+	if x == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).Set(x)
+}
+
 var _ CometInterface = (*fakeComet)(nil)
 
 /*//////////////////////////////////////////////////////////////
@@ -79,7 +145,7 @@ func TestGetAPYPromise_error_whenChainConfigNotFound(t *testing.T) {
 	cfg := &helper.Config{Evms: []helper.EvmConfig{}}
 	runtime := testutils.NewRuntime(t, nil)
 
-	p := GetAPYPromise(cfg, runtime, big.NewInt(0), 123)
+	p := GetAPYPromise(cfg, runtime, nil, big.NewInt(0), 123)
 	apy, err := p.Await()
 
 	require.Error(t, err)
@@ -99,7 +165,7 @@ func TestGetAPYPromise_error_whenCometAddressMissing(t *testing.T) {
 	}
 	runtime := testutils.NewRuntime(t, nil)
 
-	p := GetAPYPromise(cfg, runtime, big.NewInt(0), 1)
+	p := GetAPYPromise(cfg, runtime, nil, big.NewInt(0), 1)
 	apy, err := p.Await()
 
 	require.Error(t, err)
@@ -119,7 +185,7 @@ func TestGetAPYPromise_error_whenLiquidityNil(t *testing.T) {
 	}
 	runtime := testutils.NewRuntime(t, nil)
 
-	p := GetAPYPromise(cfg, runtime, nil, 1)
+	p := GetAPYPromise(cfg, runtime, nil, nil, 1)
 	apy, err := p.Await()
 
 	require.Error(t, err)
@@ -145,7 +211,7 @@ func TestGetAPYPromise_error_whenCometBindingFails(t *testing.T) {
 	newCometBindingFunc = newCometBinding
 	defer func() { newCometBindingFunc = orig }()
 
-	p := GetAPYPromise(cfg, runtime, big.NewInt(0), 1)
+	p := GetAPYPromise(cfg, runtime, nil, big.NewInt(0), 1)
 	apy, err := p.Await()
 
 	require.Error(t, err)
@@ -178,7 +244,7 @@ func TestGetAPYPromise_error_whenTotalSupplyZero(t *testing.T) {
 	}
 	defer func() { newCometBindingFunc = orig }()
 
-	p := GetAPYPromise(cfg, runtime, big.NewInt(0), 1)
+	p := GetAPYPromise(cfg, runtime, nil, big.NewInt(0), 1)
 	apy, err := p.Await()
 
 	require.Error(t, err)
@@ -221,7 +287,7 @@ func TestGetAPYPromise_success_noExtraLiquidity(t *testing.T) {
 
 	liquidityAdded := big.NewInt(0)
 
-	p := GetAPYPromise(cfg, runtime, liquidityAdded, 1)
+	p := GetAPYPromise(cfg, runtime, nil, liquidityAdded, 1)
 	apy, err := p.Await()
 
 	require.NoError(t, err)
@@ -273,7 +339,7 @@ func TestGetAPYPromise_success_withExtraLiquidity(t *testing.T) {
 	}
 	defer func() { newCometBindingFunc = orig }()
 
-	p := GetAPYPromise(cfg, runtime, liquidityAdded, 99)
+	p := GetAPYPromise(cfg, runtime, nil, liquidityAdded, 99)
 	apy, err := p.Await()
 
 	require.NoError(t, err)
@@ -290,3 +356,83 @@ func TestGetAPYPromise_success_withExtraLiquidity(t *testing.T) {
 	require.NotNil(t, fc.lastUtilization)
 	require.Equal(t, expectedUtilization.String(), fc.lastUtilization.String())
 }
+
+/*//////////////////////////////////////////////////////////////
+                        COMPOUND V3 MARKETS
+//////////////////////////////////////////////////////////////*/
+
+func usdtMarketConfig() *helper.Config {
+	return &helper.Config{
+		BlockNumber: 123,
+		Evms: []helper.EvmConfig{
+			{
+				ChainName:                  "test-chain",
+				ChainSelector:              1,
+				CompoundV3CometUSDCAddress: "0x0000000000000000000000000000000000000001",
+				CompoundV3Markets: []helper.CompoundV3MarketConfig{
+					{
+						Name:         "compound-v3-usdt",
+						CometAddress: "0x0000000000000000000000000000000000000002",
+						BaseAsset:    "0x0000000000000000000000000000000000000003",
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestGetMarketAPYPromise_error_whenMarketNotConfigured(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+
+	apy, err := GetMarketAPYPromise(usdtMarketConfig(), runtime, nil, "compound-v3-usds", big.NewInt(0), 1).Await()
+
+	require.ErrorContains(t, err, "Compound v3 market compound-v3-usds not configured for chain test-chain")
+	require.Equal(t, 0.0, apy)
+}
+
+func TestGetMarketAPYPromise_error_whenBaseTokenMismatch(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+	fc := &fakeComet{
+		totalSupply: big.NewInt(1_000_000),
+		totalBorrow: big.NewInt(500_000),
+		supplyRate:  1_000_000_000,
+		baseToken:   common.HexToAddress("0x0000000000000000000000000000000000000004"),
+	}
+
+	orig := newCometBindingFunc
+	newCometBindingFunc = func(_ *evm.Client, _ string) (CometInterface, error) {
+		return fc, nil
+	}
+	defer func() { newCometBindingFunc = orig }()
+
+	_, err := GetMarketAPYPromise(usdtMarketConfig(), runtime, nil, "compound-v3-usdt", big.NewInt(0), 1).Await()
+
+	require.ErrorContains(t, err, "has base token 0x0000000000000000000000000000000000000004")
+}
+
+func TestGetMarketAPYPromise_success_usesMarketComet(t *testing.T) {
+	cfg := usdtMarketConfig()
+	runtime := testutils.NewRuntime(t, nil)
+	supplyRate := uint64(1_000_000_000)
+	fc := &fakeComet{
+		totalSupply: big.NewInt(1_000_000),
+		totalBorrow: big.NewInt(500_000),
+		supplyRate:  supplyRate,
+		baseToken:   common.HexToAddress(cfg.Evms[0].CompoundV3Markets[0].BaseAsset),
+	}
+
+	var gotAddress string
+	orig := newCometBindingFunc
+	newCometBindingFunc = func(_ *evm.Client, address string) (CometInterface, error) {
+		gotAddress = address
+		return fc, nil
+	}
+	defer func() { newCometBindingFunc = orig }()
+
+	apy, err := GetMarketAPYPromise(cfg, runtime, nil, "compound-v3-usdt", big.NewInt(0), 1).Await()
+
+	require.NoError(t, err)
+	require.Equal(t, calculateAPYFromSupplyRate(supplyRate), apy)
+	require.Equal(t, cfg.Evms[0].CompoundV3Markets[0].CometAddress, gotAddress)
+	require.Equal(t, "123", fc.lastTotalSupplyBlock.String())
+}
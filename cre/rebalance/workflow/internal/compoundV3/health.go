@@ -0,0 +1,63 @@
+package compoundV3
+
+import (
+	"fmt"
+	"math/big"
+
+	"rebalance/workflow/internal/helper"
+
+	"github.com/smartcontractkit/cre-sdk-go/capabilities/blockchain/evm"
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+)
+
+// GetHealthPromise reports the health issues of the chain's USDC Comet and
+// returns a Promise. [Needs .Await() after this is called]
+func GetHealthPromise(config *helper.Config, runtime cre.Runtime, chainSelector uint64) cre.Promise[[]string] {
+	evmCfg, err := helper.FindEvmConfigByChainSelector(config.Evms, chainSelector)
+	if err != nil {
+		return cre.PromiseFromResult[[]string](nil, fmt.Errorf("chain config not found for chainSelector %d: %w", chainSelector, err))
+	}
+	if evmCfg.CompoundV3CometUSDCAddress == "" {
+		return cre.PromiseFromResult[[]string](nil, fmt.Errorf("CompoundV3CometUSDCAddress not configured for chain %s", evmCfg.ChainName))
+	}
+
+	return cometHealthPromise(config, runtime, evmCfg, evmCfg.CompoundV3CometUSDCAddress)
+}
+
+// GetMarketHealthPromise is GetHealthPromise for the Comet market named
+// marketName (see helper.CompoundV3MarketConfig) on a specific chain.
+func GetMarketHealthPromise(config *helper.Config, runtime cre.Runtime, marketName string, chainSelector uint64) cre.Promise[[]string] {
+	evmCfg, market, err := findMarket(config, marketName, chainSelector)
+	if err != nil {
+		return cre.PromiseFromResult[[]string](nil, err)
+	}
+
+	return cometHealthPromise(config, runtime, evmCfg, market.CometAddress)
+}
+
+// cometHealthPromise reads the pause guardian's supply and withdraw flags of
+// the Comet at cometAddress. Either being set makes the market unhealthy: a
+// paused supply cannot take a rebalance, a paused withdraw traps the funds.
+func cometHealthPromise(config *helper.Config, runtime cre.Runtime, evmCfg *helper.EvmConfig, cometAddress string) cre.Promise[[]string] {
+	cometMarket, err := newCometBindingFunc(&evm.Client{ChainSelector: evmCfg.ChainSelector}, cometAddress)
+	if err != nil {
+		return cre.PromiseFromResult[[]string](nil, fmt.Errorf("failed to create Comet binding for chain %s: %w", evmCfg.ChainName, err))
+	}
+
+	blockNumber := big.NewInt(config.BlockNumber)
+	supplyPausedPromise := cometMarket.IsSupplyPaused(runtime, blockNumber)
+	withdrawPausedPromise := cometMarket.IsWithdrawPaused(runtime, blockNumber)
+
+	return cre.ThenPromise(supplyPausedPromise, func(supplyPaused bool) cre.Promise[[]string] {
+		return cre.Then(withdrawPausedPromise, func(withdrawPaused bool) ([]string, error) {
+			var issues []string
+			if supplyPaused {
+				issues = append(issues, "supply paused")
+			}
+			if withdrawPaused {
+				issues = append(issues, "withdraw paused")
+			}
+			return issues, nil
+		})
+	})
+}
@@ -0,0 +1,39 @@
+package compoundV3
+
+import (
+	"testing"
+
+	"rebalance/workflow/internal/helper"
+
+	"github.com/smartcontractkit/cre-sdk-go/cre/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetHealthPromise_healthy(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+	useComet(t, &fakeComet{})
+
+	issues, err := GetHealthPromise(rewardsTestConfig(), runtime, 1).Await()
+
+	require.NoError(t, err)
+	require.Empty(t, issues)
+}
+
+func TestGetHealthPromise_reportsPausedActions(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+	useComet(t, &fakeComet{supplyPaused: true, withdrawPaused: true})
+
+	issues, err := GetHealthPromise(rewardsTestConfig(), runtime, 1).Await()
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"supply paused", "withdraw paused"}, issues)
+}
+
+func TestGetMarketHealthPromise_error_whenMarketNotConfigured(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+	cfg := &helper.Config{Evms: []helper.EvmConfig{{ChainName: "test-chain", ChainSelector: 1}}}
+
+	_, err := GetMarketHealthPromise(cfg, runtime, "usdt", 1).Await()
+
+	require.ErrorContains(t, err, "Compound v3 market usdt not configured for chain test-chain")
+}
@@ -5,6 +5,7 @@ import (
 
 	"rebalance/contracts/evm/src/generated/comet"
 	
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/smartcontractkit/cre-sdk-go/cre"
 )
 
@@ -13,4 +14,17 @@ type CometInterface interface {
 	TotalBorrow(runtime cre.Runtime, blockNumber *big.Int) cre.Promise[*big.Int]
 	// input: uint256 utilization = totalBorrow / totalSupply
 	GetSupplyRate(runtime cre.Runtime, input comet.GetSupplyRateInput, blockNumber *big.Int) cre.Promise[uint64]
+	BaseToken(runtime cre.Runtime, blockNumber *big.Int) cre.Promise[common.Address]
+	// Reward emissions: suppliers accrue baseTrackingSupplySpeed / trackingIndexScale COMP per second.
+	BaseTrackingSupplySpeed(runtime cre.Runtime, blockNumber *big.Int) cre.Promise[uint64]
+	TrackingIndexScale(runtime cre.Runtime, blockNumber *big.Int) cre.Promise[uint64]
+	Decimals(runtime cre.Runtime, blockNumber *big.Int) cre.Promise[uint8]
+	// Pause guardian flags
+	IsSupplyPaused(runtime cre.Runtime, blockNumber *big.Int) cre.Promise[bool]
+	IsWithdrawPaused(runtime cre.Runtime, blockNumber *big.Int) cre.Promise[bool]
+	// Supply rate curve immutables (see supplyRateModel), all scaled by 1e18
+	SupplyKink(runtime cre.Runtime, blockNumber *big.Int) cre.Promise[*big.Int]
+	SupplyPerSecondInterestRateBase(runtime cre.Runtime, blockNumber *big.Int) cre.Promise[*big.Int]
+	SupplyPerSecondInterestRateSlopeLow(runtime cre.Runtime, blockNumber *big.Int) cre.Promise[*big.Int]
+	SupplyPerSecondInterestRateSlopeHigh(runtime cre.Runtime, blockNumber *big.Int) cre.Promise[*big.Int]
 }
\ No newline at end of file
@@ -0,0 +1,71 @@
+package compoundV3
+
+import (
+	"fmt"
+	"math/big"
+
+	"rebalance/workflow/internal/helper"
+
+	"github.com/smartcontractkit/cre-sdk-go/capabilities/blockchain/evm"
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+)
+
+// GetAvailableLiquidityPromise returns how much base asset could be withdrawn
+// from the chain's USDC Comet after liquidityAdded is supplied.
+// [Needs .Await() after this is called]
+func GetAvailableLiquidityPromise(config *helper.Config, runtime cre.Runtime, liquidityAdded *big.Int, chainSelector uint64) cre.Promise[*big.Int] {
+	evmCfg, err := helper.FindEvmConfigByChainSelector(config.Evms, chainSelector)
+	if err != nil {
+		return cre.PromiseFromResult[*big.Int](nil, fmt.Errorf("chain config not found for chainSelector %d: %w", chainSelector, err))
+	}
+	if evmCfg.CompoundV3CometUSDCAddress == "" {
+		return cre.PromiseFromResult[*big.Int](nil, fmt.Errorf("CompoundV3CometUSDCAddress not configured for chain %s", evmCfg.ChainName))
+	}
+
+	return cometLiquidityPromise(config, runtime, evmCfg, evmCfg.CompoundV3CometUSDCAddress, liquidityAdded)
+}
+
+// GetMarketAvailableLiquidityPromise is GetAvailableLiquidityPromise for the
+// Comet market named marketName on a specific chain.
+func GetMarketAvailableLiquidityPromise(config *helper.Config, runtime cre.Runtime, marketName string, liquidityAdded *big.Int, chainSelector uint64) cre.Promise[*big.Int] {
+	evmCfg, market, err := findMarket(config, marketName, chainSelector)
+	if err != nil {
+		return cre.PromiseFromResult[*big.Int](nil, err)
+	}
+
+	return cometLiquidityPromise(config, runtime, evmCfg, market.CometAddress, liquidityAdded)
+}
+
+// cometLiquidityPromise reads the total supply and total borrow of the Comet at
+// cometAddress; what is supplied and not lent out is what can be withdrawn.
+func cometLiquidityPromise(config *helper.Config, runtime cre.Runtime, evmCfg *helper.EvmConfig, cometAddress string, liquidityAdded *big.Int) cre.Promise[*big.Int] {
+	if liquidityAdded == nil {
+		return cre.PromiseFromResult[*big.Int](nil, fmt.Errorf("liquidityAdded cannot be nil (use big.NewInt(0) for zero value)"))
+	}
+
+	cometMarket, err := newCometBindingFunc(&evm.Client{ChainSelector: evmCfg.ChainSelector}, cometAddress)
+	if err != nil {
+		return cre.PromiseFromResult[*big.Int](nil, fmt.Errorf("failed to create Comet binding for chain %s: %w", evmCfg.ChainName, err))
+	}
+
+	blockNumber := big.NewInt(config.BlockNumber)
+	totalSupplyPromise := cometMarket.TotalSupply(runtime, blockNumber)
+	totalBorrowPromise := cometMarket.TotalBorrow(runtime, blockNumber)
+
+	return cre.ThenPromise(totalSupplyPromise, func(totalSupply *big.Int) cre.Promise[*big.Int] {
+		return cre.Then(totalBorrowPromise, func(totalBorrow *big.Int) (*big.Int, error) {
+			return availableLiquidity(totalSupply, totalBorrow, liquidityAdded), nil
+		})
+	})
+}
+
+// availableLiquidity is totalSupply + liquidityAdded - totalBorrow, floored at
+// 0. Reserves the governor could withdraw are not counted.
+func availableLiquidity(totalSupply, totalBorrow, liquidityAdded *big.Int) *big.Int {
+	available := new(big.Int).Add(totalSupply, liquidityAdded)
+	available.Sub(available, totalBorrow)
+	if available.Sign() < 0 {
+		return new(big.Int)
+	}
+	return available
+}
@@ -0,0 +1,34 @@
+package compoundV3
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/smartcontractkit/cre-sdk-go/cre/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_availableLiquidity(t *testing.T) {
+	require.Equal(t, big.NewInt(350), availableLiquidity(big.NewInt(1000), big.NewInt(750), big.NewInt(100)))
+	require.Equal(t, big.NewInt(0), availableLiquidity(big.NewInt(1000), big.NewInt(1200), big.NewInt(0)), "over-borrowed: nothing to withdraw")
+}
+
+func TestGetAvailableLiquidityPromise_includesDeposit(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+	useComet(t, &fakeComet{totalSupply: big.NewInt(1_000_000), totalBorrow: big.NewInt(900_000)})
+
+	available, err := GetAvailableLiquidityPromise(rewardsTestConfig(), runtime, big.NewInt(50_000), 1).Await()
+
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(150_000), available)
+}
+
+func TestGetAvailableLiquidityPromise_error_whenTotalBorrowFails(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+	useComet(t, &fakeComet{totalSupply: big.NewInt(1_000_000), totalBorrowErr: errors.New("rpc down")})
+
+	_, err := GetAvailableLiquidityPromise(rewardsTestConfig(), runtime, big.NewInt(0), 1).Await()
+
+	require.ErrorContains(t, err, "rpc down")
+}
@@ -0,0 +1,125 @@
+package compoundV3
+
+import (
+	"fmt"
+	"math/big"
+
+	"rebalance/workflow/internal/helper"
+	"rebalance/workflow/internal/protocol"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+)
+
+// Name is the CompoundV3 protocol name; its protocol ID is keccak256(Name).
+const Name = "compound-v3"
+
+// ProtocolId is the CompoundV3 protocol ID.
+var ProtocolId = protocol.IDFromName(Name)
+
+// MarketFamilyName names the family of configured Comet markets beyond the
+// USDC Comet.
+const MarketFamilyName = "compound-v3-markets"
+
+func init() {
+	protocol.Register(compoundV3Protocol{})
+	protocol.RegisterFamily(marketFamily{})
+}
+
+// compoundV3Protocol registers CompoundV3 as a yield source.
+type compoundV3Protocol struct{}
+
+func (compoundV3Protocol) ID() [32]byte { return ProtocolId }
+
+func (compoundV3Protocol) Name() string { return Name }
+
+// IsConfigured only offers the USDC Comet to a USDC vault asset.
+func (compoundV3Protocol) IsConfigured(evm helper.EvmConfig, asset helper.AssetConfig) bool {
+	return evm.CompoundV3CometUSDCAddress != "" && asset.Symbol == helper.DefaultAsset
+}
+
+func (compoundV3Protocol) GetAPYPromise(config *helper.Config, runtime cre.Runtime, cache *protocol.Cache, liquidityAdded *big.Int, chainSelector uint64) cre.Promise[float64] {
+	return GetAPYPromise(config, runtime, cache, liquidityAdded, chainSelector)
+}
+
+func (compoundV3Protocol) GetRewardAPRPromise(config *helper.Config, runtime cre.Runtime, liquidityAdded *big.Int, chainSelector uint64) cre.Promise[float64] {
+	return GetRewardAPRPromise(config, runtime, liquidityAdded, chainSelector)
+}
+
+func (compoundV3Protocol) GetHealthPromise(config *helper.Config, runtime cre.Runtime, chainSelector uint64) cre.Promise[[]string] {
+	return GetHealthPromise(config, runtime, chainSelector)
+}
+
+func (compoundV3Protocol) GetAvailableLiquidityPromise(config *helper.Config, runtime cre.Runtime, liquidityAdded *big.Int, chainSelector uint64) cre.Promise[*big.Int] {
+	return GetAvailableLiquidityPromise(config, runtime, liquidityAdded, chainSelector)
+}
+
+// marketFamily contributes one protocol per Comet market name in config.
+type marketFamily struct{}
+
+func (marketFamily) Name() string { return MarketFamilyName }
+
+// Protocols returns a protocol for every distinct market name across chains, in
+// first-seen order. A name twice on one chain, or an invalid market, is an error.
+func (marketFamily) Protocols(config *helper.Config) ([]protocol.Protocol, error) {
+	var protocols []protocol.Protocol
+	seen := make(map[string]bool)
+
+	for _, evm := range config.Evms {
+		onChain := make(map[string]bool, len(evm.CompoundV3Markets))
+		for _, m := range evm.CompoundV3Markets {
+			if m.Name == "" {
+				return nil, fmt.Errorf("Compound v3 market %s on chain %s has no name", m.CometAddress, evm.ChainName)
+			}
+			if onChain[m.Name] {
+				return nil, fmt.Errorf("Compound v3 market %s is configured more than once on chain %s", m.Name, evm.ChainName)
+			}
+			onChain[m.Name] = true
+			if !common.IsHexAddress(m.CometAddress) {
+				return nil, fmt.Errorf("Compound v3 market %s on chain %s has invalid Comet address %q", m.Name, evm.ChainName, m.CometAddress)
+			}
+			if !common.IsHexAddress(m.BaseAsset) {
+				return nil, fmt.Errorf("Compound v3 market %s on chain %s has invalid base asset %q", m.Name, evm.ChainName, m.BaseAsset)
+			}
+
+			if !seen[m.Name] {
+				seen[m.Name] = true
+				protocols = append(protocols, marketProtocol{name: m.Name, id: protocol.IDFromName(m.Name)})
+			}
+		}
+	}
+	return protocols, nil
+}
+
+// marketProtocol is the protocol of the Comet markets configured under name.
+type marketProtocol struct {
+	name string
+	id   [32]byte
+}
+
+func (p marketProtocol) ID() [32]byte { return p.id }
+
+func (p marketProtocol) Name() string { return p.name }
+
+// IsConfigured only offers the market when its base asset is the vault asset.
+func (p marketProtocol) IsConfigured(evm helper.EvmConfig, asset helper.AssetConfig) bool {
+	m, ok := evm.FindCompoundV3Market(p.name)
+	return ok && common.IsHexAddress(asset.Address) &&
+		common.HexToAddress(m.BaseAsset) == common.HexToAddress(asset.Address)
+}
+
+func (p marketProtocol) GetAPYPromise(config *helper.Config, runtime cre.Runtime, cache *protocol.Cache, liquidityAdded *big.Int, chainSelector uint64) cre.Promise[float64] {
+	return GetMarketAPYPromise(config, runtime, cache, p.name, liquidityAdded, chainSelector)
+}
+
+func (p marketProtocol) GetRewardAPRPromise(config *helper.Config, runtime cre.Runtime, liquidityAdded *big.Int, chainSelector uint64) cre.Promise[float64] {
+	return GetMarketRewardAPRPromise(config, runtime, p.name, liquidityAdded, chainSelector)
+}
+
+func (p marketProtocol) GetHealthPromise(config *helper.Config, runtime cre.Runtime, chainSelector uint64) cre.Promise[[]string] {
+	return GetMarketHealthPromise(config, runtime, p.name, chainSelector)
+}
+
+func (p marketProtocol) GetAvailableLiquidityPromise(config *helper.Config, runtime cre.Runtime, liquidityAdded *big.Int, chainSelector uint64) cre.Promise[*big.Int] {
+	return GetMarketAvailableLiquidityPromise(config, runtime, p.name, liquidityAdded, chainSelector)
+}
@@ -0,0 +1,84 @@
+package compoundV3
+
+import (
+	"testing"
+
+	"rebalance/workflow/internal/helper"
+	"rebalance/workflow/internal/protocol"
+
+	"github.com/stretchr/testify/require"
+)
+
+func cometMarket(name, comet string) helper.CompoundV3MarketConfig {
+	return helper.CompoundV3MarketConfig{
+		Name:         name,
+		CometAddress: comet,
+		BaseAsset:    "0x00000000000000000000000000000000000000ff",
+	}
+}
+
+func Test_marketFamily_oneProtocolPerMarketName(t *testing.T) {
+	cfg := &helper.Config{
+		Evms: []helper.EvmConfig{
+			{ChainName: "a", CompoundV3Markets: []helper.CompoundV3MarketConfig{
+				cometMarket("compound-v3-usdc.e", "0x0000000000000000000000000000000000000001"),
+				cometMarket("compound-v3-usdt", "0x0000000000000000000000000000000000000002"),
+			}},
+			{ChainName: "b", CompoundV3Markets: []helper.CompoundV3MarketConfig{
+				cometMarket("compound-v3-usdc.e", "0x0000000000000000000000000000000000000003"),
+			}},
+		},
+	}
+
+	protocols, err := marketFamily{}.Protocols(cfg)
+	require.NoError(t, err)
+	require.Len(t, protocols, 2)
+
+	require.Equal(t, "compound-v3-usdc.e", protocols[0].Name())
+	require.Equal(t, protocol.IDFromName("compound-v3-usdt"), protocols[1].ID())
+
+	base := helper.AssetConfig{Symbol: "USDT", Address: "0x00000000000000000000000000000000000000FF"}
+	require.True(t, protocols[1].IsConfigured(cfg.Evms[0], base))
+	require.False(t, protocols[1].IsConfigured(cfg.Evms[1], base))
+
+	other := helper.AssetConfig{Symbol: "DAI", Address: "0x00000000000000000000000000000000000000ee"}
+	require.False(t, protocols[1].IsConfigured(cfg.Evms[0], other), "scoped to the market's base asset")
+}
+
+func Test_marketFamily_errors(t *testing.T) {
+	badBase := cometMarket("compound-v3-usdt", "0x0000000000000000000000000000000000000001")
+	badBase.BaseAsset = ""
+
+	cases := map[string]struct {
+		markets []helper.CompoundV3MarketConfig
+		err     string
+	}{
+		"no name": {
+			markets: []helper.CompoundV3MarketConfig{cometMarket("", "0x0000000000000000000000000000000000000001")},
+			err:     "has no name",
+		},
+		"duplicate": {
+			markets: []helper.CompoundV3MarketConfig{
+				cometMarket("compound-v3-usdt", "0x0000000000000000000000000000000000000001"),
+				cometMarket("compound-v3-usdt", "0x0000000000000000000000000000000000000002"),
+			},
+			err: "Compound v3 market compound-v3-usdt is configured more than once on chain a",
+		},
+		"invalid comet": {
+			markets: []helper.CompoundV3MarketConfig{cometMarket("compound-v3-usdt", "0x01")},
+			err:     `invalid Comet address "0x01"`,
+		},
+		"invalid base asset": {
+			markets: []helper.CompoundV3MarketConfig{badBase},
+			err:     `invalid base asset ""`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cfg := &helper.Config{Evms: []helper.EvmConfig{{ChainName: "a", CompoundV3Markets: tc.markets}}}
+			_, err := marketFamily{}.Protocols(cfg)
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package compoundV3
+
+import (
+	"fmt"
+	"math/big"
+
+	"rebalance/workflow/internal/constants"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+)
+
+// supplyRateModel is a Comet's kinked supply rate curve. All values are scaled
+// by 1e18: Kink is a utilization, the others per-second rates.
+type supplyRateModel struct {
+	Kink      *big.Int
+	Base      *big.Int
+	SlopeLow  *big.Int
+	SlopeHigh *big.Int
+}
+
+// supplyRateModelKey identifies a Comet on a chain.
+type supplyRateModelKey struct {
+	chainSelector uint64
+	comet         common.Address
+}
+
+// readSupplyRateModel reads and validates the supply rate curve immutables of
+// cometMarket.
+func readSupplyRateModel(runtime cre.Runtime, cometMarket CometInterface, blockNumber *big.Int) cre.Promise[supplyRateModel] {
+	// Issue every read before awaiting any of them.
+	kinkPromise := cometMarket.SupplyKink(runtime, blockNumber)
+	basePromise := cometMarket.SupplyPerSecondInterestRateBase(runtime, blockNumber)
+	slopeLowPromise := cometMarket.SupplyPerSecondInterestRateSlopeLow(runtime, blockNumber)
+	slopeHighPromise := cometMarket.SupplyPerSecondInterestRateSlopeHigh(runtime, blockNumber)
+
+	return cre.ThenPromise(kinkPromise, func(kink *big.Int) cre.Promise[supplyRateModel] {
+		return cre.ThenPromise(basePromise, func(base *big.Int) cre.Promise[supplyRateModel] {
+			return cre.ThenPromise(slopeLowPromise, func(slopeLow *big.Int) cre.Promise[supplyRateModel] {
+				return cre.Then(slopeHighPromise, func(slopeHigh *big.Int) (supplyRateModel, error) {
+					model := supplyRateModel{Kink: kink, Base: base, SlopeLow: slopeLow, SlopeHigh: slopeHigh}
+					if err := model.validate(); err != nil {
+						return supplyRateModel{}, fmt.Errorf("invalid Comet supply rate curve: %w", err)
+					}
+					return model, nil
+				})
+			})
+		})
+	})
+}
+
+// supplyRate is Comet.getSupplyRate in Go, with the same rounding:
+//
+//	utilization <= kink: base + slopeLow * utilization
+//	utilization >  kink: base + slopeLow * kink + slopeHigh * (utilization - kink)
+//
+// where each product is divided by 1e18, rounding down. A rate that does not
+// fit in a uint64 is an error, as it reverts on-chain.
+func (m supplyRateModel) supplyRate(utilization *big.Int) (uint64, error) {
+	rate := new(big.Int).Set(m.Base)
+	if utilization.Cmp(m.Kink) <= 0 {
+		rate.Add(rate, mulFactor(m.SlopeLow, utilization))
+	} else {
+		rate.Add(rate, mulFactor(m.SlopeLow, m.Kink))
+		rate.Add(rate, mulFactor(m.SlopeHigh, new(big.Int).Sub(utilization, m.Kink)))
+	}
+	if !rate.IsUint64() {
+		return 0, fmt.Errorf("supply rate %s does not fit in uint64", rate)
+	}
+	return rate.Uint64(), nil
+}
+
+// mulFactor is Comet's mulFactor: n * factor / 1e18, rounding down.
+func mulFactor(n, factor *big.Int) *big.Int {
+	out := new(big.Int).Mul(n, factor)
+	return out.Quo(out, big.NewInt(constants.WAD))
+}
+
+// validate rejects a curve with missing values or a kink outside 0-100%
+// utilization.
+func (m supplyRateModel) validate() error {
+	if m.Kink == nil || m.Base == nil || m.SlopeLow == nil || m.SlopeHigh == nil {
+		return fmt.Errorf("missing supply rate parameter")
+	}
+	if m.Kink.Sign() < 0 || m.Kink.Cmp(big.NewInt(constants.WAD)) > 0 {
+		return fmt.Errorf("supply kink %s out of range", m.Kink)
+	}
+	return nil
+}
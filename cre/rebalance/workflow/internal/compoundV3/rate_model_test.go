@@ -0,0 +1,161 @@
+package compoundV3
+
+import (
+	"math/big"
+	"testing"
+
+	"rebalance/workflow/internal/helper"
+	"rebalance/workflow/internal/protocol"
+
+	"github.com/smartcontractkit/cre-sdk-go/cre/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+/*//////////////////////////////////////////////////////////////
+                    TEST HELPERS / MOCKS
+//////////////////////////////////////////////////////////////*/
+
+// wadFraction returns numerator/denominator scaled by 1e18.
+func wadFraction(numerator, denominator int64) *big.Int {
+	out := new(big.Int).Mul(big.NewInt(numerator), big.NewInt(1e18))
+	return out.Quo(out, big.NewInt(denominator))
+}
+
+// testSupplyRateModel has an 80% kink, no base rate and a slopeHigh 50 times
+// slopeLow.
+func testSupplyRateModel() supplyRateModel {
+	return supplyRateModel{
+		Kink:      wadFraction(8, 10),
+		Base:      big.NewInt(0),
+		SlopeLow:  big.NewInt(2_000_000_000),
+		SlopeHigh: big.NewInt(100_000_000_000),
+	}
+}
+
+// modelComet is a Comet at 90% utilization with the testSupplyRateModel curve
+// whose getSupplyRate returns onchainRate.
+func modelComet(onchainRate uint64) *fakeComet {
+	model := testSupplyRateModel()
+	return &fakeComet{
+		totalSupply:     big.NewInt(1_000_000),
+		totalBorrow:     big.NewInt(900_000),
+		supplyRate:      onchainRate,
+		supplyKink:      model.Kink,
+		supplyRateBase:  model.Base,
+		supplySlopeLow:  model.SlopeLow,
+		supplySlopeHigh: model.SlopeHigh,
+	}
+}
+
+/*//////////////////////////////////////////////////////////////
+                      SUPPLY RATE (PURE)
+//////////////////////////////////////////////////////////////*/
+
+func Test_supplyRateModel_supplyRate(t *testing.T) {
+	model := testSupplyRateModel()
+
+	// Below the kink: slopeLow * 50%.
+	rate, err := model.supplyRate(wadFraction(5, 10))
+	require.NoError(t, err)
+	require.Equal(t, uint64(1_000_000_000), rate)
+
+	// At the kink: still on slopeLow.
+	rate, err = model.supplyRate(model.Kink)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1_600_000_000), rate)
+
+	// Above the kink: slopeLow * 80% + slopeHigh * 10%.
+	rate, err = model.supplyRate(wadFraction(9, 10))
+	require.NoError(t, err)
+	require.Equal(t, uint64(11_600_000_000), rate)
+}
+
+func Test_supplyRateModel_supplyRate_roundsDownAndAddsBase(t *testing.T) {
+	model := testSupplyRateModel()
+	model.Base = big.NewInt(7)
+
+	rate, err := model.supplyRate(big.NewInt(1))
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), rate)
+}
+
+func Test_supplyRateModel_supplyRate_errorWhen_overflowsUint64(t *testing.T) {
+	model := testSupplyRateModel()
+	model.Base = new(big.Int).Lsh(big.NewInt(1), 64)
+
+	_, err := model.supplyRate(big.NewInt(0))
+	require.ErrorContains(t, err, "does not fit in uint64")
+}
+
+func Test_supplyRateModel_validate(t *testing.T) {
+	require.NoError(t, testSupplyRateModel().validate())
+	require.ErrorContains(t, supplyRateModel{}.validate(), "missing supply rate parameter")
+
+	model := testSupplyRateModel()
+	model.Kink = wadFraction(11, 10)
+	require.ErrorContains(t, model.validate(), "supply kink")
+}
+
+/*//////////////////////////////////////////////////////////////
+                     RATE MODEL MODES
+//////////////////////////////////////////////////////////////*/
+
+func TestGetAPYPromise_localRateModel_skipsGetSupplyRate(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+	fc := modelComet(0)
+	useComet(t, fc)
+	cfg := rewardsTestConfig()
+	cfg.RateModel = helper.RateModelConfig{Mode: helper.RateModelLocal}
+
+	apy, err := GetAPYPromise(cfg, runtime, nil, big.NewInt(0), 1).Await()
+
+	require.NoError(t, err)
+	require.Equal(t, calculateAPYFromSupplyRate(11_600_000_000), apy)
+	require.Nil(t, fc.lastUtilization, "getSupplyRate must not be called")
+}
+
+func TestGetAPYPromise_verifyRateModel(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+	cfg := rewardsTestConfig()
+	cfg.RateModel = helper.RateModelConfig{Mode: helper.RateModelVerify}
+
+	useComet(t, modelComet(11_600_000_000))
+	apy, err := GetAPYPromise(cfg, runtime, nil, big.NewInt(0), 1).Await()
+	require.NoError(t, err)
+	require.Equal(t, calculateAPYFromSupplyRate(11_600_000_000), apy)
+
+	useComet(t, modelComet(11_600_000_001))
+	_, err = GetAPYPromise(cfg, runtime, nil, big.NewInt(0), 1).Await()
+	require.ErrorContains(t, err, "local Comet supply rate model disagrees with getSupplyRate")
+}
+
+func TestGetAPYPromise_localRateModel_readsCurveOncePerRun(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+	fc := modelComet(0)
+	useComet(t, fc)
+	cfg := rewardsTestConfig()
+	cfg.RateModel = helper.RateModelConfig{Mode: helper.RateModelLocal}
+
+	cache := protocol.NewCache()
+	atKink, err := GetAPYPromise(cfg, runtime, cache, big.NewInt(0), 1).Await()
+	require.NoError(t, err)
+	withDeposit, err := GetAPYPromise(cfg, runtime, cache, big.NewInt(1_000_000), 1).Await()
+	require.NoError(t, err)
+	require.Less(t, withDeposit, atKink)
+	require.Equal(t, 1, fc.curveReads)
+
+	_, err = GetAPYPromise(cfg, runtime, protocol.NewCache(), big.NewInt(0), 1).Await()
+	require.NoError(t, err)
+	require.Equal(t, 2, fc.curveReads, "a new run reads the curve again")
+}
+
+func TestGetAPYPromise_error_unknownRateModelMode(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+	useComet(t, modelComet(0))
+	cfg := rewardsTestConfig()
+	cfg.RateModel = helper.RateModelConfig{Mode: "oracle"}
+
+	_, err := GetAPYPromise(cfg, runtime, nil, big.NewInt(0), 1).Await()
+
+	require.ErrorContains(t, err, `unknown rate model mode "oracle"`)
+}
@@ -0,0 +1,106 @@
+package compoundV3
+
+import (
+	"fmt"
+	"math/big"
+
+	"rebalance/workflow/internal/constants"
+	"rebalance/workflow/internal/helper"
+	"rebalance/workflow/internal/price"
+
+	"github.com/smartcontractkit/cre-sdk-go/capabilities/blockchain/evm"
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+)
+
+// RewardTokenSymbol is the reward token symbol Comet emissions are priced as
+// (see helper.EvmConfig.RewardTokens).
+const RewardTokenSymbol = "COMP"
+
+// GetRewardAPRPromise calculates the COMP reward APR for supplying to the
+// chain's USDC Comet after liquidityAdded and returns a Promise.
+// [Needs .Await() after this is called]
+func GetRewardAPRPromise(config *helper.Config, runtime cre.Runtime, liquidityAdded *big.Int, chainSelector uint64) cre.Promise[float64] {
+	evmCfg, err := helper.FindEvmConfigByChainSelector(config.Evms, chainSelector)
+	if err != nil {
+		return cre.PromiseFromResult(0.0, fmt.Errorf("chain config not found for chainSelector %d: %w", chainSelector, err))
+	}
+	if evmCfg.CompoundV3CometUSDCAddress == "" {
+		return cre.PromiseFromResult(0.0, fmt.Errorf("CompoundV3CometUSDCAddress not configured for chain %s", evmCfg.ChainName))
+	}
+
+	return cometRewardAPRPromise(config, runtime, evmCfg, evmCfg.CompoundV3CometUSDCAddress, liquidityAdded)
+}
+
+// GetMarketRewardAPRPromise is GetRewardAPRPromise for the Comet market named
+// marketName (see helper.CompoundV3MarketConfig) on a specific chain.
+func GetMarketRewardAPRPromise(config *helper.Config, runtime cre.Runtime, marketName string, liquidityAdded *big.Int, chainSelector uint64) cre.Promise[float64] {
+	evmCfg, market, err := findMarket(config, marketName, chainSelector)
+	if err != nil {
+		return cre.PromiseFromResult(0.0, err)
+	}
+
+	return cometRewardAPRPromise(config, runtime, evmCfg, market.CometAddress, liquidityAdded)
+}
+
+// cometRewardAPRPromise reads the Comet at cometAddress's supply-side tracking
+// speed and prices it in COMP. A Comet without supply emissions needs no COMP
+// price.
+func cometRewardAPRPromise(config *helper.Config, runtime cre.Runtime, evmCfg *helper.EvmConfig, cometAddress string, liquidityAdded *big.Int) cre.Promise[float64] {
+	if liquidityAdded == nil {
+		return cre.PromiseFromResult(0.0, fmt.Errorf("liquidityAdded cannot be nil (use big.NewInt(0) for zero value)"))
+	}
+
+	cometMarket, err := newCometBindingFunc(&evm.Client{ChainSelector: evmCfg.ChainSelector}, cometAddress)
+	if err != nil {
+		return cre.PromiseFromResult(0.0, fmt.Errorf("failed to create Comet binding for chain %s: %w", evmCfg.ChainName, err))
+	}
+
+	// Issue every read before awaiting any of them.
+	blockNumber := big.NewInt(config.BlockNumber)
+	speedPromise := cometMarket.BaseTrackingSupplySpeed(runtime, blockNumber)
+	scalePromise := cometMarket.TrackingIndexScale(runtime, blockNumber)
+	decimalsPromise := cometMarket.Decimals(runtime, blockNumber)
+	totalSupplyPromise := cometMarket.TotalSupply(runtime, blockNumber)
+
+	return cre.ThenPromise(speedPromise, func(speed uint64) cre.Promise[float64] {
+		if speed == 0 {
+			return cre.PromiseFromResult(0.0, nil)
+		}
+
+		token, ok := evmCfg.FindRewardToken(RewardTokenSymbol)
+		if !ok {
+			return cre.PromiseFromResult(0.0, fmt.Errorf("reward token %s not configured for chain %s", RewardTokenSymbol, evmCfg.ChainName))
+		}
+		pricePromise := price.GetUSDPricePromise(config, runtime, evmCfg, token)
+
+		return cre.ThenPromise(scalePromise, func(scale uint64) cre.Promise[float64] {
+			return cre.ThenPromise(decimalsPromise, func(decimals uint8) cre.Promise[float64] {
+				return cre.ThenPromise(totalSupplyPromise, func(totalSupply *big.Int) cre.Promise[float64] {
+					return cre.Then(pricePromise, func(rewardPriceUSD float64) (float64, error) {
+						supply := new(big.Int).Add(totalSupply, liquidityAdded)
+						return calculateRewardAPR(speed, scale, rewardPriceUSD, supply, decimals)
+					})
+				})
+			})
+		})
+	})
+}
+
+// calculateRewardAPR is the USD value of a year of supply-side emissions over
+// the USD value of totalSupply, valuing the base asset at 1 USD:
+//
+//	speed / trackingIndexScale * SECONDS_PER_YEAR * rewardPrice / (totalSupply / 10^decimals)
+func calculateRewardAPR(speed, trackingIndexScale uint64, rewardPriceUSD float64, totalSupply *big.Int, decimals uint8) (float64, error) {
+	if trackingIndexScale == 0 {
+		return 0, fmt.Errorf("trackingIndexScale is zero")
+	}
+	if totalSupply.Sign() == 0 {
+		return 0, nil
+	}
+
+	rewardsPerYear := float64(speed) / float64(trackingIndexScale) * constants.SecondsPerYear
+	unit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	supplyUSD, _ := new(big.Rat).SetFrac(totalSupply, unit).Float64()
+
+	return rewardsPerYear * rewardPriceUSD / supplyUSD, nil
+}
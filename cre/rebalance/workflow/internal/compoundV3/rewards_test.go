@@ -0,0 +1,83 @@
+package compoundV3
+
+import (
+	"math/big"
+	"testing"
+
+	"rebalance/workflow/internal/constants"
+	"rebalance/workflow/internal/helper"
+
+	"github.com/smartcontractkit/cre-sdk-go/capabilities/blockchain/evm"
+	"github.com/smartcontractkit/cre-sdk-go/cre/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_calculateRewardAPR(t *testing.T) {
+	// 1e15 scale: 0.01 COMP per second over 10M USDC (6 decimals) at 50 USD/COMP.
+	supply := big.NewInt(10_000_000_000_000)
+	apr, err := calculateRewardAPR(10_000_000_000_000, 1_000_000_000_000_000, 50, supply, 6)
+	require.NoError(t, err)
+	require.InDelta(t, 0.01*constants.SecondsPerYear*50/10_000_000, apr, 1e-12)
+
+	apr, err = calculateRewardAPR(1, 1, 50, big.NewInt(0), 6)
+	require.NoError(t, err)
+	require.Zero(t, apr)
+
+	_, err = calculateRewardAPR(1, 0, 50, supply, 6)
+	require.ErrorContains(t, err, "trackingIndexScale is zero")
+}
+
+func rewardsTestConfig(tokens ...helper.RewardTokenConfig) *helper.Config {
+	return &helper.Config{
+		BlockNumber: 123,
+		Evms: []helper.EvmConfig{
+			{
+				ChainName:                  "test-chain",
+				ChainSelector:              1,
+				CompoundV3CometUSDCAddress: "0x0000000000000000000000000000000000000001",
+				RewardTokens:               tokens,
+			},
+		},
+	}
+}
+
+func useComet(t *testing.T, fc *fakeComet) {
+	orig := newCometBindingFunc
+	newCometBindingFunc = func(*evm.Client, string) (CometInterface, error) { return fc, nil }
+	t.Cleanup(func() { newCometBindingFunc = orig })
+}
+
+func TestGetRewardAPRPromise_success_includesLiquidityAdded(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+	useComet(t, &fakeComet{
+		totalSupply:   big.NewInt(9_000_000),
+		trackingSpeed: 1_000,
+		trackingScale: 1_000_000,
+		decimals:      6,
+	})
+	cfg := rewardsTestConfig(helper.RewardTokenConfig{Symbol: "COMP", PriceUSD: 40})
+
+	apr, err := GetRewardAPRPromise(cfg, runtime, big.NewInt(1_000_000), 1).Await()
+
+	require.NoError(t, err)
+	require.InDelta(t, 0.001*constants.SecondsPerYear*40/10, apr, 1e-9)
+}
+
+func TestGetRewardAPRPromise_noEmissionsNeedsNoPrice(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+	useComet(t, &fakeComet{totalSupply: big.NewInt(1), trackingScale: 1, decimals: 6})
+
+	apr, err := GetRewardAPRPromise(rewardsTestConfig(), runtime, big.NewInt(0), 1).Await()
+
+	require.NoError(t, err)
+	require.Zero(t, apr)
+}
+
+func TestGetRewardAPRPromise_error_whenCompNotConfigured(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+	useComet(t, &fakeComet{totalSupply: big.NewInt(1), trackingSpeed: 1, trackingScale: 1, decimals: 6})
+
+	_, err := GetRewardAPRPromise(rewardsTestConfig(), runtime, big.NewInt(0), 1).Await()
+
+	require.ErrorContains(t, err, "reward token COMP not configured for chain test-chain")
+}
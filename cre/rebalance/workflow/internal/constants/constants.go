@@ -12,8 +12,6 @@ const (
 	SecondsPerYear = 31536000
 	// UsdcDecimals is the number of decimals for USDC (6)
 	UsdcDecimals = 6
-	// UsdcDecimalsDivisor is 10^6, used for converting raw USDC amounts to units
-	UsdcDecimalsDivisor = 1e6
 	// RayDecimals is the number of decimals in RAY (27)
 	RayDecimals = 27
 	// BasisPointsDecimals is the number of decimals for basis points (4)
@@ -0,0 +1,97 @@
+package erc4626
+
+import (
+	"fmt"
+	"math/big"
+
+	"rebalance/contracts/evm/src/generated/erc4626"
+	"rebalance/workflow/internal/helper"
+
+	"github.com/smartcontractkit/chainlink-protos/cre/go/values/pb"
+	"github.com/smartcontractkit/cre-sdk-go/capabilities/blockchain/evm"
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+)
+
+// GetAPYPromise estimates the APY of the ERC-4626 vault named vaultName on a
+// specific chain from the growth of convertToAssets(1 share) between the
+// configured block and the vault's lookback block, and returns a Promise.
+// [Needs .Await() after this is called]
+//
+// The estimate is historical: it does not model how our own deposit would
+// change the vault's rate.
+//
+// Returns:
+//   - Promise of APY as float64 (e.g., 0.0523 = 5.23%)
+//   - Error will be returned when Promise is awaited if chain or vault not found or APY calculation fails
+func GetAPYPromise(config *helper.Config, runtime cre.Runtime, vaultName string, chainSelector uint64) cre.Promise[float64] {
+	// Find the chain and vault config
+	evmCfg, err := helper.FindEvmConfigByChainSelector(config.Evms, chainSelector)
+	if err != nil {
+		return cre.PromiseFromResult(0.0, fmt.Errorf("chain config not found for chainSelector %d: %w", chainSelector, err))
+	}
+	vaultCfg, ok := evmCfg.FindErc4626Vault(vaultName)
+	if !ok {
+		return cre.PromiseFromResult(0.0, fmt.Errorf("ERC-4626 vault %s not configured for chain %s", vaultName, evmCfg.ChainName))
+	}
+	if vaultCfg.LookbackBlocks == 0 {
+		return cre.PromiseFromResult(0.0, fmt.Errorf("lookbackBlocks not configured for ERC-4626 vault %s on chain %s", vaultName, evmCfg.ChainName))
+	}
+
+	// Step 1: Create EVM client and vault binding for this chain
+	evmClient := &evm.Client{
+		ChainSelector: evmCfg.ChainSelector,
+	}
+	vault, err := newVaultBindingFunc(evmClient, vaultCfg.Address)
+	if err != nil {
+		return cre.PromiseFromResult(0.0, fmt.Errorf("failed to create ERC-4626 binding for vault %s on chain %s: %w", vaultName, evmCfg.ChainName, err))
+	}
+	headers := newHeaderReaderFunc(evmClient)
+
+	// Step 2: Resolve the configured block (it may be a tag such as latest)
+	// to a number, so the lookback block can be derived from it.
+	currentHeaderPromise := readHeader(runtime, headers, big.NewInt(config.BlockNumber))
+
+	return cre.ThenPromise(currentHeaderPromise, func(current *evm.Header) cre.Promise[float64] {
+		currentBlock := pb.NewIntFromBigInt(current.GetBlockNumber())
+		if currentBlock.Cmp(new(big.Int).SetUint64(vaultCfg.LookbackBlocks)) <= 0 {
+			return cre.PromiseFromResult(0.0, fmt.Errorf("block %s is within lookbackBlocks %d of genesis", currentBlock, vaultCfg.LookbackBlocks))
+		}
+		pastBlock := new(big.Int).Sub(currentBlock, new(big.Int).SetUint64(vaultCfg.LookbackBlocks))
+
+		// Step 3: Issue the reads at both blocks before awaiting any of them
+		pastHeaderPromise := readHeader(runtime, headers, pastBlock)
+		decimalsPromise := vault.Decimals(runtime, currentBlock)
+
+		return cre.ThenPromise(decimalsPromise, func(decimals uint8) cre.Promise[float64] {
+			oneShare := erc4626.ConvertToAssetsInput{Shares: new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)}
+			currentAssetsPromise := vault.ConvertToAssets(runtime, oneShare, currentBlock)
+			pastAssetsPromise := vault.ConvertToAssets(runtime, oneShare, pastBlock)
+
+			return cre.ThenPromise(pastHeaderPromise, func(past *evm.Header) cre.Promise[float64] {
+				if past.GetTimestamp() > current.GetTimestamp() {
+					return cre.PromiseFromResult(0.0, fmt.Errorf("lookback block %s is newer than block %s", pastBlock, currentBlock))
+				}
+				elapsed := current.GetTimestamp() - past.GetTimestamp()
+
+				return cre.ThenPromise(currentAssetsPromise, func(currentAssets *big.Int) cre.Promise[float64] {
+					return cre.Then(pastAssetsPromise, func(pastAssets *big.Int) (float64, error) {
+						return calculateAPYFromSharePrice(pastAssets, currentAssets, elapsed)
+					})
+				})
+			})
+		})
+	})
+}
+
+// readHeader returns a promise of the header at blockNumber.
+func readHeader(runtime cre.Runtime, headers HeaderReaderInterface, blockNumber *big.Int) cre.Promise[*evm.Header] {
+	reply := headers.HeaderByNumber(runtime, &evm.HeaderByNumberRequest{
+		BlockNumber: pb.NewBigIntFromInt(blockNumber),
+	})
+	return cre.Then(reply, func(reply *evm.HeaderByNumberReply) (*evm.Header, error) {
+		if reply.GetHeader() == nil {
+			return nil, fmt.Errorf("missing header for block %s", blockNumber)
+		}
+		return reply.GetHeader(), nil
+	})
+}
@@ -0,0 +1,170 @@
+package erc4626
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+
+	"rebalance/contracts/evm/src/generated/erc4626"
+	"rebalance/workflow/internal/helper"
+
+	"github.com/smartcontractkit/chainlink-protos/cre/go/values/pb"
+	"github.com/smartcontractkit/cre-sdk-go/capabilities/blockchain/evm"
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+	"github.com/smartcontractkit/cre-sdk-go/cre/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+/*//////////////////////////////////////////////////////////////
+                            UTILITY
+//////////////////////////////////////////////////////////////*/
+
+// fakeChain serves headers with 12s blocks up to head, and a vault whose
+// share price is sharePrice(block).
+type fakeChain struct {
+	head       int64
+	decimals   uint8
+	sharePrice func(block int64) *big.Int
+
+	sharesAsked []*big.Int
+	blocksAsked []int64
+}
+
+func (f *fakeChain) HeaderByNumber(_ cre.Runtime, input *evm.HeaderByNumberRequest) cre.Promise[*evm.HeaderByNumberReply] {
+	n := pb.NewIntFromBigInt(input.BlockNumber).Int64()
+	if n < 0 { // block tag
+		n = f.head
+	}
+	return cre.PromiseFromResult(&evm.HeaderByNumberReply{Header: &evm.Header{
+		BlockNumber: pb.NewBigIntFromInt(big.NewInt(n)),
+		Timestamp:   uint64(n * 12),
+	}}, nil)
+}
+
+func (f *fakeChain) Decimals(cre.Runtime, *big.Int) cre.Promise[uint8] {
+	return cre.PromiseFromResult(f.decimals, nil)
+}
+
+func (f *fakeChain) ConvertToAssets(_ cre.Runtime, input erc4626.ConvertToAssetsInput, blockNumber *big.Int) cre.Promise[*big.Int] {
+	f.sharesAsked = append(f.sharesAsked, input.Shares)
+	f.blocksAsked = append(f.blocksAsked, blockNumber.Int64())
+	return cre.PromiseFromResult(f.sharePrice(blockNumber.Int64()), nil)
+}
+
+var (
+	_ VaultInterface        = (*fakeChain)(nil)
+	_ HeaderReaderInterface = (*fakeChain)(nil)
+)
+
+func vaultTestConfig() *helper.Config {
+	return &helper.Config{
+		BlockNumber: -2,
+		Evms: []helper.EvmConfig{{
+			ChainName:     "test-chain",
+			ChainSelector: 1,
+			Erc4626Vaults: []helper.Erc4626VaultConfig{
+				{Name: "susds", Address: "0x00000000000000000000000000000000000000aa", LookbackBlocks: 7200},
+			},
+		}},
+	}
+}
+
+func useFakeChain(t *testing.T, chain *fakeChain) {
+	origVault, origHeaders := newVaultBindingFunc, newHeaderReaderFunc
+	t.Cleanup(func() { newVaultBindingFunc, newHeaderReaderFunc = origVault, origHeaders })
+
+	newVaultBindingFunc = func(*evm.Client, string) (VaultInterface, error) { return chain, nil }
+	newHeaderReaderFunc = func(*evm.Client) HeaderReaderInterface { return chain }
+}
+
+/*//////////////////////////////////////////////////////////////
+                          ERROR PATHS
+//////////////////////////////////////////////////////////////*/
+
+func TestGetAPYPromise_error_whenVaultNotConfigured(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+
+	_, err := GetAPYPromise(vaultTestConfig(), runtime, "fluid", 1).Await()
+	require.ErrorContains(t, err, "ERC-4626 vault fluid not configured for chain test-chain")
+
+	_, err = GetAPYPromise(vaultTestConfig(), runtime, "susds", 2).Await()
+	require.ErrorContains(t, err, "chain config not found for chainSelector 2")
+}
+
+func TestGetAPYPromise_error_whenLookbackMissing(t *testing.T) {
+	cfg := vaultTestConfig()
+	cfg.Evms[0].Erc4626Vaults[0].LookbackBlocks = 0
+	runtime := testutils.NewRuntime(t, nil)
+
+	_, err := GetAPYPromise(cfg, runtime, "susds", 1).Await()
+	require.ErrorContains(t, err, "lookbackBlocks not configured for ERC-4626 vault susds")
+}
+
+func TestGetAPYPromise_error_whenBindingFails(t *testing.T) {
+	cfg := vaultTestConfig()
+	cfg.Evms[0].Erc4626Vaults[0].Address = "not-an-address"
+	runtime := testutils.NewRuntime(t, nil)
+
+	_, err := GetAPYPromise(cfg, runtime, "susds", 1).Await()
+	require.ErrorContains(t, err, "failed to create ERC-4626 binding for vault susds")
+}
+
+func TestGetAPYPromise_error_whenChainShorterThanLookback(t *testing.T) {
+	useFakeChain(t, &fakeChain{head: 7200, sharePrice: func(int64) *big.Int { return big.NewInt(1) }})
+	runtime := testutils.NewRuntime(t, nil)
+
+	_, err := GetAPYPromise(vaultTestConfig(), runtime, "susds", 1).Await()
+	require.ErrorContains(t, err, "block 7200 is within lookbackBlocks 7200 of genesis")
+}
+
+func TestGetAPYPromise_error_whenSharePriceReadFails(t *testing.T) {
+	chain := &fakeChain{head: 10_000, sharePrice: func(int64) *big.Int { return big.NewInt(0) }}
+	useFakeChain(t, chain)
+	runtime := testutils.NewRuntime(t, nil)
+
+	_, err := GetAPYPromise(vaultTestConfig(), runtime, "susds", 1).Await()
+	require.ErrorContains(t, err, "share price at")
+
+	useFakeChain(t, chain)
+	newHeaderReaderFunc = func(*evm.Client) HeaderReaderInterface { return failingHeaders{} }
+	_, err = GetAPYPromise(vaultTestConfig(), runtime, "susds", 1).Await()
+	require.ErrorContains(t, err, "rpc down")
+}
+
+type failingHeaders struct{}
+
+func (failingHeaders) HeaderByNumber(cre.Runtime, *evm.HeaderByNumberRequest) cre.Promise[*evm.HeaderByNumberReply] {
+	return cre.PromiseFromResult[*evm.HeaderByNumberReply](nil, errors.New("rpc down"))
+}
+
+/*//////////////////////////////////////////////////////////////
+                         SUCCESS PATHS
+//////////////////////////////////////////////////////////////*/
+
+func TestGetAPYPromise_success_fromSharePriceGrowth(t *testing.T) {
+	// Share price 1.000000 at the lookback block and 1.000100 at the head.
+	chain := &fakeChain{
+		head:     100_000,
+		decimals: 18,
+		sharePrice: func(block int64) *big.Int {
+			if block == 100_000 {
+				return big.NewInt(1_000_100)
+			}
+			return big.NewInt(1_000_000)
+		},
+	}
+	useFakeChain(t, chain)
+	runtime := testutils.NewRuntime(t, nil)
+
+	apy, err := GetAPYPromise(vaultTestConfig(), runtime, "susds", 1).Await()
+	require.NoError(t, err)
+
+	// 7200 blocks of 12s = 86400s.
+	elapsed := 7200.0 * 12
+	require.InDelta(t, math.Pow(1.0001, 31536000/elapsed)-1, apy, 1e-9)
+
+	oneShare := new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+	require.Equal(t, []*big.Int{oneShare, oneShare}, chain.sharesAsked)
+	require.ElementsMatch(t, []int64{100_000, 92_800}, chain.blocksAsked)
+}
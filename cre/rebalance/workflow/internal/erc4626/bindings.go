@@ -0,0 +1,26 @@
+package erc4626
+
+import (
+	"fmt"
+
+	"rebalance/contracts/evm/src/generated/erc4626"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/cre-sdk-go/capabilities/blockchain/evm"
+)
+
+// newVaultBinding constructs the ERC-4626 vault binding.
+// It validates the address and returns an interface for testability.
+func newVaultBinding(client *evm.Client, addr string) (VaultInterface, error) {
+	if !common.IsHexAddress(addr) {
+		return nil, fmt.Errorf("invalid ERC-4626 vault address: %s", addr)
+	}
+	vaultAddr := common.HexToAddress(addr)
+
+	return erc4626.NewErc4626(client, vaultAddr, nil)
+}
+
+// newHeaderReader returns the client itself, which reads block headers.
+func newHeaderReader(client *evm.Client) HeaderReaderInterface {
+	return client
+}
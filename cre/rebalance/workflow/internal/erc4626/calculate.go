@@ -0,0 +1,44 @@
+package erc4626
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+
+	"rebalance/workflow/internal/helper"
+	"rebalance/workflow/internal/protocol"
+)
+
+// calculateAPYFromSharePrice annualises the growth of the vault's share price
+// from pastAssets to currentAssets over elapsedSeconds.
+//
+// The growth is converted to the per-second rate r it compounds at,
+//
+//	(1 + r)^elapsedSeconds = currentAssets / pastAssets
+//
+// and r is annualised with helper.APYFromPerSecondRate.
+//
+// A share price that was flat or fell gives an APY of 0 or less, which says
+// nothing about what a deposit would earn next. It fails with
+// protocol.ErrInfeasible, so the vault is skipped for the run instead of
+// failing it, and counts as earning 0 if it is the current strategy.
+func calculateAPYFromSharePrice(pastAssets, currentAssets *big.Int, elapsedSeconds uint64) (float64, error) {
+	if pastAssets.Sign() <= 0 {
+		return 0, fmt.Errorf("share price at lookback block is %s", pastAssets)
+	}
+	if currentAssets.Sign() <= 0 {
+		return 0, fmt.Errorf("share price at current block is %s", currentAssets)
+	}
+	if elapsedSeconds == 0 {
+		return 0, fmt.Errorf("no time elapsed between lookback and current block")
+	}
+
+	growth, _ := new(big.Float).Quo(new(big.Float).SetInt(currentAssets), new(big.Float).SetInt(pastAssets)).Float64()
+	rPerSecond := math.Expm1(math.Log(growth) / float64(elapsedSeconds))
+
+	apy := helper.APYFromPerSecondRate(rPerSecond)
+	if apy <= 0 {
+		return 0, fmt.Errorf("%w: share price did not grow over the lookback (APY %g)", protocol.ErrInfeasible, apy)
+	}
+	return apy, nil
+}
@@ -0,0 +1,39 @@
+package erc4626
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"rebalance/workflow/internal/constants"
+	"rebalance/workflow/internal/protocol"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_calculateAPYFromSharePrice_annualisesGrowth(t *testing.T) {
+	// 1.00 -> 1.01 over a quarter of a year compounds to ~4.06% a year.
+	apy, err := calculateAPYFromSharePrice(big.NewInt(1_000_000), big.NewInt(1_010_000), constants.SecondsPerYear/4)
+	require.NoError(t, err)
+	require.InDelta(t, math.Pow(1.01, 4)-1, apy, 1e-6)
+}
+
+func Test_calculateAPYFromSharePrice_flatAndFallingAreInfeasible(t *testing.T) {
+	_, err := calculateAPYFromSharePrice(big.NewInt(1_000_000), big.NewInt(1_000_000), 86400)
+	require.ErrorIs(t, err, protocol.ErrInfeasible)
+	require.ErrorContains(t, err, "share price did not grow over the lookback")
+
+	_, err = calculateAPYFromSharePrice(big.NewInt(1_000_000), big.NewInt(999_000), 86400)
+	require.ErrorIs(t, err, protocol.ErrInfeasible)
+}
+
+func Test_calculateAPYFromSharePrice_errors(t *testing.T) {
+	_, err := calculateAPYFromSharePrice(big.NewInt(0), big.NewInt(1), 1)
+	require.ErrorContains(t, err, "share price at lookback block is 0")
+
+	_, err = calculateAPYFromSharePrice(big.NewInt(1), big.NewInt(0), 1)
+	require.ErrorContains(t, err, "share price at current block is 0")
+
+	_, err = calculateAPYFromSharePrice(big.NewInt(1), big.NewInt(1), 0)
+	require.ErrorContains(t, err, "no time elapsed")
+}
@@ -0,0 +1,7 @@
+package erc4626
+
+// Dependency injection for ERC-4626.
+var (
+	newVaultBindingFunc = newVaultBinding
+	newHeaderReaderFunc = newHeaderReader
+)
@@ -0,0 +1,21 @@
+package erc4626
+
+import (
+	"math/big"
+
+	"rebalance/contracts/evm/src/generated/erc4626"
+
+	"github.com/smartcontractkit/cre-sdk-go/capabilities/blockchain/evm"
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+)
+
+// VaultInterface abstracts an ERC-4626 vault.
+type VaultInterface interface {
+	Decimals(runtime cre.Runtime, blockNumber *big.Int) cre.Promise[uint8]
+	ConvertToAssets(runtime cre.Runtime, input erc4626.ConvertToAssetsInput, blockNumber *big.Int) cre.Promise[*big.Int]
+}
+
+// HeaderReaderInterface defines the subset of evm.Client used to read block headers.
+type HeaderReaderInterface interface {
+	HeaderByNumber(runtime cre.Runtime, input *evm.HeaderByNumberRequest) cre.Promise[*evm.HeaderByNumberReply]
+}
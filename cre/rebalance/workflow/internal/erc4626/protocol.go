@@ -0,0 +1,76 @@
+package erc4626
+
+import (
+	"fmt"
+	"math/big"
+
+	"rebalance/workflow/internal/helper"
+	"rebalance/workflow/internal/protocol"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+)
+
+// FamilyName names the family of configured ERC-4626 vaults.
+const FamilyName = "erc4626"
+
+func init() {
+	protocol.RegisterFamily(vaultFamily{})
+}
+
+// vaultFamily contributes one protocol per vault name in config.
+type vaultFamily struct{}
+
+func (vaultFamily) Name() string { return FamilyName }
+
+// Protocols returns a protocol for every distinct vault name across chains, in
+// first-seen order. A name twice on one chain, or an invalid vault, is an error.
+func (vaultFamily) Protocols(config *helper.Config) ([]protocol.Protocol, error) {
+	var protocols []protocol.Protocol
+	seen := make(map[string]bool)
+
+	for _, evm := range config.Evms {
+		onChain := make(map[string]bool, len(evm.Erc4626Vaults))
+		for _, v := range evm.Erc4626Vaults {
+			if v.Name == "" {
+				return nil, fmt.Errorf("vault %s on chain %s has no name", v.Address, evm.ChainName)
+			}
+			if onChain[v.Name] {
+				return nil, fmt.Errorf("vault %s is configured more than once on chain %s", v.Name, evm.ChainName)
+			}
+			onChain[v.Name] = true
+			if !common.IsHexAddress(v.Address) {
+				return nil, fmt.Errorf("vault %s on chain %s has invalid address %q", v.Name, evm.ChainName, v.Address)
+			}
+
+			if !seen[v.Name] {
+				seen[v.Name] = true
+				protocols = append(protocols, vaultProtocol{name: v.Name, id: protocol.IDFromName(v.Name)})
+			}
+		}
+	}
+	return protocols, nil
+}
+
+// vaultProtocol is the protocol of the ERC-4626 vaults configured under name.
+type vaultProtocol struct {
+	name string
+	id   [32]byte
+}
+
+func (p vaultProtocol) ID() [32]byte { return p.id }
+
+func (p vaultProtocol) Name() string { return p.name }
+
+// IsConfigured only offers the vault when its underlying token is the vault
+// asset.
+func (p vaultProtocol) IsConfigured(evm helper.EvmConfig, asset helper.AssetConfig) bool {
+	v, ok := evm.FindErc4626Vault(p.name)
+	return ok && v.AssetSymbol() == asset.Symbol
+}
+
+// GetAPYPromise ignores liquidityAdded: the APY is estimated from past
+// share-price growth.
+func (p vaultProtocol) GetAPYPromise(config *helper.Config, runtime cre.Runtime, _ *protocol.Cache, _ *big.Int, chainSelector uint64) cre.Promise[float64] {
+	return GetAPYPromise(config, runtime, p.name, chainSelector)
+}
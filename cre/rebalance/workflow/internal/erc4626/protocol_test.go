@@ -0,0 +1,48 @@
+package erc4626
+
+import (
+	"testing"
+
+	"rebalance/workflow/internal/helper"
+	"rebalance/workflow/internal/protocol"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_vaultFamily_onePerNameAcrossChains(t *testing.T) {
+	cfg := &helper.Config{Evms: []helper.EvmConfig{
+		{ChainName: "a", Erc4626Vaults: []helper.Erc4626VaultConfig{
+			{Name: "susds", Address: "0x0000000000000000000000000000000000000001"},
+			{Name: "fluid-usdc", Address: "0x0000000000000000000000000000000000000002"},
+		}},
+		{ChainName: "b", Erc4626Vaults: []helper.Erc4626VaultConfig{
+			{Name: "fluid-usdc", Address: "0x0000000000000000000000000000000000000003"},
+		}},
+	}}
+
+	protocols, err := vaultFamily{}.Protocols(cfg)
+	require.NoError(t, err)
+	require.Len(t, protocols, 2)
+
+	require.Equal(t, "susds", protocols[0].Name())
+	require.Equal(t, protocol.IDFromName("susds"), protocols[0].ID())
+	usdc := helper.AssetConfig{Symbol: helper.DefaultAsset}
+	require.True(t, protocols[0].IsConfigured(cfg.Evms[0], usdc))
+	require.False(t, protocols[0].IsConfigured(cfg.Evms[1], usdc))
+	require.False(t, protocols[0].IsConfigured(cfg.Evms[0], helper.AssetConfig{Symbol: "DAI"}), "scoped to the vault's asset")
+
+	require.Equal(t, "fluid-usdc", protocols[1].Name())
+	require.True(t, protocols[1].IsConfigured(cfg.Evms[1], usdc))
+}
+
+func Test_vaultFamily_errors(t *testing.T) {
+	for name, vaults := range map[string][]helper.Erc4626VaultConfig{
+		"has no name":                  {{Address: "0x0000000000000000000000000000000000000001"}},
+		"is configured more than once": {{Name: "v", Address: "0x0000000000000000000000000000000000000001"}, {Name: "v", Address: "0x0000000000000000000000000000000000000002"}},
+		"has invalid address":          {{Name: "v", Address: "nope"}},
+	} {
+		cfg := &helper.Config{Evms: []helper.EvmConfig{{ChainName: "a", Erc4626Vaults: vaults}}}
+		_, err := vaultFamily{}.Protocols(cfg)
+		require.ErrorContains(t, err, name)
+	}
+}
@@ -0,0 +1,24 @@
+package helper
+
+// AaveMarketConfig is an Aave v3 compatible market (SparkLend, Aave's Prime
+// instance, other forks) priced by the same pipeline as Aave v3 itself, from
+// its PoolAddressesProvider.
+//
+// Name is the protocol name, so the market's protocol ID is keccak256(Name)
+// and a name used on several chains is the same protocol on each of them.
+// RewardsControllerAddress is the market's incentives controller, if any.
+type AaveMarketConfig struct {
+	Name                         string `json:"name"`
+	PoolAddressesProviderAddress string `json:"poolAddressesProviderAddress"`
+	RewardsControllerAddress     string `json:"rewardsControllerAddress"`
+}
+
+// FindAaveMarket returns the Aave-compatible market named name on the chain.
+func (c EvmConfig) FindAaveMarket(name string) (AaveMarketConfig, bool) {
+	for _, m := range c.AaveMarkets {
+		if m.Name == name {
+			return m, true
+		}
+	}
+	return AaveMarketConfig{}, false
+}
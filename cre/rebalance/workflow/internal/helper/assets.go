@@ -0,0 +1,70 @@
+package helper
+
+import (
+	"fmt"
+	"math/big"
+
+	"rebalance/workflow/internal/constants"
+)
+
+// DefaultAsset is the vault asset when Config.Asset is empty.
+const DefaultAsset = "USDC"
+
+// AssetConfig is a token the YieldPeers may hold on a chain.
+type AssetConfig struct {
+	Symbol   string `json:"symbol"`
+	Address  string `json:"address"`
+	Decimals uint8  `json:"decimals"`
+}
+
+// AssetSymbol returns the symbol of the vault asset.
+func (c *Config) AssetSymbol() string {
+	if c.Asset == "" {
+		return DefaultAsset
+	}
+	return c.Asset
+}
+
+// VaultAsset returns the vault asset on the chain. A chain without an assets
+// entry for USDC falls back to USDCAddress with 6 decimals, so configs written
+// before assets existed keep working.
+func (c *Config) VaultAsset(evm EvmConfig) (AssetConfig, bool) {
+	symbol := c.AssetSymbol()
+	for _, a := range evm.Assets {
+		if a.Symbol == symbol {
+			return a, true
+		}
+	}
+	if symbol == DefaultAsset {
+		return AssetConfig{Symbol: DefaultAsset, Address: evm.USDCAddress, Decimals: constants.UsdcDecimals}, true
+	}
+	return AssetConfig{}, false
+}
+
+// VaultAssetDecimals returns the decimals of the vault asset on chainSelector.
+func (c *Config) VaultAssetDecimals(chainSelector uint64) (uint8, error) {
+	evm, err := FindEvmConfigByChainSelector(c.Evms, chainSelector)
+	if err != nil {
+		return 0, err
+	}
+	asset, ok := c.VaultAsset(*evm)
+	if !ok {
+		return 0, fmt.Errorf("asset %s not configured for chain %s", c.AssetSymbol(), evm.ChainName)
+	}
+	return asset.Decimals, nil
+}
+
+// ConvertDecimals rescales a raw token amount from one decimals to another,
+// rounding down. The result is a new value.
+func ConvertDecimals(amount *big.Int, from, to uint8) *big.Int {
+	switch {
+	case from == to:
+		return new(big.Int).Set(amount)
+	case from < to:
+		scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(to-from)), nil)
+		return new(big.Int).Mul(amount, scale)
+	default:
+		scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(from-to)), nil)
+		return new(big.Int).Quo(amount, scale)
+	}
+}
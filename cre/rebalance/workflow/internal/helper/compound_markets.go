@@ -0,0 +1,23 @@
+package helper
+
+// CompoundV3MarketConfig is a Compound v3 (Comet) market beyond the USDC Comet
+// in EvmConfig.CompoundV3CometUSDCAddress, e.g. USDC.e, USDT or USDS Comets.
+//
+// Name is the protocol name, so the market's protocol ID is keccak256(Name)
+// and a name used on several chains is the same protocol on each of them.
+// BaseAsset must match the Comet's baseToken().
+type CompoundV3MarketConfig struct {
+	Name         string `json:"name"`
+	CometAddress string `json:"cometAddress"`
+	BaseAsset    string `json:"baseAsset"`
+}
+
+// FindCompoundV3Market returns the Compound v3 market named name on the chain.
+func (c EvmConfig) FindCompoundV3Market(name string) (CompoundV3MarketConfig, bool) {
+	for _, m := range c.CompoundV3Markets {
+		if m.Name == name {
+			return m, true
+		}
+	}
+	return CompoundV3MarketConfig{}, false
+}
@@ -8,20 +8,94 @@ import (
 //
 //	{
 //	  "schedule": "0 */1 * * * *",
+//	  "asset": "USDT",
 //	  "evms": [
 //	    {
 //	      "chainName": "ethereum-testnet-sepolia",
 //	      "chainSelector": 16015286601757825753,
 //	      "yieldPeerAddress": "0x...",
 //	      "rebalancerAddress": "0x...",
-//	      "gasLimit": 500000
+//	      "gasLimit": 500000,
+//	      "inFlightLookbackBlocks": 600,
+//	      "assets": [
+//	        { "symbol": "USDT", "address": "0x...", "decimals": 6 }
+//	      ],
+//	      "aaveMarkets": [
+//	        { "name": "spark", "poolAddressesProviderAddress": "0x..." }
+//	      ],
+//	      "compoundV3Markets": [
+//	        { "name": "compound-v3-usdt", "cometAddress": "0x...", "baseAsset": "0x..." }
+//	      ],
+//	      "aaveV3RewardsControllerAddress": "0x...",
+//	      "rewardTokens": [
+//	        { "symbol": "COMP", "address": "0x...", "decimals": 18, "priceFeedAddress": "0x..." }
+//	      ],
+//	      "erc4626Vaults": [
+//	        { "name": "susds", "address": "0x...", "asset": "USDT", "lookbackBlocks": 7200 }
+//	      ]
 //	    }
-//	  ]
+//	  ],
+//	  "threshold": {
+//	    "minApyDeltaBps": 100,
+//	    "minAnnualGainUsd": 500,
+//	    "crossChain": { "minApyDeltaBps": 150 },
+//	    "protocols": { "compound-v3": { "minAnnualGainUsd": 1000 } }
+//	  },
+//	  "cost": {
+//	    "enabled": true,
+//	    "horizonDays": 30,
+//	    "bridgeDowntimeSeconds": 1200
+//	  },
+//	  "evaluation": {
+//	    "errorPolicy": "exclude",
+//	    "minHealthyCandidates": 3
+//	  },
+//	  "discovery": { "mode": "onchain" },
+//	  "cooldown": {
+//	    "enabled": true,
+//	    "minIntervalSeconds": 43200,
+//	    "budgets": [{ "windowSeconds": 604800, "maxRebalances": 3 }],
+//	    "lookbackBlocks": 350000,
+//	    "blockTimeSeconds": 2
+//	  },
+//	  "inFlight": {
+//	    "enabled": true,
+//	    "lookbackBlocks": 600
+//	  },
+//	  "flowTrigger": {
+//	    "enabled": true,
+//	    "minTvlFraction": 0.1
+//	  },
+//	  "verification": { "enabled": true },
+//	  "operator": {
+//	    "enabled": true,
+//	    "authorizedKeys": ["0x..."]
+//	  },
+//	  "rewards": { "enabled": true, "includeInDecision": false },
+//	  "liquidity": { "enabled": true, "minTvlMultiple": 2 },
+//	  "rateModel": { "mode": "verify" }
 //	}
+//
+// With "dryRun": true the full pipeline runs but no report is written; the
+// workflow returns the report and calldata it would have submitted instead.
 type Config struct {
-	Schedule    string      `json:"schedule"`
-	BlockNumber int64       `json:"blockNumber"`
-	Evms        []EvmConfig `json:"evms"` // Parent chain is Evms[0]
+	Schedule     string             `json:"schedule"`
+	BlockNumber  int64              `json:"blockNumber"`
+	DryRun       bool               `json:"dryRun"`
+	Asset        string             `json:"asset"` // symbol of the vault asset; defaults to USDC
+	Evms         []EvmConfig        `json:"evms"`  // Parent chain is Evms[0]
+	Threshold    ThresholdConfig    `json:"threshold"`
+	Cost         CostConfig         `json:"cost"`
+	Evaluation   EvaluationConfig   `json:"evaluation"`
+	Discovery    DiscoveryConfig    `json:"discovery"`
+	Cooldown     CooldownConfig     `json:"cooldown"`
+	InFlight     InFlightConfig     `json:"inFlight"`
+	FlowTrigger  FlowTriggerConfig  `json:"flowTrigger"`
+	Verification VerificationConfig `json:"verification"`
+	Operator     OperatorConfig     `json:"operator"`
+	Rewards      RewardsConfig      `json:"rewards"`
+	Liquidity    LiquidityConfig    `json:"liquidity"`
+	RateModel    RateModelConfig    `json:"rateModel"`
 }
 
 // EvmConfig:
@@ -30,14 +104,41 @@ type Config struct {
 //   - currentStrategy.ChainSelector tells us which chain the active strategy
 //     adapter lives on.
 type EvmConfig struct {
-	ChainName         				   string `json:"chainName"`
-	ChainSelector     				   uint64 `json:"chainSelector"`
-	YieldPeerAddress  				   string `json:"yieldPeerAddress"`
-	RebalancerAddress 				   string `json:"rebalancerAddress"`
+	ChainName                          string `json:"chainName"`
+	ChainSelector                      uint64 `json:"chainSelector"`
+	YieldPeerAddress                   string `json:"yieldPeerAddress"`
+	RebalancerAddress                  string `json:"rebalancerAddress"`
 	GasLimit                           uint64 `json:"gasLimit"`
-	USDCAddress       				   string `json:"usdcAddress"`
+	USDCAddress                        string `json:"usdcAddress"`
 	AaveV3PoolAddressesProviderAddress string `json:"aaveV3PoolAddressesProviderAddress"`
 	CompoundV3CometUSDCAddress         string `json:"compoundV3CometUSDCAddress"`
+	MorphoVaultAddress                 string `json:"morphoVaultAddress"` // MetaMorpho USDC vault
+
+	// Tokens on this chain; the vault asset (Config.Asset) is looked up here
+	// by symbol (see Config.VaultAsset).
+	Assets []AssetConfig `json:"assets"`
+
+	// Aave v3 forks and further Aave v3 instances, each its own protocol
+	// (see AaveMarketConfig).
+	AaveMarkets []AaveMarketConfig `json:"aaveMarkets"`
+	// Further Compound v3 Comets, each its own protocol
+	// (see CompoundV3MarketConfig).
+	CompoundV3Markets []CompoundV3MarketConfig `json:"compoundV3Markets"`
+	// Generic ERC-4626 vaults, each its own protocol (see Erc4626VaultConfig).
+	Erc4626Vaults []Erc4626VaultConfig `json:"erc4626Vaults"`
+
+	// Reward emissions (see RewardsConfig). The Aave RewardsController pays
+	// any of RewardTokens; Comet pays COMP, priced as the "COMP" reward token.
+	AaveV3RewardsControllerAddress string              `json:"aaveV3RewardsControllerAddress"`
+	RewardTokens                   []RewardTokenConfig `json:"rewardTokens"`
+
+	// Cost model inputs (see CostConfig). All are estimates in USD / gwei.
+	GasPriceGwei        float64 `json:"gasPriceGwei"`
+	NativeTokenPriceUSD float64 `json:"nativeTokenPriceUsd"`
+	CCIPFeeUSD          float64 `json:"ccipFeeUsd"` // fee per CCIP message sent from this chain
+
+	// Overrides InFlightConfig.LookbackBlocks on this chain when non-zero.
+	InFlightLookbackBlocks uint64 `json:"inFlightLookbackBlocks"`
 }
 
 func FindEvmConfigByChainSelector(evms []EvmConfig, target uint64) (*EvmConfig, error) {
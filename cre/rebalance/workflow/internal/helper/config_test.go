@@ -1,6 +1,7 @@
 package helper
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -27,4 +28,59 @@ func Test_FindEvmConfigByChainSelector_notFound(t *testing.T) {
 	require.Error(t, err, "expected error when selector does not exist")
 	require.Nil(t, cfg, "expected nil config when selector does not exist")
 	require.ErrorContains(t, err, "no evm config found for chainSelector 999")
-}
\ No newline at end of file
+}
+
+func Test_VaultAsset_fallsBackToUSDCAddress(t *testing.T) {
+	evm := EvmConfig{ChainName: "c", ChainSelector: 1, USDCAddress: "0xusdc"}
+	cfg := &Config{Evms: []EvmConfig{evm}}
+
+	asset, ok := cfg.VaultAsset(evm)
+	require.True(t, ok)
+	require.Equal(t, AssetConfig{Symbol: "USDC", Address: "0xusdc", Decimals: 6}, asset)
+
+	cfg.Asset = "DAI"
+	_, ok = cfg.VaultAsset(evm)
+	require.False(t, ok)
+	_, err := cfg.VaultAssetDecimals(1)
+	require.ErrorContains(t, err, "asset DAI not configured for chain c")
+
+	cfg.Evms[0].Assets = []AssetConfig{{Symbol: "DAI", Address: "0xdai", Decimals: 18}}
+	decimals, err := cfg.VaultAssetDecimals(1)
+	require.NoError(t, err)
+	require.Equal(t, uint8(18), decimals)
+}
+
+func Test_ConvertDecimals(t *testing.T) {
+	require.Equal(t, "1000000000000000000", ConvertDecimals(big.NewInt(1_000_000), 6, 18).String())
+	require.Equal(t, "1", ConvertDecimals(big.NewInt(1_999_999_999_999), 18, 6).String(), "rounds down")
+	require.Equal(t, "5", ConvertDecimals(big.NewInt(5), 6, 6).String())
+}
+
+func Test_CooldownConfig_Validate(t *testing.T) {
+	require.NoError(t, CooldownConfig{}.Validate(), "a disabled cooldown needs no lookback")
+
+	cfg := CooldownConfig{
+		Enabled:            true,
+		MinIntervalSeconds: 12 * 60 * 60,
+		Budgets:            []RebalanceBudget{{WindowSeconds: 7 * 24 * 60 * 60, MaxRebalances: 3}},
+		LookbackBlocks:     50_400,
+		BlockTimeSeconds:   12,
+	}
+	require.NoError(t, cfg.Validate())
+
+	cfg.LookbackBlocks = 50_399
+	require.ErrorContains(t, cfg.Validate(), "covers 604788s at 12s per block; the longest window is 604800s")
+
+	cfg.BlockTimeSeconds = 0
+	require.ErrorContains(t, cfg.Validate(), "blockTimeSeconds must be greater than zero")
+
+	cfg.LookbackBlocks = 0
+	require.ErrorContains(t, cfg.Validate(), "lookbackBlocks must be greater than zero")
+}
+
+func Test_CostConfig_Validate(t *testing.T) {
+	require.NoError(t, CostConfig{}.Validate(), "a disabled cost model needs no horizon")
+	require.NoError(t, CostConfig{Enabled: true, HorizonDays: 7}.Validate())
+	require.ErrorContains(t, CostConfig{Enabled: true}.Validate(), "horizonDays must be greater than zero")
+	require.ErrorContains(t, CostConfig{Enabled: true, HorizonDays: -1}.Validate(), "horizonDays must be greater than zero")
+}
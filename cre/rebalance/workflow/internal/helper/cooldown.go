@@ -0,0 +1,56 @@
+package helper
+
+import "fmt"
+
+// CooldownConfig rate-limits rebalances so the vault does not thrash between
+// strategies when APYs oscillate around the threshold. The rules apply only
+// when Enabled.
+//
+// The workflow keeps no state between runs: past moves are read back from the
+// StrategyUpdated events ParentPeer emitted in the last LookbackBlocks blocks,
+// and timed against the block the workflow reads at (Config.BlockNumber).
+//
+//   - MinIntervalSeconds: minimum time since the last StrategyUpdated.
+//   - Budgets: at most MaxRebalances moves in any WindowSeconds window.
+//
+// LookbackBlocks must cover the longest window on the parent chain, whose
+// average block time is BlockTimeSeconds; moves older than the lookback are
+// not seen, so a shorter lookback silently loosens the rules.
+type CooldownConfig struct {
+	Enabled            bool              `json:"enabled"`
+	MinIntervalSeconds uint64            `json:"minIntervalSeconds"`
+	Budgets            []RebalanceBudget `json:"budgets"`
+	LookbackBlocks     uint64            `json:"lookbackBlocks"`
+	BlockTimeSeconds   float64           `json:"blockTimeSeconds"`
+}
+
+// RebalanceBudget caps the number of rebalances in a rolling window.
+type RebalanceBudget struct {
+	WindowSeconds uint64 `json:"windowSeconds"`
+	MaxRebalances int    `json:"maxRebalances"`
+}
+
+// Validate rejects an enabled cooldown whose lookback does not cover its
+// longest window.
+func (c CooldownConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.LookbackBlocks == 0 {
+		return fmt.Errorf("cooldown lookbackBlocks must be greater than zero")
+	}
+	if c.BlockTimeSeconds <= 0 {
+		return fmt.Errorf("cooldown blockTimeSeconds must be greater than zero")
+	}
+
+	window := c.MinIntervalSeconds
+	for _, budget := range c.Budgets {
+		window = max(window, budget.WindowSeconds)
+	}
+	covered := float64(c.LookbackBlocks) * c.BlockTimeSeconds
+	if covered < float64(window) {
+		return fmt.Errorf("cooldown lookbackBlocks %d covers %.0fs at %gs per block; the longest window is %ds",
+			c.LookbackBlocks, covered, c.BlockTimeSeconds, window)
+	}
+	return nil
+}
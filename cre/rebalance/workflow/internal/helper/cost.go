@@ -0,0 +1,31 @@
+package helper
+
+import "fmt"
+
+// CostConfig configures the cost-aware rebalance decision.
+//
+// When enabled, a move that passes the threshold policy is only executed if the
+// projected gain over HorizonDays exceeds the estimated cost of the move:
+// the WriteRebalance gas on the parent chain, which executes the report, the
+// CCIP fees for the RebalanceOldStrategy / RebalanceNewStrategy messages, each
+// paid on the chain that sends it, and the yield lost while funds are in
+// flight across chains (BridgeDowntimeSeconds per hop).
+//
+// Per-chain prices (gas price, native token price, CCIP fee) live on EvmConfig.
+type CostConfig struct {
+	Enabled               bool    `json:"enabled"`
+	HorizonDays           float64 `json:"horizonDays"`
+	BridgeDowntimeSeconds uint64  `json:"bridgeDowntimeSeconds"`
+}
+
+// Validate rejects an enabled cost model without a positive horizon, which
+// would value every gain at zero or less.
+func (c CostConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.HorizonDays <= 0 {
+		return fmt.Errorf("cost horizonDays must be greater than zero")
+	}
+	return nil
+}
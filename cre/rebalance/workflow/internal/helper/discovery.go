@@ -0,0 +1,18 @@
+package helper
+
+// Strategy discovery modes.
+const (
+	// DiscoveryModeConfig builds the candidates from the protocol addresses
+	// set on each EvmConfig.
+	DiscoveryModeConfig = "config"
+	// DiscoveryModeOnChain keeps only the configured candidates the contracts
+	// would accept: ParentPeer supports the protocol and allows the chain, and
+	// the chain's peer has a strategy adapter for the protocol.
+	DiscoveryModeOnChain = "onchain"
+)
+
+// DiscoveryConfig selects how the candidate strategies are discovered. An
+// empty Mode means DiscoveryModeConfig.
+type DiscoveryConfig struct {
+	Mode string `json:"mode"`
+}
@@ -0,0 +1,35 @@
+package helper
+
+// Erc4626VaultConfig is an ERC-4626 vault priced from the growth of its share
+// price, convertToAssets(1 share), between the configured block and
+// LookbackBlocks earlier. LookbackBlocks has no default since block times
+// differ per chain; a day's worth smooths out one-off donations and losses.
+//
+// Name is the protocol name, so the vault's protocol ID is keccak256(Name) and
+// a name used on several chains is the same protocol on each of them. Asset is
+// the symbol of the vault's underlying token (defaults to USDC); the vault is
+// only a candidate when it matches the vault asset.
+type Erc4626VaultConfig struct {
+	Name           string `json:"name"`
+	Address        string `json:"address"`
+	Asset          string `json:"asset"`
+	LookbackBlocks uint64 `json:"lookbackBlocks"`
+}
+
+// AssetSymbol returns the symbol of the vault's underlying token.
+func (c Erc4626VaultConfig) AssetSymbol() string {
+	if c.Asset == "" {
+		return DefaultAsset
+	}
+	return c.Asset
+}
+
+// FindErc4626Vault returns the vault named name on the chain.
+func (c EvmConfig) FindErc4626Vault(name string) (Erc4626VaultConfig, bool) {
+	for _, v := range c.Erc4626Vaults {
+		if v.Name == name {
+			return v, true
+		}
+	}
+	return Erc4626VaultConfig{}, false
+}
@@ -0,0 +1,35 @@
+package helper
+
+// Candidate error policies.
+const (
+	// ErrorPolicyStrict fails the run if any candidate's APY cannot be computed.
+	ErrorPolicyStrict = "strict"
+	// ErrorPolicyExclude drops failing candidates and evaluates the rest. The
+	// current strategy must still evaluate successfully.
+	ErrorPolicyExclude = "exclude"
+)
+
+// EvaluationConfig controls how candidate APY failures (an error, a zero APY
+// or NaN/Inf) are handled. An empty ErrorPolicy means ErrorPolicyStrict.
+//
+// With ErrorPolicyExclude, MinHealthyCandidates is the number of candidates,
+// the current strategy included, that must evaluate successfully and be
+// healthy and feasible for the result to be trusted. Zero disables the check.
+type EvaluationConfig struct {
+	ErrorPolicy          string `json:"errorPolicy"`
+	MinHealthyCandidates int    `json:"minHealthyCandidates"`
+	SupplyCapPolicy      string `json:"supplyCapPolicy"`
+}
+
+// Supply cap policies, for candidates whose supply cap leaves less headroom
+// than the deposit they are evaluated with. An empty SupplyCapPolicy means
+// SupplyCapPolicyExclude.
+const (
+	// SupplyCapPolicyExclude marks the candidate infeasible: it is reported
+	// but never selected.
+	SupplyCapPolicyExclude = "exclude"
+	// SupplyCapPolicyClamp evaluates the candidate at the largest deposit its
+	// supply cap allows. A candidate with no headroom at all is still
+	// infeasible.
+	SupplyCapPolicyClamp = "clamp"
+)
@@ -0,0 +1,12 @@
+package helper
+
+// FlowTriggerConfig re-runs the strategy evaluation when a single deposit or
+// withdrawal on ParentPeer or a ChildPeer moves at least MinTVLFraction of the
+// TVL (e.g. 0.1 = 10%), instead of waiting for the next cron tick.
+//
+// Deposits are compared against the TVL, withdrawals (which are denominated
+// in shares) against the total share supply.
+type FlowTriggerConfig struct {
+	Enabled        bool    `json:"enabled"`
+	MinTVLFraction float64 `json:"minTvlFraction"`
+}
@@ -0,0 +1,22 @@
+package helper
+
+// InFlightConfig skips the evaluation while a cross-chain rebalance is still
+// moving funds: the TVL read on the strategy peer is misleading mid-transit,
+// and a second rebalance must not be stacked on the first.
+//
+// Every configured peer's CCIPMessageSent and CCIPMessageReceived events from
+// the last LookbackBlocks blocks are paired by message ID. LookbackBlocks must
+// cover the longest CCIP delivery time; EvmConfig.InFlightLookbackBlocks
+// overrides it per chain, since block times differ.
+type InFlightConfig struct {
+	Enabled        bool   `json:"enabled"`
+	LookbackBlocks uint64 `json:"lookbackBlocks"`
+}
+
+// LookbackBlocksFor returns the lookback to use on evm's chain.
+func (c InFlightConfig) LookbackBlocksFor(evm EvmConfig) uint64 {
+	if evm.InFlightLookbackBlocks > 0 {
+		return evm.InFlightLookbackBlocks
+	}
+	return c.LookbackBlocks
+}
@@ -0,0 +1,17 @@
+package helper
+
+// LiquidityConfig controls the exit-liquidity check: a market can pay a high
+// APY precisely because it is highly utilised, with little cash left to
+// withdraw.
+//
+// When Enabled, the liquidity available to withdraw from each candidate before
+// the TVL is deposited there must be at least MinTVLMultiple times the TVL
+// (e.g. 2 = twice the TVL); a target that falls short is infeasible. Zero
+// means 1: the market must already hold the whole TVL in cash. The deposit
+// itself is not counted, since it could be withdrawn right after it is made
+// even from a fully utilised market. Each candidate's margin is reported
+// either way.
+type LiquidityConfig struct {
+	Enabled        bool    `json:"enabled"`
+	MinTVLMultiple float64 `json:"minTvlMultiple"`
+}
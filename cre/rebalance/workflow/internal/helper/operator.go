@@ -0,0 +1,11 @@
+package helper
+
+// OperatorConfig configures the operator endpoint, which lets an operator
+// request an on-demand evaluation or force a move to a specific strategy.
+//
+// AuthorizedKeys are the EVM addresses allowed to sign operator requests.
+// Requests signed by any other key are refused.
+type OperatorConfig struct {
+	Enabled        bool     `json:"enabled"`
+	AuthorizedKeys []string `json:"authorizedKeys"`
+}
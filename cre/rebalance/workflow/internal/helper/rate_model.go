@@ -0,0 +1,22 @@
+package helper
+
+// Rate model modes.
+const (
+	// RateModelContract asks the protocol's rate contract for every rate: one
+	// eth_call per candidate amount.
+	RateModelContract = "contract"
+	// RateModelLocal reads the rate parameters once per market per run and
+	// computes rates with the protocol's rate model ported to Go, for any
+	// amount.
+	RateModelLocal = "local"
+	// RateModelVerify computes rates locally and cross-checks every one with
+	// the rate contract; a mismatch fails the candidate.
+	RateModelVerify = "verify"
+)
+
+// RateModelConfig selects how supply rates of protocols with a native Go rate
+// model (Aave v3, Compound v3) are calculated. An empty Mode means
+// RateModelContract.
+type RateModelConfig struct {
+	Mode string `json:"mode"`
+}
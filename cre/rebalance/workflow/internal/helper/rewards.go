@@ -0,0 +1,50 @@
+package helper
+
+// RewardsConfig controls reward emission APRs (COMP on Compound v3, the Aave
+// RewardsController) on top of the base supply APY.
+//
+// When Enabled, each candidate's reward APR is calculated and reported. Only
+// with IncludeInDecision is it added to the APY that picks the optimal
+// strategy and feeds the threshold and cost policies; rewards are claimed and
+// sold separately, so they are not always realisable yield.
+type RewardsConfig struct {
+	Enabled           bool `json:"enabled"`
+	IncludeInDecision bool `json:"includeInDecision"`
+}
+
+// DefaultPriceFeedHeartbeatSeconds is the longest a Chainlink feed answer may
+// go without an update when RewardTokenConfig sets no heartbeat: 24h, the
+// slowest heartbeat Chainlink price feeds use.
+const DefaultPriceFeedHeartbeatSeconds = 24 * 60 * 60
+
+// RewardTokenConfig is a reward token on a chain and its USD price source: the
+// Chainlink AggregatorV3 feed at PriceFeedAddress when set, otherwise the
+// static PriceUSD. A feed answer older than PriceFeedHeartbeatSeconds
+// (DefaultPriceFeedHeartbeatSeconds when zero) is stale and is not used.
+type RewardTokenConfig struct {
+	Symbol                    string  `json:"symbol"`
+	Address                   string  `json:"address"`
+	Decimals                  uint8   `json:"decimals"`
+	PriceUSD                  float64 `json:"priceUsd"`
+	PriceFeedAddress          string  `json:"priceFeedAddress"`
+	PriceFeedHeartbeatSeconds uint64  `json:"priceFeedHeartbeatSeconds"`
+}
+
+// PriceFeedHeartbeat returns the heartbeat of the token's price feed in
+// seconds.
+func (t RewardTokenConfig) PriceFeedHeartbeat() uint64 {
+	if t.PriceFeedHeartbeatSeconds == 0 {
+		return DefaultPriceFeedHeartbeatSeconds
+	}
+	return t.PriceFeedHeartbeatSeconds
+}
+
+// FindRewardToken returns the reward token with symbol on the chain.
+func (c EvmConfig) FindRewardToken(symbol string) (RewardTokenConfig, bool) {
+	for _, t := range c.RewardTokens {
+		if t.Symbol == symbol {
+			return t, true
+		}
+	}
+	return RewardTokenConfig{}, false
+}
@@ -0,0 +1,31 @@
+package helper
+
+// DefaultMinAPYDeltaBps is the minimum APY improvement applied when the config
+// does not set one. 100 bps = 1 percentage point.
+const DefaultMinAPYDeltaBps = 100
+
+// ThresholdConfig is the rebalance threshold policy.
+//
+// The top-level fields are the default rule. SameChain / CrossChain override it
+// depending on whether the optimal strategy lives on the same chain as the
+// current one, and Protocols overrides both for a given target protocol
+// (keyed by protocol name, e.g. "aave-v3").
+//
+// Precedence (most specific wins, per field): protocol > route > default > built-in.
+type ThresholdConfig struct {
+	// MinAPYDeltaBps is the minimum optimal - current APY improvement in basis points.
+	MinAPYDeltaBps *float64 `json:"minApyDeltaBps,omitempty"`
+	// MinAnnualGainUSD is the minimum projected annual gain (TVL * delta) in USD.
+	MinAnnualGainUSD *float64 `json:"minAnnualGainUsd,omitempty"`
+
+	SameChain  *ThresholdRule           `json:"sameChain,omitempty"`
+	CrossChain *ThresholdRule           `json:"crossChain,omitempty"`
+	Protocols  map[string]ThresholdRule `json:"protocols,omitempty"`
+}
+
+// ThresholdRule overrides one or both threshold fields. Unset (nil) fields
+// fall through to the less specific rule.
+type ThresholdRule struct {
+	MinAPYDeltaBps   *float64 `json:"minApyDeltaBps,omitempty"`
+	MinAnnualGainUSD *float64 `json:"minAnnualGainUsd,omitempty"`
+}
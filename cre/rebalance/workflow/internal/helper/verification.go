@@ -0,0 +1,9 @@
+package helper
+
+// VerificationConfig registers log triggers on the parent Rebalancer
+// (ReportDecoded, InvalidProtocolIdInReport, InvalidChainSelectorInReport) and
+// on ParentPeer (StrategyUpdated) that confirm whether a written report was
+// applied, rejected or superseded.
+type VerificationConfig struct {
+	Enabled bool `json:"enabled"`
+}
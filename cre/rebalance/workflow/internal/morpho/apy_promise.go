@@ -0,0 +1,118 @@
+package morpho
+
+import (
+	"fmt"
+	"math/big"
+
+	"rebalance/contracts/evm/src/generated/irm"
+	"rebalance/workflow/internal/helper"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/cre-sdk-go/capabilities/blockchain/evm"
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+)
+
+// GetAPYPromise calculates the supply APY of the MetaMorpho vault configured on
+// a specific chain and returns a Promise. [Needs .Await() after this is called]
+//
+// The vault APY is the supply APY of each Morpho Blue market it allocates to,
+// weighted by its allocation and net of the vault's performance fee.
+// liquidityAdded is allocated over the supply queue up to each market's cap,
+// and every affected market's borrow rate is re-read from its IRM with the
+// higher supply. Interest accrued since a market's last update is ignored.
+//
+// Parameters:
+//   - config: The helper.Config containing all chain configurations
+//   - runtime: CRE runtime for contract calls
+//   - liquidityAdded: Amount of liquidity being added (use big.NewInt(0) for current APY)
+//   - chainSelector: Chain selector to identify which chain config to use
+//
+// Returns:
+//   - Promise of APY as float64 (e.g., 0.0523 = 5.23%)
+//   - Error will be returned when Promise is awaited if chain not found or APY calculation fails
+func GetAPYPromise(config *helper.Config, runtime cre.Runtime, liquidityAdded *big.Int, chainSelector uint64) cre.Promise[float64] {
+	// Find the chain config by chainSelector
+	evmCfg, err := helper.FindEvmConfigByChainSelector(config.Evms, chainSelector)
+	if err != nil {
+		return cre.PromiseFromResult(0.0, fmt.Errorf("chain config not found for chainSelector %d: %w", chainSelector, err))
+	}
+
+	// Validate required fields
+	if evmCfg.MorphoVaultAddress == "" {
+		return cre.PromiseFromResult(0.0, fmt.Errorf("MorphoVaultAddress not configured for chain %s", evmCfg.ChainName))
+	}
+
+	// We allow liquidityAdded == 0, but not nil
+	if liquidityAdded == nil {
+		return cre.PromiseFromResult(0.0, fmt.Errorf("liquidityAdded cannot be nil (use big.NewInt(0) for zero value)"))
+	}
+
+	// Step 1: Create EVM client for this chain
+	evmClient := &evm.Client{
+		ChainSelector: evmCfg.ChainSelector,
+	}
+
+	// Step 2: Create vault binding
+	vault, err := newMetaMorphoBindingFunc(evmClient, evmCfg.MorphoVaultAddress)
+	if err != nil {
+		return cre.PromiseFromResult(0.0, fmt.Errorf("failed to create MetaMorpho binding for chain %s: %w", evmCfg.ChainName, err))
+	}
+	vaultAddress := common.HexToAddress(evmCfg.MorphoVaultAddress)
+
+	blockNumber := big.NewInt(config.BlockNumber)
+
+	// Step 3: Morpho Blue address from the vault
+	morphoPromise := vault.MORPHO(runtime, blockNumber)
+
+	// Step 4+: Chain the rest of the pipeline:
+	//   Morpho Blue -> vault state
+	//   -> (optionally + liquidityAdded over the supply queue)
+	//   -> borrow rate of every market
+	//   -> APY
+	return cre.ThenPromise(morphoPromise, func(morphoAddress common.Address) cre.Promise[float64] {
+		morphoBlue, err := newMorphoBlueBindingFunc(evmClient, morphoAddress)
+		if err != nil {
+			return cre.PromiseFromResult(0.0, fmt.Errorf("failed to create Morpho Blue binding for chain %s: %w", evmCfg.ChainName, err))
+		}
+
+		statePromise := readVaultState(runtime, vault, morphoBlue, vaultAddress, blockNumber)
+
+		return cre.ThenPromise(statePromise, func(state *vaultState) cre.Promise[float64] {
+			markets, err := applyDeposit(state.Markets, state.SupplyQueue, liquidityAdded)
+			if err != nil {
+				return cre.PromiseFromResult(0.0, fmt.Errorf("failed to allocate liquidity on chain %s: %w", evmCfg.ChainName, err))
+			}
+
+			ratePromises := make([]cre.Promise[*big.Int], 0, len(markets))
+			for _, m := range markets {
+				ratePromises = append(ratePromises, borrowRatePromise(runtime, evmClient, m, blockNumber))
+			}
+
+			return cre.Then(all(ratePromises), func(rates []*big.Int) (float64, error) {
+				apys := make([]float64, len(markets))
+				for i, m := range markets {
+					apys[i] = calculateMarketSupplyAPY(rates[i], m.Market)
+				}
+				return calculateVaultAPY(markets, apys, state.Fee)
+			})
+		})
+	})
+}
+
+// borrowRatePromise reads a market's borrow rate from its IRM. Idle markets
+// have no IRM and pay nothing.
+func borrowRatePromise(runtime cre.Runtime, client *evm.Client, m marketState, blockNumber *big.Int) cre.Promise[*big.Int] {
+	if m.Params.Irm == (common.Address{}) {
+		return cre.PromiseFromResult(big.NewInt(0), nil)
+	}
+
+	rateModel, err := newIrmBindingFunc(client, m.Params.Irm)
+	if err != nil {
+		return cre.PromiseFromResult[*big.Int](nil, fmt.Errorf("failed to create IRM binding for market %x: %w", m.Id, err))
+	}
+
+	return rateModel.BorrowRateView(runtime, irm.BorrowRateViewInput{
+		MarketParams: m.Params,
+		Market:       m.Market,
+	}, blockNumber)
+}
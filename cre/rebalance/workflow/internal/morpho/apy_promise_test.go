@@ -0,0 +1,276 @@
+package morpho
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"rebalance/contracts/evm/src/generated/irm"
+	"rebalance/contracts/evm/src/generated/meta_morpho"
+	"rebalance/contracts/evm/src/generated/morpho_blue"
+	"rebalance/workflow/internal/constants"
+	"rebalance/workflow/internal/helper"
+	"rebalance/workflow/internal/protocol"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/cre-sdk-go/capabilities/blockchain/evm"
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+	"github.com/smartcontractkit/cre-sdk-go/cre/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+/*//////////////////////////////////////////////////////////////
+                            UTILITY
+//////////////////////////////////////////////////////////////*/
+
+const testVaultAddress = "0x00000000000000000000000000000000000000aa"
+
+var (
+	testMorphoAddress = common.HexToAddress("0xbb")
+	testIrmAddress    = common.HexToAddress("0xcc")
+)
+
+// fakeVault serves a vault whose withdraw queue is the given markets.
+type fakeVault struct {
+	fee         *big.Int
+	markets     []marketState
+	supplyQueue [][32]byte
+	morphoErr   error
+}
+
+func (f *fakeVault) MORPHO(cre.Runtime, *big.Int) cre.Promise[common.Address] {
+	return cre.PromiseFromResult(testMorphoAddress, f.morphoErr)
+}
+
+func (f *fakeVault) Fee(cre.Runtime, *big.Int) cre.Promise[*big.Int] {
+	return cre.PromiseFromResult(f.fee, nil)
+}
+
+func (f *fakeVault) Config(_ cre.Runtime, input meta_morpho.ConfigInput, _ *big.Int) cre.Promise[meta_morpho.ConfigOutput] {
+	for _, m := range f.markets {
+		if m.Id == input.Arg0 {
+			return cre.PromiseFromResult(meta_morpho.ConfigOutput{Cap: m.Cap, Enabled: true}, nil)
+		}
+	}
+	return cre.PromiseFromResult(meta_morpho.ConfigOutput{Cap: big.NewInt(0)}, nil)
+}
+
+func (f *fakeVault) SupplyQueueLength(cre.Runtime, *big.Int) cre.Promise[*big.Int] {
+	return cre.PromiseFromResult(big.NewInt(int64(len(f.supplyQueue))), nil)
+}
+
+func (f *fakeVault) SupplyQueue(_ cre.Runtime, input meta_morpho.SupplyQueueInput, _ *big.Int) cre.Promise[[32]byte] {
+	return cre.PromiseFromResult(f.supplyQueue[input.Arg0.Int64()], nil)
+}
+
+func (f *fakeVault) WithdrawQueueLength(cre.Runtime, *big.Int) cre.Promise[*big.Int] {
+	return cre.PromiseFromResult(big.NewInt(int64(len(f.markets))), nil)
+}
+
+func (f *fakeVault) WithdrawQueue(_ cre.Runtime, input meta_morpho.WithdrawQueueInput, _ *big.Int) cre.Promise[[32]byte] {
+	return cre.PromiseFromResult(f.markets[input.Arg0.Int64()].Id, nil)
+}
+
+// fakeMorphoBlue serves the markets of a fakeVault, with the vault holding
+// VaultAssets of each.
+type fakeMorphoBlue struct {
+	vault *fakeVault
+}
+
+func (f *fakeMorphoBlue) find(id [32]byte) marketState {
+	for _, m := range f.vault.markets {
+		if m.Id == id {
+			return m
+		}
+	}
+	panic("unknown market")
+}
+
+func (f *fakeMorphoBlue) IdToMarketParams(_ cre.Runtime, input morpho_blue.IdToMarketParamsInput, _ *big.Int) cre.Promise[morpho_blue.IdToMarketParamsOutput] {
+	p := f.find(input.Arg0).Params
+	return cre.PromiseFromResult(morpho_blue.IdToMarketParamsOutput{
+		LoanToken: p.LoanToken, CollateralToken: p.CollateralToken, Oracle: p.Oracle, Irm: p.Irm, Lltv: p.Lltv,
+	}, nil)
+}
+
+func (f *fakeMorphoBlue) Market(_ cre.Runtime, input morpho_blue.MarketInput, _ *big.Int) cre.Promise[morpho_blue.MarketOutput] {
+	m := f.find(input.Id).Market
+	return cre.PromiseFromResult(morpho_blue.MarketOutput{
+		TotalSupplyAssets: m.TotalSupplyAssets, TotalSupplyShares: m.TotalSupplyShares,
+		TotalBorrowAssets: m.TotalBorrowAssets, TotalBorrowShares: m.TotalBorrowShares,
+		LastUpdate: m.LastUpdate, Fee: m.Fee,
+	}, nil)
+}
+
+func (f *fakeMorphoBlue) Position(_ cre.Runtime, input morpho_blue.PositionInput, _ *big.Int) cre.Promise[morpho_blue.PositionOutput] {
+	if input.User != common.HexToAddress(testVaultAddress) {
+		return cre.PromiseFromResult(morpho_blue.PositionOutput{}, errors.New("unexpected position owner"))
+	}
+	// Shares minted at 1e6 per asset, matching testMarket's totals.
+	shares := new(big.Int).Mul(f.find(input.Id).VaultAssets, virtualShares)
+	return cre.PromiseFromResult(morpho_blue.PositionOutput{SupplyShares: shares, BorrowShares: big.NewInt(0), Collateral: big.NewInt(0)}, nil)
+}
+
+// linearIrm charges maxRate * utilization and records the supply it was asked about.
+type linearIrm struct {
+	maxRate  *big.Int
+	supplies []*big.Int
+}
+
+func (f *linearIrm) BorrowRateView(_ cre.Runtime, input irm.BorrowRateViewInput, _ *big.Int) cre.Promise[*big.Int] {
+	f.supplies = append(f.supplies, input.Market.TotalSupplyAssets)
+	rate := new(big.Int).Mul(f.maxRate, input.Market.TotalBorrowAssets)
+	return cre.PromiseFromResult(rate.Div(rate, input.Market.TotalSupplyAssets), nil)
+}
+
+var (
+	_ MetaMorphoInterface = (*fakeVault)(nil)
+	_ MorphoBlueInterface = (*fakeMorphoBlue)(nil)
+	_ IrmInterface        = (*linearIrm)(nil)
+)
+
+func morphoTestConfig() *helper.Config {
+	return &helper.Config{
+		Evms: []helper.EvmConfig{{
+			ChainName:          "test-chain",
+			ChainSelector:      1,
+			MorphoVaultAddress: testVaultAddress,
+		}},
+	}
+}
+
+// useFakes points the binding constructors at the fakes for one test.
+func useFakes(t *testing.T, vault *fakeVault, rateModel *linearIrm) {
+	origVault, origMorpho, origIrm := newMetaMorphoBindingFunc, newMorphoBlueBindingFunc, newIrmBindingFunc
+	t.Cleanup(func() {
+		newMetaMorphoBindingFunc, newMorphoBlueBindingFunc, newIrmBindingFunc = origVault, origMorpho, origIrm
+	})
+
+	newMetaMorphoBindingFunc = func(*evm.Client, string) (MetaMorphoInterface, error) { return vault, nil }
+	newMorphoBlueBindingFunc = func(_ *evm.Client, addr common.Address) (MorphoBlueInterface, error) {
+		require.Equal(t, testMorphoAddress, addr)
+		return &fakeMorphoBlue{vault: vault}, nil
+	}
+	newIrmBindingFunc = func(_ *evm.Client, addr common.Address) (IrmInterface, error) {
+		require.Equal(t, testIrmAddress, addr)
+		return rateModel, nil
+	}
+}
+
+func twoMarketVault() *fakeVault {
+	lent := testMarket(1, 1_000_000, 800_000, 400_000, 500_000)
+	lent.Params = irm.MarketParams{Irm: testIrmAddress, Lltv: big.NewInt(0)}
+	idle := testMarket(2, 100_000, 0, 100_000, 10_000_000) // idle market, no IRM
+	idle.Params = irm.MarketParams{Lltv: big.NewInt(0)}
+
+	return &fakeVault{
+		fee:         big.NewInt(0),
+		markets:     []marketState{lent, idle},
+		supplyQueue: [][32]byte{lent.Id, idle.Id},
+	}
+}
+
+/*//////////////////////////////////////////////////////////////
+                          ERROR PATHS
+//////////////////////////////////////////////////////////////*/
+
+func TestGetAPYPromise_error_whenChainConfigNotFound(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+
+	apy, err := GetAPYPromise(&helper.Config{}, runtime, big.NewInt(0), 123).Await()
+	require.ErrorContains(t, err, "chain config not found for chainSelector")
+	require.Zero(t, apy)
+}
+
+func TestGetAPYPromise_error_whenVaultAddressMissing(t *testing.T) {
+	cfg := morphoTestConfig()
+	cfg.Evms[0].MorphoVaultAddress = ""
+	runtime := testutils.NewRuntime(t, nil)
+
+	_, err := GetAPYPromise(cfg, runtime, big.NewInt(0), 1).Await()
+	require.ErrorContains(t, err, "MorphoVaultAddress not configured for chain test-chain")
+}
+
+func TestGetAPYPromise_error_whenLiquidityNil(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+
+	_, err := GetAPYPromise(morphoTestConfig(), runtime, nil, 1).Await()
+	require.ErrorContains(t, err, "liquidityAdded cannot be nil")
+}
+
+func TestGetAPYPromise_error_whenVaultBindingFails(t *testing.T) {
+	cfg := morphoTestConfig()
+	cfg.Evms[0].MorphoVaultAddress = "not-a-valid-address"
+	runtime := testutils.NewRuntime(t, nil)
+
+	_, err := GetAPYPromise(cfg, runtime, big.NewInt(0), 1).Await()
+	require.ErrorContains(t, err, "failed to create MetaMorpho binding for chain test-chain")
+}
+
+func TestGetAPYPromise_error_whenMorphoReadFails(t *testing.T) {
+	vault := twoMarketVault()
+	vault.morphoErr = errors.New("rpc down")
+	useFakes(t, vault, &linearIrm{maxRate: big.NewInt(0)})
+	runtime := testutils.NewRuntime(t, nil)
+
+	_, err := GetAPYPromise(morphoTestConfig(), runtime, big.NewInt(0), 1).Await()
+	require.ErrorContains(t, err, "rpc down")
+}
+
+func TestGetAPYPromise_infeasible_whenCapsReached(t *testing.T) {
+	useFakes(t, twoMarketVault(), &linearIrm{maxRate: big.NewInt(0)})
+	runtime := testutils.NewRuntime(t, nil)
+
+	// Room is 100k in the lent market and 9.9M in the idle one.
+	_, err := GetAPYPromise(morphoTestConfig(), runtime, big.NewInt(10_000_001), 1).Await()
+	require.ErrorContains(t, err, "failed to allocate liquidity on chain test-chain")
+	require.ErrorIs(t, err, protocol.ErrInfeasible, "a full vault is infeasible, not a failed evaluation")
+}
+
+/*//////////////////////////////////////////////////////////////
+                         SUCCESS PATHS
+//////////////////////////////////////////////////////////////*/
+
+func TestGetAPYPromise_success_currentAPY(t *testing.T) {
+	rateModel := &linearIrm{maxRate: wad(0.10 / constants.SecondsPerYear)}
+	useFakes(t, twoMarketVault(), rateModel)
+	runtime := testutils.NewRuntime(t, nil)
+
+	apy, err := GetAPYPromise(morphoTestConfig(), runtime, big.NewInt(0), 1).Await()
+	require.NoError(t, err)
+
+	// Lent market: 80% utilized at 8% borrow -> 6.4% supply rate; 4/5 of the vault.
+	lentAPY := helper.APYFromPerSecondRate(0.08 / constants.SecondsPerYear * 0.8)
+	require.InDelta(t, 0.8*lentAPY, apy, 1e-9)
+	require.Equal(t, []*big.Int{big.NewInt(1_000_000)}, rateModel.supplies, "idle market has no IRM")
+}
+
+func TestGetAPYPromise_success_depositLowersAPY(t *testing.T) {
+	rateModel := &linearIrm{maxRate: wad(0.10 / constants.SecondsPerYear)}
+	useFakes(t, twoMarketVault(), rateModel)
+	runtime := testutils.NewRuntime(t, nil)
+
+	current, err := GetAPYPromise(morphoTestConfig(), runtime, big.NewInt(0), 1).Await()
+	require.NoError(t, err)
+	projected, err := GetAPYPromise(morphoTestConfig(), runtime, big.NewInt(200_000), 1).Await()
+	require.NoError(t, err)
+
+	require.Less(t, projected, current)
+	// The lent market takes 100k up to its cap; the rest goes idle.
+	require.Equal(t, big.NewInt(1_100_000), rateModel.supplies[1])
+}
+
+func TestGetAPYPromise_success_netOfVaultFee(t *testing.T) {
+	vault := twoMarketVault()
+	useFakes(t, vault, &linearIrm{maxRate: wad(0.10 / constants.SecondsPerYear)})
+	runtime := testutils.NewRuntime(t, nil)
+
+	gross, err := GetAPYPromise(morphoTestConfig(), runtime, big.NewInt(0), 1).Await()
+	require.NoError(t, err)
+
+	vault.fee = wad(0.2)
+	net, err := GetAPYPromise(morphoTestConfig(), runtime, big.NewInt(0), 1).Await()
+	require.NoError(t, err)
+
+	require.InDelta(t, gross*0.8, net, 1e-12)
+}
@@ -0,0 +1,34 @@
+package morpho
+
+import (
+	"fmt"
+
+	"rebalance/contracts/evm/src/generated/irm"
+	"rebalance/contracts/evm/src/generated/meta_morpho"
+	"rebalance/contracts/evm/src/generated/morpho_blue"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/cre-sdk-go/capabilities/blockchain/evm"
+)
+
+// newMetaMorphoBinding constructs the MetaMorpho vault binding.
+// It validates the address and returns an interface for testability.
+func newMetaMorphoBinding(client *evm.Client, addr string) (MetaMorphoInterface, error) {
+	if !common.IsHexAddress(addr) {
+		return nil, fmt.Errorf("invalid MetaMorpho vault address: %s", addr)
+	}
+	vaultAddr := common.HexToAddress(addr)
+
+	return meta_morpho.NewMetaMorpho(client, vaultAddr, nil)
+}
+
+// newMorphoBlueBinding constructs the Morpho Blue binding. The address is
+// read from the vault, so it is not validated here.
+func newMorphoBlueBinding(client *evm.Client, addr common.Address) (MorphoBlueInterface, error) {
+	return morpho_blue.NewMorphoBlue(client, addr, nil)
+}
+
+// newIrmBinding constructs the binding of a market's interest rate model.
+func newIrmBinding(client *evm.Client, addr common.Address) (IrmInterface, error) {
+	return irm.NewIrm(client, addr, nil)
+}
@@ -0,0 +1,54 @@
+package morpho
+
+import (
+	"math/big"
+	"testing"
+
+	"rebalance/contracts/evm/src/generated/irm"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/cre-sdk-go/capabilities/blockchain/evm"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_newMetaMorphoBinding_success(t *testing.T) {
+	var client *evm.Client = nil
+
+	binding, err := newMetaMorphoBinding(client, "0x0000000000000000000000000000000000000001")
+	require.NoError(t, err)
+	require.NotNil(t, binding)
+}
+
+func Test_newMetaMorphoBinding_errorWhen_invalidAddress(t *testing.T) {
+	var client *evm.Client = nil
+
+	binding, err := newMetaMorphoBinding(client, "not-an-address")
+	require.ErrorContains(t, err, "invalid MetaMorpho vault address: not-an-address")
+	require.Nil(t, binding)
+}
+
+func Test_newMorphoBlueAndIrmBindings_success(t *testing.T) {
+	var client *evm.Client = nil
+
+	morphoBlue, err := newMorphoBlueBinding(client, common.HexToAddress("0x01"))
+	require.NoError(t, err)
+	require.NotNil(t, morphoBlue)
+
+	rateModel, err := newIrmBinding(client, common.HexToAddress("0x02"))
+	require.NoError(t, err)
+	require.NotNil(t, rateModel)
+}
+
+func Test_irmCodec_encodesMarketTuples(t *testing.T) {
+	codec, err := irm.NewCodec()
+	require.NoError(t, err)
+
+	m := testMarket(1, 1000, 500, 0, 0)
+	calldata, err := codec.EncodeBorrowRateViewMethodCall(irm.BorrowRateViewInput{
+		MarketParams: irm.MarketParams{Irm: common.HexToAddress("0x02"), Lltv: big.NewInt(0)},
+		Market:       m.Market,
+	})
+	require.NoError(t, err)
+	// selector + 5 MarketParams words + 6 Market words
+	require.Len(t, calldata, 4+11*32)
+}
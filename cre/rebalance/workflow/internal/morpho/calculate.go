@@ -0,0 +1,113 @@
+package morpho
+
+import (
+	"fmt"
+	"math/big"
+
+	"rebalance/contracts/evm/src/generated/irm"
+	"rebalance/workflow/internal/constants"
+	"rebalance/workflow/internal/helper"
+	"rebalance/workflow/internal/protocol"
+)
+
+// Morpho Blue's SharesMathLib virtual shares and assets.
+var (
+	virtualShares = big.NewInt(1e6)
+	virtualAssets = big.NewInt(1)
+)
+
+// toAssetsDown converts supply shares to assets, rounding down, as Morpho Blue's
+// SharesMathLib.toAssetsDown does.
+func toAssetsDown(shares, totalAssets, totalShares *big.Int) *big.Int {
+	num := new(big.Int).Mul(shares, new(big.Int).Add(totalAssets, virtualAssets))
+	return num.Div(num, new(big.Int).Add(totalShares, virtualShares))
+}
+
+// applyDeposit returns a copy of markets with deposit supplied the way
+// MetaMorpho's _supplyMorpho does: in supply queue order, each market up to the
+// vault's cap in it. A deposit the caps cannot absorb reverts on-chain
+// (AllCapsReached), so it fails with protocol.ErrInfeasible here.
+func applyDeposit(markets []marketState, supplyQueue [][32]byte, deposit *big.Int) ([]marketState, error) {
+	out := make([]marketState, len(markets))
+	index := make(map[[32]byte]int, len(markets))
+	for i, m := range markets {
+		out[i] = m
+		out[i].Market.TotalSupplyAssets = new(big.Int).Set(m.Market.TotalSupplyAssets)
+		out[i].VaultAssets = new(big.Int).Set(m.VaultAssets)
+		index[m.Id] = i
+	}
+
+	remaining := new(big.Int).Set(deposit)
+	for _, id := range supplyQueue {
+		if remaining.Sign() == 0 {
+			break
+		}
+		i, ok := index[id]
+		if !ok {
+			return nil, fmt.Errorf("supply queue market %x is not in the withdraw queue", id)
+		}
+
+		room := new(big.Int).Sub(out[i].Cap, out[i].VaultAssets)
+		if room.Sign() <= 0 {
+			continue
+		}
+		supplied := room
+		if remaining.Cmp(room) < 0 {
+			supplied = remaining
+		}
+
+		out[i].Market.TotalSupplyAssets.Add(out[i].Market.TotalSupplyAssets, supplied)
+		out[i].VaultAssets.Add(out[i].VaultAssets, supplied)
+		remaining = new(big.Int).Sub(remaining, supplied)
+	}
+
+	if remaining.Sign() != 0 {
+		return nil, fmt.Errorf("%w: vault caps cannot absorb deposit of %s (%s left over)", protocol.ErrInfeasible, deposit, remaining)
+	}
+	return out, nil
+}
+
+// calculateMarketSupplyAPY converts a market's WAD-scaled per-second borrow
+// rate into the supply APY of the market:
+//
+//	supplyRate = borrowRate * utilization * (1 - marketFee)
+func calculateMarketSupplyAPY(borrowRate *big.Int, market irm.Market) float64 {
+	if market.TotalSupplyAssets.Sign() == 0 || borrowRate.Sign() == 0 {
+		return 0
+	}
+
+	utilization := ratio(market.TotalBorrowAssets, market.TotalSupplyAssets)
+	fee := ratio(market.Fee, big.NewInt(constants.WAD))
+	rPerSecond := ratio(borrowRate, big.NewInt(constants.WAD)) * utilization * (1 - fee)
+
+	return helper.APYFromPerSecondRate(rPerSecond)
+}
+
+// calculateVaultAPY is the supply APY of each market weighted by the vault's
+// assets in it, net of the vault's performance fee. A vault with nothing
+// allocated earns nothing.
+func calculateVaultAPY(markets []marketState, marketAPYs []float64, vaultFee *big.Int) (float64, error) {
+	if len(markets) != len(marketAPYs) {
+		return 0, fmt.Errorf("got %d market APYs for %d markets", len(marketAPYs), len(markets))
+	}
+
+	total := new(big.Int)
+	for _, m := range markets {
+		total.Add(total, m.VaultAssets)
+	}
+	if total.Sign() == 0 {
+		return 0, nil
+	}
+
+	var apy float64
+	for i, m := range markets {
+		apy += ratio(m.VaultAssets, total) * marketAPYs[i]
+	}
+	return apy * (1 - ratio(vaultFee, big.NewInt(constants.WAD))), nil
+}
+
+// ratio returns a / b as a float64.
+func ratio(a, b *big.Int) float64 {
+	f, _ := new(big.Float).Quo(new(big.Float).SetInt(a), new(big.Float).SetInt(b)).Float64()
+	return f
+}
@@ -0,0 +1,121 @@
+package morpho
+
+import (
+	"math/big"
+	"testing"
+
+	"rebalance/contracts/evm/src/generated/irm"
+	"rebalance/workflow/internal/constants"
+	"rebalance/workflow/internal/helper"
+	"rebalance/workflow/internal/protocol"
+
+	"github.com/stretchr/testify/require"
+)
+
+func wad(f float64) *big.Int {
+	v, _ := new(big.Float).Mul(big.NewFloat(f), big.NewFloat(constants.WAD)).Int(nil)
+	return v
+}
+
+func testMarket(id byte, supply, borrow, vaultAssets, cap int64) marketState {
+	return marketState{
+		Id: [32]byte{id},
+		Market: irm.Market{
+			TotalSupplyAssets: big.NewInt(supply),
+			TotalSupplyShares: new(big.Int).Mul(big.NewInt(supply), virtualShares),
+			TotalBorrowAssets: big.NewInt(borrow),
+			TotalBorrowShares: new(big.Int).Mul(big.NewInt(borrow), virtualShares),
+			LastUpdate:        big.NewInt(0),
+			Fee:               big.NewInt(0),
+		},
+		VaultAssets: big.NewInt(vaultAssets),
+		Cap:         big.NewInt(cap),
+	}
+}
+
+func Test_toAssetsDown_matchesSharesMathLib(t *testing.T) {
+	// 1e6 shares per asset at genesis.
+	require.Equal(t, big.NewInt(1), toAssetsDown(big.NewInt(1e6), big.NewInt(0), big.NewInt(0)))
+	require.Zero(t, toAssetsDown(big.NewInt(1e6-1), big.NewInt(0), big.NewInt(0)).Sign())
+	// (2e6 * (1000+1)) / (1000e6 + 1e6) = 2
+	require.Equal(t, big.NewInt(2), toAssetsDown(big.NewInt(2e6), big.NewInt(1000), big.NewInt(1000e6)))
+	// Interest accrued: 1100 assets for 1000e6 shares.
+	require.Equal(t, big.NewInt(109), toAssetsDown(big.NewInt(100e6), big.NewInt(1100), big.NewInt(1000e6)))
+}
+
+func Test_applyDeposit_fillsSupplyQueueUpToCaps(t *testing.T) {
+	a := testMarket(1, 1000, 500, 100, 150) // room 50
+	b := testMarket(2, 2000, 500, 0, 0)     // no cap
+	c := testMarket(3, 3000, 500, 200, 1000)
+
+	out, err := applyDeposit([]marketState{a, b, c}, [][32]byte{a.Id, b.Id, c.Id}, big.NewInt(80))
+	require.NoError(t, err)
+
+	require.Equal(t, big.NewInt(150), out[0].VaultAssets)
+	require.Equal(t, big.NewInt(1050), out[0].Market.TotalSupplyAssets)
+	require.Equal(t, big.NewInt(0), out[1].VaultAssets)
+	require.Equal(t, big.NewInt(230), out[2].VaultAssets)
+	require.Equal(t, big.NewInt(3030), out[2].Market.TotalSupplyAssets)
+
+	// Inputs are not modified.
+	require.Equal(t, big.NewInt(100), a.VaultAssets)
+	require.Equal(t, big.NewInt(1000), a.Market.TotalSupplyAssets)
+}
+
+func Test_applyDeposit_errorWhenCapsReached(t *testing.T) {
+	a := testMarket(1, 1000, 500, 100, 150)
+
+	_, err := applyDeposit([]marketState{a}, [][32]byte{a.Id}, big.NewInt(51))
+	require.ErrorIs(t, err, protocol.ErrInfeasible)
+	require.ErrorContains(t, err, "vault caps cannot absorb deposit of 51 (1 left over)")
+}
+
+func Test_applyDeposit_errorWhenSupplyQueueMarketUnknown(t *testing.T) {
+	a := testMarket(1, 1000, 500, 100, 150)
+
+	_, err := applyDeposit([]marketState{a}, [][32]byte{{9}}, big.NewInt(1))
+	require.ErrorContains(t, err, "is not in the withdraw queue")
+}
+
+func Test_applyDeposit_zeroIsNoop(t *testing.T) {
+	a := testMarket(1, 1000, 500, 100, 100)
+
+	out, err := applyDeposit([]marketState{a}, [][32]byte{{9}}, big.NewInt(0))
+	require.NoError(t, err)
+	require.Equal(t, []marketState{a}, out)
+}
+
+func Test_calculateMarketSupplyAPY(t *testing.T) {
+	m := testMarket(1, 1000, 800, 0, 0).Market
+	m.Fee = wad(0.1)
+	borrowRate := wad(0.05 / constants.SecondsPerYear)
+
+	expected := helper.APYFromPerSecondRate(0.05 / constants.SecondsPerYear * 0.8 * 0.9)
+	require.InDelta(t, expected, calculateMarketSupplyAPY(borrowRate, m), 1e-9)
+
+	empty := testMarket(1, 0, 0, 0, 0).Market
+	require.Zero(t, calculateMarketSupplyAPY(borrowRate, empty))
+	require.Zero(t, calculateMarketSupplyAPY(big.NewInt(0), m))
+}
+
+func Test_calculateVaultAPY_weightsByAllocationNetOfFee(t *testing.T) {
+	markets := []marketState{
+		testMarket(1, 1000, 0, 300, 0),
+		testMarket(2, 1000, 0, 100, 0),
+	}
+
+	apy, err := calculateVaultAPY(markets, []float64{0.04, 0.08}, wad(0.1))
+	require.NoError(t, err)
+	require.InDelta(t, (0.75*0.04+0.25*0.08)*0.9, apy, 1e-12)
+}
+
+func Test_calculateVaultAPY_zeroWhenNothingAllocated(t *testing.T) {
+	apy, err := calculateVaultAPY([]marketState{testMarket(1, 1000, 0, 0, 0)}, []float64{0.05}, big.NewInt(0))
+	require.NoError(t, err)
+	require.Zero(t, apy)
+}
+
+func Test_calculateVaultAPY_errorOnLengthMismatch(t *testing.T) {
+	_, err := calculateVaultAPY([]marketState{testMarket(1, 1000, 0, 1, 0)}, nil, big.NewInt(0))
+	require.ErrorContains(t, err, "got 0 market APYs for 1 markets")
+}
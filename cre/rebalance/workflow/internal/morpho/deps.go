@@ -0,0 +1,8 @@
+package morpho
+
+// Dependency injection for Morpho.
+var (
+	newMetaMorphoBindingFunc = newMetaMorphoBinding
+	newMorphoBlueBindingFunc = newMorphoBlueBinding
+	newIrmBindingFunc        = newIrmBinding
+)
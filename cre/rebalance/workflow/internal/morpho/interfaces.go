@@ -0,0 +1,39 @@
+package morpho
+
+import (
+	"math/big"
+
+	"rebalance/contracts/evm/src/generated/irm"
+	"rebalance/contracts/evm/src/generated/meta_morpho"
+	"rebalance/contracts/evm/src/generated/morpho_blue"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+)
+
+// MetaMorphoInterface abstracts a MetaMorpho vault.
+type MetaMorphoInterface interface {
+	MORPHO(runtime cre.Runtime, blockNumber *big.Int) cre.Promise[common.Address]
+	// Performance fee, WAD-scaled.
+	Fee(runtime cre.Runtime, blockNumber *big.Int) cre.Promise[*big.Int]
+	// Supply cap of the vault in a market.
+	Config(runtime cre.Runtime, input meta_morpho.ConfigInput, blockNumber *big.Int) cre.Promise[meta_morpho.ConfigOutput]
+	SupplyQueueLength(runtime cre.Runtime, blockNumber *big.Int) cre.Promise[*big.Int]
+	SupplyQueue(runtime cre.Runtime, input meta_morpho.SupplyQueueInput, blockNumber *big.Int) cre.Promise[[32]byte]
+	// The withdraw queue holds every market the vault is enabled on.
+	WithdrawQueueLength(runtime cre.Runtime, blockNumber *big.Int) cre.Promise[*big.Int]
+	WithdrawQueue(runtime cre.Runtime, input meta_morpho.WithdrawQueueInput, blockNumber *big.Int) cre.Promise[[32]byte]
+}
+
+// MorphoBlueInterface abstracts the Morpho Blue singleton the vault supplies to.
+type MorphoBlueInterface interface {
+	IdToMarketParams(runtime cre.Runtime, input morpho_blue.IdToMarketParamsInput, blockNumber *big.Int) cre.Promise[morpho_blue.IdToMarketParamsOutput]
+	Market(runtime cre.Runtime, input morpho_blue.MarketInput, blockNumber *big.Int) cre.Promise[morpho_blue.MarketOutput]
+	Position(runtime cre.Runtime, input morpho_blue.PositionInput, blockNumber *big.Int) cre.Promise[morpho_blue.PositionOutput]
+}
+
+// IrmInterface abstracts a Morpho Blue interest rate model.
+type IrmInterface interface {
+	// Returns the per-second borrow rate, WAD-scaled, for the given market state.
+	BorrowRateView(runtime cre.Runtime, input irm.BorrowRateViewInput, blockNumber *big.Int) cre.Promise[*big.Int]
+}
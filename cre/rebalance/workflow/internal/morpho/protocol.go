@@ -0,0 +1,37 @@
+package morpho
+
+import (
+	"math/big"
+
+	"rebalance/workflow/internal/helper"
+	"rebalance/workflow/internal/protocol"
+
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+)
+
+// Name is the Morpho protocol name; its protocol ID is keccak256(Name).
+const Name = "morpho"
+
+// ProtocolId is the Morpho protocol ID.
+var ProtocolId = protocol.IDFromName(Name)
+
+func init() {
+	protocol.Register(morphoProtocol{})
+}
+
+// morphoProtocol registers a MetaMorpho vault as a yield source.
+type morphoProtocol struct{}
+
+func (morphoProtocol) ID() [32]byte { return ProtocolId }
+
+func (morphoProtocol) Name() string { return Name }
+
+// IsConfigured only offers the vault to a USDC vault asset, since
+// MorphoVaultAddress is a USDC vault.
+func (morphoProtocol) IsConfigured(evm helper.EvmConfig, asset helper.AssetConfig) bool {
+	return evm.MorphoVaultAddress != "" && asset.Symbol == helper.DefaultAsset
+}
+
+func (morphoProtocol) GetAPYPromise(config *helper.Config, runtime cre.Runtime, _ *protocol.Cache, liquidityAdded *big.Int, chainSelector uint64) cre.Promise[float64] {
+	return GetAPYPromise(config, runtime, liquidityAdded, chainSelector)
+}
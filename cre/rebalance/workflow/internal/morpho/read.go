@@ -0,0 +1,135 @@
+package morpho
+
+import (
+	"fmt"
+	"math/big"
+
+	"rebalance/contracts/evm/src/generated/irm"
+	"rebalance/contracts/evm/src/generated/meta_morpho"
+	"rebalance/contracts/evm/src/generated/morpho_blue"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+)
+
+// maxQueueLength is MetaMorpho's ConstantsLib.MAX_QUEUE_LENGTH.
+const maxQueueLength = 30
+
+// readVaultState reads the vault's fee, queues, and for every market in the
+// withdraw queue the market params, market totals, the vault's position and
+// its supply cap. All calls are issued before any is awaited.
+func readVaultState(
+	runtime cre.Runtime,
+	vault MetaMorphoInterface,
+	morphoBlue MorphoBlueInterface,
+	vaultAddress common.Address,
+	blockNumber *big.Int,
+) cre.Promise[*vaultState] {
+	feePromise := vault.Fee(runtime, blockNumber)
+	withdrawQueuePromise := readQueue(runtime, vault.WithdrawQueueLength(runtime, blockNumber), func(i *big.Int) cre.Promise[[32]byte] {
+		return vault.WithdrawQueue(runtime, meta_morpho.WithdrawQueueInput{Arg0: i}, blockNumber)
+	})
+	supplyQueuePromise := readQueue(runtime, vault.SupplyQueueLength(runtime, blockNumber), func(i *big.Int) cre.Promise[[32]byte] {
+		return vault.SupplyQueue(runtime, meta_morpho.SupplyQueueInput{Arg0: i}, blockNumber)
+	})
+
+	return cre.ThenPromise(withdrawQueuePromise, func(ids [][32]byte) cre.Promise[*vaultState] {
+		marketPromises := make([]cre.Promise[marketState], 0, len(ids))
+		for _, id := range ids {
+			marketPromises = append(marketPromises, readMarketState(runtime, vault, morphoBlue, vaultAddress, id, blockNumber))
+		}
+		marketsPromise := all(marketPromises)
+
+		return cre.ThenPromise(supplyQueuePromise, func(supplyQueue [][32]byte) cre.Promise[*vaultState] {
+			return cre.ThenPromise(feePromise, func(fee *big.Int) cre.Promise[*vaultState] {
+				return cre.Then(marketsPromise, func(markets []marketState) (*vaultState, error) {
+					return &vaultState{
+						Fee:         fee,
+						Markets:     markets,
+						SupplyQueue: supplyQueue,
+					}, nil
+				})
+			})
+		})
+	})
+}
+
+// readQueue reads every entry of a vault queue given a promise of its length.
+func readQueue(
+	runtime cre.Runtime,
+	lengthPromise cre.Promise[*big.Int],
+	at func(i *big.Int) cre.Promise[[32]byte],
+) cre.Promise[[][32]byte] {
+	return cre.ThenPromise(lengthPromise, func(length *big.Int) cre.Promise[[][32]byte] {
+		if !length.IsInt64() || length.Int64() > maxQueueLength {
+			return cre.PromiseFromResult[[][32]byte](nil, fmt.Errorf("vault queue length %s exceeds %d", length, maxQueueLength))
+		}
+
+		entries := make([]cre.Promise[[32]byte], 0, length.Int64())
+		for i := int64(0); i < length.Int64(); i++ {
+			entries = append(entries, at(big.NewInt(i)))
+		}
+		return all(entries)
+	})
+}
+
+// readMarketState reads one market the vault is enabled on.
+func readMarketState(
+	runtime cre.Runtime,
+	vault MetaMorphoInterface,
+	morphoBlue MorphoBlueInterface,
+	vaultAddress common.Address,
+	id [32]byte,
+	blockNumber *big.Int,
+) cre.Promise[marketState] {
+	paramsPromise := morphoBlue.IdToMarketParams(runtime, morpho_blue.IdToMarketParamsInput{Arg0: id}, blockNumber)
+	marketPromise := morphoBlue.Market(runtime, morpho_blue.MarketInput{Id: id}, blockNumber)
+	positionPromise := morphoBlue.Position(runtime, morpho_blue.PositionInput{Id: id, User: vaultAddress}, blockNumber)
+	configPromise := vault.Config(runtime, meta_morpho.ConfigInput{Arg0: id}, blockNumber)
+
+	return cre.ThenPromise(paramsPromise, func(params morpho_blue.IdToMarketParamsOutput) cre.Promise[marketState] {
+		return cre.ThenPromise(marketPromise, func(market morpho_blue.MarketOutput) cre.Promise[marketState] {
+			return cre.ThenPromise(positionPromise, func(position morpho_blue.PositionOutput) cre.Promise[marketState] {
+				return cre.Then(configPromise, func(config meta_morpho.ConfigOutput) (marketState, error) {
+					return marketState{
+						Id: id,
+						Params: irm.MarketParams{
+							LoanToken:       params.LoanToken,
+							CollateralToken: params.CollateralToken,
+							Oracle:          params.Oracle,
+							Irm:             params.Irm,
+							Lltv:            params.Lltv,
+						},
+						Market: irm.Market{
+							TotalSupplyAssets: market.TotalSupplyAssets,
+							TotalSupplyShares: market.TotalSupplyShares,
+							TotalBorrowAssets: market.TotalBorrowAssets,
+							TotalBorrowShares: market.TotalBorrowShares,
+							LastUpdate:        market.LastUpdate,
+							Fee:               market.Fee,
+						},
+						VaultAssets: toAssetsDown(position.SupplyShares, market.TotalSupplyAssets, market.TotalSupplyShares),
+						Cap:         config.Cap,
+					}, nil
+				})
+			})
+		})
+	})
+}
+
+// all resolves promises in order into a promise of their results.
+func all[T any](promises []cre.Promise[T]) cre.Promise[[]T] {
+	results := make([]T, 0, len(promises))
+
+	var next func(i int) cre.Promise[[]T]
+	next = func(i int) cre.Promise[[]T] {
+		if i == len(promises) {
+			return cre.PromiseFromResult(results, nil)
+		}
+		return cre.ThenPromise(promises[i], func(v T) cre.Promise[[]T] {
+			results = append(results, v)
+			return next(i + 1)
+		})
+	}
+	return next(0)
+}
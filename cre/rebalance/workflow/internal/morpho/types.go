@@ -0,0 +1,28 @@
+package morpho
+
+import (
+	"math/big"
+
+	"rebalance/contracts/evm/src/generated/irm"
+)
+
+// marketState is a Morpho Blue market the vault is enabled on.
+type marketState struct {
+	Id     [32]byte
+	Params irm.MarketParams
+	Market irm.Market
+	// Vault's supply in the market, in assets.
+	VaultAssets *big.Int
+	// Vault's supply cap in the market, in assets.
+	Cap *big.Int
+}
+
+// vaultState is everything needed to price a MetaMorpho vault.
+type vaultState struct {
+	// Performance fee, WAD-scaled.
+	Fee *big.Int
+	// Markets in withdraw queue order.
+	Markets []marketState
+	// Order new deposits are supplied to markets in.
+	SupplyQueue [][32]byte
+}
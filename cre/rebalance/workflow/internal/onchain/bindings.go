@@ -33,6 +33,30 @@ func NewChildPeerBinding(client *evm.Client, addr string) (YieldPeerInterface, e
 	return child_peer.NewChildPeer(client, childPeerAddr, nil)
 }
 
+// NewStrategyAdapterReaderBinding constructs a binding that reads the strategy
+// adapter of the YieldPeer (parent or child) at addr.
+// It satisfies StrategyAdapterReaderInterface.
+func NewStrategyAdapterReaderBinding(client *evm.Client, addr string) (StrategyAdapterReaderInterface, error) {
+	if !common.IsHexAddress(addr) {
+		return nil, fmt.Errorf("invalid YieldPeer address: %s", addr)
+	}
+	peerAddr := common.HexToAddress(addr)
+
+	return child_peer.NewChildPeer(client, peerAddr, nil)
+}
+
+// NewCCIPLogReaderBinding constructs a binding that reads the CCIP message
+// logs of the YieldPeer (parent or child) at addr.
+// It satisfies CCIPLogReaderInterface.
+func NewCCIPLogReaderBinding(client *evm.Client, addr string) (CCIPLogReaderInterface, error) {
+	if !common.IsHexAddress(addr) {
+		return nil, fmt.Errorf("invalid YieldPeer address: %s", addr)
+	}
+	peerAddr := common.HexToAddress(addr)
+
+	return child_peer.NewChildPeer(client, peerAddr, nil)
+}
+
 // NewRebalancerBinding constructs the rebalancer binding.
 // It satisfies RebalancerInterface.
 func NewRebalancerBinding(client *evm.Client, addr string) (RebalancerInterface, error) {
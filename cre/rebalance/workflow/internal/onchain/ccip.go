@@ -0,0 +1,107 @@
+package onchain
+
+import (
+	"fmt"
+	"math/big"
+
+	"rebalance/contracts/evm/src/generated/child_peer"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/chainlink-protos/cre/go/values/pb"
+	"github.com/smartcontractkit/cre-sdk-go/capabilities/blockchain/evm"
+	"github.com/smartcontractkit/cre-sdk-go/capabilities/blockchain/evm/bindings"
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+)
+
+// ReadCCIPMessages reads the CCIPMessageSent and CCIPMessageReceived events a
+// peer on chainSelector emitted in the last lookbackBlocks blocks.
+//
+// The head is the latest block rather than config.BlockNumber: a message sent
+// after the finalized head must still be seen.
+func ReadCCIPMessages(
+	runtime cre.Runtime,
+	chainSelector uint64,
+	lookbackBlocks uint64,
+	headers HeaderReaderInterface,
+	peer CCIPLogReaderInterface,
+) (CCIPMessageLogs, error) {
+	if lookbackBlocks == 0 {
+		return CCIPMessageLogs{}, fmt.Errorf("lookbackBlocks must be greater than zero")
+	}
+
+	head, err := readHeader(runtime, headers, big.NewInt(LatestBlockNumber))
+	if err != nil {
+		return CCIPMessageLogs{}, fmt.Errorf("failed to read head block: %w", err)
+	}
+	headBlock := pb.NewIntFromBigInt(head.BlockNumber)
+
+	fromBlock := new(big.Int).Sub(headBlock, new(big.Int).SetUint64(lookbackBlocks))
+	if fromBlock.Sign() < 0 {
+		fromBlock.SetInt64(0)
+	}
+	options := &bindings.FilterOptions{FromBlock: fromBlock, ToBlock: headBlock}
+
+	// Issue both filters before awaiting either.
+	sentPromise, err := peer.FilterLogsCCIPMessageSent(runtime, options)
+	if err != nil {
+		return CCIPMessageLogs{}, fmt.Errorf("failed to filter CCIPMessageSent logs: %w", err)
+	}
+	receivedPromise, err := peer.FilterLogsCCIPMessageReceived(runtime, options)
+	if err != nil {
+		return CCIPMessageLogs{}, fmt.Errorf("failed to filter CCIPMessageReceived logs: %w", err)
+	}
+
+	sentReply, err := sentPromise.Await()
+	if err != nil {
+		return CCIPMessageLogs{}, fmt.Errorf("failed to filter CCIPMessageSent logs: %w", err)
+	}
+	receivedReply, err := receivedPromise.Await()
+	if err != nil {
+		return CCIPMessageLogs{}, fmt.Errorf("failed to filter CCIPMessageReceived logs: %w", err)
+	}
+
+	codec, err := child_peer.NewCodec()
+	if err != nil {
+		return CCIPMessageLogs{}, fmt.Errorf("failed to create YieldPeer codec: %w", err)
+	}
+
+	logs := CCIPMessageLogs{
+		ChainSelector: chainSelector,
+		FromBlock:     fromBlock.Uint64(),
+		HeadBlock:     headBlock.Uint64(),
+	}
+
+	for _, log := range sentReply.GetLogs() {
+		if log.Removed {
+			continue
+		}
+		decoded, err := codec.DecodeCCIPMessageSent(log)
+		if err != nil {
+			return CCIPMessageLogs{}, fmt.Errorf("failed to decode CCIPMessageSent log: %w", err)
+		}
+		logs.Sent = append(logs.Sent, newCCIPMessage(decoded.MessageId, decoded.TxType, chainSelector, log))
+	}
+
+	for _, log := range receivedReply.GetLogs() {
+		if log.Removed {
+			continue
+		}
+		decoded, err := codec.DecodeCCIPMessageReceived(log)
+		if err != nil {
+			return CCIPMessageLogs{}, fmt.Errorf("failed to decode CCIPMessageReceived log: %w", err)
+		}
+		logs.Received = append(logs.Received, newCCIPMessage(decoded.MessageId, decoded.TxType, chainSelector, log))
+	}
+
+	return logs, nil
+}
+
+func newCCIPMessage(messageId [32]byte, txType uint8, chainSelector uint64, log *evm.Log) CCIPMessage {
+	return CCIPMessage{
+		MessageId:     common.Hash(messageId),
+		TxType:        txType,
+		ChainSelector: chainSelector,
+		BlockNumber:   pb.NewIntFromBigInt(log.BlockNumber).Uint64(),
+		TxHash:        log.TxHash,
+	}
+}
@@ -0,0 +1,176 @@
+package onchain
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"rebalance/contracts/evm/src/generated/child_peer"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/chainlink-protos/cre/go/values/pb"
+	"github.com/smartcontractkit/cre-sdk-go/capabilities/blockchain/evm"
+	"github.com/smartcontractkit/cre-sdk-go/capabilities/blockchain/evm/bindings"
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+	"github.com/smartcontractkit/cre-sdk-go/cre/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+/*//////////////////////////////////////////////////////////////
+                             MOCKS
+//////////////////////////////////////////////////////////////*/
+
+type mockCCIPLogReader struct {
+	filterLogsCCIPMessageSentFunc     func(runtime cre.Runtime, options *bindings.FilterOptions) (cre.Promise[*evm.FilterLogsReply], error)
+	filterLogsCCIPMessageReceivedFunc func(runtime cre.Runtime, options *bindings.FilterOptions) (cre.Promise[*evm.FilterLogsReply], error)
+}
+
+func (m *mockCCIPLogReader) FilterLogsCCIPMessageSent(runtime cre.Runtime, options *bindings.FilterOptions) (cre.Promise[*evm.FilterLogsReply], error) {
+	if m.filterLogsCCIPMessageSentFunc == nil {
+		return cre.PromiseFromResult(&evm.FilterLogsReply{}, nil), nil
+	}
+	return m.filterLogsCCIPMessageSentFunc(runtime, options)
+}
+
+func (m *mockCCIPLogReader) FilterLogsCCIPMessageReceived(runtime cre.Runtime, options *bindings.FilterOptions) (cre.Promise[*evm.FilterLogsReply], error) {
+	if m.filterLogsCCIPMessageReceivedFunc == nil {
+		return cre.PromiseFromResult(&evm.FilterLogsReply{}, nil), nil
+	}
+	return m.filterLogsCCIPMessageReceivedFunc(runtime, options)
+}
+
+// latestHeaderReader answers every header request with head.
+type latestHeaderReader struct {
+	head int64
+	err  error
+}
+
+func (m *latestHeaderReader) HeaderByNumber(_ cre.Runtime, _ *evm.HeaderByNumberRequest) cre.Promise[*evm.HeaderByNumberReply] {
+	if m.err != nil {
+		return cre.PromiseFromResult[*evm.HeaderByNumberReply](nil, m.err)
+	}
+	return cre.PromiseFromResult(&evm.HeaderByNumberReply{
+		Header: &evm.Header{BlockNumber: pb.NewBigIntFromInt(big.NewInt(m.head))},
+	}, nil)
+}
+
+// ccipMessageLog builds a CCIPMessageSent or CCIPMessageReceived log as a
+// YieldPeer would emit it. Every argument of both events is indexed.
+func ccipMessageLog(eventHash []byte, blockNumber int64, messageId common.Hash, txType uint8, last uint64) *evm.Log {
+	return &evm.Log{
+		Topics: [][]byte{
+			eventHash,
+			messageId.Bytes(),
+			uint64Topic(uint64(txType)),
+			uint64Topic(last),
+		},
+		TxHash:      big.NewInt(blockNumber).Bytes(),
+		BlockNumber: pb.NewBigIntFromInt(big.NewInt(blockNumber)),
+	}
+}
+
+/*//////////////////////////////////////////////////////////////
+                             TESTS
+//////////////////////////////////////////////////////////////*/
+
+func Test_ReadCCIPMessages_success(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+	codec, err := child_peer.NewCodec()
+	require.NoError(t, err)
+
+	sentId := common.HexToHash("0x01")
+	receivedId := common.HexToHash("0x02")
+
+	peer := &mockCCIPLogReader{
+		filterLogsCCIPMessageSentFunc: func(_ cre.Runtime, options *bindings.FilterOptions) (cre.Promise[*evm.FilterLogsReply], error) {
+			require.Equal(t, int64(900), options.FromBlock.Int64())
+			require.Equal(t, int64(1_000), options.ToBlock.Int64())
+
+			removed := ccipMessageLog(codec.CCIPMessageSentLogHash(), 950, common.HexToHash("0x03"), CcipTxTypeRebalanceOldStrategy, 1)
+			removed.Removed = true
+
+			return cre.PromiseFromResult(&evm.FilterLogsReply{Logs: []*evm.Log{
+				ccipMessageLog(codec.CCIPMessageSentLogHash(), 910, sentId, CcipTxTypeRebalanceOldStrategy, 1_000_000),
+				removed,
+			}}, nil), nil
+		},
+		filterLogsCCIPMessageReceivedFunc: func(_ cre.Runtime, options *bindings.FilterOptions) (cre.Promise[*evm.FilterLogsReply], error) {
+			require.Equal(t, int64(900), options.FromBlock.Int64())
+			return cre.PromiseFromResult(&evm.FilterLogsReply{Logs: []*evm.Log{
+				ccipMessageLog(codec.CCIPMessageReceivedLogHash(), 990, receivedId, CcipTxTypeRebalanceNewStrategy, 2),
+			}}, nil), nil
+		},
+	}
+
+	logs, err := ReadCCIPMessages(runtime, 1, 100, &latestHeaderReader{head: 1_000}, peer)
+	require.NoError(t, err)
+
+	require.Equal(t, CCIPMessageLogs{
+		ChainSelector: 1,
+		FromBlock:     900,
+		HeadBlock:     1_000,
+		Sent: []CCIPMessage{{
+			MessageId:     sentId,
+			TxType:        CcipTxTypeRebalanceOldStrategy,
+			ChainSelector: 1,
+			BlockNumber:   910,
+			TxHash:        big.NewInt(910).Bytes(),
+		}},
+		Received: []CCIPMessage{{
+			MessageId:     receivedId,
+			TxType:        CcipTxTypeRebalanceNewStrategy,
+			ChainSelector: 1,
+			BlockNumber:   990,
+			TxHash:        big.NewInt(990).Bytes(),
+		}},
+	}, logs)
+}
+
+func Test_ReadCCIPMessages_clampsFromBlockAtGenesis(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+
+	peer := &mockCCIPLogReader{
+		filterLogsCCIPMessageSentFunc: func(_ cre.Runtime, options *bindings.FilterOptions) (cre.Promise[*evm.FilterLogsReply], error) {
+			require.Equal(t, int64(0), options.FromBlock.Int64())
+			return cre.PromiseFromResult(&evm.FilterLogsReply{}, nil), nil
+		},
+	}
+
+	logs, err := ReadCCIPMessages(runtime, 1, 500, &latestHeaderReader{head: 100}, peer)
+	require.NoError(t, err)
+	require.Empty(t, logs.Sent)
+	require.Empty(t, logs.Received)
+	require.Equal(t, uint64(0), logs.FromBlock)
+}
+
+func Test_ReadCCIPMessages_errorWhenLookbackZero(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+
+	_, err := ReadCCIPMessages(runtime, 1, 0, &latestHeaderReader{}, &mockCCIPLogReader{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "lookbackBlocks must be greater than zero")
+}
+
+func Test_ReadCCIPMessages_errorWhenHeadUnavailable(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+	headerErr := errors.New("rpc down")
+
+	_, err := ReadCCIPMessages(runtime, 1, 10, &latestHeaderReader{err: headerErr}, &mockCCIPLogReader{})
+	require.ErrorIs(t, err, headerErr)
+	require.Contains(t, err.Error(), "failed to read head block")
+}
+
+func Test_ReadCCIPMessages_errorWhenFilterLogsFails(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+	filterErr := errors.New("filter failed")
+
+	peer := &mockCCIPLogReader{
+		filterLogsCCIPMessageReceivedFunc: func(_ cre.Runtime, _ *bindings.FilterOptions) (cre.Promise[*evm.FilterLogsReply], error) {
+			return cre.PromiseFromResult[*evm.FilterLogsReply](nil, filterErr), nil
+		},
+	}
+
+	_, err := ReadCCIPMessages(runtime, 1, 10, &latestHeaderReader{head: 100}, peer)
+	require.ErrorIs(t, err, filterErr)
+	require.Contains(t, err.Error(), "failed to filter CCIPMessageReceived logs")
+}
@@ -8,4 +8,14 @@ var (
     AaveV3ProtocolId     = [32]byte(common.HexToHash("0xbbbf88eb3aaea499bd8961e51ce38087d4dda7879001b87ead64f8a7a3d0b2da"))
 	// keccak256("compound-v3")
     CompoundV3ProtocolId = [32]byte(common.HexToHash("0x3af167fff8b2aadd8bc497987eee3c5c291f8d6741dda2249d1df61732ddfda1"))
-)
\ No newline at end of file
+)
+
+// LatestBlockNumber is the "latest" block tag (config.BlockNumber uses the same
+// encoding: -2 latest, -3 finalized).
+const LatestBlockNumber int64 = -2
+
+// CCIP transaction types (IYieldPeer.CcipTxType) sent during a rebalance.
+const (
+	CcipTxTypeRebalanceOldStrategy uint8 = 7 // parent -> old strategy chain
+	CcipTxTypeRebalanceNewStrategy uint8 = 8 // old strategy chain -> new strategy chain
+)
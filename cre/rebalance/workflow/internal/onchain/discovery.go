@@ -0,0 +1,122 @@
+package onchain
+
+import (
+	"fmt"
+	"math/big"
+
+	"rebalance/contracts/evm/src/generated/child_peer"
+	"rebalance/contracts/evm/src/generated/parent_peer"
+	"rebalance/workflow/internal/helper"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+)
+
+// Reasons on-chain discovery excludes a configured strategy, in the order they
+// are checked.
+const (
+	ExcludedProtocolUnsupported = "protocol-unsupported"
+	ExcludedChainNotAllowed     = "chain-not-allowed"
+	ExcludedNoStrategyAdapter   = "no-strategy-adapter"
+)
+
+// ExcludedStrategy is a configured strategy the contracts would reject.
+type ExcludedStrategy struct {
+	Strategy NamedStrategy `json:"strategy"`
+	Reason   string        `json:"reason"`
+}
+
+// StrategyDiscovery is the configured strategy set intersected with on-chain
+// state.
+type StrategyDiscovery struct {
+	Strategies StrategySet
+	Excluded   []ExcludedStrategy
+}
+
+// DiscoverStrategies keeps the strategies in configured that ParentPeer
+// supports the protocol of and allows the chain of, and that the peer on the
+// strategy's chain has a strategy adapter for. peers maps every chain selector
+// in configured to that chain's YieldPeer.
+//
+// Every read is issued before any is awaited. Strategies keep their order.
+func DiscoverStrategies(
+	config *helper.Config,
+	runtime cre.Runtime,
+	configured StrategySet,
+	parentPeer ParentPeerInterface,
+	peers map[uint64]StrategyAdapterReaderInterface,
+) (StrategyDiscovery, error) {
+	blockNumber := big.NewInt(config.BlockNumber)
+
+	// Each protocol and chain is read once; the slices keep the await order
+	// deterministic.
+	var (
+		protocols        [][32]byte
+		chains           []uint64
+		protocolPromises = make(map[[32]byte]cre.Promise[bool])
+		chainPromises    = make(map[uint64]cre.Promise[bool])
+		adapterPromises  = make([]cre.Promise[common.Address], 0, configured.Len())
+	)
+
+	for _, strategy := range configured.strategies {
+		if _, ok := protocolPromises[strategy.ProtocolId]; !ok {
+			protocols = append(protocols, strategy.ProtocolId)
+			protocolPromises[strategy.ProtocolId] = parentPeer.GetSupportedProtocol(runtime, parent_peer.GetSupportedProtocolInput{ProtocolId: strategy.ProtocolId}, blockNumber)
+		}
+		if _, ok := chainPromises[strategy.ChainSelector]; !ok {
+			chains = append(chains, strategy.ChainSelector)
+			chainPromises[strategy.ChainSelector] = parentPeer.GetAllowedChain(runtime, parent_peer.GetAllowedChainInput{ChainSelector: strategy.ChainSelector}, blockNumber)
+		}
+
+		peer, ok := peers[strategy.ChainSelector]
+		if !ok {
+			return StrategyDiscovery{}, fmt.Errorf("no YieldPeer for chainSelector %d", strategy.ChainSelector)
+		}
+		adapterPromises = append(adapterPromises, peer.GetStrategyAdapter(runtime, child_peer.GetStrategyAdapterInput{ProtocolId: strategy.ProtocolId}, blockNumber))
+	}
+
+	var (
+		discovery = StrategyDiscovery{Strategies: StrategySet{protocols: configured.protocols}}
+		supported = make(map[[32]byte]bool, len(protocols))
+		allowed   = make(map[uint64]bool, len(chains))
+	)
+	for _, protocolId := range protocols {
+		ok, err := protocolPromises[protocolId].Await()
+		if err != nil {
+			return StrategyDiscovery{}, fmt.Errorf("failed to read supported protocol %s: %w", configured.ProtocolName(Strategy{ProtocolId: protocolId}), err)
+		}
+		supported[protocolId] = ok
+	}
+	for _, chainSelector := range chains {
+		ok, err := chainPromises[chainSelector].Await()
+		if err != nil {
+			return StrategyDiscovery{}, fmt.Errorf("failed to read allowed chain %d: %w", chainSelector, err)
+		}
+		allowed[chainSelector] = ok
+	}
+
+	for i, strategy := range configured.strategies {
+		adapter, err := adapterPromises[i].Await()
+		if err != nil {
+			return StrategyDiscovery{}, fmt.Errorf("failed to read strategy adapter for %s on chain %d: %w", configured.ProtocolName(strategy), strategy.ChainSelector, err)
+		}
+
+		var reason string
+		switch {
+		case !supported[strategy.ProtocolId]:
+			reason = ExcludedProtocolUnsupported
+		case !allowed[strategy.ChainSelector]:
+			reason = ExcludedChainNotAllowed
+		case adapter == (common.Address{}):
+			reason = ExcludedNoStrategyAdapter
+		}
+
+		if reason != "" {
+			discovery.Excluded = append(discovery.Excluded, ExcludedStrategy{Strategy: configured.Named(strategy), Reason: reason})
+			continue
+		}
+		discovery.Strategies.strategies = append(discovery.Strategies.strategies, strategy)
+	}
+
+	return discovery, nil
+}
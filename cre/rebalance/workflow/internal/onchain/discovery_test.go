@@ -0,0 +1,127 @@
+package onchain
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"rebalance/contracts/evm/src/generated/child_peer"
+	"rebalance/contracts/evm/src/generated/parent_peer"
+	"rebalance/workflow/internal/helper"
+	"rebalance/workflow/internal/protocol"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+	"github.com/smartcontractkit/cre-sdk-go/cre/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+// newDiscoveryParentPeer supports the given protocols and allows the given chains.
+func newDiscoveryParentPeer(protocols [][32]byte, chains []uint64) *mockParentPeer {
+	return &mockParentPeer{
+		getSupportedProtocolFunc: func(_ cre.Runtime, args parent_peer.GetSupportedProtocolInput, _ *big.Int) cre.Promise[bool] {
+			for _, p := range protocols {
+				if p == args.ProtocolId {
+					return cre.PromiseFromResult(true, nil)
+				}
+			}
+			return cre.PromiseFromResult(false, nil)
+		},
+		getAllowedChainFunc: func(_ cre.Runtime, args parent_peer.GetAllowedChainInput, _ *big.Int) cre.Promise[bool] {
+			for _, c := range chains {
+				if c == args.ChainSelector {
+					return cre.PromiseFromResult(true, nil)
+				}
+			}
+			return cre.PromiseFromResult(false, nil)
+		},
+	}
+}
+
+// newDiscoveryPeer has a strategy adapter for the given protocols.
+func newDiscoveryPeer(protocols ...[32]byte) *mockStrategyAdapterReader {
+	return &mockStrategyAdapterReader{
+		getStrategyAdapterFunc: func(_ cre.Runtime, args child_peer.GetStrategyAdapterInput, _ *big.Int) cre.Promise[common.Address] {
+			for _, p := range protocols {
+				if p == args.ProtocolId {
+					return cre.PromiseFromResult(common.HexToAddress("0x01"), nil)
+				}
+			}
+			return cre.PromiseFromResult(common.Address{}, nil)
+		},
+	}
+}
+
+func Test_DiscoverStrategies_intersectsConfigWithOnChainState(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+
+	aave1 := Strategy{ProtocolId: AaveV3ProtocolId, ChainSelector: 1}
+	comp1 := Strategy{ProtocolId: CompoundV3ProtocolId, ChainSelector: 1}
+	aave2 := Strategy{ProtocolId: AaveV3ProtocolId, ChainSelector: 2}
+	comp2 := Strategy{ProtocolId: CompoundV3ProtocolId, ChainSelector: 2}
+	aave3 := Strategy{ProtocolId: AaveV3ProtocolId, ChainSelector: 3}
+	configured := NewStrategySetOf(aave1, comp1, aave2, comp2, aave3)
+
+	// Compound is supported, but chain 3 is not allowed and the chain 2 peer
+	// has no CompoundV3 adapter.
+	parentPeer := newDiscoveryParentPeer([][32]byte{AaveV3ProtocolId, CompoundV3ProtocolId}, []uint64{1, 2})
+	peers := map[uint64]StrategyAdapterReaderInterface{
+		1: newDiscoveryPeer(AaveV3ProtocolId, CompoundV3ProtocolId),
+		2: newDiscoveryPeer(AaveV3ProtocolId),
+		3: newDiscoveryPeer(AaveV3ProtocolId),
+	}
+
+	discovery, err := DiscoverStrategies(&helper.Config{}, runtime, configured, parentPeer, peers)
+	require.NoError(t, err)
+
+	require.Equal(t, []Strategy{aave1, comp1, aave2}, discovery.Strategies.Strategies())
+	require.Equal(t, []ExcludedStrategy{
+		{Strategy: configured.Named(comp2), Reason: ExcludedNoStrategyAdapter},
+		{Strategy: configured.Named(aave3), Reason: ExcludedChainNotAllowed},
+	}, discovery.Excluded)
+}
+
+func Test_DiscoverStrategies_excludesUnsupportedProtocol(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+
+	aave := Strategy{ProtocolId: AaveV3ProtocolId, ChainSelector: 1}
+	comp := Strategy{ProtocolId: CompoundV3ProtocolId, ChainSelector: 1}
+
+	parentPeer := newDiscoveryParentPeer([][32]byte{AaveV3ProtocolId}, []uint64{1})
+	peers := map[uint64]StrategyAdapterReaderInterface{1: newDiscoveryPeer(AaveV3ProtocolId, CompoundV3ProtocolId)}
+
+	configured := StrategySet{strategies: []Strategy{aave, comp}, protocols: protocol.Default}
+	discovery, err := DiscoverStrategies(&helper.Config{}, runtime, configured, parentPeer, peers)
+	require.NoError(t, err)
+
+	require.Equal(t, []Strategy{aave}, discovery.Strategies.Strategies())
+	require.Equal(t, []ExcludedStrategy{{Strategy: NamedStrategy{Strategy: comp, Protocol: "compound-v3"}, Reason: ExcludedProtocolUnsupported}}, discovery.Excluded)
+	require.Equal(t, "aave-v3", discovery.Strategies.ProtocolName(aave), "the kept set names with the configured set's registry")
+}
+
+func Test_DiscoverStrategies_errorWhenPeerMissing(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+	parentPeer := newDiscoveryParentPeer(nil, nil)
+
+	_, err := DiscoverStrategies(&helper.Config{}, runtime, NewStrategySetOf(Strategy{ProtocolId: AaveV3ProtocolId, ChainSelector: 9}), parentPeer, nil)
+	require.ErrorContains(t, err, "no YieldPeer for chainSelector 9")
+}
+
+func Test_DiscoverStrategies_errorWhenReadFails(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+	readErr := errors.New("rpc down")
+
+	parentPeer := newDiscoveryParentPeer([][32]byte{AaveV3ProtocolId}, []uint64{1})
+	peers := map[uint64]StrategyAdapterReaderInterface{
+		1: &mockStrategyAdapterReader{
+			getStrategyAdapterFunc: func(cre.Runtime, child_peer.GetStrategyAdapterInput, *big.Int) cre.Promise[common.Address] {
+				return cre.PromiseFromResult(common.Address{}, readErr)
+			},
+		},
+	}
+
+	configured := StrategySet{strategies: []Strategy{{ProtocolId: AaveV3ProtocolId, ChainSelector: 1}}, protocols: protocol.Default}
+	_, err := DiscoverStrategies(&helper.Config{}, runtime, configured, parentPeer, peers)
+	require.ErrorIs(t, err, readErr)
+	require.ErrorContains(t, err, "failed to read strategy adapter for aave-v3 on chain 1")
+}
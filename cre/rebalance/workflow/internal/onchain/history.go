@@ -0,0 +1,136 @@
+package onchain
+
+import (
+	"fmt"
+	"math/big"
+
+	"rebalance/contracts/evm/src/generated/parent_peer"
+
+	"github.com/smartcontractkit/chainlink-protos/cre/go/values/pb"
+	"github.com/smartcontractkit/cre-sdk-go/capabilities/blockchain/evm"
+	"github.com/smartcontractkit/cre-sdk-go/capabilities/blockchain/evm/bindings"
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+
+	"rebalance/workflow/internal/helper"
+)
+
+// ReadStrategyHistory reads the StrategyUpdated events ParentPeer emitted in the
+// last config.Cooldown.LookbackBlocks blocks, with the timestamp of the block
+// each was emitted in.
+//
+// The head block is the one the workflow reads at (config.BlockNumber), so the
+// history and the current strategy are observed at the same point.
+func ReadStrategyHistory(
+	config *helper.Config,
+	runtime cre.Runtime,
+	headers HeaderReaderInterface,
+	peer ParentPeerInterface,
+) (StrategyHistory, error) {
+	lookbackBlocks := config.Cooldown.LookbackBlocks
+	if lookbackBlocks == 0 {
+		return StrategyHistory{}, fmt.Errorf("lookbackBlocks must be greater than zero")
+	}
+
+	head, err := readHeader(runtime, headers, big.NewInt(config.BlockNumber))
+	if err != nil {
+		return StrategyHistory{}, fmt.Errorf("failed to read head block: %w", err)
+	}
+	headBlock := pb.NewIntFromBigInt(head.BlockNumber)
+
+	fromBlock := new(big.Int).Sub(headBlock, new(big.Int).SetUint64(lookbackBlocks))
+	if fromBlock.Sign() < 0 {
+		fromBlock.SetInt64(0)
+	}
+
+	logsPromise, err := peer.FilterLogsStrategyUpdated(runtime, &bindings.FilterOptions{
+		FromBlock: fromBlock,
+		ToBlock:   headBlock,
+	})
+	if err != nil {
+		return StrategyHistory{}, fmt.Errorf("failed to filter StrategyUpdated logs: %w", err)
+	}
+	reply, err := logsPromise.Await()
+	if err != nil {
+		return StrategyHistory{}, fmt.Errorf("failed to filter StrategyUpdated logs: %w", err)
+	}
+
+	codec, err := parent_peer.NewCodec()
+	if err != nil {
+		return StrategyHistory{}, fmt.Errorf("failed to create ParentPeer codec: %w", err)
+	}
+
+	history := StrategyHistory{
+		FromBlock:     fromBlock.Uint64(),
+		HeadBlock:     headBlock.Uint64(),
+		HeadTimestamp: head.Timestamp,
+	}
+
+	// Request the header of every block with an event up front so the
+	// reads run concurrently, then await them in order.
+	headerPromises := make(map[uint64]cre.Promise[*evm.HeaderByNumberReply])
+	for _, log := range reply.GetLogs() {
+		if log.Removed {
+			continue
+		}
+		decoded, err := codec.DecodeStrategyUpdated(log)
+		if err != nil {
+			return StrategyHistory{}, fmt.Errorf("failed to decode StrategyUpdated log: %w", err)
+		}
+
+		blockNumber := pb.NewIntFromBigInt(log.BlockNumber)
+		if _, ok := headerPromises[blockNumber.Uint64()]; !ok {
+			headerPromises[blockNumber.Uint64()] = headers.HeaderByNumber(runtime, &evm.HeaderByNumberRequest{
+				BlockNumber: pb.NewBigIntFromInt(blockNumber),
+			})
+		}
+
+		history.Updates = append(history.Updates, StrategyUpdate{
+			Strategy: Strategy{
+				ProtocolId:    decoded.ProtocolId,
+				ChainSelector: decoded.ChainSelector,
+			},
+			OldChainSelector: decoded.OldChainSelector,
+			BlockNumber:      blockNumber.Uint64(),
+		})
+	}
+
+	for i := range history.Updates {
+		reply, err := headerPromises[history.Updates[i].BlockNumber].Await()
+		if err != nil {
+			return StrategyHistory{}, fmt.Errorf("failed to read header of block %d: %w", history.Updates[i].BlockNumber, err)
+		}
+		if reply.GetHeader() == nil {
+			return StrategyHistory{}, fmt.Errorf("missing header for block %d", history.Updates[i].BlockNumber)
+		}
+		history.Updates[i].Timestamp = reply.GetHeader().Timestamp
+	}
+
+	return history, nil
+}
+
+// ReadBlock resolves the block the workflow reads at (config.BlockNumber, which
+// may be a tag such as latest or finalized) to its number and timestamp.
+func ReadBlock(config *helper.Config, runtime cre.Runtime, headers HeaderReaderInterface) (Block, error) {
+	header, err := readHeader(runtime, headers, big.NewInt(config.BlockNumber))
+	if err != nil {
+		return Block{}, err
+	}
+	return Block{
+		Number:    pb.NewIntFromBigInt(header.BlockNumber).Uint64(),
+		Timestamp: header.Timestamp,
+	}, nil
+}
+
+// readHeader reads a block header, treating a missing header as an error.
+func readHeader(runtime cre.Runtime, headers HeaderReaderInterface, blockNumber *big.Int) (*evm.Header, error) {
+	reply, err := headers.HeaderByNumber(runtime, &evm.HeaderByNumberRequest{
+		BlockNumber: pb.NewBigIntFromInt(blockNumber),
+	}).Await()
+	if err != nil {
+		return nil, err
+	}
+	if reply.GetHeader() == nil {
+		return nil, fmt.Errorf("missing header for block %s", blockNumber.String())
+	}
+	return reply.GetHeader(), nil
+}
@@ -0,0 +1,225 @@
+package onchain
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"testing"
+
+	"rebalance/contracts/evm/src/generated/parent_peer"
+
+	"rebalance/workflow/internal/helper"
+
+	"github.com/smartcontractkit/chainlink-protos/cre/go/values/pb"
+	"github.com/smartcontractkit/cre-sdk-go/capabilities/blockchain/evm"
+	"github.com/smartcontractkit/cre-sdk-go/capabilities/blockchain/evm/bindings"
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+	"github.com/smartcontractkit/cre-sdk-go/cre/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+/*//////////////////////////////////////////////////////////////
+                             MOCKS
+//////////////////////////////////////////////////////////////*/
+
+// mockHeaderReader serves headers from a block number -> timestamp map.
+type mockHeaderReader struct {
+	timestamps map[int64]uint64
+	err        error
+}
+
+func (m *mockHeaderReader) HeaderByNumber(
+	_ cre.Runtime,
+	input *evm.HeaderByNumberRequest,
+) cre.Promise[*evm.HeaderByNumberReply] {
+	if m.err != nil {
+		return cre.PromiseFromResult[*evm.HeaderByNumberReply](nil, m.err)
+	}
+	blockNumber := pb.NewIntFromBigInt(input.BlockNumber)
+	timestamp, ok := m.timestamps[blockNumber.Int64()]
+	if !ok {
+		return cre.PromiseFromResult(&evm.HeaderByNumberReply{}, nil)
+	}
+	return cre.PromiseFromResult(&evm.HeaderByNumberReply{
+		Header: &evm.Header{
+			BlockNumber: pb.NewBigIntFromInt(blockNumber),
+			Timestamp:   timestamp,
+		},
+	}, nil)
+}
+
+// strategyUpdatedLog builds a StrategyUpdated log as ParentPeer would emit it.
+func strategyUpdatedLog(t *testing.T, blockNumber int64, protocolId [32]byte, chainSelector, oldChainSelector uint64) *evm.Log {
+	t.Helper()
+	codec, err := parent_peer.NewCodec()
+	require.NoError(t, err)
+
+	return &evm.Log{
+		Topics: [][]byte{
+			codec.StrategyUpdatedLogHash(),
+			uint64Topic(chainSelector),
+			protocolId[:],
+			uint64Topic(oldChainSelector),
+		},
+		BlockNumber: pb.NewBigIntFromInt(big.NewInt(blockNumber)),
+	}
+}
+
+func uint64Topic(v uint64) []byte {
+	topic := make([]byte, 32)
+	binary.BigEndian.PutUint64(topic[24:], v)
+	return topic
+}
+
+/*//////////////////////////////////////////////////////////////
+                             TESTS
+//////////////////////////////////////////////////////////////*/
+
+func Test_ReadStrategyHistory_success(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+	config := &helper.Config{
+		BlockNumber: 1_000,
+		Cooldown:    helper.CooldownConfig{LookbackBlocks: 500},
+	}
+
+	headers := &mockHeaderReader{timestamps: map[int64]uint64{
+		1_000: 10_000,
+		600:   6_000,
+		900:   9_000,
+	}}
+
+	peer := &mockParentPeer{
+		filterLogsStrategyUpdatedFunc: func(_ cre.Runtime, options *bindings.FilterOptions) (cre.Promise[*evm.FilterLogsReply], error) {
+			require.Equal(t, int64(500), options.FromBlock.Int64())
+			require.Equal(t, int64(1_000), options.ToBlock.Int64())
+
+			removed := strategyUpdatedLog(t, 700, AaveV3ProtocolId, 1, 1)
+			removed.Removed = true
+
+			return cre.PromiseFromResult(&evm.FilterLogsReply{Logs: []*evm.Log{
+				strategyUpdatedLog(t, 600, CompoundV3ProtocolId, 2, 1),
+				removed,
+				strategyUpdatedLog(t, 900, AaveV3ProtocolId, 1, 2),
+			}}, nil), nil
+		},
+	}
+
+	history, err := ReadStrategyHistory(config, runtime, headers, peer)
+	require.NoError(t, err)
+
+	require.Equal(t, uint64(500), history.FromBlock)
+	require.Equal(t, uint64(1_000), history.HeadBlock)
+	require.Equal(t, uint64(10_000), history.HeadTimestamp)
+	require.Equal(t, []StrategyUpdate{
+		{
+			Strategy:         Strategy{ProtocolId: CompoundV3ProtocolId, ChainSelector: 2},
+			OldChainSelector: 1,
+			BlockNumber:      600,
+			Timestamp:        6_000,
+		},
+		{
+			Strategy:         Strategy{ProtocolId: AaveV3ProtocolId, ChainSelector: 1},
+			OldChainSelector: 2,
+			BlockNumber:      900,
+			Timestamp:        9_000,
+		},
+	}, history.Updates)
+}
+
+func Test_ReadStrategyHistory_clampsFromBlockAtGenesis(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+	config := &helper.Config{
+		BlockNumber: 100,
+		Cooldown:    helper.CooldownConfig{LookbackBlocks: 500},
+	}
+
+	peer := &mockParentPeer{
+		filterLogsStrategyUpdatedFunc: func(_ cre.Runtime, options *bindings.FilterOptions) (cre.Promise[*evm.FilterLogsReply], error) {
+			require.Equal(t, int64(0), options.FromBlock.Int64())
+			return cre.PromiseFromResult(&evm.FilterLogsReply{}, nil), nil
+		},
+	}
+
+	history, err := ReadStrategyHistory(config, runtime, &mockHeaderReader{timestamps: map[int64]uint64{100: 1}}, peer)
+	require.NoError(t, err)
+	require.Empty(t, history.Updates)
+	require.Equal(t, uint64(0), history.FromBlock)
+}
+
+func Test_ReadStrategyHistory_errorWhenLookbackZero(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+	config := &helper.Config{BlockNumber: 100}
+
+	_, err := ReadStrategyHistory(config, runtime, &mockHeaderReader{}, &mockParentPeer{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "lookbackBlocks must be greater than zero")
+}
+
+func Test_ReadStrategyHistory_errorWhenHeadUnavailable(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+	config := &helper.Config{
+		BlockNumber: 100,
+		Cooldown:    helper.CooldownConfig{LookbackBlocks: 10},
+	}
+	headerErr := errors.New("rpc down")
+
+	_, err := ReadStrategyHistory(config, runtime, &mockHeaderReader{err: headerErr}, &mockParentPeer{})
+	require.ErrorIs(t, err, headerErr)
+	require.Contains(t, err.Error(), "failed to read head block")
+}
+
+func Test_ReadStrategyHistory_errorWhenFilterLogsFails(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+	config := &helper.Config{
+		BlockNumber: 100,
+		Cooldown:    helper.CooldownConfig{LookbackBlocks: 10},
+	}
+	filterErr := errors.New("filter failed")
+
+	peer := &mockParentPeer{
+		filterLogsStrategyUpdatedFunc: func(_ cre.Runtime, _ *bindings.FilterOptions) (cre.Promise[*evm.FilterLogsReply], error) {
+			return cre.PromiseFromResult[*evm.FilterLogsReply](nil, filterErr), nil
+		},
+	}
+
+	_, err := ReadStrategyHistory(config, runtime, &mockHeaderReader{timestamps: map[int64]uint64{100: 1}}, peer)
+	require.ErrorIs(t, err, filterErr)
+}
+
+func Test_ReadStrategyHistory_errorWhenEventHeaderMissing(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+	config := &helper.Config{
+		BlockNumber: 100,
+		Cooldown:    helper.CooldownConfig{LookbackBlocks: 10},
+	}
+
+	peer := &mockParentPeer{
+		filterLogsStrategyUpdatedFunc: func(_ cre.Runtime, _ *bindings.FilterOptions) (cre.Promise[*evm.FilterLogsReply], error) {
+			return cre.PromiseFromResult(&evm.FilterLogsReply{Logs: []*evm.Log{
+				strategyUpdatedLog(t, 95, AaveV3ProtocolId, 1, 1),
+			}}, nil), nil
+		},
+	}
+
+	_, err := ReadStrategyHistory(config, runtime, &mockHeaderReader{timestamps: map[int64]uint64{100: 1}}, peer)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "missing header for block 95")
+}
+
+func Test_ReadBlock_success(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+	config := &helper.Config{BlockNumber: 1_234}
+
+	block, err := ReadBlock(config, runtime, &mockHeaderReader{timestamps: map[int64]uint64{1_234: 99}})
+	require.NoError(t, err)
+	require.Equal(t, Block{Number: 1_234, Timestamp: 99}, block)
+}
+
+func Test_ReadBlock_errorWhenHeaderMissing(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+	config := &helper.Config{BlockNumber: 1_234}
+
+	_, err := ReadBlock(config, runtime, &mockHeaderReader{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "missing header for block 1234")
+}
@@ -3,17 +3,27 @@ package onchain
 import (
 	"math/big"
 
+	"rebalance/contracts/evm/src/generated/child_peer"
 	"rebalance/contracts/evm/src/generated/parent_peer"
 	"rebalance/contracts/evm/src/generated/rebalancer"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/smartcontractkit/cre-sdk-go/capabilities/blockchain/evm"
+	"github.com/smartcontractkit/cre-sdk-go/capabilities/blockchain/evm/bindings"
 	"github.com/smartcontractkit/cre-sdk-go/cre"
 )
 
-// ParentPeerInterface defines the subset used to read the current strategy.
+// ParentPeerInterface defines the subset used to read the current strategy,
+// the share supply, the pause state, the supported protocols / allowed chains
+// and the StrategyUpdated history.
 type ParentPeerInterface interface {
 	YieldPeerInterface
 	GetStrategy(runtime cre.Runtime, blockNumber *big.Int) cre.Promise[parent_peer.IYieldPeerStrategy]
+	GetTotalShares(runtime cre.Runtime, blockNumber *big.Int) cre.Promise[*big.Int]
+	GetSupportedProtocol(runtime cre.Runtime, args parent_peer.GetSupportedProtocolInput, blockNumber *big.Int) cre.Promise[bool]
+	GetAllowedChain(runtime cre.Runtime, args parent_peer.GetAllowedChainInput, blockNumber *big.Int) cre.Promise[bool]
+	Paused(runtime cre.Runtime, blockNumber *big.Int) cre.Promise[bool]
+	FilterLogsStrategyUpdated(runtime cre.Runtime, options *bindings.FilterOptions) (cre.Promise[*evm.FilterLogsReply], error)
 }
 
 // YieldPeerInterface defines the subset used to read TVL.
@@ -21,7 +31,29 @@ type YieldPeerInterface interface {
 	GetTotalValue(runtime cre.Runtime, blockNumber *big.Int) cre.Promise[*big.Int]
 }
 
-// RebalancerInterface defines the subset used to write the rebalance report.
+// StrategyAdapterReaderInterface defines the subset used to look up a peer's
+// strategy adapter. getStrategyAdapter is declared on YieldPeer, so the
+// ChildPeer binding reads it from a ParentPeer as well.
+type StrategyAdapterReaderInterface interface {
+	GetStrategyAdapter(runtime cre.Runtime, args child_peer.GetStrategyAdapterInput, blockNumber *big.Int) cre.Promise[common.Address]
+}
+
+// CCIPLogReaderInterface defines the subset used to read a peer's CCIP message
+// logs. Both events are declared on YieldPeer, so ParentPeer and ChildPeer
+// bindings satisfy it.
+type CCIPLogReaderInterface interface {
+	FilterLogsCCIPMessageSent(runtime cre.Runtime, options *bindings.FilterOptions) (cre.Promise[*evm.FilterLogsReply], error)
+	FilterLogsCCIPMessageReceived(runtime cre.Runtime, options *bindings.FilterOptions) (cre.Promise[*evm.FilterLogsReply], error)
+}
+
+// RebalancerInterface defines the subset used to write the rebalance report
+// and to check which ParentPeer it forwards to.
 type RebalancerInterface interface {
 	WriteReportFromIYieldPeerStrategy(runtime cre.Runtime, input rebalancer.IYieldPeerStrategy, gasConfig *evm.GasConfig) cre.Promise[*evm.WriteReportReply]
-}
\ No newline at end of file
+	GetParentPeer(runtime cre.Runtime, blockNumber *big.Int) cre.Promise[common.Address]
+}
+
+// HeaderReaderInterface defines the subset of evm.Client used to read block headers.
+type HeaderReaderInterface interface {
+	HeaderByNumber(runtime cre.Runtime, input *evm.HeaderByNumberRequest) cre.Promise[*evm.HeaderByNumberReply]
+}
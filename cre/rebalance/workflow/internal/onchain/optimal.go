@@ -1,13 +1,14 @@
 package onchain
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"math/big"
+	"strings"
 
-	"rebalance/workflow/internal/aaveV3"
-	"rebalance/workflow/internal/compoundV3"
 	"rebalance/workflow/internal/helper"
+	"rebalance/workflow/internal/protocol"
 
 	"github.com/smartcontractkit/cre-sdk-go/cre"
 )
@@ -19,107 +20,304 @@ import (
 // Promise-based APY deps used by GetOptimalStrategy to evaluate
 // all candidate strategies in parallel.
 type apyPromiseDeps struct {
-	AaveV3GetAPYPromise     func(*helper.Config, cre.Runtime, *big.Int, uint64) cre.Promise[float64]
-	CompoundV3GetAPYPromise func(*helper.Config, cre.Runtime, *big.Int, uint64) cre.Promise[float64]
+	Protocols *protocol.Registry
+	// Cache holds the reads the run's candidates share; nil caches nothing.
+	Cache *protocol.Cache
 }
 
 var defaultAPYPromiseDeps = apyPromiseDeps{
-	AaveV3GetAPYPromise:     aaveV3.GetAPYPromise,
-	CompoundV3GetAPYPromise: compoundV3.GetAPYPromise,
+	Protocols: protocol.Default,
 }
 
 /*//////////////////////////////////////////////////////////////
                      GET OPTIMAL STRATEGY
 //////////////////////////////////////////////////////////////*/
 
-// GetOptimalAndCurrentStrategyWithAPY evaluates all supported strategies in parallel using
-// promise-based APY calculations and returns the strategy with the highest APY and the current strategy with its APY.
+// GetOptimalAndCurrentStrategyWithAPY evaluates every strategy in strategies in parallel using
+// promise-based APY calculations and returns the strategy with the highest APY, the current
+// strategy with its APY, and every evaluated candidate with its APY. Reads the
+// candidates share are made once per call.
 func GetOptimalAndCurrentStrategyWithAPY(
 	config *helper.Config,
 	runtime cre.Runtime,
+	strategies StrategySet,
 	currentStrategy Strategy,
 	liquidityAdded *big.Int,
-) (StrategyWithAPY, StrategyWithAPY, error) {
-	return getOptimalAndCurrentStrategyWithAPYWithDeps(config, runtime, currentStrategy, liquidityAdded, defaultAPYPromiseDeps)
+) (StrategyWithAPY, StrategyWithAPY, []StrategyWithAPY, error) {
+	protocols, err := defaultAPYPromiseDeps.Protocols.ForConfig(config)
+	if err != nil {
+		return StrategyWithAPY{}, StrategyWithAPY{}, nil, fmt.Errorf("failed to resolve configured protocols: %w", err)
+	}
+	return getOptimalAndCurrentStrategyWithAPYWithDeps(config, runtime, strategies, currentStrategy, liquidityAdded, apyPromiseDeps{Protocols: protocols, Cache: protocol.NewCache()})
 }
 
-// getOptimalAndCurrentStrategyWithAPYWithDeps starts APY calculations for all supported
-// strategies in parallel using promises, then awaits and selects the best.
-// Returns the optimal strategy with its APY and the current strategy with its APY.
+// getOptimalAndCurrentStrategyWithAPYWithDeps starts APY calculations for all
+// strategies in the set in parallel using promises, then awaits and selects the best.
+// Returns the optimal strategy with its APY, the current strategy with its APY,
+// and every candidate with its APY in set order.
+//
+// With config.Rewards.Enabled, the reward APR of every protocol that is a
+// protocol.RewardSource is calculated alongside its APY, and added to it when
+// config.Rewards.IncludeInDecision is set.
+//
+// The health of every protocol that is a protocol.HealthSource is checked
+// first. An unhealthy candidate is kept in the candidate list with Unhealthy
+// and Err set but never selected; if it is the current strategy, it is still
+// returned as current so the caller can exit it.
 //
-// Error policy: if any strategy’s APY calculation fails or returns an invalid
-// APY, the whole function returns an error.
+// A candidate whose APY error wraps protocol.ErrInfeasible cannot absorb the
+// liquidity it was evaluated with (see config.Evaluation.SupplyCapPolicy). It
+// is kept in the candidate list with Infeasible set but never selected. It
+// does not fail the run under either error policy. An infeasible current
+// strategy is returned as current with an APY of 0.
+//
+// With config.Liquidity.Enabled, the liquidity available to withdraw from
+// every protocol that is a protocol.LiquiditySource before anything is
+// deposited is read alongside its APY and reported as a multiple of
+// liquidityAdded (LiquidityMargin). A target other than the current strategy
+// whose margin is below config.Liquidity.MinTVLMultiple is infeasible in the
+// same way.
+//
+// Error policy (config.Evaluation.ErrorPolicy): a candidate fails if its APY
+// calculation errors or returns a zero, NaN or Inf APY, or if its reward APR
+// calculation errors or returns a negative, NaN or Inf APR.
+//   - strict (default): any failure fails the whole function.
+//   - exclude: failing candidates are kept in the candidate list with Err set
+//     but never selected. The current strategy must still succeed, and at least
+//     MinHealthyCandidates candidates must succeed and be healthy and
+//     feasible.
 func getOptimalAndCurrentStrategyWithAPYWithDeps(
-    config *helper.Config,
-    runtime cre.Runtime,
-    currentStrategy Strategy,
-    liquidityAdded *big.Int,
-    deps apyPromiseDeps,
-) (StrategyWithAPY, StrategyWithAPY, error) {
-    if len(supportedStrategies) == 0 {
-        return StrategyWithAPY{}, StrategyWithAPY{}, fmt.Errorf("no supported strategies configured")
-    }
-    if liquidityAdded == nil {
-        return StrategyWithAPY{}, StrategyWithAPY{}, fmt.Errorf("liquidityAdded must not be nil")
-    }
-
-    // We keep strategies and promises aligned by index.
-    strategies := make([]Strategy, 0, len(supportedStrategies))
-    apyPromises := make([]cre.Promise[float64], 0, len(supportedStrategies))
-
-    // First pass: kick off all APY computations (no Await yet).
-    for _, strategy := range supportedStrategies {
-        liq := liquidityAdded
-        if sameStrategy(strategy, currentStrategy) {
-            liq = big.NewInt(0)
-        }
-
-	    apyPromise := getAPYPromiseFromStrategy(config, runtime, strategy, liq, deps)
-
-        strategies = append(strategies, strategy)
-        apyPromises = append(apyPromises, apyPromise)
-    }
-
-    var (
-        bestStrategy Strategy
-        bestAPY      float64
-        bestSet      bool
-        currentAPY   float64
-    )
-
-    // Second pass: Await each APY and pick the best.
-    for i, apyPromise := range apyPromises {
+	config *helper.Config,
+	runtime cre.Runtime,
+	strategySet StrategySet,
+	currentStrategy Strategy,
+	liquidityAdded *big.Int,
+	deps apyPromiseDeps,
+) (StrategyWithAPY, StrategyWithAPY, []StrategyWithAPY, error) {
+	if strategySet.Len() == 0 {
+		return StrategyWithAPY{}, StrategyWithAPY{}, nil, fmt.Errorf("no supported strategies configured")
+	}
+	if liquidityAdded == nil {
+		return StrategyWithAPY{}, StrategyWithAPY{}, nil, fmt.Errorf("liquidityAdded must not be nil")
+	}
+
+	var exclude bool
+	switch config.Evaluation.ErrorPolicy {
+	case "", helper.ErrorPolicyStrict:
+	case helper.ErrorPolicyExclude:
+		exclude = true
+	default:
+		return StrategyWithAPY{}, StrategyWithAPY{}, nil, fmt.Errorf("unknown evaluation error policy %q", config.Evaluation.ErrorPolicy)
+	}
+	switch config.Evaluation.SupplyCapPolicy {
+	case "", helper.SupplyCapPolicyExclude, helper.SupplyCapPolicyClamp:
+	default:
+		return StrategyWithAPY{}, StrategyWithAPY{}, nil, fmt.Errorf("unknown supply cap policy %q", config.Evaluation.SupplyCapPolicy)
+	}
+	minTVLMultiple := config.Liquidity.MinTVLMultiple
+	if minTVLMultiple < 0 || math.IsNaN(minTVLMultiple) || math.IsInf(minTVLMultiple, 0) {
+		return StrategyWithAPY{}, StrategyWithAPY{}, nil, fmt.Errorf("invalid liquidity minTvlMultiple %v", minTVLMultiple)
+	}
+	if minTVLMultiple == 0 {
+		minTVLMultiple = 1
+	}
+
+	// liquidityAdded is in the vault asset's units on the current chain; each
+	// candidate is priced in the units of its own chain. A current strategy on
+	// a chain no longer configured has no known decimals and is not converted.
+	fromDecimals, decimalsErr := config.VaultAssetDecimals(currentStrategy.ChainSelector)
+
+	// We keep strategies and promises aligned by index.
+	strategies := make([]Strategy, 0, strategySet.Len())
+	tvls := make([]*big.Int, 0, strategySet.Len())
+	apyPromises := make([]cre.Promise[float64], 0, strategySet.Len())
+	rewardPromises := make([]cre.Promise[float64], 0, strategySet.Len())
+	healthPromises := make([]cre.Promise[[]string], 0, strategySet.Len())
+	liquidityPromises := make([]cre.Promise[*big.Int], 0, strategySet.Len())
+
+	// First pass: kick off all APY computations (no Await yet).
+	for _, strategy := range strategySet.strategies {
+		// tvl is liquidityAdded in the strategy's units; liq is what is
+		// deposited there, nothing for the current strategy.
+		tvl := liquidityAdded
+		if decimalsErr == nil && strategy.ChainSelector != currentStrategy.ChainSelector {
+			toDecimals, err := config.VaultAssetDecimals(strategy.ChainSelector)
+			if err != nil {
+				return StrategyWithAPY{}, StrategyWithAPY{}, nil, fmt.Errorf("failed to resolve vault asset decimals: %w", err)
+			}
+			tvl = helper.ConvertDecimals(liquidityAdded, fromDecimals, toDecimals)
+		}
+		liq := tvl
+		if sameStrategy(strategy, currentStrategy) {
+			liq = big.NewInt(0)
+		}
+
+		apyPromise := getAPYPromiseFromStrategy(config, runtime, strategy, liq, deps)
+		var rewardPromise cre.Promise[float64]
+		if config.Rewards.Enabled {
+			rewardPromise = getRewardAPRPromiseFromStrategy(config, runtime, strategy, liq, deps)
+		}
+
+		// The deposit does not count towards the exit liquidity: it could be
+		// withdrawn right after it is made, whatever the market's utilisation.
+		var liquidityPromise cre.Promise[*big.Int]
+		if config.Liquidity.Enabled {
+			liquidityPromise = getLiquidityPromiseFromStrategy(config, runtime, strategy, big.NewInt(0), deps)
+		}
+
+		strategies = append(strategies, strategy)
+		tvls = append(tvls, tvl)
+		apyPromises = append(apyPromises, apyPromise)
+		rewardPromises = append(rewardPromises, rewardPromise)
+		healthPromises = append(healthPromises, getHealthPromiseFromStrategy(config, runtime, strategy, deps))
+		liquidityPromises = append(liquidityPromises, liquidityPromise)
+	}
+
+	var (
+		best       StrategyWithAPY
+		bestSet    bool
+		current    = StrategyWithAPY{Strategy: currentStrategy}
+		healthy    int
+		candidates = make([]StrategyWithAPY, 0, len(strategies))
+		logger     = runtime.Logger()
+	)
+
+	// Second pass: Await each APY and pick the best.
+	for i, apyPromise := range apyPromises {
 		strategy := strategies[i]
+		protocolName := deps.Protocols.Name(strategy.ProtocolId)
+
+		evaluated, err := awaitCandidate(healthPromises[i], apyPromise, rewardPromises[i], strategy, config.Rewards.IncludeInDecision)
+		if err == nil && !evaluated.Unhealthy && liquidityPromises[i] != nil {
+			evaluated.LiquidityMargin, err = awaitLiquidityMargin(liquidityPromises[i], tvls[i], strategy)
+			if err == nil && !sameStrategy(strategy, currentStrategy) && tvls[i].Sign() > 0 && evaluated.LiquidityMargin < minTVLMultiple {
+				err = fmt.Errorf("%w: available liquidity covers %.2fx the TVL, %.2fx required",
+					protocol.ErrInfeasible, evaluated.LiquidityMargin, minTVLMultiple)
+			}
+		}
+		if errors.Is(err, protocol.ErrInfeasible) {
+			logger.Info("Skipping strategy that cannot absorb the deposit", "protocol", protocolName, "chainSelector", strategy.ChainSelector, "reason", err)
+			evaluated.Strategy = strategy
+			evaluated.Err = err
+			evaluated.Infeasible = true
+			if sameStrategy(strategy, currentStrategy) {
+				current = evaluated
+			}
+			candidates = append(candidates, evaluated)
+			continue
+		}
+		if err != nil {
+			if !exclude || sameStrategy(strategy, currentStrategy) {
+				return StrategyWithAPY{}, StrategyWithAPY{}, nil, err
+			}
+			logger.Warn("Excluding strategy whose APY could not be calculated", "protocol", protocolName, "chainSelector", strategy.ChainSelector, "error", err)
+			candidates = append(candidates, StrategyWithAPY{Strategy: strategy, Err: err})
+			continue
+		}
+
+		if sameStrategy(strategy, currentStrategy) {
+			current = evaluated
+		}
+		candidates = append(candidates, evaluated)
+
+		if evaluated.Unhealthy {
+			logger.Warn("Excluding unhealthy strategy", "protocol", protocolName, "chainSelector", strategy.ChainSelector, "reason", evaluated.Err)
+			continue
+		}
+		healthy++
+
+		if !bestSet || evaluated.APY > best.APY {
+			best = evaluated
+			bestSet = true
+		}
+
+		logger.Info("APY calculated for strategy", "apy", evaluated.APY, "rewardApr", evaluated.RewardAPR, "protocol", protocolName, "chainSelector", strategy.ChainSelector)
+	}
+
+	if !bestSet {
+		return StrategyWithAPY{}, StrategyWithAPY{}, nil, fmt.Errorf("no eligible strategy: all %d candidates are unhealthy or infeasible", len(candidates))
+	}
+	if exclude && healthy < config.Evaluation.MinHealthyCandidates {
+		return StrategyWithAPY{}, StrategyWithAPY{}, nil, fmt.Errorf("only %d of %d candidates evaluated successfully; need %d", healthy, len(candidates), config.Evaluation.MinHealthyCandidates)
+	}
+
+	return best, current, candidates, nil
+}
+
+// awaitCandidate awaits a strategy's health if healthPromise is not nil, its
+// APY and, if rewardPromise is not nil, its reward APR, adding the latter to
+// the APY when includeRewards is set.
+//
+// An unhealthy strategy is not an error: it is returned with Unhealthy and Err
+// (wrapping protocol.ErrUnhealthy) set, and its APY if that could be calculated.
+func awaitCandidate(healthPromise cre.Promise[[]string], apyPromise, rewardPromise cre.Promise[float64], strategy Strategy, includeRewards bool) (StrategyWithAPY, error) {
+	if healthPromise != nil {
+		issues, err := healthPromise.Await()
+		if err != nil {
+			return StrategyWithAPY{}, fmt.Errorf("check health of strategy %+v: %w", strategy, err)
+		}
+		if len(issues) > 0 {
+			evaluated, _ := awaitCandidate(nil, apyPromise, rewardPromise, strategy, includeRewards)
+			evaluated.Strategy = strategy
+			evaluated.Err = fmt.Errorf("%w: %s", protocol.ErrUnhealthy, strings.Join(issues, ", "))
+			evaluated.Unhealthy = true
+			return evaluated, nil
+		}
+	}
+
+	apy, err := awaitValidAPY(apyPromise, strategy)
+	if err != nil {
+		return StrategyWithAPY{}, err
+	}
+	if rewardPromise == nil {
+		return StrategyWithAPY{Strategy: strategy, APY: apy}, nil
+	}
+
+	rewardAPR, err := rewardPromise.Await()
+	if err != nil {
+		return StrategyWithAPY{}, fmt.Errorf("calculate reward APR for strategy %+v: %w", strategy, err)
+	}
+	if rewardAPR < 0 || math.IsNaN(rewardAPR) || math.IsInf(rewardAPR, 0) {
+		return StrategyWithAPY{}, fmt.Errorf("invalid reward APR value for protocolId %x: %v", strategy.ProtocolId, rewardAPR)
+	}
+
+	evaluated := StrategyWithAPY{Strategy: strategy, APY: apy, BaseAPY: apy, RewardAPR: rewardAPR}
+	if includeRewards {
+		evaluated.APY += rewardAPR
+	}
+	return evaluated, nil
+}
+
+// awaitLiquidityMargin awaits the liquidity available to withdraw from a
+// strategy and returns it as a multiple of tvl; 0 when tvl is zero.
+func awaitLiquidityMargin(liquidityPromise cre.Promise[*big.Int], tvl *big.Int, strategy Strategy) (float64, error) {
+	available, err := liquidityPromise.Await()
+	if err != nil {
+		return 0, fmt.Errorf("read available liquidity of strategy %+v: %w", strategy, err)
+	}
+	if available == nil || available.Sign() < 0 {
+		return 0, fmt.Errorf("invalid available liquidity for protocolId %x: %v", strategy.ProtocolId, available)
+	}
+	if tvl.Sign() == 0 {
+		return 0, nil
+	}
+	margin, _ := new(big.Rat).SetFrac(available, tvl).Float64()
+	return margin, nil
+}
 
-        apy, err := apyPromise.Await()
-        if err != nil {
-            return StrategyWithAPY{}, StrategyWithAPY{}, fmt.Errorf("calculate APY for strategy %+v: %w", strategy, err)
-        }
-
-        if apy == 0 {
-            return StrategyWithAPY{}, StrategyWithAPY{}, fmt.Errorf("0 APY returned for strategy %+v", strategy)
-        }
-        if math.IsNaN(apy) || math.IsInf(apy, 0) {
-            return StrategyWithAPY{}, StrategyWithAPY{}, fmt.Errorf("invalid APY value (NaN/Inf) for protocolId %x: %v",
-			strategy.ProtocolId, apy)
-        }
-
-        if sameStrategy(strategy, currentStrategy) {
-            currentAPY = apy
-        }
-
-        if !bestSet || apy > bestAPY {
-            bestAPY = apy
-            bestStrategy = strategy
-            bestSet = true
-        }
-
-        logger := runtime.Logger()
-        protocolName := protocolIDToString(strategy.ProtocolId)
-        logger.Info("APY calculated for strategy", "apy", apy, "protocol", protocolName, "chainSelector", strategy.ChainSelector)
-    }
-
-    return StrategyWithAPY{Strategy: bestStrategy, APY: bestAPY}, StrategyWithAPY{Strategy: currentStrategy, APY: currentAPY}, nil
+// awaitValidAPY awaits an APY promise and rejects zero, NaN and Inf values.
+func awaitValidAPY(apyPromise cre.Promise[float64], strategy Strategy) (float64, error) {
+	apy, err := apyPromise.Await()
+	if err != nil {
+		return 0, fmt.Errorf("calculate APY for strategy %+v: %w", strategy, err)
+	}
+	if apy == 0 {
+		return 0, fmt.Errorf("0 APY returned for strategy %+v", strategy)
+	}
+	if math.IsNaN(apy) || math.IsInf(apy, 0) {
+		return 0, fmt.Errorf("invalid APY value (NaN/Inf) for protocolId %x: %v", strategy.ProtocolId, apy)
+	}
+	return apy, nil
 }
 
 func getAPYPromiseFromStrategy(
@@ -129,14 +327,69 @@ func getAPYPromiseFromStrategy(
 	liquidity *big.Int,
 	deps apyPromiseDeps,
 ) cre.Promise[float64] {
-	switch strategy.ProtocolId {
-	case AaveV3ProtocolId:
-		return deps.AaveV3GetAPYPromise(config, runtime, liquidity, strategy.ChainSelector)
-
-	case CompoundV3ProtocolId:
-		return deps.CompoundV3GetAPYPromise(config, runtime, liquidity, strategy.ChainSelector)
+	p, ok := deps.Protocols.Lookup(strategy.ProtocolId)
+	if !ok {
+		return cre.PromiseFromResult(0.0, fmt.Errorf("unsupported protocolId: %x", strategy.ProtocolId))
+	}
+	return p.GetAPYPromise(config, runtime, deps.Cache, liquidity, strategy.ChainSelector)
+}
 
-	default:
+// getRewardAPRPromiseFromStrategy returns the strategy's reward APR; 0 for a
+// protocol that is not a protocol.RewardSource.
+func getRewardAPRPromiseFromStrategy(
+	config *helper.Config,
+	runtime cre.Runtime,
+	strategy Strategy,
+	liquidity *big.Int,
+	deps apyPromiseDeps,
+) cre.Promise[float64] {
+	p, ok := deps.Protocols.Lookup(strategy.ProtocolId)
+	if !ok {
 		return cre.PromiseFromResult(0.0, fmt.Errorf("unsupported protocolId: %x", strategy.ProtocolId))
 	}
+	source, ok := p.(protocol.RewardSource)
+	if !ok {
+		return cre.PromiseFromResult(0.0, nil)
+	}
+	return source.GetRewardAPRPromise(config, runtime, liquidity, strategy.ChainSelector)
+}
+
+// getHealthPromiseFromStrategy returns the strategy's health issues; nil for a
+// protocol that is not a protocol.HealthSource.
+func getHealthPromiseFromStrategy(
+	config *helper.Config,
+	runtime cre.Runtime,
+	strategy Strategy,
+	deps apyPromiseDeps,
+) cre.Promise[[]string] {
+	p, ok := deps.Protocols.Lookup(strategy.ProtocolId)
+	if !ok {
+		return nil
+	}
+	source, ok := p.(protocol.HealthSource)
+	if !ok {
+		return nil
+	}
+	return source.GetHealthPromise(config, runtime, strategy.ChainSelector)
+}
+
+// getLiquidityPromiseFromStrategy returns the liquidity available to withdraw
+// from the strategy after liquidity is supplied; nil for a protocol that is
+// not a protocol.LiquiditySource.
+func getLiquidityPromiseFromStrategy(
+	config *helper.Config,
+	runtime cre.Runtime,
+	strategy Strategy,
+	liquidity *big.Int,
+	deps apyPromiseDeps,
+) cre.Promise[*big.Int] {
+	p, ok := deps.Protocols.Lookup(strategy.ProtocolId)
+	if !ok {
+		return nil
+	}
+	source, ok := p.(protocol.LiquiditySource)
+	if !ok {
+		return nil
+	}
+	return source.GetAvailableLiquidityPromise(config, runtime, liquidity, strategy.ChainSelector)
 }
@@ -48,7 +48,7 @@ func Fuzz_getOptimalAndCurrentStrategyWithAPYWithDeps_selectsHighestAPY(f *testi
 			}
 		}
 
-		cfg := setupConfigWithStrategies(t, 1, 2)
+		cfg, strategies := setupConfigWithStrategies(t, 1, 2)
 		runtime := testutils.NewRuntime(t, nil)
 		liquidityAdded := big.NewInt(1_000)
 
@@ -65,7 +65,7 @@ func Fuzz_getOptimalAndCurrentStrategyWithAPYWithDeps_selectsHighestAPY(f *testi
 			{ProtocolId: CompoundV3ProtocolId, ChainSelector: 2}:   comp2,
 		}
 
-		deps := apyPromiseDeps{
+		deps := apyFuncs{
 			AaveV3GetAPYPromise: func(_ *helper.Config, _ cre.Runtime, _ *big.Int, chain uint64) cre.Promise[float64] {
 				str := Strategy{ProtocolId: AaveV3ProtocolId, ChainSelector: chain}
 				apy, ok := apyMap[str]
@@ -78,11 +78,12 @@ func Fuzz_getOptimalAndCurrentStrategyWithAPYWithDeps_selectsHighestAPY(f *testi
 				require.True(t, ok, "missing APY for Compound strategy: %+v", str)
 				return cre.PromiseFromResult(apy, nil)
 			},
-		}
+		}.deps()
 
-		optimal, current, err := getOptimalAndCurrentStrategyWithAPYWithDeps(
+		optimal, current, _, err := getOptimalAndCurrentStrategyWithAPYWithDeps(
 			cfg,
 			runtime,
+			strategies,
 			currentStrategy,
 			liquidityAdded,
 			deps,
@@ -128,7 +129,7 @@ func Fuzz_getOptimalAndCurrentStrategyWithAPYWithDeps_zeroLiquidityForCurrent(f
 		}
 		liquidityAdded := big.NewInt(liq)
 
-		cfg := setupConfigWithStrategies(t, 1, 2)
+		cfg, strategies := setupConfigWithStrategies(t, 1, 2)
 		runtime := testutils.NewRuntime(t, nil)
 
 		currentStrategy := Strategy{
@@ -141,7 +142,7 @@ func Fuzz_getOptimalAndCurrentStrategyWithAPYWithDeps_zeroLiquidityForCurrent(f
 			otherLiquidities []*big.Int
 		)
 
-		deps := apyPromiseDeps{
+		deps := apyFuncs{
 			AaveV3GetAPYPromise: func(_ *helper.Config, _ cre.Runtime, liqArg *big.Int, chain uint64) cre.Promise[float64] {
 				str := Strategy{ProtocolId: AaveV3ProtocolId, ChainSelector: chain}
 				if sameStrategy(str, currentStrategy) {
@@ -161,11 +162,12 @@ func Fuzz_getOptimalAndCurrentStrategyWithAPYWithDeps_zeroLiquidityForCurrent(f
 				// Non-zero, finite APY to avoid triggering error paths.
 				return cre.PromiseFromResult(0.04, nil)
 			},
-		}
+		}.deps()
 
-		_, _, err := getOptimalAndCurrentStrategyWithAPYWithDeps(
+		_, _, _, err := getOptimalAndCurrentStrategyWithAPYWithDeps(
 			cfg,
 			runtime,
+			strategies,
 			currentStrategy,
 			liquidityAdded,
 			deps,
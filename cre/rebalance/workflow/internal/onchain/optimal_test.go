@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"rebalance/workflow/internal/helper"
+	"rebalance/workflow/internal/protocol"
 
 	"github.com/smartcontractkit/cre-sdk-go/cre"
 	"github.com/smartcontractkit/cre-sdk-go/cre/testutils"
@@ -22,6 +23,50 @@ func requireBigEqual(t *testing.T, want, got *big.Int) {
 	require.Zero(t, want.Cmp(got), "big.Int mismatch: want=%s got=%s", want.String(), got.String())
 }
 
+type apyPromiseFunc func(*helper.Config, cre.Runtime, *big.Int, uint64) cre.Promise[float64]
+
+// mockProtocol is a Protocol whose APY comes from apy.
+type mockProtocol struct {
+	id   [32]byte
+	name string
+	apy  apyPromiseFunc
+}
+
+func (m mockProtocol) ID() [32]byte                         { return m.id }
+func (m mockProtocol) Name() string                         { return m.name }
+func (m mockProtocol) IsConfigured(helper.EvmConfig, helper.AssetConfig) bool { return true }
+func (m mockProtocol) GetAPYPromise(config *helper.Config, runtime cre.Runtime, _ *protocol.Cache, liquidityAdded *big.Int, chainSelector uint64) cre.Promise[float64] {
+	return m.apy(config, runtime, liquidityAdded, chainSelector)
+}
+
+// rewardMockProtocol is a mockProtocol that is also a protocol.RewardSource.
+type rewardMockProtocol struct {
+	mockProtocol
+	reward apyPromiseFunc
+}
+
+func (m rewardMockProtocol) GetRewardAPRPromise(config *helper.Config, runtime cre.Runtime, liquidityAdded *big.Int, chainSelector uint64) cre.Promise[float64] {
+	return m.reward(config, runtime, liquidityAdded, chainSelector)
+}
+
+// apyFuncs builds apyPromiseDeps whose registry holds AaveV3 and CompoundV3
+// mocks backed by the given functions.
+type apyFuncs struct {
+	AaveV3GetAPYPromise     apyPromiseFunc
+	CompoundV3GetAPYPromise apyPromiseFunc
+}
+
+func (f apyFuncs) deps() apyPromiseDeps {
+	registry := protocol.NewRegistry()
+	if err := registry.Register(mockProtocol{id: AaveV3ProtocolId, name: "aave-v3", apy: f.AaveV3GetAPYPromise}); err != nil {
+		panic(err)
+	}
+	if err := registry.Register(mockProtocol{id: CompoundV3ProtocolId, name: "compound-v3", apy: f.CompoundV3GetAPYPromise}); err != nil {
+		panic(err)
+	}
+	return apyPromiseDeps{Protocols: registry}
+}
+
 // mockAPYPromiseDeps creates a mock dependency set for testing.
 func mockAPYPromiseDeps(
 	aaveAPY float64,
@@ -29,7 +74,7 @@ func mockAPYPromiseDeps(
 	aaveErr error,
 	compoundErr error,
 ) apyPromiseDeps {
-	return apyPromiseDeps{
+	return apyFuncs{
 		AaveV3GetAPYPromise: func(*helper.Config, cre.Runtime, *big.Int, uint64) cre.Promise[float64] {
 			if aaveErr != nil {
 				return cre.PromiseFromResult(0.0, aaveErr)
@@ -42,14 +87,13 @@ func mockAPYPromiseDeps(
 			}
 			return cre.PromiseFromResult(compoundAPY, nil)
 		},
-	}
+	}.deps()
 }
 
 // setupConfigWithStrategies creates a config with the specified chain selectors
-// and initializes supportedStrategies. Both protocols (AaveV3 and CompoundV3) are
+// and builds its strategy set. Both protocols (AaveV3 and CompoundV3) are
 // enabled for each chain selector.
-func setupConfigWithStrategies(t *testing.T, chainSelectors ...uint64) *helper.Config {
-	resetState()
+func setupConfigWithStrategies(t *testing.T, chainSelectors ...uint64) (*helper.Config, StrategySet) {
 	evms := make([]helper.EvmConfig, len(chainSelectors))
 	for i, cs := range chainSelectors {
 		evms[i] = helper.EvmConfig{
@@ -59,9 +103,9 @@ func setupConfigWithStrategies(t *testing.T, chainSelectors ...uint64) *helper.C
 		}
 	}
 	cfg := &helper.Config{Evms: evms}
-	err := InitSupportedStrategies(cfg)
-	require.NoError(t, err, "failed to initialize supported strategies")
-	return cfg
+	strategies, err := NewStrategySet(cfg)
+	require.NoError(t, err, "failed to build strategy set")
+	return cfg, strategies
 }
 
 /*//////////////////////////////////////////////////////////////
@@ -69,7 +113,7 @@ func setupConfigWithStrategies(t *testing.T, chainSelectors ...uint64) *helper.C
 //////////////////////////////////////////////////////////////*/
 
 func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_singleStrategy_success(t *testing.T) {
-	cfg := setupConfigWithStrategies(t, 1)
+	cfg, strategies := setupConfigWithStrategies(t, 1)
 	runtime := testutils.NewRuntime(t, nil)
 	currentStrategy := Strategy{ProtocolId: AaveV3ProtocolId, ChainSelector: 1}
 	liquidityAdded := big.NewInt(1000)
@@ -77,7 +121,7 @@ func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_singleStrategy_success(t *
 	// Both strategies will be evaluated, so both need non-zero APYs
 	deps := mockAPYPromiseDeps(0.05, 0.03, nil, nil)
 
-	optimal, current, err := getOptimalAndCurrentStrategyWithAPYWithDeps(cfg, runtime, currentStrategy, liquidityAdded, deps)
+	optimal, current, _, err := getOptimalAndCurrentStrategyWithAPYWithDeps(cfg, runtime, strategies, currentStrategy, liquidityAdded, deps)
 	require.NoError(t, err)
 	require.Equal(t, AaveV3ProtocolId, optimal.Strategy.ProtocolId)
 	require.Equal(t, uint64(1), optimal.Strategy.ChainSelector)
@@ -88,7 +132,7 @@ func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_singleStrategy_success(t *
 }
 
 func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_multipleStrategies_picksHighestAPY(t *testing.T) {
-	cfg := setupConfigWithStrategies(t, 1)
+	cfg, strategies := setupConfigWithStrategies(t, 1)
 	runtime := testutils.NewRuntime(t, nil)
 	currentStrategy := Strategy{ProtocolId: AaveV3ProtocolId, ChainSelector: 1}
 	liquidityAdded := big.NewInt(1000)
@@ -96,7 +140,7 @@ func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_multipleStrategies_picksHi
 	// Aave has higher APY
 	deps := mockAPYPromiseDeps(0.08, 0.05, nil, nil)
 
-	optimal, current, err := getOptimalAndCurrentStrategyWithAPYWithDeps(cfg, runtime, currentStrategy, liquidityAdded, deps)
+	optimal, current, candidates, err := getOptimalAndCurrentStrategyWithAPYWithDeps(cfg, runtime, strategies, currentStrategy, liquidityAdded, deps)
 	require.NoError(t, err)
 	require.Equal(t, AaveV3ProtocolId, optimal.Strategy.ProtocolId)
 	require.Equal(t, uint64(1), optimal.Strategy.ChainSelector)
@@ -104,10 +148,14 @@ func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_multipleStrategies_picksHi
 	require.Equal(t, AaveV3ProtocolId, current.Strategy.ProtocolId)
 	require.Equal(t, uint64(1), current.Strategy.ChainSelector)
 	require.Equal(t, 0.08, current.APY)
+	require.Equal(t, []StrategyWithAPY{
+		{Strategy: Strategy{ProtocolId: AaveV3ProtocolId, ChainSelector: 1}, APY: 0.08},
+		{Strategy: Strategy{ProtocolId: CompoundV3ProtocolId, ChainSelector: 1}, APY: 0.05},
+	}, candidates)
 }
 
 func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_multipleStrategies_picksCompoundWhenHigher(t *testing.T) {
-	cfg := setupConfigWithStrategies(t, 1)
+	cfg, strategies := setupConfigWithStrategies(t, 1)
 	runtime := testutils.NewRuntime(t, nil)
 	currentStrategy := Strategy{ProtocolId: AaveV3ProtocolId, ChainSelector: 1}
 	liquidityAdded := big.NewInt(1000)
@@ -115,7 +163,7 @@ func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_multipleStrategies_picksCo
 	// Compound has higher APY
 	deps := mockAPYPromiseDeps(0.05, 0.10, nil, nil)
 
-	optimal, current, err := getOptimalAndCurrentStrategyWithAPYWithDeps(cfg, runtime, currentStrategy, liquidityAdded, deps)
+	optimal, current, _, err := getOptimalAndCurrentStrategyWithAPYWithDeps(cfg, runtime, strategies, currentStrategy, liquidityAdded, deps)
 	require.NoError(t, err)
 	require.Equal(t, CompoundV3ProtocolId, optimal.Strategy.ProtocolId)
 	require.Equal(t, uint64(1), optimal.Strategy.ChainSelector)
@@ -126,14 +174,14 @@ func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_multipleStrategies_picksCo
 }
 
 func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_multipleChains_picksBestAcrossChains(t *testing.T) {
-	cfg := setupConfigWithStrategies(t, 1, 2)
+	cfg, strategies := setupConfigWithStrategies(t, 1, 2)
 	runtime := testutils.NewRuntime(t, nil)
 	currentStrategy := Strategy{ProtocolId: AaveV3ProtocolId, ChainSelector: 1}
 	liquidityAdded := big.NewInt(1000)
 
 	// Mock deps that return different APYs based on chain selector
 	// Note: currentStrategy matches AaveV3 on chain 1, so that will use 0 liquidity
-	deps := apyPromiseDeps{
+	deps := apyFuncs{
 		AaveV3GetAPYPromise: func(_ *helper.Config, _ cre.Runtime, liq *big.Int, chain uint64) cre.Promise[float64] {
 			var apy float64
 			if chain == 1 {
@@ -158,9 +206,9 @@ func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_multipleChains_picksBestAc
 			}
 			return cre.PromiseFromResult(apy, nil)
 		},
-	}
+	}.deps()
 
-	optimal, current, err := getOptimalAndCurrentStrategyWithAPYWithDeps(cfg, runtime, currentStrategy, liquidityAdded, deps)
+	optimal, current, _, err := getOptimalAndCurrentStrategyWithAPYWithDeps(cfg, runtime, strategies, currentStrategy, liquidityAdded, deps)
 	require.NoError(t, err)
 	require.Equal(t, AaveV3ProtocolId, optimal.Strategy.ProtocolId)
 	require.Equal(t, uint64(2), optimal.Strategy.ChainSelector) // Chain 2 has highest APY (0.07)
@@ -170,14 +218,49 @@ func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_multipleChains_picksBestAc
 	require.Equal(t, 0.05, current.APY)
 }
 
+func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_convertsLiquidityToCandidateChainDecimals(t *testing.T) {
+	cfg := &helper.Config{
+		Asset: "USDT",
+		Evms: []helper.EvmConfig{
+			{ChainSelector: 1, Assets: []helper.AssetConfig{{Symbol: "USDT", Decimals: 6}}},
+			{ChainSelector: 2, Assets: []helper.AssetConfig{{Symbol: "USDT", Decimals: 18}}},
+		},
+	}
+	runtime := testutils.NewRuntime(t, nil)
+	strategies := NewStrategySetOf(
+		Strategy{ProtocolId: AaveV3ProtocolId, ChainSelector: 1},
+		Strategy{ProtocolId: CompoundV3ProtocolId, ChainSelector: 1},
+		Strategy{ProtocolId: AaveV3ProtocolId, ChainSelector: 2},
+	)
+	currentStrategy := Strategy{ProtocolId: AaveV3ProtocolId, ChainSelector: 1}
+	liquidityAdded := big.NewInt(1_000_000) // 1 USDT on chain 1
+
+	gotLiquidity := make(map[uint64]*big.Int)
+	deps := apyFuncs{
+		AaveV3GetAPYPromise: func(_ *helper.Config, _ cre.Runtime, liq *big.Int, chain uint64) cre.Promise[float64] {
+			gotLiquidity[chain] = liq
+			return cre.PromiseFromResult(0.05, nil)
+		},
+		CompoundV3GetAPYPromise: func(_ *helper.Config, _ cre.Runtime, liq *big.Int, _ uint64) cre.Promise[float64] {
+			requireBigEqual(t, liquidityAdded, liq)
+			return cre.PromiseFromResult(0.04, nil)
+		},
+	}.deps()
+
+	_, _, _, err := getOptimalAndCurrentStrategyWithAPYWithDeps(cfg, runtime, strategies, currentStrategy, liquidityAdded, deps)
+	require.NoError(t, err)
+	requireBigEqual(t, big.NewInt(0), gotLiquidity[1])
+	requireBigEqual(t, new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil), gotLiquidity[2])
+}
+
 func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_currentStrategyMatches_usesZeroLiquidity(t *testing.T) {
-	cfg := setupConfigWithStrategies(t, 1)
+	cfg, strategies := setupConfigWithStrategies(t, 1)
 	runtime := testutils.NewRuntime(t, nil)
 	currentStrategy := Strategy{ProtocolId: AaveV3ProtocolId, ChainSelector: 1}
 	liquidityAdded := big.NewInt(1000)
 
 	var gotLiquidity *big.Int
-	deps := apyPromiseDeps{
+	deps := apyFuncs{
 		AaveV3GetAPYPromise: func(_ *helper.Config, _ cre.Runtime, liq *big.Int, chain uint64) cre.Promise[float64] {
 			gotLiquidity = new(big.Int).Set(liq)
 			return cre.PromiseFromResult(0.05, nil)
@@ -187,9 +270,9 @@ func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_currentStrategyMatches_use
 			requireBigEqual(t, liquidityAdded, liq)
 			return cre.PromiseFromResult(0.03, nil)
 		},
-	}
+	}.deps()
 
-	optimal, current, err := getOptimalAndCurrentStrategyWithAPYWithDeps(cfg, runtime, currentStrategy, liquidityAdded, deps)
+	optimal, current, _, err := getOptimalAndCurrentStrategyWithAPYWithDeps(cfg, runtime, strategies, currentStrategy, liquidityAdded, deps)
 	require.NoError(t, err)
 	require.Equal(t, AaveV3ProtocolId, optimal.Strategy.ProtocolId)
 	requireBigEqual(t, big.NewInt(0), gotLiquidity)
@@ -198,7 +281,7 @@ func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_currentStrategyMatches_use
 }
 
 func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_currentStrategyNotInSupported_returnsZeroAPY(t *testing.T) {
-	cfg := setupConfigWithStrategies(t, 1)
+	cfg, strategies := setupConfigWithStrategies(t, 1)
 	runtime := testutils.NewRuntime(t, nil)
 	// Current strategy is not in supported strategies (different chain selector)
 	currentStrategy := Strategy{ProtocolId: AaveV3ProtocolId, ChainSelector: 999}
@@ -206,7 +289,7 @@ func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_currentStrategyNotInSuppor
 
 	deps := mockAPYPromiseDeps(0.05, 0.03, nil, nil)
 
-	optimal, current, err := getOptimalAndCurrentStrategyWithAPYWithDeps(cfg, runtime, currentStrategy, liquidityAdded, deps)
+	optimal, current, _, err := getOptimalAndCurrentStrategyWithAPYWithDeps(cfg, runtime, strategies, currentStrategy, liquidityAdded, deps)
 	require.NoError(t, err)
 	require.Equal(t, AaveV3ProtocolId, optimal.Strategy.ProtocolId)
 	require.Equal(t, 0.05, optimal.APY)
@@ -220,15 +303,15 @@ func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_currentStrategyNotInSuppor
 //////////////////////////////////////////////////////////////*/
 
 func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_errorWhen_noSupportedStrategies(t *testing.T) {
-	resetState()
 	cfg := &helper.Config{Evms: []helper.EvmConfig{}}
+	strategies := StrategySet{}
 	runtime := testutils.NewRuntime(t, nil)
 	currentStrategy := Strategy{ProtocolId: AaveV3ProtocolId, ChainSelector: 1}
 	liquidityAdded := big.NewInt(1000)
 
 	deps := mockAPYPromiseDeps(0.05, 0.0, nil, nil)
 
-	optimal, current, err := getOptimalAndCurrentStrategyWithAPYWithDeps(cfg, runtime, currentStrategy, liquidityAdded, deps)
+	optimal, current, _, err := getOptimalAndCurrentStrategyWithAPYWithDeps(cfg, runtime, strategies, currentStrategy, liquidityAdded, deps)
 	require.Error(t, err)
 	require.ErrorContains(t, err, "no supported strategies configured")
 	require.Equal(t, StrategyWithAPY{}, optimal)
@@ -236,13 +319,13 @@ func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_errorWhen_noSupportedStrat
 }
 
 func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_errorWhen_nilLiquidityAdded(t *testing.T) {
-	cfg := setupConfigWithStrategies(t, 1)
+	cfg, strategies := setupConfigWithStrategies(t, 1)
 	runtime := testutils.NewRuntime(t, nil)
 	currentStrategy := Strategy{ProtocolId: AaveV3ProtocolId, ChainSelector: 1}
 
 	deps := mockAPYPromiseDeps(0.05, 0.0, nil, nil)
 
-	optimal, current, err := getOptimalAndCurrentStrategyWithAPYWithDeps(cfg, runtime, currentStrategy, nil, deps)
+	optimal, current, _, err := getOptimalAndCurrentStrategyWithAPYWithDeps(cfg, runtime, strategies, currentStrategy, nil, deps)
 	require.Error(t, err)
 	require.ErrorContains(t, err, "liquidityAdded must not be nil")
 	require.Equal(t, StrategyWithAPY{}, optimal)
@@ -250,12 +333,12 @@ func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_errorWhen_nilLiquidityAdde
 }
 
 func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_errorWhen_unsupportedProtocol(t *testing.T) {
-	cfg := setupConfigWithStrategies(t, 1)
+	cfg, _ := setupConfigWithStrategies(t, 1)
 	runtime := testutils.NewRuntime(t, nil)
 	liquidityAdded := big.NewInt(1000)
 
 	// Create a config that somehow results in an unsupported protocol
-	// This is tricky since initSupportedStrategies only creates AaveV3 and CompoundV3
+	// This is tricky since the strategy set only holds registered protocols
 	// So we'll test the getAPYPromiseFromStrategy function directly instead
 	var unsupportedProtocolId [32]byte
 	copy(unsupportedProtocolId[:], []byte("unsupported-protocol-123456789012"))
@@ -275,7 +358,7 @@ func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_errorWhen_unsupportedProto
 }
 
 func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_errorWhen_aavePromiseAwaitFails(t *testing.T) {
-	cfg := setupConfigWithStrategies(t, 1)
+	cfg, strategies := setupConfigWithStrategies(t, 1)
 	runtime := testutils.NewRuntime(t, nil)
 	currentStrategy := Strategy{ProtocolId: AaveV3ProtocolId, ChainSelector: 1}
 	liquidityAdded := big.NewInt(1000)
@@ -283,7 +366,7 @@ func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_errorWhen_aavePromiseAwait
 	expectedErr := fmt.Errorf("aave promise creation failed")
 	deps := mockAPYPromiseDeps(0.05, 0.0, expectedErr, nil)
 
-	optimal, current, err := getOptimalAndCurrentStrategyWithAPYWithDeps(cfg, runtime, currentStrategy, liquidityAdded, deps)
+	optimal, current, _, err := getOptimalAndCurrentStrategyWithAPYWithDeps(cfg, runtime, strategies, currentStrategy, liquidityAdded, deps)
 	require.Error(t, err)
 	require.ErrorContains(t, err, "calculate APY for strategy")
 	require.ErrorContains(t, err, "aave promise creation failed")
@@ -292,7 +375,7 @@ func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_errorWhen_aavePromiseAwait
 }
 
 func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_errorWhen_compoundPromiseAwaitFails(t *testing.T) {
-	cfg := setupConfigWithStrategies(t, 1)
+	cfg, strategies := setupConfigWithStrategies(t, 1)
 	runtime := testutils.NewRuntime(t, nil)
 	currentStrategy := Strategy{ProtocolId: AaveV3ProtocolId, ChainSelector: 1}
 	liquidityAdded := big.NewInt(1000)
@@ -300,7 +383,7 @@ func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_errorWhen_compoundPromiseA
 	expectedErr := fmt.Errorf("compound promise creation failed")
 	deps := mockAPYPromiseDeps(0.05, 0.0, nil, expectedErr)
 
-	optimal, current, err := getOptimalAndCurrentStrategyWithAPYWithDeps(cfg, runtime, currentStrategy, liquidityAdded, deps)
+	optimal, current, _, err := getOptimalAndCurrentStrategyWithAPYWithDeps(cfg, runtime, strategies, currentStrategy, liquidityAdded, deps)
 	require.Error(t, err)
 	require.ErrorContains(t, err, "calculate APY for strategy")
 	require.ErrorContains(t, err, "compound promise creation failed")
@@ -309,22 +392,22 @@ func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_errorWhen_compoundPromiseA
 }
 
 func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_errorWhen_apyPromiseAwaitFails(t *testing.T) {
-	cfg := setupConfigWithStrategies(t, 1)
+	cfg, strategies := setupConfigWithStrategies(t, 1)
 	runtime := testutils.NewRuntime(t, nil)
 	currentStrategy := Strategy{ProtocolId: AaveV3ProtocolId, ChainSelector: 1}
 	liquidityAdded := big.NewInt(1000)
 
 	expectedErr := fmt.Errorf("apy calculation failed")
-	deps := apyPromiseDeps{
+	deps := apyFuncs{
 		AaveV3GetAPYPromise: func(*helper.Config, cre.Runtime, *big.Int, uint64) cre.Promise[float64] {
 			return cre.PromiseFromResult(0.0, expectedErr)
 		},
 		CompoundV3GetAPYPromise: func(*helper.Config, cre.Runtime, *big.Int, uint64) cre.Promise[float64] {
 			return cre.PromiseFromResult(0.0, nil)
 		},
-	}
+	}.deps()
 
-	optimal, current, err := getOptimalAndCurrentStrategyWithAPYWithDeps(cfg, runtime, currentStrategy, liquidityAdded, deps)
+	optimal, current, _, err := getOptimalAndCurrentStrategyWithAPYWithDeps(cfg, runtime, strategies, currentStrategy, liquidityAdded, deps)
 	require.Error(t, err)
 	require.ErrorContains(t, err, "calculate APY for strategy")
 	require.ErrorContains(t, err, "apy calculation failed")
@@ -333,14 +416,14 @@ func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_errorWhen_apyPromiseAwaitF
 }
 
 func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_errorWhen_apyIsZero(t *testing.T) {
-	cfg := setupConfigWithStrategies(t, 1)
+	cfg, strategies := setupConfigWithStrategies(t, 1)
 	runtime := testutils.NewRuntime(t, nil)
 	currentStrategy := Strategy{ProtocolId: AaveV3ProtocolId, ChainSelector: 1}
 	liquidityAdded := big.NewInt(1000)
 
 	deps := mockAPYPromiseDeps(0.0, 0.0, nil, nil)
 
-	optimal, current, err := getOptimalAndCurrentStrategyWithAPYWithDeps(cfg, runtime, currentStrategy, liquidityAdded, deps)
+	optimal, current, _, err := getOptimalAndCurrentStrategyWithAPYWithDeps(cfg, runtime, strategies, currentStrategy, liquidityAdded, deps)
 	require.Error(t, err)
 	require.ErrorContains(t, err, "0 APY returned for strategy")
 	require.Equal(t, StrategyWithAPY{}, optimal)
@@ -348,21 +431,21 @@ func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_errorWhen_apyIsZero(t *tes
 }
 
 func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_errorWhen_apyIsNaN(t *testing.T) {
-	cfg := setupConfigWithStrategies(t, 1)
+	cfg, strategies := setupConfigWithStrategies(t, 1)
 	runtime := testutils.NewRuntime(t, nil)
 	currentStrategy := Strategy{ProtocolId: AaveV3ProtocolId, ChainSelector: 1}
 	liquidityAdded := big.NewInt(1000)
 
-	deps := apyPromiseDeps{
+	deps := apyFuncs{
 		AaveV3GetAPYPromise: func(*helper.Config, cre.Runtime, *big.Int, uint64) cre.Promise[float64] {
 			return cre.PromiseFromResult(math.NaN(), nil)
 		},
 		CompoundV3GetAPYPromise: func(*helper.Config, cre.Runtime, *big.Int, uint64) cre.Promise[float64] {
 			return cre.PromiseFromResult(0.0, nil)
 		},
-	}
+	}.deps()
 
-	optimal, current, err := getOptimalAndCurrentStrategyWithAPYWithDeps(cfg, runtime, currentStrategy, liquidityAdded, deps)
+	optimal, current, _, err := getOptimalAndCurrentStrategyWithAPYWithDeps(cfg, runtime, strategies, currentStrategy, liquidityAdded, deps)
 	require.Error(t, err)
 	require.ErrorContains(t, err, "invalid APY value (NaN/Inf)")
 	require.Equal(t, StrategyWithAPY{}, optimal)
@@ -370,27 +453,463 @@ func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_errorWhen_apyIsNaN(t *test
 }
 
 func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_errorWhen_apyIsInf(t *testing.T) {
-	cfg := setupConfigWithStrategies(t, 1)
+	cfg, strategies := setupConfigWithStrategies(t, 1)
 	runtime := testutils.NewRuntime(t, nil)
 	currentStrategy := Strategy{ProtocolId: AaveV3ProtocolId, ChainSelector: 1}
 	liquidityAdded := big.NewInt(1000)
 
-	deps := apyPromiseDeps{
+	deps := apyFuncs{
 		AaveV3GetAPYPromise: func(*helper.Config, cre.Runtime, *big.Int, uint64) cre.Promise[float64] {
 			return cre.PromiseFromResult(math.Inf(1), nil)
 		},
 		CompoundV3GetAPYPromise: func(*helper.Config, cre.Runtime, *big.Int, uint64) cre.Promise[float64] {
 			return cre.PromiseFromResult(0.0, nil)
 		},
-	}
+	}.deps()
 
-	optimal, current, err := getOptimalAndCurrentStrategyWithAPYWithDeps(cfg, runtime, currentStrategy, liquidityAdded, deps)
+	optimal, current, _, err := getOptimalAndCurrentStrategyWithAPYWithDeps(cfg, runtime, strategies, currentStrategy, liquidityAdded, deps)
 	require.Error(t, err)
 	require.ErrorContains(t, err, "invalid APY value (NaN/Inf)")
 	require.Equal(t, StrategyWithAPY{}, optimal)
 	require.Equal(t, StrategyWithAPY{}, current)
 }
 
+func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_excludePolicy_skipsFailingCandidate(t *testing.T) {
+	cfg, strategies := setupConfigWithStrategies(t, 1, 2)
+	cfg.Evaluation = helper.EvaluationConfig{ErrorPolicy: helper.ErrorPolicyExclude}
+	runtime := testutils.NewRuntime(t, nil)
+	currentStrategy := Strategy{ProtocolId: AaveV3ProtocolId, ChainSelector: 1}
+	liquidityAdded := big.NewInt(1000)
+
+	rpcErr := fmt.Errorf("rpc unavailable")
+	deps := apyFuncs{
+		AaveV3GetAPYPromise: func(_ *helper.Config, _ cre.Runtime, _ *big.Int, chainSelector uint64) cre.Promise[float64] {
+			return cre.PromiseFromResult(0.03*float64(chainSelector), nil)
+		},
+		CompoundV3GetAPYPromise: func(_ *helper.Config, _ cre.Runtime, _ *big.Int, chainSelector uint64) cre.Promise[float64] {
+			if chainSelector == 2 {
+				// Would be the best candidate, but its RPC is down.
+				return cre.PromiseFromResult(0.0, rpcErr)
+			}
+			return cre.PromiseFromResult(0.04, nil)
+		},
+	}.deps()
+
+	optimal, current, candidates, err := getOptimalAndCurrentStrategyWithAPYWithDeps(cfg, runtime, strategies, currentStrategy, liquidityAdded, deps)
+	require.NoError(t, err)
+	require.Equal(t, Strategy{ProtocolId: AaveV3ProtocolId, ChainSelector: 2}, optimal.Strategy)
+	require.Equal(t, 0.06, optimal.APY)
+	require.Equal(t, 0.03, current.APY)
+	require.Len(t, candidates, 4)
+
+	failed := candidates[3]
+	require.Equal(t, Strategy{ProtocolId: CompoundV3ProtocolId, ChainSelector: 2}, failed.Strategy)
+	require.ErrorIs(t, failed.Err, rpcErr)
+	require.Zero(t, failed.APY)
+}
+
+func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_excludePolicy_errorWhen_currentFails(t *testing.T) {
+	cfg, strategies := setupConfigWithStrategies(t, 1)
+	cfg.Evaluation = helper.EvaluationConfig{ErrorPolicy: helper.ErrorPolicyExclude}
+	runtime := testutils.NewRuntime(t, nil)
+	currentStrategy := Strategy{ProtocolId: AaveV3ProtocolId, ChainSelector: 1}
+
+	deps := mockAPYPromiseDeps(math.NaN(), 0.05, nil, nil)
+
+	_, _, _, err := getOptimalAndCurrentStrategyWithAPYWithDeps(cfg, runtime, strategies, currentStrategy, big.NewInt(1000), deps)
+	require.ErrorContains(t, err, "invalid APY value (NaN/Inf)")
+}
+
+func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_excludePolicy_errorWhen_tooFewHealthy(t *testing.T) {
+	cfg, strategies := setupConfigWithStrategies(t, 1)
+	cfg.Evaluation = helper.EvaluationConfig{ErrorPolicy: helper.ErrorPolicyExclude, MinHealthyCandidates: 2}
+	runtime := testutils.NewRuntime(t, nil)
+	currentStrategy := Strategy{ProtocolId: AaveV3ProtocolId, ChainSelector: 1}
+
+	deps := mockAPYPromiseDeps(0.05, 0.0, nil, nil)
+
+	_, _, _, err := getOptimalAndCurrentStrategyWithAPYWithDeps(cfg, runtime, strategies, currentStrategy, big.NewInt(1000), deps)
+	require.ErrorContains(t, err, "only 1 of 2 candidates evaluated successfully; need 2")
+}
+
+func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_errorWhen_unknownErrorPolicy(t *testing.T) {
+	cfg, strategies := setupConfigWithStrategies(t, 1)
+	cfg.Evaluation.ErrorPolicy = "lenient"
+	runtime := testutils.NewRuntime(t, nil)
+
+	_, _, _, err := getOptimalAndCurrentStrategyWithAPYWithDeps(cfg, runtime, strategies, Strategy{}, big.NewInt(0), mockAPYPromiseDeps(0.05, 0.05, nil, nil))
+	require.ErrorContains(t, err, `unknown evaluation error policy "lenient"`)
+}
+
+func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_infeasibleCandidateNeverSelected(t *testing.T) {
+	cfg, strategies := setupConfigWithStrategies(t, 1)
+	runtime := testutils.NewRuntime(t, nil)
+	currentStrategy := Strategy{ProtocolId: CompoundV3ProtocolId, ChainSelector: 1}
+
+	// Strict policy: an infeasible candidate is not a failure.
+	full := fmt.Errorf("%w: supply cap reached", protocol.ErrInfeasible)
+	deps := mockAPYPromiseDeps(0.09, 0.03, full, nil)
+
+	optimal, current, candidates, err := getOptimalAndCurrentStrategyWithAPYWithDeps(cfg, runtime, strategies, currentStrategy, big.NewInt(1000), deps)
+	require.NoError(t, err)
+	require.Equal(t, currentStrategy, optimal.Strategy)
+	require.Equal(t, 0.03, current.APY)
+	require.Len(t, candidates, 2)
+	require.True(t, candidates[0].Infeasible)
+	require.ErrorIs(t, candidates[0].Err, protocol.ErrInfeasible)
+}
+
+func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_infeasibleCurrentEarnsZero(t *testing.T) {
+	cfg, strategies := setupConfigWithStrategies(t, 1)
+	runtime := testutils.NewRuntime(t, nil)
+	currentStrategy := Strategy{ProtocolId: AaveV3ProtocolId, ChainSelector: 1}
+
+	flat := fmt.Errorf("%w: share price did not grow", protocol.ErrInfeasible)
+	deps := mockAPYPromiseDeps(0.09, 0.03, flat, nil)
+
+	optimal, current, _, err := getOptimalAndCurrentStrategyWithAPYWithDeps(cfg, runtime, strategies, currentStrategy, big.NewInt(0), deps)
+	require.NoError(t, err)
+	require.Equal(t, CompoundV3ProtocolId, optimal.Strategy.ProtocolId)
+	require.Equal(t, currentStrategy, current.Strategy)
+	require.True(t, current.Infeasible)
+	require.Zero(t, current.APY)
+}
+
+func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_errorWhen_unknownSupplyCapPolicy(t *testing.T) {
+	cfg, strategies := setupConfigWithStrategies(t, 1)
+	cfg.Evaluation.SupplyCapPolicy = "ignore"
+	runtime := testutils.NewRuntime(t, nil)
+
+	_, _, _, err := getOptimalAndCurrentStrategyWithAPYWithDeps(cfg, runtime, strategies, Strategy{}, big.NewInt(0), mockAPYPromiseDeps(0.05, 0.05, nil, nil))
+	require.ErrorContains(t, err, `unknown supply cap policy "ignore"`)
+}
+
+/*//////////////////////////////////////////////////////////////
+                            HEALTH
+//////////////////////////////////////////////////////////////*/
+
+// healthMockProtocol is a mockProtocol that is also a protocol.HealthSource.
+type healthMockProtocol struct {
+	mockProtocol
+	issues []string
+	err    error
+}
+
+func (m healthMockProtocol) GetHealthPromise(*helper.Config, cre.Runtime, uint64) cre.Promise[[]string] {
+	return cre.PromiseFromResult(m.issues, m.err)
+}
+
+// healthDeps registers an AaveV3 mock at aaveAPY reporting issues, and a
+// CompoundV3 mock at compoundAPY without health checks.
+func healthDeps(aaveAPY, compoundAPY float64, issues []string, err error) apyPromiseDeps {
+	registry := protocol.NewRegistry()
+	aave := healthMockProtocol{
+		mockProtocol: mockProtocol{id: AaveV3ProtocolId, name: "aave-v3", apy: func(*helper.Config, cre.Runtime, *big.Int, uint64) cre.Promise[float64] {
+			return cre.PromiseFromResult(aaveAPY, nil)
+		}},
+		issues: issues,
+		err:    err,
+	}
+	if err := registry.Register(aave); err != nil {
+		panic(err)
+	}
+	if err := registry.Register(mockProtocol{id: CompoundV3ProtocolId, name: "compound-v3", apy: func(*helper.Config, cre.Runtime, *big.Int, uint64) cre.Promise[float64] {
+		return cre.PromiseFromResult(compoundAPY, nil)
+	}}); err != nil {
+		panic(err)
+	}
+	return apyPromiseDeps{Protocols: registry}
+}
+
+func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_unhealthyCandidateNeverSelected(t *testing.T) {
+	cfg, strategies := setupConfigWithStrategies(t, 1)
+	runtime := testutils.NewRuntime(t, nil)
+	currentStrategy := Strategy{ProtocolId: CompoundV3ProtocolId, ChainSelector: 1}
+
+	deps := healthDeps(0.09, 0.03, []string{"reserve frozen"}, nil)
+
+	optimal, _, candidates, err := getOptimalAndCurrentStrategyWithAPYWithDeps(cfg, runtime, strategies, currentStrategy, big.NewInt(1000), deps)
+	require.NoError(t, err)
+	require.Equal(t, currentStrategy, optimal.Strategy)
+	require.True(t, candidates[0].Unhealthy)
+	require.ErrorIs(t, candidates[0].Err, protocol.ErrUnhealthy)
+	require.ErrorContains(t, candidates[0].Err, "reserve frozen")
+	require.Equal(t, 0.09, candidates[0].APY)
+}
+
+func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_unhealthyCurrentIsReturned(t *testing.T) {
+	cfg, strategies := setupConfigWithStrategies(t, 1)
+	runtime := testutils.NewRuntime(t, nil)
+	currentStrategy := Strategy{ProtocolId: AaveV3ProtocolId, ChainSelector: 1}
+
+	deps := healthDeps(0.09, 0.03, []string{"reserve paused"}, nil)
+
+	optimal, current, _, err := getOptimalAndCurrentStrategyWithAPYWithDeps(cfg, runtime, strategies, currentStrategy, big.NewInt(1000), deps)
+	require.NoError(t, err)
+	require.Equal(t, CompoundV3ProtocolId, optimal.Strategy.ProtocolId)
+	require.Equal(t, currentStrategy, current.Strategy)
+	require.True(t, current.Unhealthy)
+}
+
+func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_errorWhen_healthCheckFails(t *testing.T) {
+	cfg, strategies := setupConfigWithStrategies(t, 1)
+	runtime := testutils.NewRuntime(t, nil)
+	currentStrategy := Strategy{ProtocolId: CompoundV3ProtocolId, ChainSelector: 1}
+
+	deps := healthDeps(0.09, 0.03, nil, fmt.Errorf("rpc unavailable"))
+
+	_, _, _, err := getOptimalAndCurrentStrategyWithAPYWithDeps(cfg, runtime, strategies, currentStrategy, big.NewInt(1000), deps)
+	require.ErrorContains(t, err, "check health of strategy")
+	require.ErrorContains(t, err, "rpc unavailable")
+}
+
+func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_errorWhen_noEligibleStrategy(t *testing.T) {
+	cfg := &helper.Config{Evms: []helper.EvmConfig{{ChainSelector: 1, AaveV3PoolAddressesProviderAddress: "0xaave"}}}
+	strategies, err := NewStrategySet(cfg)
+	require.NoError(t, err)
+	runtime := testutils.NewRuntime(t, nil)
+
+	deps := healthDeps(0.09, 0.03, []string{"reserve paused"}, nil)
+
+	_, _, _, err = getOptimalAndCurrentStrategyWithAPYWithDeps(cfg, runtime, strategies, Strategy{ProtocolId: AaveV3ProtocolId, ChainSelector: 1}, big.NewInt(1000), deps)
+	require.ErrorContains(t, err, "no eligible strategy: all 1 candidates are unhealthy or infeasible")
+}
+
+func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_errorWhen_unhealthyCandidatesMissMinHealthy(t *testing.T) {
+	cfg, strategies := setupConfigWithStrategies(t, 1)
+	cfg.Evaluation = helper.EvaluationConfig{ErrorPolicy: helper.ErrorPolicyExclude, MinHealthyCandidates: 2}
+	runtime := testutils.NewRuntime(t, nil)
+	currentStrategy := Strategy{ProtocolId: CompoundV3ProtocolId, ChainSelector: 1}
+
+	// Both candidates evaluate, but the unhealthy one does not count.
+	deps := healthDeps(0.09, 0.03, []string{"reserve frozen"}, nil)
+
+	_, _, _, err := getOptimalAndCurrentStrategyWithAPYWithDeps(cfg, runtime, strategies, currentStrategy, big.NewInt(1000), deps)
+	require.ErrorContains(t, err, "only 1 of 2 candidates evaluated successfully; need 2")
+}
+
+func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_strictPolicyIgnoresMinHealthy(t *testing.T) {
+	cfg, strategies := setupConfigWithStrategies(t, 1)
+	cfg.Evaluation = helper.EvaluationConfig{ErrorPolicy: helper.ErrorPolicyStrict, MinHealthyCandidates: 2}
+	runtime := testutils.NewRuntime(t, nil)
+	currentStrategy := Strategy{ProtocolId: CompoundV3ProtocolId, ChainSelector: 1}
+
+	// Only the current strategy is healthy; the minimum applies under exclude only.
+	deps := healthDeps(0.09, 0.03, []string{"reserve frozen"}, nil)
+
+	optimal, _, _, err := getOptimalAndCurrentStrategyWithAPYWithDeps(cfg, runtime, strategies, currentStrategy, big.NewInt(1000), deps)
+	require.NoError(t, err)
+	require.Equal(t, currentStrategy, optimal.Strategy)
+}
+
+/*//////////////////////////////////////////////////////////////
+                           LIQUIDITY
+//////////////////////////////////////////////////////////////*/
+
+// liquidityMockProtocol is a mockProtocol that is also a
+// protocol.LiquiditySource: available is what it holds before a deposit.
+type liquidityMockProtocol struct {
+	mockProtocol
+	available *big.Int
+}
+
+func (m liquidityMockProtocol) GetAvailableLiquidityPromise(_ *helper.Config, _ cre.Runtime, liquidityAdded *big.Int, _ uint64) cre.Promise[*big.Int] {
+	return cre.PromiseFromResult(new(big.Int).Add(m.available, liquidityAdded), nil)
+}
+
+// liquidityDeps registers an AaveV3 mock at aaveAPY holding aaveAvailable, and
+// a CompoundV3 mock at compoundAPY holding compoundAvailable.
+func liquidityDeps(aaveAPY float64, aaveAvailable int64, compoundAPY float64, compoundAvailable int64) apyPromiseDeps {
+	registry := protocol.NewRegistry()
+	for _, p := range []liquidityMockProtocol{
+		{mockProtocol: mockProtocol{id: AaveV3ProtocolId, name: "aave-v3", apy: fixedAPY(aaveAPY)}, available: big.NewInt(aaveAvailable)},
+		{mockProtocol: mockProtocol{id: CompoundV3ProtocolId, name: "compound-v3", apy: fixedAPY(compoundAPY)}, available: big.NewInt(compoundAvailable)},
+	} {
+		if err := registry.Register(p); err != nil {
+			panic(err)
+		}
+	}
+	return apyPromiseDeps{Protocols: registry}
+}
+
+func fixedAPY(apy float64) func(*helper.Config, cre.Runtime, *big.Int, uint64) cre.Promise[float64] {
+	return func(*helper.Config, cre.Runtime, *big.Int, uint64) cre.Promise[float64] {
+		return cre.PromiseFromResult(apy, nil)
+	}
+}
+
+func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_illiquidTargetIsInfeasible(t *testing.T) {
+	cfg, strategies := setupConfigWithStrategies(t, 1)
+	cfg.Liquidity = helper.LiquidityConfig{Enabled: true, MinTVLMultiple: 2}
+	runtime := testutils.NewRuntime(t, nil)
+	currentStrategy := Strategy{ProtocolId: CompoundV3ProtocolId, ChainSelector: 1}
+
+	// Aave holds 1500 to withdraw before our 1000 deposit: 1.5x the TVL.
+	deps := liquidityDeps(0.09, 1500, 0.03, 100)
+
+	optimal, current, candidates, err := getOptimalAndCurrentStrategyWithAPYWithDeps(cfg, runtime, strategies, currentStrategy, big.NewInt(1000), deps)
+	require.NoError(t, err)
+	require.Equal(t, currentStrategy, optimal.Strategy)
+	require.Equal(t, 0.1, current.LiquidityMargin, "the current strategy is reported but not checked")
+
+	require.True(t, candidates[0].Infeasible)
+	require.ErrorIs(t, candidates[0].Err, protocol.ErrInfeasible)
+	require.ErrorContains(t, candidates[0].Err, "available liquidity covers 1.50x the TVL, 2.00x required")
+	require.Equal(t, 0.09, candidates[0].APY)
+	require.Equal(t, 1.5, candidates[0].LiquidityMargin)
+}
+
+func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_liquidTargetIsSelected(t *testing.T) {
+	cfg, strategies := setupConfigWithStrategies(t, 1)
+	cfg.Liquidity = helper.LiquidityConfig{Enabled: true}
+	runtime := testutils.NewRuntime(t, nil)
+	currentStrategy := Strategy{ProtocolId: CompoundV3ProtocolId, ChainSelector: 1}
+
+	// The default multiple of 1 needs the TVL in the market already.
+	deps := liquidityDeps(0.09, 1000, 0.03, 0)
+
+	optimal, _, _, err := getOptimalAndCurrentStrategyWithAPYWithDeps(cfg, runtime, strategies, currentStrategy, big.NewInt(1000), deps)
+	require.NoError(t, err)
+	require.Equal(t, AaveV3ProtocolId, optimal.Strategy.ProtocolId)
+	require.Equal(t, 1.0, optimal.LiquidityMargin)
+}
+
+func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_fullyUtilisedTargetIsInfeasible(t *testing.T) {
+	cfg, strategies := setupConfigWithStrategies(t, 1)
+	cfg.Liquidity = helper.LiquidityConfig{Enabled: true}
+	runtime := testutils.NewRuntime(t, nil)
+	currentStrategy := Strategy{ProtocolId: CompoundV3ProtocolId, ChainSelector: 1}
+
+	// An Aave reserve at 100% utilisation has no virtual balance left; only
+	// our own deposit could be withdrawn from it.
+	deps := liquidityDeps(0.09, 0, 0.03, 5000)
+
+	optimal, _, candidates, err := getOptimalAndCurrentStrategyWithAPYWithDeps(cfg, runtime, strategies, currentStrategy, big.NewInt(1000), deps)
+	require.NoError(t, err)
+	require.Equal(t, currentStrategy, optimal.Strategy)
+	require.True(t, candidates[0].Infeasible)
+	require.ErrorContains(t, candidates[0].Err, "available liquidity covers 0.00x the TVL, 1.00x required")
+	require.Zero(t, candidates[0].LiquidityMargin)
+}
+
+func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_liquidityNotReadWhenDisabled(t *testing.T) {
+	cfg, strategies := setupConfigWithStrategies(t, 1)
+	runtime := testutils.NewRuntime(t, nil)
+	currentStrategy := Strategy{ProtocolId: CompoundV3ProtocolId, ChainSelector: 1}
+
+	deps := liquidityDeps(0.09, 0, 0.03, 0)
+
+	optimal, _, _, err := getOptimalAndCurrentStrategyWithAPYWithDeps(cfg, runtime, strategies, currentStrategy, big.NewInt(1000), deps)
+	require.NoError(t, err)
+	require.Equal(t, AaveV3ProtocolId, optimal.Strategy.ProtocolId)
+	require.Zero(t, optimal.LiquidityMargin)
+}
+
+func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_errorWhen_invalidMinTVLMultiple(t *testing.T) {
+	cfg, strategies := setupConfigWithStrategies(t, 1)
+	cfg.Liquidity = helper.LiquidityConfig{Enabled: true, MinTVLMultiple: -1}
+	runtime := testutils.NewRuntime(t, nil)
+
+	_, _, _, err := getOptimalAndCurrentStrategyWithAPYWithDeps(cfg, runtime, strategies, Strategy{ProtocolId: AaveV3ProtocolId, ChainSelector: 1}, big.NewInt(1000), liquidityDeps(0.09, 0, 0.03, 0))
+	require.ErrorContains(t, err, "invalid liquidity minTvlMultiple -1")
+}
+
+/*//////////////////////////////////////////////////////////////
+                            REWARDS
+//////////////////////////////////////////////////////////////*/
+
+// rewardDeps registers an AaveV3 mock with a base APY of aaveAPY and a reward
+// APR from reward, and a CompoundV3 mock without rewards at compoundAPY.
+func rewardDeps(aaveAPY, compoundAPY float64, reward apyPromiseFunc) apyPromiseDeps {
+	registry := protocol.NewRegistry()
+	aave := rewardMockProtocol{
+		mockProtocol: mockProtocol{id: AaveV3ProtocolId, name: "aave-v3", apy: func(*helper.Config, cre.Runtime, *big.Int, uint64) cre.Promise[float64] {
+			return cre.PromiseFromResult(aaveAPY, nil)
+		}},
+		reward: reward,
+	}
+	if err := registry.Register(aave); err != nil {
+		panic(err)
+	}
+	if err := registry.Register(mockProtocol{id: CompoundV3ProtocolId, name: "compound-v3", apy: func(*helper.Config, cre.Runtime, *big.Int, uint64) cre.Promise[float64] {
+		return cre.PromiseFromResult(compoundAPY, nil)
+	}}); err != nil {
+		panic(err)
+	}
+	return apyPromiseDeps{Protocols: registry}
+}
+
+func fixedReward(apr float64, err error) apyPromiseFunc {
+	return func(*helper.Config, cre.Runtime, *big.Int, uint64) cre.Promise[float64] {
+		return cre.PromiseFromResult(apr, err)
+	}
+}
+
+func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_rewardsReportedButNotIncluded(t *testing.T) {
+	cfg, strategies := setupConfigWithStrategies(t, 1)
+	cfg.Rewards = helper.RewardsConfig{Enabled: true}
+	runtime := testutils.NewRuntime(t, nil)
+	currentStrategy := Strategy{ProtocolId: AaveV3ProtocolId, ChainSelector: 1}
+
+	deps := rewardDeps(0.03, 0.04, fixedReward(0.02, nil))
+
+	optimal, current, candidates, err := getOptimalAndCurrentStrategyWithAPYWithDeps(cfg, runtime, strategies, currentStrategy, big.NewInt(1000), deps)
+	require.NoError(t, err)
+	require.Equal(t, CompoundV3ProtocolId, optimal.Strategy.ProtocolId)
+	require.Equal(t, 0.04, optimal.APY)
+	require.Equal(t, StrategyWithAPY{Strategy: currentStrategy, APY: 0.03, BaseAPY: 0.03, RewardAPR: 0.02}, current)
+	require.Equal(t, StrategyWithAPY{Strategy: optimal.Strategy, APY: 0.04, BaseAPY: 0.04}, candidates[1])
+}
+
+func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_rewardsIncludedInDecision(t *testing.T) {
+	cfg, strategies := setupConfigWithStrategies(t, 1)
+	cfg.Rewards = helper.RewardsConfig{Enabled: true, IncludeInDecision: true}
+	runtime := testutils.NewRuntime(t, nil)
+	currentStrategy := Strategy{ProtocolId: CompoundV3ProtocolId, ChainSelector: 1}
+
+	deps := rewardDeps(0.03, 0.04, fixedReward(0.02, nil))
+
+	optimal, current, _, err := getOptimalAndCurrentStrategyWithAPYWithDeps(cfg, runtime, strategies, currentStrategy, big.NewInt(1000), deps)
+	require.NoError(t, err)
+	require.Equal(t, AaveV3ProtocolId, optimal.Strategy.ProtocolId)
+	require.InDelta(t, 0.05, optimal.APY, 1e-12)
+	require.Equal(t, 0.03, optimal.BaseAPY)
+	require.Equal(t, 0.02, optimal.RewardAPR)
+	require.Equal(t, 0.04, current.APY)
+}
+
+func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_rewardsDisabled_notCalculated(t *testing.T) {
+	cfg, strategies := setupConfigWithStrategies(t, 1)
+	runtime := testutils.NewRuntime(t, nil)
+	currentStrategy := Strategy{ProtocolId: AaveV3ProtocolId, ChainSelector: 1}
+
+	deps := rewardDeps(0.03, 0.04, func(*helper.Config, cre.Runtime, *big.Int, uint64) cre.Promise[float64] {
+		t.Fatal("reward APR calculated with rewards disabled")
+		return nil
+	})
+
+	_, current, _, err := getOptimalAndCurrentStrategyWithAPYWithDeps(cfg, runtime, strategies, currentStrategy, big.NewInt(1000), deps)
+	require.NoError(t, err)
+	require.Equal(t, StrategyWithAPY{Strategy: currentStrategy, APY: 0.03}, current)
+}
+
+func Test_getOptimalAndCurrentStrategyWithAPYWithDeps_rewardErrorFailsCandidate(t *testing.T) {
+	cfg, strategies := setupConfigWithStrategies(t, 1)
+	cfg.Rewards = helper.RewardsConfig{Enabled: true}
+	runtime := testutils.NewRuntime(t, nil)
+	currentStrategy := Strategy{ProtocolId: CompoundV3ProtocolId, ChainSelector: 1}
+
+	_, _, _, err := getOptimalAndCurrentStrategyWithAPYWithDeps(cfg, runtime, strategies, currentStrategy, big.NewInt(1000), rewardDeps(0.03, 0.04, fixedReward(0, fmt.Errorf("feed down"))))
+	require.ErrorContains(t, err, "calculate reward APR for strategy")
+	require.ErrorContains(t, err, "feed down")
+
+	cfg.Evaluation = helper.EvaluationConfig{ErrorPolicy: helper.ErrorPolicyExclude}
+	optimal, _, candidates, err := getOptimalAndCurrentStrategyWithAPYWithDeps(cfg, runtime, strategies, currentStrategy, big.NewInt(1000), rewardDeps(0.05, 0.04, fixedReward(math.NaN(), nil)))
+	require.NoError(t, err)
+	require.Equal(t, CompoundV3ProtocolId, optimal.Strategy.ProtocolId)
+	require.ErrorContains(t, candidates[0].Err, "invalid reward APR value")
+}
+
 /*//////////////////////////////////////////////////////////////
               GET APY PROMISE FROM STRATEGY
 //////////////////////////////////////////////////////////////*/
@@ -402,7 +921,7 @@ func Test_getAPYPromiseFromStrategy_aaveV3_success(t *testing.T) {
 	liquidity := big.NewInt(1000)
 
 	var called bool
-	deps := apyPromiseDeps{
+	deps := apyFuncs{
 		AaveV3GetAPYPromise: func(*helper.Config, cre.Runtime, *big.Int, uint64) cre.Promise[float64] {
 			called = true
 			return cre.PromiseFromResult(0.05, nil)
@@ -410,7 +929,7 @@ func Test_getAPYPromiseFromStrategy_aaveV3_success(t *testing.T) {
 		CompoundV3GetAPYPromise: func(*helper.Config, cre.Runtime, *big.Int, uint64) cre.Promise[float64] {
 			return cre.PromiseFromResult(0.0, fmt.Errorf("should not be called"))
 		},
-	}
+	}.deps()
 
 	promise := getAPYPromiseFromStrategy(cfg, runtime, strategy, liquidity, deps)
 	require.NotNil(t, promise)
@@ -428,7 +947,7 @@ func Test_getAPYPromiseFromStrategy_compoundV3_success(t *testing.T) {
 	liquidity := big.NewInt(1000)
 
 	var called bool
-	deps := apyPromiseDeps{
+	deps := apyFuncs{
 		AaveV3GetAPYPromise: func(*helper.Config, cre.Runtime, *big.Int, uint64) cre.Promise[float64] {
 			return cre.PromiseFromResult(0.0, fmt.Errorf("should not be called"))
 		},
@@ -436,7 +955,7 @@ func Test_getAPYPromiseFromStrategy_compoundV3_success(t *testing.T) {
 			called = true
 			return cre.PromiseFromResult(0.10, nil)
 		},
-	}
+	}.deps()
 
 	promise := getAPYPromiseFromStrategy(cfg, runtime, strategy, liquidity, deps)
 	require.NotNil(t, promise)
@@ -454,14 +973,14 @@ func Test_getAPYPromiseFromStrategy_aaveV3Error_propagatesError(t *testing.T) {
 	liquidity := big.NewInt(1000)
 
 	expectedErr := fmt.Errorf("aave error")
-	deps := apyPromiseDeps{
+	deps := apyFuncs{
 		AaveV3GetAPYPromise: func(*helper.Config, cre.Runtime, *big.Int, uint64) cre.Promise[float64] {
 			return cre.PromiseFromResult(0.0, expectedErr)
 		},
 		CompoundV3GetAPYPromise: func(*helper.Config, cre.Runtime, *big.Int, uint64) cre.Promise[float64] {
 			return cre.PromiseFromResult(0.0, fmt.Errorf("should not be called"))
 		},
-	}
+	}.deps()
 
 	promise := getAPYPromiseFromStrategy(cfg, runtime, strategy, liquidity, deps)
 	require.NotNil(t, promise)
@@ -478,14 +997,14 @@ func Test_getAPYPromiseFromStrategy_compoundV3Error_propagatesError(t *testing.T
 	liquidity := big.NewInt(1000)
 
 	expectedErr := fmt.Errorf("compound error")
-	deps := apyPromiseDeps{
+	deps := apyFuncs{
 		AaveV3GetAPYPromise: func(*helper.Config, cre.Runtime, *big.Int, uint64) cre.Promise[float64] {
 			return cre.PromiseFromResult(0.0, fmt.Errorf("should not be called"))
 		},
 		CompoundV3GetAPYPromise: func(*helper.Config, cre.Runtime, *big.Int, uint64) cre.Promise[float64] {
 			return cre.PromiseFromResult(0.0, expectedErr)
 		},
-	}
+	}.deps()
 
 	promise := getAPYPromiseFromStrategy(cfg, runtime, strategy, liquidity, deps)
 	require.NotNil(t, promise)
@@ -504,7 +1023,7 @@ func Test_GetOptimalAndCurrentStrategyWithAPY_usesDefaultDeps(t *testing.T) {
 	original := defaultAPYPromiseDeps
 	defer func() { defaultAPYPromiseDeps = original }()
 
-	cfg := setupConfigWithStrategies(t, 1)
+	cfg, strategies := setupConfigWithStrategies(t, 1)
 	runtime := testutils.NewRuntime(t, nil)
 	currentStrategy := Strategy{ProtocolId: AaveV3ProtocolId, ChainSelector: 1}
 	liquidityAdded := big.NewInt(1000)
@@ -516,7 +1035,7 @@ func Test_GetOptimalAndCurrentStrategyWithAPY_usesDefaultDeps(t *testing.T) {
 		gotChain       uint64
 	)
 
-	defaultAPYPromiseDeps = apyPromiseDeps{
+	defaultAPYPromiseDeps = apyFuncs{
 		AaveV3GetAPYPromise: func(c *helper.Config, r cre.Runtime, liq *big.Int, chain uint64) cre.Promise[float64] {
 			calledAave = true
 			require.Same(t, cfg, c)
@@ -533,9 +1052,9 @@ func Test_GetOptimalAndCurrentStrategyWithAPY_usesDefaultDeps(t *testing.T) {
 			requireBigEqual(t, liquidityAdded, liq)
 			return cre.PromiseFromResult(0.05, nil)
 		},
-	}
+	}.deps()
 
-	optimal, current, err := GetOptimalAndCurrentStrategyWithAPY(cfg, runtime, currentStrategy, liquidityAdded)
+	optimal, current, _, err := GetOptimalAndCurrentStrategyWithAPY(cfg, runtime, strategies, currentStrategy, liquidityAdded)
 	require.NoError(t, err)
 	require.True(t, calledAave, "AaveV3GetAPYPromise should be called")
 	require.True(t, calledCompound, "CompoundV3GetAPYPromise should be called")
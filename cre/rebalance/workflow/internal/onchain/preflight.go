@@ -0,0 +1,55 @@
+package onchain
+
+import (
+	"fmt"
+	"math/big"
+
+	"rebalance/contracts/evm/src/generated/child_peer"
+	"rebalance/contracts/evm/src/generated/parent_peer"
+	"rebalance/workflow/internal/helper"
+
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+)
+
+// ReadPreflightState reads everything ParentPeer.rebalance(strategy) and the
+// Rebalancer depend on: the ParentPeer pause state, whether the protocol is
+// supported and the chain allowed, the target peer's strategy adapter and the
+// ParentPeer the Rebalancer forwards to. All reads are issued before any is
+// awaited.
+func ReadPreflightState(
+	config *helper.Config,
+	runtime cre.Runtime,
+	parentPeer ParentPeerInterface,
+	targetPeer StrategyAdapterReaderInterface,
+	rb RebalancerInterface,
+	strategy Strategy,
+) (PreflightState, error) {
+	blockNumber := big.NewInt(config.BlockNumber)
+
+	pausedPromise := parentPeer.Paused(runtime, blockNumber)
+	protocolPromise := parentPeer.GetSupportedProtocol(runtime, parent_peer.GetSupportedProtocolInput{ProtocolId: strategy.ProtocolId}, blockNumber)
+	chainPromise := parentPeer.GetAllowedChain(runtime, parent_peer.GetAllowedChainInput{ChainSelector: strategy.ChainSelector}, blockNumber)
+	adapterPromise := targetPeer.GetStrategyAdapter(runtime, child_peer.GetStrategyAdapterInput{ProtocolId: strategy.ProtocolId}, blockNumber)
+	parentPeerPromise := rb.GetParentPeer(runtime, blockNumber)
+
+	var (
+		state PreflightState
+		err   error
+	)
+	if state.ParentPaused, err = pausedPromise.Await(); err != nil {
+		return PreflightState{}, fmt.Errorf("failed to read ParentPeer paused state: %w", err)
+	}
+	if state.ProtocolSupported, err = protocolPromise.Await(); err != nil {
+		return PreflightState{}, fmt.Errorf("failed to read supported protocol: %w", err)
+	}
+	if state.ChainAllowed, err = chainPromise.Await(); err != nil {
+		return PreflightState{}, fmt.Errorf("failed to read allowed chain: %w", err)
+	}
+	if state.StrategyAdapter, err = adapterPromise.Await(); err != nil {
+		return PreflightState{}, fmt.Errorf("failed to read strategy adapter: %w", err)
+	}
+	if state.RebalancerParentPeer, err = parentPeerPromise.Await(); err != nil {
+		return PreflightState{}, fmt.Errorf("failed to read Rebalancer parent peer: %w", err)
+	}
+	return state, nil
+}
@@ -0,0 +1,104 @@
+package onchain
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"rebalance/contracts/evm/src/generated/child_peer"
+	"rebalance/contracts/evm/src/generated/parent_peer"
+	"rebalance/workflow/internal/helper"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+	"github.com/smartcontractkit/cre-sdk-go/cre/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+/*//////////////////////////////////////////////////////////////
+                             MOCKS
+//////////////////////////////////////////////////////////////*/
+
+// mockStrategyAdapterReader is a mock implementation of StrategyAdapterReaderInterface for testing.
+type mockStrategyAdapterReader struct {
+	getStrategyAdapterFunc func(cre.Runtime, child_peer.GetStrategyAdapterInput, *big.Int) cre.Promise[common.Address]
+}
+
+func (m *mockStrategyAdapterReader) GetStrategyAdapter(
+	runtime cre.Runtime,
+	args child_peer.GetStrategyAdapterInput,
+	blockNumber *big.Int,
+) cre.Promise[common.Address] {
+	if m.getStrategyAdapterFunc != nil {
+		return m.getStrategyAdapterFunc(runtime, args, blockNumber)
+	}
+	return cre.PromiseFromResult(common.Address{}, errors.New("getStrategyAdapterFunc not set"))
+}
+
+// newPreflightMocks returns mocks reporting a healthy, unpaused system.
+func newPreflightMocks(strategy Strategy, adapter, parent common.Address) (*mockParentPeer, *mockStrategyAdapterReader, *mockRebalancer) {
+	parentPeer := &mockParentPeer{
+		pausedFunc: func(cre.Runtime, *big.Int) cre.Promise[bool] {
+			return cre.PromiseFromResult(false, nil)
+		},
+		getSupportedProtocolFunc: func(_ cre.Runtime, args parent_peer.GetSupportedProtocolInput, _ *big.Int) cre.Promise[bool] {
+			return cre.PromiseFromResult(args.ProtocolId == strategy.ProtocolId, nil)
+		},
+		getAllowedChainFunc: func(_ cre.Runtime, args parent_peer.GetAllowedChainInput, _ *big.Int) cre.Promise[bool] {
+			return cre.PromiseFromResult(args.ChainSelector == strategy.ChainSelector, nil)
+		},
+	}
+	targetPeer := &mockStrategyAdapterReader{
+		getStrategyAdapterFunc: func(_ cre.Runtime, args child_peer.GetStrategyAdapterInput, _ *big.Int) cre.Promise[common.Address] {
+			if args.ProtocolId != strategy.ProtocolId {
+				return cre.PromiseFromResult(common.Address{}, nil)
+			}
+			return cre.PromiseFromResult(adapter, nil)
+		},
+	}
+	rb := &mockRebalancer{
+		getParentPeerFunc: func(cre.Runtime, *big.Int) cre.Promise[common.Address] {
+			return cre.PromiseFromResult(parent, nil)
+		},
+	}
+	return parentPeer, targetPeer, rb
+}
+
+/*//////////////////////////////////////////////////////////////
+                             TESTS
+//////////////////////////////////////////////////////////////*/
+
+func Test_ReadPreflightState_success(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+	strategy := Strategy{ProtocolId: AaveV3ProtocolId, ChainSelector: 7}
+	adapter := common.HexToAddress("0x01")
+	parent := common.HexToAddress("0x02")
+
+	parentPeer, targetPeer, rb := newPreflightMocks(strategy, adapter, parent)
+
+	state, err := ReadPreflightState(&helper.Config{}, runtime, parentPeer, targetPeer, rb, strategy)
+	require.NoError(t, err)
+	require.Equal(t, PreflightState{
+		ParentPaused:         false,
+		ProtocolSupported:    true,
+		ChainAllowed:         true,
+		StrategyAdapter:      adapter,
+		RebalancerParentPeer: parent,
+	}, state)
+}
+
+func Test_ReadPreflightState_errorWhen_readFails(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+	strategy := Strategy{ProtocolId: AaveV3ProtocolId, ChainSelector: 7}
+	expectedError := errors.New("call failed")
+
+	parentPeer, targetPeer, rb := newPreflightMocks(strategy, common.Address{}, common.Address{})
+	targetPeer.getStrategyAdapterFunc = func(cre.Runtime, child_peer.GetStrategyAdapterInput, *big.Int) cre.Promise[common.Address] {
+		return cre.PromiseFromResult(common.Address{}, expectedError)
+	}
+
+	state, err := ReadPreflightState(&helper.Config{}, runtime, parentPeer, targetPeer, rb, strategy)
+	require.ErrorIs(t, err, expectedError)
+	require.Contains(t, err.Error(), "failed to read strategy adapter")
+	require.Equal(t, PreflightState{}, state)
+}
@@ -0,0 +1,9 @@
+package onchain
+
+import (
+	// Yield sources register themselves with protocol.Default on import.
+	_ "rebalance/workflow/internal/aaveV3"
+	_ "rebalance/workflow/internal/compoundV3"
+	_ "rebalance/workflow/internal/erc4626"
+	_ "rebalance/workflow/internal/morpho"
+)
@@ -20,4 +20,9 @@ func ReadCurrentStrategy(config *helper.Config, runtime cre.Runtime, peer Parent
 // ReadTVL reads the total value locked from a yield peer using the runtime
 func ReadTVL(config *helper.Config, runtime cre.Runtime, peer YieldPeerInterface) (*big.Int, error) {
 	return peer.GetTotalValue(runtime, big.NewInt(config.BlockNumber)).Await()
-}
\ No newline at end of file
+}
+
+// ReadTotalShares reads the total share supply from the parent peer using the runtime
+func ReadTotalShares(config *helper.Config, runtime cre.Runtime, peer ParentPeerInterface) (*big.Int, error) {
+	return peer.GetTotalShares(runtime, big.NewInt(config.BlockNumber)).Await()
+}
@@ -9,6 +9,8 @@ import (
 
 	"rebalance/workflow/internal/helper"
 
+	"github.com/smartcontractkit/cre-sdk-go/capabilities/blockchain/evm"
+	"github.com/smartcontractkit/cre-sdk-go/capabilities/blockchain/evm/bindings"
 	"github.com/smartcontractkit/cre-sdk-go/cre"
 	"github.com/smartcontractkit/cre-sdk-go/cre/testutils"
 	"github.com/stretchr/testify/require"
@@ -20,8 +22,13 @@ import (
 
 // mockParentPeer is a mock implementation of ParentPeerInterface for testing.
 type mockParentPeer struct {
-	getStrategyFunc   func(cre.Runtime, *big.Int) cre.Promise[parent_peer.IYieldPeerStrategy]
-	getTotalValueFunc func(cre.Runtime, *big.Int) cre.Promise[*big.Int]
+	getStrategyFunc               func(cre.Runtime, *big.Int) cre.Promise[parent_peer.IYieldPeerStrategy]
+	getTotalValueFunc             func(cre.Runtime, *big.Int) cre.Promise[*big.Int]
+	getTotalSharesFunc            func(cre.Runtime, *big.Int) cre.Promise[*big.Int]
+	filterLogsStrategyUpdatedFunc func(cre.Runtime, *bindings.FilterOptions) (cre.Promise[*evm.FilterLogsReply], error)
+	getSupportedProtocolFunc      func(cre.Runtime, parent_peer.GetSupportedProtocolInput, *big.Int) cre.Promise[bool]
+	getAllowedChainFunc           func(cre.Runtime, parent_peer.GetAllowedChainInput, *big.Int) cre.Promise[bool]
+	pausedFunc                    func(cre.Runtime, *big.Int) cre.Promise[bool]
 }
 
 func (m *mockParentPeer) GetStrategy(
@@ -47,6 +54,58 @@ func (m *mockParentPeer) GetTotalValue(
 	return cre.PromiseFromResult[*big.Int](nil, errors.New("getTotalValueFunc not set"))
 }
 
+func (m *mockParentPeer) GetTotalShares(
+	runtime cre.Runtime,
+	blockNumber *big.Int,
+) cre.Promise[*big.Int] {
+	if m.getTotalSharesFunc != nil {
+		return m.getTotalSharesFunc(runtime, blockNumber)
+	}
+	return cre.PromiseFromResult[*big.Int](nil, errors.New("getTotalSharesFunc not set"))
+}
+
+func (m *mockParentPeer) FilterLogsStrategyUpdated(
+	runtime cre.Runtime,
+	options *bindings.FilterOptions,
+) (cre.Promise[*evm.FilterLogsReply], error) {
+	if m.filterLogsStrategyUpdatedFunc != nil {
+		return m.filterLogsStrategyUpdatedFunc(runtime, options)
+	}
+	return nil, errors.New("filterLogsStrategyUpdatedFunc not set")
+}
+
+func (m *mockParentPeer) GetSupportedProtocol(
+	runtime cre.Runtime,
+	args parent_peer.GetSupportedProtocolInput,
+	blockNumber *big.Int,
+) cre.Promise[bool] {
+	if m.getSupportedProtocolFunc != nil {
+		return m.getSupportedProtocolFunc(runtime, args, blockNumber)
+	}
+	return cre.PromiseFromResult(false, errors.New("getSupportedProtocolFunc not set"))
+}
+
+func (m *mockParentPeer) GetAllowedChain(
+	runtime cre.Runtime,
+	args parent_peer.GetAllowedChainInput,
+	blockNumber *big.Int,
+) cre.Promise[bool] {
+	if m.getAllowedChainFunc != nil {
+		return m.getAllowedChainFunc(runtime, args, blockNumber)
+	}
+	return cre.PromiseFromResult(false, errors.New("getAllowedChainFunc not set"))
+}
+
+func (m *mockParentPeer) Paused(
+	runtime cre.Runtime,
+	blockNumber *big.Int,
+) cre.Promise[bool] {
+	if m.pausedFunc != nil {
+		return m.pausedFunc(runtime, blockNumber)
+	}
+	return cre.PromiseFromResult(false, errors.New("pausedFunc not set"))
+}
+
 // mockYieldPeer is a mock implementation of YieldPeerInterface for testing.
 type mockYieldPeer struct {
 	getTotalValueFunc func(cre.Runtime, *big.Int) cre.Promise[*big.Int]
@@ -217,3 +276,36 @@ func Test_ReadTVL_withZeroValue(t *testing.T) {
 	require.NotNil(t, tvl)
 	require.Equal(t, 0, expectedTVL.Cmp(tvl))
 }
+
+func Test_ReadTotalShares_success(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+	config := &helper.Config{BlockNumber: 12345}
+
+	expectedShares := new(big.Int).Exp(big.NewInt(10), big.NewInt(24), nil)
+
+	mockPeer := &mockParentPeer{
+		getTotalSharesFunc: func(_ cre.Runtime, blockNumber *big.Int) cre.Promise[*big.Int] {
+			require.Equal(t, 0, big.NewInt(config.BlockNumber).Cmp(blockNumber), "expected BlockNumber from config")
+			return cre.PromiseFromResult(expectedShares, nil)
+		},
+	}
+
+	shares, err := ReadTotalShares(config, runtime, mockPeer)
+	require.NoError(t, err)
+	require.Equal(t, 0, expectedShares.Cmp(shares))
+}
+
+func Test_ReadTotalShares_error(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+	expectedError := errors.New("failed to read shares")
+
+	mockPeer := &mockParentPeer{
+		getTotalSharesFunc: func(_ cre.Runtime, _ *big.Int) cre.Promise[*big.Int] {
+			return cre.PromiseFromResult[*big.Int](nil, expectedError)
+		},
+	}
+
+	shares, err := ReadTotalShares(&helper.Config{}, runtime, mockPeer)
+	require.ErrorIs(t, err, expectedError)
+	require.Nil(t, shares)
+}
@@ -2,43 +2,149 @@ package onchain
 
 import (
 	"fmt"
+
 	"rebalance/workflow/internal/helper"
+	"rebalance/workflow/internal/protocol"
 )
 
-const numberOfProtocols = 2 // @review this needs to be updated when new protocols are added
-
-var supportedStrategies []Strategy
-var initialized bool
-
-// InitSupportedStrategies builds the cross-product of
-//   all configured chains × all hardcoded protocols.
-// Call this once at startup, after config is loaded.
-func InitSupportedStrategies(cfg *helper.Config) error {
-    if initialized {
-        return fmt.Errorf("InitSupportedStrategies called more than once")
-    }
-    initialized = true
-
-    // pre-allocate capacity for less GC.
-    supportedStrategies = make([]Strategy, 0, len(cfg.Evms)*numberOfProtocols)
-
-    for _, evm := range cfg.Evms {
-        if evm.AaveV3PoolAddressesProviderAddress != "" {
-            // AaveV3 on this chain
-            supportedStrategies = append(supportedStrategies, Strategy{
-                ProtocolId:    AaveV3ProtocolId,
-                ChainSelector: evm.ChainSelector,
-            })
-        }
-
-        if evm.CompoundV3CometUSDCAddress != "" {
-            // CompoundV3 on this chain
-            supportedStrategies = append(supportedStrategies, Strategy{
-                ProtocolId:    CompoundV3ProtocolId,
-                ChainSelector: evm.ChainSelector,
-            })
-        }
-    }
-
-    return nil
+// StrategySet is the set of strategies a run may evaluate and rebalance to.
+//
+// It is an immutable value built from config for each run, so handlers can run
+// any number of times in one process. Strategies keep a deterministic order:
+// chains in config order, and on each chain protocols ordered by name.
+type StrategySet struct {
+	strategies []Strategy
+	protocols  *protocol.Registry // names the strategies' protocols
+}
+
+// NewStrategySet builds the cross-product of
+//
+//	all configured chains × the registered protocols configured on each chain
+//
+// for the vault asset; chains without the vault asset are skipped.
+//
+// A chain selector configured twice is an error.
+func NewStrategySet(cfg *helper.Config) (StrategySet, error) {
+	protocols, err := protocol.Default.ForConfig(cfg)
+	if err != nil {
+		return StrategySet{}, err
+	}
+	return newStrategySet(cfg, protocols)
+}
+
+func newStrategySet(cfg *helper.Config, protocols *protocol.Registry) (StrategySet, error) {
+	var strategies []Strategy
+	seen := make(map[uint64]bool, len(cfg.Evms))
+
+	for _, evm := range cfg.Evms {
+		if seen[evm.ChainSelector] {
+			return StrategySet{}, fmt.Errorf("chainSelector %d is configured more than once", evm.ChainSelector)
+		}
+		seen[evm.ChainSelector] = true
+
+		// A chain without the vault asset has nothing to allocate to.
+		asset, ok := cfg.VaultAsset(evm)
+		if !ok {
+			continue
+		}
+
+		for _, p := range protocols.All() {
+			if p.IsConfigured(evm, asset) {
+				strategies = append(strategies, Strategy{
+					ProtocolId:    p.ID(),
+					ChainSelector: evm.ChainSelector,
+				})
+			}
+		}
+	}
+
+	return StrategySet{strategies: strategies, protocols: protocols}, nil
+}
+
+// NewStrategySetOf returns a set of the given strategies in the given order,
+// dropping duplicates. It has no registry, so it names no protocol.
+func NewStrategySetOf(strategies ...Strategy) StrategySet {
+	set := StrategySet{strategies: make([]Strategy, 0, len(strategies))}
+	for _, s := range strategies {
+		if !set.Contains(s) {
+			set.strategies = append(set.strategies, s)
+		}
+	}
+	return set
+}
+
+// Strategies returns the strategies in set order. The slice is a copy.
+func (s StrategySet) Strategies() []Strategy {
+	return append([]Strategy(nil), s.strategies...)
+}
+
+// Len returns the number of strategies in the set.
+func (s StrategySet) Len() int {
+	return len(s.strategies)
+}
+
+// Contains reports whether strategy is in the set.
+func (s StrategySet) Contains(strategy Strategy) bool {
+	_, ok := s.Lookup(strategy.ProtocolId, strategy.ChainSelector)
+	return ok
+}
+
+// Lookup returns the strategy for protocolId on chainSelector, if the set has it.
+func (s StrategySet) Lookup(protocolId [32]byte, chainSelector uint64) (Strategy, bool) {
+	for _, strategy := range s.strategies {
+		if strategy.ProtocolId == protocolId && strategy.ChainSelector == chainSelector {
+			return strategy, true
+		}
+	}
+	return Strategy{}, false
+}
+
+// Filter returns the strategies keep reports true for, in set order.
+func (s StrategySet) Filter(keep func(Strategy) bool) StrategySet {
+	var filtered []Strategy
+	for _, strategy := range s.strategies {
+		if keep(strategy) {
+			filtered = append(filtered, strategy)
+		}
+	}
+	return StrategySet{strategies: filtered, protocols: s.protocols}
+}
+
+// OnChain returns the strategies on chainSelector.
+func (s StrategySet) OnChain(chainSelector uint64) StrategySet {
+	return s.Filter(func(strategy Strategy) bool { return strategy.ChainSelector == chainSelector })
+}
+
+// ForProtocol returns the strategies of protocolId, on every chain.
+func (s StrategySet) ForProtocol(protocolId [32]byte) StrategySet {
+	return s.Filter(func(strategy Strategy) bool { return strategy.ProtocolId == protocolId })
+}
+
+// ProtocolName returns the name of strategy's protocol in the registry the set
+// was built from, so protocols defined in config are named too.
+func (s StrategySet) ProtocolName(strategy Strategy) string {
+	return s.protocols.Name(strategy.ProtocolId)
+}
+
+// Named returns strategy with its protocol name (see ProtocolName).
+func (s StrategySet) Named(strategy Strategy) NamedStrategy {
+	return NamedStrategy{Strategy: strategy, Protocol: s.ProtocolName(strategy)}
+}
+
+// Resolve returns the strategy named describes. A protocol name is looked up in
+// the registry the set was built from, so protocols defined in config resolve
+// too; without one, the protocol ID is used as is. A name and an ID that
+// disagree are an error.
+func (s StrategySet) Resolve(named NamedStrategy) (Strategy, error) {
+	if named.Protocol == "" {
+		return named.Strategy, nil
+	}
+	p, ok := s.protocols.Lookup(protocol.IDFromName(named.Protocol))
+	if !ok {
+		return Strategy{}, fmt.Errorf("unknown protocol %q", named.Protocol)
+	}
+	if named.ProtocolId != ([32]byte{}) && named.ProtocolId != p.ID() {
+		return Strategy{}, fmt.Errorf("protocol %q does not match protocolId 0x%x", named.Protocol, named.ProtocolId)
+	}
+	return Strategy{ProtocolId: p.ID(), ChainSelector: named.ChainSelector}, nil
 }
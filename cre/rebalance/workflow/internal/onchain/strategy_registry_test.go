@@ -4,228 +4,309 @@ import (
 	"testing"
 
 	"rebalance/workflow/internal/helper"
+	"rebalance/workflow/internal/protocol"
 
 	"github.com/stretchr/testify/require"
 )
 
-// resetState resets the package-level state for testing.
-// This allows tests to run independently.
-func resetState() {
-	initialized = false
-	supportedStrategies = nil
-}
-
-func Test_InitSupportedStrategies_noEvms(t *testing.T) {
-	resetState()
+func Test_NewStrategySet_noEvms(t *testing.T) {
 	cfg := &helper.Config{
 		Evms: nil,
 	}
 
-	err := InitSupportedStrategies(cfg)
+	set, err := NewStrategySet(cfg)
 	require.NoError(t, err, "expected no error when no EVMs are configured")
-	require.Len(t, supportedStrategies, 0, "expected 0 supported strategies when no EVMs are configured")
-}
-
-func Test_InitSupportedStrategies_emptyEvms(t *testing.T) {
-	resetState()
-	cfg := &helper.Config{
-		Evms: []helper.EvmConfig{},
-	}
-
-	err := InitSupportedStrategies(cfg)
-	require.NoError(t, err, "expected no error when EVMs slice is empty")
-	require.Len(t, supportedStrategies, 0, "expected 0 supported strategies when EVMs slice is empty")
+	require.Zero(t, set.Len(), "expected 0 strategies when no EVMs are configured")
 }
 
-func Test_InitSupportedStrategies_noAddresses(t *testing.T) {
-	resetState()
+func Test_NewStrategySet_noAddresses(t *testing.T) {
 	cfg := &helper.Config{
 		Evms: []helper.EvmConfig{
 			{ChainSelector: 1111},
 		},
 	}
 
-	err := InitSupportedStrategies(cfg)
+	set, err := NewStrategySet(cfg)
 	require.NoError(t, err, "expected no error when addresses are not set")
-	require.Len(t, supportedStrategies, 0, "expected 0 supported strategies when no addresses are configured")
+	require.Zero(t, set.Len(), "expected 0 strategies when no addresses are configured")
 }
 
-func Test_InitSupportedStrategies_onlyAaveV3(t *testing.T) {
-	resetState()
+func Test_NewStrategySet_onlyAaveV3(t *testing.T) {
 	cfg := &helper.Config{
 		Evms: []helper.EvmConfig{
 			{
-				ChainSelector:                        1111,
+				ChainSelector:                      1111,
 				AaveV3PoolAddressesProviderAddress: "0xaave",
 			},
 		},
 	}
 
-	err := InitSupportedStrategies(cfg)
+	set, err := NewStrategySet(cfg)
 	require.NoError(t, err)
-	require.Len(t, supportedStrategies, 1, "expected 1 strategy when only AaveV3 is configured")
-	require.Equal(t, AaveV3ProtocolId, supportedStrategies[0].ProtocolId)
-	require.Equal(t, uint64(1111), supportedStrategies[0].ChainSelector)
+	require.Equal(t, []Strategy{{ProtocolId: AaveV3ProtocolId, ChainSelector: 1111}}, set.Strategies())
 }
 
-func Test_InitSupportedStrategies_onlyCompoundV3(t *testing.T) {
-	resetState()
+func Test_NewStrategySet_onlyCompoundV3(t *testing.T) {
 	cfg := &helper.Config{
 		Evms: []helper.EvmConfig{
 			{
-				ChainSelector:                 2222,
+				ChainSelector:              2222,
 				CompoundV3CometUSDCAddress: "0xcompound",
 			},
 		},
 	}
 
-	err := InitSupportedStrategies(cfg)
+	set, err := NewStrategySet(cfg)
 	require.NoError(t, err)
-	require.Len(t, supportedStrategies, 1, "expected 1 strategy when only CompoundV3 is configured")
-	require.Equal(t, CompoundV3ProtocolId, supportedStrategies[0].ProtocolId)
-	require.Equal(t, uint64(2222), supportedStrategies[0].ChainSelector)
+	require.Equal(t, []Strategy{{ProtocolId: CompoundV3ProtocolId, ChainSelector: 2222}}, set.Strategies())
 }
 
-func Test_InitSupportedStrategies_bothProtocols(t *testing.T) {
-	resetState()
+func Test_NewStrategySet_mixedConfigurations(t *testing.T) {
 	cfg := &helper.Config{
 		Evms: []helper.EvmConfig{
 			{
-				ChainSelector:                        1111,
+				ChainSelector:                      1111,
 				AaveV3PoolAddressesProviderAddress: "0xaave",
-				CompoundV3CometUSDCAddress:         "0xcompound",
+				// No CompoundV3
+			},
+			{
+				ChainSelector:              2222,
+				CompoundV3CometUSDCAddress: "0xcompound",
+				// No AaveV3
+			},
+			{
+				ChainSelector: 3333,
+				// No protocols
+			},
+			{
+				ChainSelector:                      4444,
+				AaveV3PoolAddressesProviderAddress: "0xaave4",
+				CompoundV3CometUSDCAddress:         "0xcompound4",
 			},
 		},
 	}
 
-	err := InitSupportedStrategies(cfg)
+	set, err := NewStrategySet(cfg)
 	require.NoError(t, err)
-	require.Len(t, supportedStrategies, 2, "expected 2 strategies when both protocols are configured")
 
+	// Chains in config order, AaveV3 before CompoundV3 on each chain.
 	expected := []Strategy{
-		{
-			ProtocolId:    AaveV3ProtocolId,
-			ChainSelector: 1111,
-		},
-		{
-			ProtocolId:    CompoundV3ProtocolId,
-			ChainSelector: 1111,
-		},
+		{ProtocolId: AaveV3ProtocolId, ChainSelector: 1111},
+		{ProtocolId: CompoundV3ProtocolId, ChainSelector: 2222},
+		{ProtocolId: AaveV3ProtocolId, ChainSelector: 4444},
+		{ProtocolId: CompoundV3ProtocolId, ChainSelector: 4444},
 	}
-	require.Equal(t, expected, supportedStrategies)
+	require.Equal(t, expected, set.Strategies())
 }
 
-func Test_InitSupportedStrategies_multipleChains(t *testing.T) {
-	resetState()
+func Test_NewStrategySet_canBeBuiltRepeatedly(t *testing.T) {
 	cfg := &helper.Config{
 		Evms: []helper.EvmConfig{
-			{
-				ChainSelector:                        1111,
-				AaveV3PoolAddressesProviderAddress: "0xaave1",
-				CompoundV3CometUSDCAddress:         "0xcompound1",
-			},
-			{
-				ChainSelector:                        2222,
-				AaveV3PoolAddressesProviderAddress: "0xaave2",
-				CompoundV3CometUSDCAddress:         "0xcompound2",
-			},
+			{ChainSelector: 1111, AaveV3PoolAddressesProviderAddress: "0xaave"},
 		},
 	}
 
-	err := InitSupportedStrategies(cfg)
+	first, err := NewStrategySet(cfg)
+	require.NoError(t, err)
+	second, err := NewStrategySet(cfg)
 	require.NoError(t, err)
-	require.Len(t, supportedStrategies, 4, "expected 4 strategies (2 protocols × 2 chains)")
 
-	expected := []Strategy{
-		{
-			ProtocolId:    AaveV3ProtocolId,
-			ChainSelector: 1111,
-		},
-		{
-			ProtocolId:    CompoundV3ProtocolId,
-			ChainSelector: 1111,
-		},
-		{
-			ProtocolId:    AaveV3ProtocolId,
-			ChainSelector: 2222,
-		},
-		{
-			ProtocolId:    CompoundV3ProtocolId,
-			ChainSelector: 2222,
+	require.Equal(t, first, second)
+}
+
+func Test_NewStrategySet_errorWhenChainConfiguredTwice(t *testing.T) {
+	cfg := &helper.Config{
+		Evms: []helper.EvmConfig{
+			{ChainSelector: 1111, AaveV3PoolAddressesProviderAddress: "0xaave"},
+			{ChainSelector: 1111, CompoundV3CometUSDCAddress: "0xcompound"},
 		},
 	}
-	require.Equal(t, expected, supportedStrategies)
+
+	_, err := NewStrategySet(cfg)
+	require.ErrorContains(t, err, "chainSelector 1111 is configured more than once")
+}
+
+func Test_StrategySet_lookupAndFilter(t *testing.T) {
+	aave1 := Strategy{ProtocolId: AaveV3ProtocolId, ChainSelector: 1111}
+	comp1 := Strategy{ProtocolId: CompoundV3ProtocolId, ChainSelector: 1111}
+	aave2 := Strategy{ProtocolId: AaveV3ProtocolId, ChainSelector: 2222}
+	set := NewStrategySetOf(aave1, comp1, aave2, aave1)
+
+	require.Equal(t, 3, set.Len(), "duplicates are dropped")
+	require.True(t, set.Contains(comp1))
+	require.False(t, set.Contains(Strategy{ProtocolId: CompoundV3ProtocolId, ChainSelector: 2222}))
+
+	got, ok := set.Lookup(AaveV3ProtocolId, 2222)
+	require.True(t, ok)
+	require.Equal(t, aave2, got)
+	_, ok = set.Lookup(CompoundV3ProtocolId, 2222)
+	require.False(t, ok)
+
+	require.Equal(t, []Strategy{aave1, comp1}, set.OnChain(1111).Strategies())
+	require.Equal(t, []Strategy{aave1, aave2}, set.ForProtocol(AaveV3ProtocolId).Strategies())
+	require.Equal(t, []Strategy{comp1}, set.Filter(func(s Strategy) bool { return s == comp1 }).Strategies())
+}
+
+func Test_StrategySet_strategiesReturnsCopy(t *testing.T) {
+	set := NewStrategySetOf(Strategy{ProtocolId: AaveV3ProtocolId, ChainSelector: 1111})
+
+	strategies := set.Strategies()
+	strategies[0].ChainSelector = 9999
+
+	require.True(t, set.Contains(Strategy{ProtocolId: AaveV3ProtocolId, ChainSelector: 1111}))
+}
+
+func Test_registeredProtocols_matchProtocolIds(t *testing.T) {
+	aave, ok := protocol.Default.Lookup(AaveV3ProtocolId)
+	require.True(t, ok, "aave-v3 must be registered")
+	require.Equal(t, "aave-v3", aave.Name())
+
+	compound, ok := protocol.Default.Lookup(CompoundV3ProtocolId)
+	require.True(t, ok, "compound-v3 must be registered")
+	require.Equal(t, "compound-v3", compound.Name())
+
+	morpho, ok := protocol.Default.Lookup(protocol.IDFromName("morpho"))
+	require.True(t, ok, "morpho must be registered")
+	require.Equal(t, "morpho", morpho.Name())
+}
+
+func Test_newStrategySet_usesRegisteredProtocols(t *testing.T) {
+	registry := protocol.NewRegistry()
+	extra := mockProtocol{id: protocol.IDFromName("extra"), name: "extra"}
+	require.NoError(t, registry.Register(extra))
+
+	set, err := newStrategySet(&helper.Config{Evms: []helper.EvmConfig{{ChainSelector: 1111}}}, registry)
+	require.NoError(t, err)
+	require.Equal(t, []Strategy{{ProtocolId: extra.id, ChainSelector: 1111}}, set.Strategies())
 }
 
-func Test_InitSupportedStrategies_mixedConfigurations(t *testing.T) {
-	resetState()
+func Test_NewStrategySet_includesConfiguredErc4626Vaults(t *testing.T) {
 	cfg := &helper.Config{
 		Evms: []helper.EvmConfig{
 			{
-				ChainSelector:                        1111,
+				ChainSelector:                      1111,
 				AaveV3PoolAddressesProviderAddress: "0xaave",
-				// No CompoundV3
-			},
-			{
-				ChainSelector:                 2222,
-				CompoundV3CometUSDCAddress: "0xcompound",
-				// No AaveV3
+				Erc4626Vaults: []helper.Erc4626VaultConfig{
+					{Name: "susds", Address: "0x00000000000000000000000000000000000000aa", LookbackBlocks: 7200},
+				},
 			},
+		},
+	}
+
+	set, err := NewStrategySet(cfg)
+	require.NoError(t, err)
+	susds := Strategy{ProtocolId: protocol.IDFromName("susds"), ChainSelector: 1111}
+	require.Equal(t, []Strategy{{ProtocolId: AaveV3ProtocolId, ChainSelector: 1111}, susds}, set.Strategies())
+	require.Equal(t, "susds", set.ProtocolName(susds))
+
+	cfg.Evms[0].Erc4626Vaults[0].Address = "nope"
+	_, err = NewStrategySet(cfg)
+	require.ErrorContains(t, err, "protocol family erc4626")
+}
+
+func Test_NewStrategySet_includesConfiguredAaveMarkets(t *testing.T) {
+	cfg := &helper.Config{
+		Evms: []helper.EvmConfig{
 			{
-				ChainSelector: 3333,
-				// No protocols
+				ChainSelector:                      1111,
+				AaveV3PoolAddressesProviderAddress: "0xaave",
+				AaveMarkets: []helper.AaveMarketConfig{
+					{Name: "spark", PoolAddressesProviderAddress: "0x00000000000000000000000000000000000000bb"},
+				},
 			},
+		},
+	}
+
+	set, err := NewStrategySet(cfg)
+	require.NoError(t, err)
+	spark := Strategy{ProtocolId: protocol.IDFromName("spark"), ChainSelector: 1111}
+	require.Equal(t, []Strategy{{ProtocolId: AaveV3ProtocolId, ChainSelector: 1111}, spark}, set.Strategies())
+	require.Equal(t, "spark", set.ProtocolName(spark))
+}
+
+func Test_StrategySet_resolve(t *testing.T) {
+	cfg := &helper.Config{
+		Evms: []helper.EvmConfig{
 			{
-				ChainSelector:                        4444,
-				AaveV3PoolAddressesProviderAddress: "0xaave4",
-				CompoundV3CometUSDCAddress:         "0xcompound4",
+				ChainSelector:                      1111,
+				AaveV3PoolAddressesProviderAddress: "0xaave",
+				AaveMarkets: []helper.AaveMarketConfig{
+					{Name: "spark", PoolAddressesProviderAddress: "0x00000000000000000000000000000000000000bb"},
+				},
 			},
 		},
 	}
+	set, err := NewStrategySet(cfg)
+	require.NoError(t, err)
+	spark := Strategy{ProtocolId: protocol.IDFromName("spark"), ChainSelector: 1111}
+
+	resolved, err := set.Resolve(NamedStrategy{Strategy: Strategy{ChainSelector: 1111}, Protocol: "spark"})
+	require.NoError(t, err, "a protocol defined in config resolves by name")
+	require.Equal(t, spark, resolved)
 
-	err := InitSupportedStrategies(cfg)
+	resolved, err = set.Resolve(NamedStrategy{Strategy: spark, Protocol: "spark"})
 	require.NoError(t, err)
-	require.Len(t, supportedStrategies, 4, "expected 4 strategies (1 + 1 + 0 + 2)")
+	require.Equal(t, spark, resolved)
 
-	expected := []Strategy{
-		{
-			ProtocolId:    AaveV3ProtocolId,
-			ChainSelector: 1111,
-		},
-		{
-			ProtocolId:    CompoundV3ProtocolId,
-			ChainSelector: 2222,
-		},
-		{
-			ProtocolId:    AaveV3ProtocolId,
-			ChainSelector: 4444,
-		},
-		{
-			ProtocolId:    CompoundV3ProtocolId,
-			ChainSelector: 4444,
+	resolved, err = set.Resolve(NamedStrategy{Strategy: spark})
+	require.NoError(t, err, "without a name the ID is used as is")
+	require.Equal(t, spark, resolved)
+
+	_, err = set.Resolve(NamedStrategy{Strategy: Strategy{ChainSelector: 1111}, Protocol: "prime"})
+	require.ErrorContains(t, err, `unknown protocol "prime"`)
+
+	_, err = set.Resolve(NamedStrategy{Strategy: Strategy{ProtocolId: AaveV3ProtocolId, ChainSelector: 1111}, Protocol: "spark"})
+	require.ErrorContains(t, err, `protocol "spark" does not match protocolId`)
+}
+
+func Test_NewStrategySet_includesConfiguredCompoundV3Markets(t *testing.T) {
+	cfg := &helper.Config{
+		Asset: "USDT",
+		Evms: []helper.EvmConfig{
+			{
+				ChainSelector:              1111,
+				CompoundV3CometUSDCAddress: "0xcomet",
+				Assets: []helper.AssetConfig{
+					{Symbol: "USDT", Address: "0x00000000000000000000000000000000000000dd", Decimals: 6},
+				},
+				CompoundV3Markets: []helper.CompoundV3MarketConfig{
+					{
+						Name:         "compound-v3-usdt",
+						CometAddress: "0x00000000000000000000000000000000000000cc",
+						BaseAsset:    "0x00000000000000000000000000000000000000dd",
+					},
+				},
+			},
 		},
 	}
-	require.Equal(t, expected, supportedStrategies)
+
+	set, err := NewStrategySet(cfg)
+	require.NoError(t, err)
+	usdt := Strategy{ProtocolId: protocol.IDFromName("compound-v3-usdt"), ChainSelector: 1111}
+	require.Equal(t, []Strategy{usdt}, set.Strategies(), "the USDC Comet is not a candidate for a USDT vault")
+	require.Equal(t, "compound-v3-usdt", set.ProtocolName(usdt))
 }
 
-func Test_InitSupportedStrategies_doubleCallError(t *testing.T) {
-	resetState()
+func Test_NewStrategySet_skipsChainsWithoutVaultAsset(t *testing.T) {
 	cfg := &helper.Config{
+		Asset: "DAI",
 		Evms: []helper.EvmConfig{
 			{
-				ChainSelector:                        1111,
+				ChainSelector:                      1111,
+				AaveV3PoolAddressesProviderAddress: "0xaave",
+				Assets: []helper.AssetConfig{
+					{Symbol: "DAI", Address: "0x00000000000000000000000000000000000000da", Decimals: 18},
+				},
+			},
+			{
+				ChainSelector:                      2222,
 				AaveV3PoolAddressesProviderAddress: "0xaave",
+				MorphoVaultAddress:                 "0xmorpho",
 			},
 		},
 	}
 
-	err := InitSupportedStrategies(cfg)
-	require.NoError(t, err, "first call should succeed")
-	require.Len(t, supportedStrategies, 1, "first call should initialize strategies")
-
-	// Second call should fail
-	err = InitSupportedStrategies(cfg)
-	require.Error(t, err, "second call should return an error")
-	require.ErrorContains(t, err, "InitSupportedStrategies called more than once")
-	require.Len(t, supportedStrategies, 1, "second call should not modify strategies")
+	set, err := NewStrategySet(cfg)
+	require.NoError(t, err)
+	require.Equal(t, []Strategy{{ProtocolId: AaveV3ProtocolId, ChainSelector: 1111}}, set.Strategies())
 }
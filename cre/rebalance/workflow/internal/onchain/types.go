@@ -1,12 +1,148 @@
 package onchain
 
+import (
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
 // Strategy represents a yield strategy configuration
 type Strategy struct {
 	ProtocolId    [32]byte
 	ChainSelector uint64
 }
 
+// strategyJSON is the JSON form of Strategy and NamedStrategy: the protocol ID
+// is hex-encoded and, for a NamedStrategy, accompanied by its human-readable
+// name.
+type strategyJSON struct {
+	Protocol      string      `json:"protocol,omitempty"`
+	ProtocolId    common.Hash `json:"protocolId"`
+	ChainSelector uint64      `json:"chainSelector"`
+}
+
+// MarshalJSON encodes the strategy with a hex protocol ID. A Strategy does not
+// know its protocol's name; see NamedStrategy.
+func (s Strategy) MarshalJSON() ([]byte, error) {
+	return json.Marshal(strategyJSON{
+		ProtocolId:    s.ProtocolId,
+		ChainSelector: s.ChainSelector,
+	})
+}
+
+// UnmarshalJSON decodes a strategy encoded by MarshalJSON. A protocol name, if
+// present, is ignored; the protocol ID is authoritative.
+func (s *Strategy) UnmarshalJSON(data []byte) error {
+	var decoded strategyJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	s.ProtocolId = decoded.ProtocolId
+	s.ChainSelector = decoded.ChainSelector
+	return nil
+}
+
+// NamedStrategy is a strategy with the name of its protocol (e.g. "aave-v3")
+// as the run's StrategySet names it. Results report strategies in this form.
+type NamedStrategy struct {
+	Strategy
+	Protocol string
+}
+
+// MarshalJSON encodes the strategy with its readable protocol name.
+func (s NamedStrategy) MarshalJSON() ([]byte, error) {
+	return json.Marshal(strategyJSON{
+		Protocol:      s.Protocol,
+		ProtocolId:    s.ProtocolId,
+		ChainSelector: s.ChainSelector,
+	})
+}
+
+// UnmarshalJSON decodes a strategy encoded by MarshalJSON.
+func (s *NamedStrategy) UnmarshalJSON(data []byte) error {
+	var decoded strategyJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	s.Protocol = decoded.Protocol
+	s.ProtocolId = decoded.ProtocolId
+	s.ChainSelector = decoded.ChainSelector
+	return nil
+}
+
 type StrategyWithAPY struct {
-	Strategy Strategy
-	APY      float64
-}
\ No newline at end of file
+	Strategy   Strategy
+	APY        float64 // the APY the strategy is ranked by
+	Err        error   // set when the candidate was excluded because its APY could not be calculated
+	Infeasible bool    // set, with Err, when the candidate cannot absorb the liquidity it was evaluated with
+	Unhealthy  bool    // set, with Err, when the candidate's market is paused, frozen or in deficit
+
+	// Set only when rewards are enabled (see helper.RewardsConfig): the base
+	// supply APY and the reward emission APR. APY is their sum when rewards
+	// are included in the decision, otherwise BaseAPY.
+	BaseAPY   float64
+	RewardAPR float64
+
+	// Set only when the exit-liquidity check is enabled (see
+	// helper.LiquidityConfig): the liquidity available to withdraw before the
+	// deposit, as a multiple of the TVL.
+	LiquidityMargin float64
+}
+
+// Block identifies the block a chain was read at.
+type Block struct {
+	Number    uint64 `json:"number"`
+	Timestamp uint64 `json:"timestamp"`
+}
+
+// StrategyUpdate is a StrategyUpdated event emitted by ParentPeer.
+type StrategyUpdate struct {
+	Strategy         Strategy
+	OldChainSelector uint64
+	BlockNumber      uint64
+	Timestamp        uint64
+}
+
+// StrategyHistory is the StrategyUpdated history in [FromBlock, HeadBlock],
+// oldest first.
+type StrategyHistory struct {
+	Updates       []StrategyUpdate
+	FromBlock     uint64
+	HeadBlock     uint64
+	HeadTimestamp uint64
+}
+
+// RebalancePayload is the rebalance the workflow would submit for a strategy.
+type RebalancePayload struct {
+	Report   hexutil.Bytes `json:"report"`   // ABI-encoded IYieldPeer.Strategy, the Rebalancer report payload
+	Calldata hexutil.Bytes `json:"calldata"` // ParentPeer.rebalance(newStrategy)
+}
+
+// PreflightState is the on-chain state a rebalance to a strategy depends on.
+type PreflightState struct {
+	ParentPaused         bool           `json:"parentPaused"`
+	ProtocolSupported    bool           `json:"protocolSupported"`
+	ChainAllowed         bool           `json:"chainAllowed"`
+	StrategyAdapter      common.Address `json:"strategyAdapter"`      // the target peer's adapter for the protocol
+	RebalancerParentPeer common.Address `json:"rebalancerParentPeer"` // the ParentPeer the Rebalancer forwards to
+}
+
+// CCIPMessage is a CCIPMessageSent or CCIPMessageReceived log of a peer.
+type CCIPMessage struct {
+	MessageId     common.Hash   `json:"messageId"`
+	TxType        uint8         `json:"txType"`
+	ChainSelector uint64        `json:"chainSelector"` // chain of the peer that emitted the log
+	BlockNumber   uint64        `json:"blockNumber"`
+	TxHash        hexutil.Bytes `json:"txHash"`
+}
+
+// CCIPMessageLogs are the CCIP messages one peer sent and received in
+// [FromBlock, HeadBlock].
+type CCIPMessageLogs struct {
+	ChainSelector uint64        `json:"chainSelector"`
+	Sent          []CCIPMessage `json:"sent"`
+	Received      []CCIPMessage `json:"received"`
+	FromBlock     uint64        `json:"fromBlock"`
+	HeadBlock     uint64        `json:"headBlock"`
+}
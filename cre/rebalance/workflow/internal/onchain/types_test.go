@@ -0,0 +1,46 @@
+package onchain
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Strategy_MarshalJSON_hexProtocolId(t *testing.T) {
+	strategy := Strategy{ProtocolId: AaveV3ProtocolId, ChainSelector: 42}
+
+	data, err := json.Marshal(strategy)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"protocolId": "0xbbbf88eb3aaea499bd8961e51ce38087d4dda7879001b87ead64f8a7a3d0b2da",
+		"chainSelector": 42
+	}`, string(data))
+}
+
+func Test_NamedStrategy_MarshalJSON_readableProtocol(t *testing.T) {
+	strategy := NamedStrategy{Strategy: Strategy{ProtocolId: AaveV3ProtocolId, ChainSelector: 42}, Protocol: "aave-v3"}
+
+	data, err := json.Marshal(strategy)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"protocol": "aave-v3",
+		"protocolId": "0xbbbf88eb3aaea499bd8961e51ce38087d4dda7879001b87ead64f8a7a3d0b2da",
+		"chainSelector": 42
+	}`, string(data))
+
+	var decoded NamedStrategy
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.Equal(t, strategy, decoded)
+}
+
+func Test_Strategy_JSONRoundTrip(t *testing.T) {
+	strategy := Strategy{ProtocolId: CompoundV3ProtocolId, ChainSelector: 5009297550715157269}
+
+	data, err := json.Marshal(strategy)
+	require.NoError(t, err)
+
+	var decoded Strategy
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.Equal(t, strategy, decoded)
+}
@@ -4,6 +4,8 @@ import (
 	"testing"
 	"fmt"
 
+	"rebalance/workflow/internal/protocol"
+
 	"github.com/stretchr/testify/require"
 )
 
@@ -43,22 +45,29 @@ func Test_sameStrategy(t *testing.T) {
 	}
 }
 
-func Test_protocolIDToString(t *testing.T) {
+func Test_StrategySet_ProtocolName(t *testing.T) {
+	set := StrategySet{protocols: protocol.Default}
+
 	t.Run("aave_v3", func(t *testing.T) {
-		got := protocolIDToString(AaveV3ProtocolId)
+		got := set.ProtocolName(Strategy{ProtocolId: AaveV3ProtocolId})
 		require.Equal(t, "aave-v3", got)
 	})
 
 	t.Run("compound_v3", func(t *testing.T) {
-		got := protocolIDToString(CompoundV3ProtocolId)
+		got := set.ProtocolName(Strategy{ProtocolId: CompoundV3ProtocolId})
 		require.Equal(t, "compound-v3", got)
 	})
 
 	t.Run("unknown_protocol", func(t *testing.T) {
 		var unknown [32]byte // zero value; guaranteed != the known IDs
-		got := protocolIDToString(unknown)
+		got := set.ProtocolName(Strategy{ProtocolId: unknown})
 
 		expected := fmt.Sprintf("unknown(%x)", unknown)
 		require.Equal(t, expected, got)
 	})
+
+	t.Run("no_registry", func(t *testing.T) {
+		got := NewStrategySetOf().ProtocolName(Strategy{ProtocolId: AaveV3ProtocolId})
+		require.Contains(t, got, "unknown(")
+	})
 }
\ No newline at end of file
@@ -3,18 +3,21 @@ package onchain
 import (
 	"fmt"
 
+	"rebalance/contracts/evm/src/generated/parent_peer"
 	"rebalance/contracts/evm/src/generated/rebalancer"
 
 	"github.com/smartcontractkit/cre-sdk-go/capabilities/blockchain/evm"
 	"github.com/smartcontractkit/cre-sdk-go/cre"
 )
 
+// WriteRebalance writes the optimal strategy report to the Rebalancer and
+// returns the hash of the submitted transaction.
 func WriteRebalance(
 	rb RebalancerInterface,
 	runtime cre.Runtime,
 	gasLimit uint64,
 	optimal Strategy,
-) error {
+) ([]byte, error) {
 	gasConfig := &evm.GasConfig{GasLimit: gasLimit}
 
 	rebalancerStrategy := rebalancer.IYieldPeerStrategy{
@@ -24,7 +27,7 @@ func WriteRebalance(
 
 	resp, err := rb.WriteReportFromIYieldPeerStrategy(runtime, rebalancerStrategy, gasConfig).Await()
 	if err != nil {
-		return fmt.Errorf("failed to update strategy on Rebalancer: %w", err)
+		return nil, fmt.Errorf("failed to update strategy on Rebalancer: %w", err)
 	}
 
 	logger := runtime.Logger()
@@ -32,5 +35,38 @@ func WriteRebalance(
 		"Rebalancer update transaction submitted",
 		"txHash", fmt.Sprintf("0x%x", resp.TxHash),
 	)
-	return nil
-}
\ No newline at end of file
+	return resp.TxHash, nil
+}
+
+// EncodeRebalance builds what WriteRebalance would submit for optimal without
+// submitting it: the ABI-encoded report the Rebalancer receives, and the
+// equivalent ParentPeer.rebalance calldata for manual execution.
+func EncodeRebalance(optimal Strategy) (RebalancePayload, error) {
+	rebalancerCodec, err := rebalancer.NewCodec()
+	if err != nil {
+		return RebalancePayload{}, fmt.Errorf("failed to create Rebalancer codec: %w", err)
+	}
+	report, err := rebalancerCodec.EncodeIYieldPeerStrategyStruct(rebalancer.IYieldPeerStrategy{
+		ProtocolId:    optimal.ProtocolId,
+		ChainSelector: optimal.ChainSelector,
+	})
+	if err != nil {
+		return RebalancePayload{}, fmt.Errorf("failed to encode rebalance report: %w", err)
+	}
+
+	parentPeerCodec, err := parent_peer.NewCodec()
+	if err != nil {
+		return RebalancePayload{}, fmt.Errorf("failed to create ParentPeer codec: %w", err)
+	}
+	calldata, err := parentPeerCodec.EncodeRebalanceMethodCall(parent_peer.RebalanceInput{
+		NewStrategy: parent_peer.IYieldPeerStrategy{
+			ProtocolId:    optimal.ProtocolId,
+			ChainSelector: optimal.ChainSelector,
+		},
+	})
+	if err != nil {
+		return RebalancePayload{}, fmt.Errorf("failed to encode ParentPeer.rebalance calldata: %w", err)
+	}
+
+	return RebalancePayload{Report: report, Calldata: calldata}, nil
+}
@@ -1,11 +1,15 @@
 package onchain
 
 import (
+	"encoding/binary"
 	"errors"
+	"math/big"
 	"testing"
 
 	"rebalance/contracts/evm/src/generated/rebalancer"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/smartcontractkit/cre-sdk-go/capabilities/blockchain/evm"
 	"github.com/smartcontractkit/cre-sdk-go/cre"
 	"github.com/smartcontractkit/cre-sdk-go/cre/testutils"
@@ -18,7 +22,8 @@ import (
 
 // mockRebalancer is a mock implementation of RebalancerInterface for testing.
 type mockRebalancer struct {
-	writeReportFunc func(cre.Runtime, rebalancer.IYieldPeerStrategy, *evm.GasConfig) cre.Promise[*evm.WriteReportReply]
+	writeReportFunc   func(cre.Runtime, rebalancer.IYieldPeerStrategy, *evm.GasConfig) cre.Promise[*evm.WriteReportReply]
+	getParentPeerFunc func(cre.Runtime, *big.Int) cre.Promise[common.Address]
 }
 
 func (m *mockRebalancer) WriteReportFromIYieldPeerStrategy(
@@ -32,6 +37,16 @@ func (m *mockRebalancer) WriteReportFromIYieldPeerStrategy(
 	return cre.PromiseFromResult[*evm.WriteReportReply](nil, errors.New("writeReportFunc not set"))
 }
 
+func (m *mockRebalancer) GetParentPeer(
+	runtime cre.Runtime,
+	blockNumber *big.Int,
+) cre.Promise[common.Address] {
+	if m.getParentPeerFunc != nil {
+		return m.getParentPeerFunc(runtime, blockNumber)
+	}
+	return cre.PromiseFromResult(common.Address{}, errors.New("getParentPeerFunc not set"))
+}
+
 /*//////////////////////////////////////////////////////////////
                              TESTS
 //////////////////////////////////////////////////////////////*/
@@ -62,8 +77,9 @@ func Test_WriteRebalance_success(t *testing.T) {
 		},
 	}
 
-	err := WriteRebalance(mockRb, runtime, gasLimit, optimal)
+	txHash, err := WriteRebalance(mockRb, runtime, gasLimit, optimal)
 	require.NoError(t, err, "WriteRebalance should not return error in success case")
+	require.Equal(t, expectedTxHash, txHash, "WriteRebalance should return the submitted tx hash")
 }
 
 func Test_WriteRebalance_error(t *testing.T) {
@@ -83,8 +99,9 @@ func Test_WriteRebalance_error(t *testing.T) {
 		},
 	}
 
-	err := WriteRebalance(mockRb, runtime, gasLimit, optimal)
+	txHash, err := WriteRebalance(mockRb, runtime, gasLimit, optimal)
 	require.Error(t, err, "WriteRebalance should return error when underlying call fails")
+	require.Nil(t, txHash, "tx hash should be nil on error")
 	require.ErrorIs(t, err, expectedError, "error should wrap the underlying transaction error")
 	require.Contains(t, err.Error(), "failed to update strategy on Rebalancer", "error message should include context")
 }
@@ -117,6 +134,26 @@ func Test_WriteRebalance_withDifferentStrategy(t *testing.T) {
 		},
 	}
 
-	err := WriteRebalance(mockRb, runtime, gasLimit, optimal)
+	txHash, err := WriteRebalance(mockRb, runtime, gasLimit, optimal)
 	require.NoError(t, err, "WriteRebalance should succeed with different strategy values")
+	require.Equal(t, expectedTxHash, txHash)
+}
+
+func Test_EncodeRebalance_success(t *testing.T) {
+	optimal := Strategy{
+		ProtocolId:    CompoundV3ProtocolId,
+		ChainSelector: 12_345,
+	}
+
+	payload, err := EncodeRebalance(optimal)
+	require.NoError(t, err)
+
+	// abi.encode(Strategy{protocolId, chainSelector})
+	expectedReport := make([]byte, 64)
+	copy(expectedReport[:32], optimal.ProtocolId[:])
+	binary.BigEndian.PutUint64(expectedReport[56:], optimal.ChainSelector)
+	require.Equal(t, expectedReport, []byte(payload.Report))
+
+	selector := crypto.Keccak256([]byte("rebalance((bytes32,uint64))"))[:4]
+	require.Equal(t, append(selector, expectedReport...), []byte(payload.Calldata))
 }
@@ -0,0 +1,88 @@
+package policy
+
+import (
+	"slices"
+
+	"rebalance/workflow/internal/helper"
+	"rebalance/workflow/internal/onchain"
+)
+
+// Cooldown rules, in the order they are checked.
+const (
+	CooldownMinInterval = "min-interval"
+	CooldownBudget      = "budget"
+)
+
+// CooldownDecision is the outcome of the cooldown policy.
+//
+// When a rule blocks the move, Rule names it and NextAllowedAt is the earliest
+// timestamp at which that rule would allow a move again. Window, Count and
+// MaxRebalances describe the blocking budget (zero for the min-interval rule).
+type CooldownDecision struct {
+	Allowed         bool   `json:"allowed"`
+	Rule            string `json:"rule,omitempty"`
+	Now             uint64 `json:"now"`
+	LastRebalanceAt uint64 `json:"lastRebalanceAt,omitempty"`
+	NextAllowedAt   uint64 `json:"nextAllowedAt,omitempty"`
+	WindowSeconds   uint64 `json:"windowSeconds,omitempty"`
+	Count           int    `json:"count,omitempty"`
+	MaxRebalances   int    `json:"maxRebalances,omitempty"`
+}
+
+// EvaluateCooldown decides whether another rebalance is allowed given the
+// StrategyUpdated history, timed against the history's head block. The
+// updates may be in any order.
+//
+// The min interval is checked first, then every budget. Budgets with a zero
+// window or a non-positive maxRebalances are ignored.
+func EvaluateCooldown(cfg helper.CooldownConfig, history onchain.StrategyHistory) CooldownDecision {
+	now := history.HeadTimestamp
+	decision := CooldownDecision{Allowed: true, Now: now}
+
+	for _, update := range history.Updates {
+		if update.Timestamp > decision.LastRebalanceAt {
+			decision.LastRebalanceAt = update.Timestamp
+		}
+	}
+
+	if cfg.MinIntervalSeconds > 0 && len(history.Updates) > 0 {
+		next := decision.LastRebalanceAt + cfg.MinIntervalSeconds
+		if now < next {
+			decision.Allowed = false
+			decision.Rule = CooldownMinInterval
+			decision.NextAllowedAt = next
+			return decision
+		}
+	}
+
+	for _, budget := range cfg.Budgets {
+		if budget.WindowSeconds == 0 || budget.MaxRebalances <= 0 {
+			continue
+		}
+
+		// Timestamps of the moves inside the window, oldest first.
+		var inWindow []uint64
+		for _, update := range history.Updates {
+			if update.Timestamp+budget.WindowSeconds > now {
+				inWindow = append(inWindow, update.Timestamp)
+			}
+		}
+		if len(inWindow) < budget.MaxRebalances {
+			continue
+		}
+		slices.Sort(inWindow)
+
+		// A move is allowed again once enough of the oldest ones leave the window.
+		next := inWindow[len(inWindow)-budget.MaxRebalances] + budget.WindowSeconds
+		if decision.Allowed || next > decision.NextAllowedAt {
+			decision.Allowed = false
+			decision.Rule = CooldownBudget
+			decision.NextAllowedAt = next
+			decision.WindowSeconds = budget.WindowSeconds
+			decision.Count = len(inWindow)
+			decision.MaxRebalances = budget.MaxRebalances
+		}
+	}
+
+	return decision
+}
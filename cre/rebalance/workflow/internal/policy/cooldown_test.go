@@ -0,0 +1,124 @@
+package policy
+
+import (
+	"testing"
+
+	"rebalance/workflow/internal/helper"
+	"rebalance/workflow/internal/onchain"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	hour = uint64(60 * 60)
+	day  = 24 * hour
+	week = 7 * day
+)
+
+// historyAt builds a StrategyHistory with updates at the given timestamps.
+func historyAt(now uint64, timestamps ...uint64) onchain.StrategyHistory {
+	history := onchain.StrategyHistory{HeadTimestamp: now}
+	for _, ts := range timestamps {
+		history.Updates = append(history.Updates, onchain.StrategyUpdate{Timestamp: ts})
+	}
+	return history
+}
+
+func newCooldownConfig() helper.CooldownConfig {
+	return helper.CooldownConfig{
+		MinIntervalSeconds: 12 * hour,
+		Budgets:            []helper.RebalanceBudget{{WindowSeconds: week, MaxRebalances: 3}},
+		LookbackBlocks:     1,
+	}
+}
+
+func Test_EvaluateCooldown_allowsWithoutHistory(t *testing.T) {
+	decision := EvaluateCooldown(newCooldownConfig(), historyAt(10*week))
+
+	require.True(t, decision.Allowed)
+	require.Empty(t, decision.Rule)
+	require.Zero(t, decision.LastRebalanceAt)
+}
+
+func Test_EvaluateCooldown_blocksWithinMinInterval(t *testing.T) {
+	now := 10 * week
+	decision := EvaluateCooldown(newCooldownConfig(), historyAt(now, now-2*day, now-hour))
+
+	require.False(t, decision.Allowed)
+	require.Equal(t, CooldownMinInterval, decision.Rule)
+	require.Equal(t, now-hour, decision.LastRebalanceAt)
+	require.Equal(t, now-hour+12*hour, decision.NextAllowedAt)
+}
+
+func Test_EvaluateCooldown_allowsAfterMinInterval(t *testing.T) {
+	now := 10 * week
+	decision := EvaluateCooldown(newCooldownConfig(), historyAt(now, now-12*hour))
+
+	require.True(t, decision.Allowed)
+	require.Equal(t, now-12*hour, decision.LastRebalanceAt)
+}
+
+func Test_EvaluateCooldown_blocksWhenBudgetExhausted(t *testing.T) {
+	now := 10 * week
+	decision := EvaluateCooldown(
+		newCooldownConfig(),
+		historyAt(now, now-8*day, now-6*day, now-4*day, now-2*day),
+	)
+
+	require.False(t, decision.Allowed)
+	require.Equal(t, CooldownBudget, decision.Rule)
+	require.Equal(t, week, decision.WindowSeconds)
+	require.Equal(t, 3, decision.Count)
+	require.Equal(t, 3, decision.MaxRebalances)
+	// The move at now-6d leaves the window first.
+	require.Equal(t, now-6*day+week, decision.NextAllowedAt)
+}
+
+func Test_EvaluateCooldown_allowsWhenBudgetHasRoom(t *testing.T) {
+	now := 10 * week
+	decision := EvaluateCooldown(newCooldownConfig(), historyAt(now, now-8*day, now-4*day, now-2*day))
+
+	require.True(t, decision.Allowed)
+}
+
+func Test_EvaluateCooldown_reportsLatestBlockingBudget(t *testing.T) {
+	now := 10 * week
+	cfg := helper.CooldownConfig{
+		Budgets: []helper.RebalanceBudget{
+			{WindowSeconds: week, MaxRebalances: 3},
+			{WindowSeconds: day, MaxRebalances: 1},
+		},
+	}
+	decision := EvaluateCooldown(cfg, historyAt(now, now-5*day, now-4*day, now-hour))
+
+	require.False(t, decision.Allowed)
+	require.Equal(t, week, decision.WindowSeconds)
+	require.Equal(t, now-5*day+week, decision.NextAllowedAt)
+}
+
+func Test_EvaluateCooldown_budgetWithUnorderedHistory(t *testing.T) {
+	now := 10 * week
+	cfg := helper.CooldownConfig{
+		Budgets: []helper.RebalanceBudget{{WindowSeconds: week, MaxRebalances: 2}},
+	}
+	decision := EvaluateCooldown(cfg, historyAt(now, now-hour, now-5*day, now-2*day))
+
+	require.False(t, decision.Allowed)
+	require.Equal(t, 3, decision.Count)
+	require.Equal(t, now-hour, decision.LastRebalanceAt)
+	// Two of the three moves must leave the window: the oldest two.
+	require.Equal(t, now-2*day+week, decision.NextAllowedAt)
+}
+
+func Test_EvaluateCooldown_ignoresDisabledBudgets(t *testing.T) {
+	now := 10 * week
+	cfg := helper.CooldownConfig{
+		Budgets: []helper.RebalanceBudget{
+			{WindowSeconds: 0, MaxRebalances: 1},
+			{WindowSeconds: week, MaxRebalances: 0},
+		},
+	}
+	decision := EvaluateCooldown(cfg, historyAt(now, now-hour))
+
+	require.True(t, decision.Allowed)
+}
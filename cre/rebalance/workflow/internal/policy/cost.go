@@ -0,0 +1,110 @@
+package policy
+
+import (
+	"fmt"
+	"math/big"
+
+	"rebalance/workflow/internal/constants"
+	"rebalance/workflow/internal/helper"
+	"rebalance/workflow/internal/onchain"
+)
+
+// CostEstimate is the estimated cost of moving TVL from the current to the
+// optimal strategy, and whether the projected gain over the horizon covers it.
+type CostEstimate struct {
+	GasUSD            float64 `json:"gasUsd"`
+	CCIPMessages      int     `json:"ccipMessages"`
+	CCIPFeeUSD        float64 `json:"ccipFeeUsd"`
+	BridgeHops        int     `json:"bridgeHops"`
+	BridgeDowntimeUSD float64 `json:"bridgeDowntimeUsd"`
+	TotalUSD          float64 `json:"totalUsd"`
+
+	HorizonDays    float64 `json:"horizonDays"`
+	HorizonGainUSD float64 `json:"horizonGainUsd"`
+	NetGainUSD     float64 `json:"netGainUsd"`
+	Worthwhile     bool    `json:"worthwhile"`
+}
+
+// rebalanceRoute describes the CCIP traffic ParentPeer generates for a move.
+type rebalanceRoute struct {
+	// messages lists the source chain of every CCIP message sent.
+	messages []uint64
+	// bridgeHops is the number of messages that carry the TVL.
+	bridgeHops int
+}
+
+// EstimateRebalanceCost estimates the cost of rebalancing from current to optimal.
+//
+//   - gas:      gasLimit * parent gasPriceGwei * nativeTokenPriceUsd (the
+//     report is written to the Rebalancer on the parent chain, which executes
+//     ParentPeer.rebalance there). gasLimit is the limit the report is
+//     written with.
+//   - CCIP:     ccipFeeUsd of the source chain, per message ParentPeer/ChildPeer sends.
+//   - downtime: TVL * optimal APY * bridgeDowntimeSeconds / year, per hop that
+//     carries the TVL (funds earn nothing while in flight).
+//
+// The gain is TVL * (optimal APY - current APY) * horizonDays / 365. tvl is in
+// vault asset units with tvlDecimals decimals.
+func EstimateRebalanceCost(
+	config *helper.Config,
+	current onchain.StrategyWithAPY,
+	optimal onchain.StrategyWithAPY,
+	gasLimit uint64,
+	tvl *big.Int,
+	tvlDecimals uint8,
+) (CostEstimate, error) {
+	if len(config.Evms) == 0 {
+		return CostEstimate{}, fmt.Errorf("no EVM configs provided")
+	}
+	parentCfg := config.Evms[0]
+
+	route := rebalanceRouteFor(parentCfg.ChainSelector, current.Strategy, optimal.Strategy)
+
+	estimate := CostEstimate{
+		GasUSD:       float64(gasLimit) * parentCfg.GasPriceGwei * 1e-9 * parentCfg.NativeTokenPriceUSD,
+		CCIPMessages: len(route.messages),
+		BridgeHops:   route.bridgeHops,
+		HorizonDays:  config.Cost.HorizonDays,
+	}
+
+	for _, source := range route.messages {
+		sourceCfg, err := helper.FindEvmConfigByChainSelector(config.Evms, source)
+		if err != nil {
+			return CostEstimate{}, fmt.Errorf("CCIP fee for source chain: %w", err)
+		}
+		estimate.CCIPFeeUSD += sourceCfg.CCIPFeeUSD
+	}
+
+	tvlUSD := TVLToUSD(tvl, tvlDecimals)
+	downtimeYears := float64(config.Cost.BridgeDowntimeSeconds) / constants.SecondsPerYear
+	estimate.BridgeDowntimeUSD = tvlUSD * optimal.APY * downtimeYears * float64(route.bridgeHops)
+
+	estimate.TotalUSD = estimate.GasUSD + estimate.CCIPFeeUSD + estimate.BridgeDowntimeUSD
+	estimate.HorizonGainUSD = tvlUSD * (optimal.APY - current.APY) * config.Cost.HorizonDays / 365
+	estimate.NetGainUSD = estimate.HorizonGainUSD - estimate.TotalUSD
+	estimate.Worthwhile = estimate.HorizonGainUSD > estimate.TotalUSD
+
+	return estimate, nil
+}
+
+// rebalanceRouteFor mirrors ParentPeer._rebalance / ChildPeer._handleCCIPRebalanceOldStrategy:
+//   - parent -> parent: local, no CCIP.
+//   - parent -> child:  RebalanceNewStrategy from parent, carrying TVL.
+//   - child  -> same child: RebalanceOldStrategy from parent, no TVL.
+//   - child  -> other:  RebalanceOldStrategy from parent, then
+//     RebalanceNewStrategy from the old chain carrying TVL.
+func rebalanceRouteFor(parentChainSelector uint64, current, optimal onchain.Strategy) rebalanceRoute {
+	oldChain := current.ChainSelector
+	newChain := optimal.ChainSelector
+
+	switch {
+	case oldChain == parentChainSelector && newChain == parentChainSelector:
+		return rebalanceRoute{}
+	case oldChain == parentChainSelector:
+		return rebalanceRoute{messages: []uint64{parentChainSelector}, bridgeHops: 1}
+	case oldChain == newChain:
+		return rebalanceRoute{messages: []uint64{parentChainSelector}}
+	default:
+		return rebalanceRoute{messages: []uint64{parentChainSelector, oldChain}, bridgeHops: 1}
+	}
+}
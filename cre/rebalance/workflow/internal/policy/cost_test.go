@@ -0,0 +1,144 @@
+package policy
+
+import (
+	"testing"
+
+	"rebalance/workflow/internal/helper"
+	"rebalance/workflow/internal/onchain"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	parentChain = 1
+	childChainA = 2
+	childChainB = 3
+)
+
+func newCostConfig() *helper.Config {
+	return &helper.Config{
+		Evms: []helper.EvmConfig{
+			{ChainSelector: parentChain, GasLimit: 500_000, GasPriceGwei: 10, NativeTokenPriceUSD: 2_000, CCIPFeeUSD: 5},
+			{ChainSelector: childChainA, GasLimit: 2_000_000, GasPriceGwei: 50, NativeTokenPriceUSD: 1, CCIPFeeUSD: 0.5},
+			{ChainSelector: childChainB, CCIPFeeUSD: 0.25},
+		},
+		Cost: helper.CostConfig{
+			Enabled:               true,
+			HorizonDays:           365,
+			BridgeDowntimeSeconds: 31_536, // 1/1000 of a year
+		},
+	}
+}
+
+func Test_rebalanceRouteFor(t *testing.T) {
+	tests := []struct {
+		name       string
+		oldChain   uint64
+		newChain   uint64
+		messages   []uint64
+		bridgeHops int
+	}{
+		{"parent_to_parent", parentChain, parentChain, nil, 0},
+		{"parent_to_child", parentChain, childChainA, []uint64{parentChain}, 1},
+		{"child_to_same_child", childChainA, childChainA, []uint64{parentChain}, 0},
+		{"child_to_parent", childChainA, parentChain, []uint64{parentChain, childChainA}, 1},
+		{"child_to_other_child", childChainA, childChainB, []uint64{parentChain, childChainA}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			route := rebalanceRouteFor(
+				parentChain,
+				onchain.Strategy{ProtocolId: onchain.AaveV3ProtocolId, ChainSelector: tt.oldChain},
+				onchain.Strategy{ProtocolId: onchain.CompoundV3ProtocolId, ChainSelector: tt.newChain},
+			)
+			require.Equal(t, tt.messages, route.messages)
+			require.Equal(t, tt.bridgeHops, route.bridgeHops)
+		})
+	}
+}
+
+func Test_EstimateRebalanceCost_sameChain(t *testing.T) {
+	cfg := newCostConfig()
+	current := withAPY(onchain.AaveV3ProtocolId, parentChain, 0.03)
+	optimal := withAPY(onchain.CompoundV3ProtocolId, parentChain, 0.05)
+
+	estimate, err := EstimateRebalanceCost(cfg, current, optimal, cfg.Evms[0].GasLimit, usdc(100_000), 6)
+	require.NoError(t, err)
+
+	// 500k gas * 10 gwei * $2000 = $10
+	require.InDelta(t, 10.0, estimate.GasUSD, 1e-9)
+	require.Zero(t, estimate.CCIPMessages)
+	require.Zero(t, estimate.CCIPFeeUSD)
+	require.Zero(t, estimate.BridgeDowntimeUSD)
+	require.InDelta(t, 10.0, estimate.TotalUSD, 1e-9)
+	// 100k * 2pp * 1y = $2000
+	require.InDelta(t, 2_000.0, estimate.HorizonGainUSD, 1e-6)
+	require.InDelta(t, 1_990.0, estimate.NetGainUSD, 1e-6)
+	require.True(t, estimate.Worthwhile)
+}
+
+func Test_EstimateRebalanceCost_crossChain(t *testing.T) {
+	cfg := newCostConfig()
+	current := withAPY(onchain.AaveV3ProtocolId, childChainA, 0.03)
+	optimal := withAPY(onchain.CompoundV3ProtocolId, childChainB, 0.05)
+
+	estimate, err := EstimateRebalanceCost(cfg, current, optimal, cfg.Evms[0].GasLimit, usdc(100_000), 6)
+	require.NoError(t, err)
+
+	require.Equal(t, 2, estimate.CCIPMessages)
+	require.InDelta(t, 5.5, estimate.CCIPFeeUSD, 1e-9)
+	require.Equal(t, 1, estimate.BridgeHops)
+	// 100k * 5% * 1/1000y = $5
+	require.InDelta(t, 5.0, estimate.BridgeDowntimeUSD, 1e-9)
+	require.InDelta(t, 20.5, estimate.TotalUSD, 1e-9)
+}
+
+func Test_EstimateRebalanceCost_costsGasLimitAtParentPricesAndEachHopOnItsSource(t *testing.T) {
+	cfg := newCostConfig()
+	current := withAPY(onchain.AaveV3ProtocolId, childChainA, 0.03)
+	optimal := withAPY(onchain.CompoundV3ProtocolId, parentChain, 0.05)
+
+	estimate, err := EstimateRebalanceCost(cfg, current, optimal, cfg.Evms[1].GasLimit, usdc(100_000), 6)
+	require.NoError(t, err)
+
+	// The report is written with child A's 2M gas limit but runs on the
+	// parent: 2M gas * 10 gwei * $2000 = $40.
+	require.InDelta(t, 40.0, estimate.GasUSD, 1e-9)
+	// RebalanceOldStrategy from the parent ($5), RebalanceNewStrategy from child A ($0.5).
+	require.InDelta(t, 5.5, estimate.CCIPFeeUSD, 1e-9)
+
+	// Moving from child A to child B: child B sends nothing.
+	optimal = withAPY(onchain.CompoundV3ProtocolId, childChainB, 0.05)
+	cfg.Evms[1].CCIPFeeUSD = 1.5
+	estimate, err = EstimateRebalanceCost(cfg, current, optimal, cfg.Evms[1].GasLimit, usdc(100_000), 6)
+	require.NoError(t, err)
+	require.InDelta(t, 6.5, estimate.CCIPFeeUSD, 1e-9)
+}
+
+func Test_EstimateRebalanceCost_notWorthwhileOverShortHorizon(t *testing.T) {
+	cfg := newCostConfig()
+	cfg.Cost.HorizonDays = 1
+	current := withAPY(onchain.AaveV3ProtocolId, parentChain, 0.03)
+	optimal := withAPY(onchain.CompoundV3ProtocolId, parentChain, 0.04)
+
+	// 10k * 1pp / 365 = ~$0.27 < $10 gas
+	estimate, err := EstimateRebalanceCost(cfg, current, optimal, cfg.Evms[0].GasLimit, usdc(10_000), 6)
+	require.NoError(t, err)
+	require.False(t, estimate.Worthwhile)
+	require.Less(t, estimate.NetGainUSD, 0.0)
+}
+
+func Test_EstimateRebalanceCost_errorWhen_sourceChainNotConfigured(t *testing.T) {
+	cfg := newCostConfig()
+	current := withAPY(onchain.AaveV3ProtocolId, 999, 0.03)
+	optimal := withAPY(onchain.CompoundV3ProtocolId, parentChain, 0.05)
+
+	_, err := EstimateRebalanceCost(cfg, current, optimal, cfg.Evms[0].GasLimit, usdc(1), 6)
+	require.ErrorContains(t, err, "no evm config found for chainSelector 999")
+}
+
+func Test_EstimateRebalanceCost_errorWhen_noEvms(t *testing.T) {
+	_, err := EstimateRebalanceCost(&helper.Config{}, onchain.StrategyWithAPY{}, onchain.StrategyWithAPY{}, 0, usdc(1), 6)
+	require.ErrorContains(t, err, "no EVM configs provided")
+}
@@ -0,0 +1,57 @@
+package policy
+
+import (
+	"rebalance/workflow/internal/onchain"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// InFlightDecision reports whether a cross-chain rebalance is still moving
+// funds. Open lists the rebalance messages that were sent but not yet
+// received by any configured peer, oldest block first per chain.
+type InFlightDecision struct {
+	InFlight bool                  `json:"inFlight"`
+	Open     []onchain.CCIPMessage `json:"open,omitempty"`
+	Sent     int                   `json:"sent"`     // rebalance messages sent in the lookback windows
+	Received int                   `json:"received"` // rebalance messages received in the lookback windows
+}
+
+// EvaluateInFlight pairs the rebalance messages (RebalanceOldStrategy and
+// RebalanceNewStrategy) the peers sent with the ones they received, by CCIP
+// message ID. A sent message no peer has received yet means funds are still
+// in transit.
+//
+// logs must cover every peer a rebalance can send to, otherwise a message to
+// an unlisted peer stays open until it leaves the lookback window.
+func EvaluateInFlight(logs []onchain.CCIPMessageLogs) InFlightDecision {
+	var decision InFlightDecision
+
+	received := make(map[common.Hash]bool)
+	for _, peer := range logs {
+		for _, msg := range peer.Received {
+			if isRebalanceTxType(msg.TxType) {
+				received[msg.MessageId] = true
+				decision.Received++
+			}
+		}
+	}
+
+	for _, peer := range logs {
+		for _, msg := range peer.Sent {
+			if !isRebalanceTxType(msg.TxType) {
+				continue
+			}
+			decision.Sent++
+			if !received[msg.MessageId] {
+				decision.Open = append(decision.Open, msg)
+			}
+		}
+	}
+
+	decision.InFlight = len(decision.Open) > 0
+	return decision
+}
+
+func isRebalanceTxType(txType uint8) bool {
+	return txType == onchain.CcipTxTypeRebalanceOldStrategy || txType == onchain.CcipTxTypeRebalanceNewStrategy
+}
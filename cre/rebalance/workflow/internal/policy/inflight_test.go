@@ -0,0 +1,76 @@
+package policy
+
+import (
+	"testing"
+
+	"rebalance/workflow/internal/onchain"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func ccipMessage(id string, txType uint8, chainSelector uint64) onchain.CCIPMessage {
+	return onchain.CCIPMessage{MessageId: common.HexToHash(id), TxType: txType, ChainSelector: chainSelector}
+}
+
+func Test_EvaluateInFlight_noMessages(t *testing.T) {
+	decision := EvaluateInFlight([]onchain.CCIPMessageLogs{{ChainSelector: 1}, {ChainSelector: 2}})
+
+	require.False(t, decision.InFlight)
+	require.Empty(t, decision.Open)
+}
+
+func Test_EvaluateInFlight_completedRebalance(t *testing.T) {
+	// parent -> old strategy chain -> new strategy chain, both delivered.
+	decision := EvaluateInFlight([]onchain.CCIPMessageLogs{
+		{
+			ChainSelector: 1,
+			Sent:          []onchain.CCIPMessage{ccipMessage("0x01", onchain.CcipTxTypeRebalanceOldStrategy, 1)},
+		},
+		{
+			ChainSelector: 2,
+			Sent:          []onchain.CCIPMessage{ccipMessage("0x02", onchain.CcipTxTypeRebalanceNewStrategy, 2)},
+			Received:      []onchain.CCIPMessage{ccipMessage("0x01", onchain.CcipTxTypeRebalanceOldStrategy, 2)},
+		},
+		{
+			ChainSelector: 3,
+			Received:      []onchain.CCIPMessage{ccipMessage("0x02", onchain.CcipTxTypeRebalanceNewStrategy, 3)},
+		},
+	})
+
+	require.False(t, decision.InFlight)
+	require.Equal(t, 2, decision.Sent)
+	require.Equal(t, 2, decision.Received)
+}
+
+func Test_EvaluateInFlight_openRebalance(t *testing.T) {
+	open := ccipMessage("0x02", onchain.CcipTxTypeRebalanceNewStrategy, 2)
+
+	decision := EvaluateInFlight([]onchain.CCIPMessageLogs{
+		{
+			ChainSelector: 1,
+			Sent:          []onchain.CCIPMessage{ccipMessage("0x01", onchain.CcipTxTypeRebalanceOldStrategy, 1)},
+		},
+		{
+			ChainSelector: 2,
+			Sent:          []onchain.CCIPMessage{open},
+			Received:      []onchain.CCIPMessage{ccipMessage("0x01", onchain.CcipTxTypeRebalanceOldStrategy, 2)},
+		},
+	})
+
+	require.True(t, decision.InFlight)
+	require.Equal(t, []onchain.CCIPMessage{open}, decision.Open)
+}
+
+func Test_EvaluateInFlight_ignoresOtherTxTypes(t *testing.T) {
+	// A deposit still in transit does not block a rebalance.
+	const depositToParent uint8 = 0
+
+	decision := EvaluateInFlight([]onchain.CCIPMessageLogs{{
+		ChainSelector: 2,
+		Sent:          []onchain.CCIPMessage{ccipMessage("0x01", depositToParent, 2)},
+	}})
+
+	require.False(t, decision.InFlight)
+	require.Zero(t, decision.Sent)
+}
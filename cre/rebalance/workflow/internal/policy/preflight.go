@@ -0,0 +1,49 @@
+package policy
+
+import (
+	"rebalance/workflow/internal/onchain"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Pre-flight checks, in the order they are evaluated.
+const (
+	PreflightParentPaused        = "parent-paused"
+	PreflightProtocolUnsupported = "protocol-unsupported"
+	PreflightChainNotAllowed     = "chain-not-allowed"
+	PreflightNoStrategyAdapter   = "no-strategy-adapter"
+	PreflightParentPeerMismatch  = "parent-peer-mismatch"
+)
+
+// PreflightDecision is the outcome of the pre-flight checks. Check names the
+// first failed check and is empty when every check passed.
+type PreflightDecision struct {
+	Passed bool                   `json:"passed"`
+	Check  string                 `json:"check,omitempty"`
+	State  onchain.PreflightState `json:"state"`
+}
+
+// EvaluatePreflight decides whether a rebalance report would be accepted:
+// ParentPeer must not be paused, must support the protocol and allow the
+// chain, the target peer must have a strategy adapter for the protocol, and
+// the Rebalancer must forward to parentPeer.
+func EvaluatePreflight(state onchain.PreflightState, parentPeer common.Address) PreflightDecision {
+	decision := PreflightDecision{State: state}
+
+	switch {
+	case state.ParentPaused:
+		decision.Check = PreflightParentPaused
+	case !state.ProtocolSupported:
+		decision.Check = PreflightProtocolUnsupported
+	case !state.ChainAllowed:
+		decision.Check = PreflightChainNotAllowed
+	case state.StrategyAdapter == (common.Address{}):
+		decision.Check = PreflightNoStrategyAdapter
+	case state.RebalancerParentPeer != parentPeer:
+		decision.Check = PreflightParentPeerMismatch
+	default:
+		decision.Passed = true
+	}
+
+	return decision
+}
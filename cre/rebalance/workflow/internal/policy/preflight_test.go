@@ -0,0 +1,57 @@
+package policy
+
+import (
+	"testing"
+
+	"rebalance/workflow/internal/onchain"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+var preflightParentPeer = common.HexToAddress("0x0000000000000000000000000000000000000001")
+
+func healthyPreflightState() onchain.PreflightState {
+	return onchain.PreflightState{
+		ProtocolSupported:    true,
+		ChainAllowed:         true,
+		StrategyAdapter:      common.HexToAddress("0x0000000000000000000000000000000000000002"),
+		RebalancerParentPeer: preflightParentPeer,
+	}
+}
+
+func Test_EvaluatePreflight_passes(t *testing.T) {
+	decision := EvaluatePreflight(healthyPreflightState(), preflightParentPeer)
+
+	require.True(t, decision.Passed)
+	require.Empty(t, decision.Check)
+}
+
+func Test_EvaluatePreflight_failedChecks(t *testing.T) {
+	for check, mutate := range map[string]func(*onchain.PreflightState){
+		PreflightParentPaused:        func(s *onchain.PreflightState) { s.ParentPaused = true },
+		PreflightProtocolUnsupported: func(s *onchain.PreflightState) { s.ProtocolSupported = false },
+		PreflightChainNotAllowed:     func(s *onchain.PreflightState) { s.ChainAllowed = false },
+		PreflightNoStrategyAdapter:   func(s *onchain.PreflightState) { s.StrategyAdapter = common.Address{} },
+		PreflightParentPeerMismatch:  func(s *onchain.PreflightState) { s.RebalancerParentPeer = common.HexToAddress("0x03") },
+	} {
+		state := healthyPreflightState()
+		mutate(&state)
+
+		decision := EvaluatePreflight(state, preflightParentPeer)
+
+		require.False(t, decision.Passed, check)
+		require.Equal(t, check, decision.Check)
+		require.Equal(t, state, decision.State, check)
+	}
+}
+
+func Test_EvaluatePreflight_reportsFirstFailedCheck(t *testing.T) {
+	state := healthyPreflightState()
+	state.ParentPaused = true
+	state.StrategyAdapter = common.Address{}
+
+	decision := EvaluatePreflight(state, preflightParentPeer)
+
+	require.Equal(t, PreflightParentPaused, decision.Check)
+}
@@ -0,0 +1,162 @@
+package policy
+
+import (
+	"math/big"
+
+	"rebalance/workflow/internal/helper"
+	"rebalance/workflow/internal/onchain"
+	"rebalance/workflow/internal/protocol"
+)
+
+// Rule names reported in ThresholdDecision.Rule.
+// Protocol overrides are reported as "protocol:<name>", e.g. "protocol:aave-v3".
+const (
+	RuleBuiltin    = "builtin"
+	RuleDefault    = "default"
+	RuleSameChain  = "route:same-chain"
+	RuleCrossChain = "route:cross-chain"
+	ruleProtocol   = "protocol:"
+)
+
+// Checks reported in ThresholdDecision.Check when a rebalance is blocked.
+const (
+	CheckAPYDelta   = "apy-delta"
+	CheckAnnualGain = "annual-gain"
+)
+
+// ThresholdDecision is the outcome of evaluating the threshold policy for a
+// single current -> optimal move.
+type ThresholdDecision struct {
+	Allowed bool `json:"allowed"`
+	// Rule is the rule that blocked the rebalance or, when allowed,
+	// the most specific rule that was applied.
+	Rule string `json:"rule"`
+	// Check is the check that blocked the rebalance. Empty when allowed.
+	Check            string  `json:"check,omitempty"`
+	APYDelta         float64 `json:"apyDelta"`
+	MinAPYDelta      float64 `json:"minApyDelta"`
+	AnnualGainUSD    float64 `json:"annualGainUsd"`
+	MinAnnualGainUSD float64 `json:"minAnnualGainUsd"`
+}
+
+// resolvedThreshold is a ThresholdConfig flattened for one move,
+// with the rule each value came from.
+type resolvedThreshold struct {
+	minAPYDeltaBps       float64
+	minAPYDeltaRule      string
+	minAnnualGainUSD     float64
+	minAnnualGainUSDRule string
+}
+
+// EvaluateThreshold applies the threshold policy to a current -> optimal move.
+// tvl is the raw TVL in vault asset units with tvlDecimals decimals and is used
+// to project the annual USD gain as TVL * delta.
+func EvaluateThreshold(
+	cfg helper.ThresholdConfig,
+	current onchain.StrategyWithAPY,
+	optimal onchain.StrategyWithAPY,
+	tvl *big.Int,
+	tvlDecimals uint8,
+) ThresholdDecision {
+	crossChain := current.Strategy.ChainSelector != optimal.Strategy.ChainSelector
+	resolved := resolveThreshold(cfg, optimal.Strategy.ProtocolId, crossChain)
+
+	delta := optimal.APY - current.APY
+	decision := ThresholdDecision{
+		APYDelta:         delta,
+		MinAPYDelta:      resolved.minAPYDeltaBps / 10_000,
+		AnnualGainUSD:    TVLToUSD(tvl, tvlDecimals) * delta,
+		MinAnnualGainUSD: resolved.minAnnualGainUSD,
+	}
+
+	if decision.APYDelta < decision.MinAPYDelta {
+		decision.Rule = resolved.minAPYDeltaRule
+		decision.Check = CheckAPYDelta
+		return decision
+	}
+	if decision.AnnualGainUSD < decision.MinAnnualGainUSD {
+		decision.Rule = resolved.minAnnualGainUSDRule
+		decision.Check = CheckAnnualGain
+		return decision
+	}
+
+	decision.Allowed = true
+	decision.Rule = mostSpecificRule(resolved.minAPYDeltaRule, resolved.minAnnualGainUSDRule)
+	return decision
+}
+
+// resolveThreshold layers built-in defaults, the default rule, the route rule
+// and the protocol rule, field by field. Protocol rules are matched by ID, so
+// protocols defined in config need no registry to be found.
+func resolveThreshold(cfg helper.ThresholdConfig, protocolId [32]byte, crossChain bool) resolvedThreshold {
+	resolved := resolvedThreshold{
+		minAPYDeltaBps:       helper.DefaultMinAPYDeltaBps,
+		minAPYDeltaRule:      RuleBuiltin,
+		minAnnualGainUSD:     0,
+		minAnnualGainUSDRule: RuleBuiltin,
+	}
+
+	resolved.apply(helper.ThresholdRule{
+		MinAPYDeltaBps:   cfg.MinAPYDeltaBps,
+		MinAnnualGainUSD: cfg.MinAnnualGainUSD,
+	}, RuleDefault)
+
+	if crossChain {
+		if cfg.CrossChain != nil {
+			resolved.apply(*cfg.CrossChain, RuleCrossChain)
+		}
+	} else if cfg.SameChain != nil {
+		resolved.apply(*cfg.SameChain, RuleSameChain)
+	}
+
+	for name, rule := range cfg.Protocols {
+		if protocol.IDFromName(name) == protocolId {
+			resolved.apply(rule, ruleProtocol+name)
+		}
+	}
+
+	return resolved
+}
+
+func (r *resolvedThreshold) apply(rule helper.ThresholdRule, name string) {
+	if rule.MinAPYDeltaBps != nil {
+		r.minAPYDeltaBps = *rule.MinAPYDeltaBps
+		r.minAPYDeltaRule = name
+	}
+	if rule.MinAnnualGainUSD != nil {
+		r.minAnnualGainUSD = *rule.MinAnnualGainUSD
+		r.minAnnualGainUSDRule = name
+	}
+}
+
+// mostSpecificRule returns whichever of a and b has the higher precedence.
+func mostSpecificRule(a, b string) string {
+	if rulePrecedence(b) > rulePrecedence(a) {
+		return b
+	}
+	return a
+}
+
+func rulePrecedence(rule string) int {
+	switch rule {
+	case RuleBuiltin:
+		return 0
+	case RuleDefault:
+		return 1
+	case RuleSameChain, RuleCrossChain:
+		return 2
+	default:
+		return 3 // protocol:<name>
+	}
+}
+
+// TVLToUSD converts a raw amount of the vault asset with the given decimals to
+// USD, assuming the asset is a stablecoin worth 1 USD.
+func TVLToUSD(tvl *big.Int, decimals uint8) float64 {
+	if tvl == nil {
+		return 0
+	}
+	unit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	usd, _ := new(big.Rat).SetFrac(tvl, unit).Float64()
+	return usd
+}
@@ -0,0 +1,165 @@
+package policy
+
+import (
+	"math/big"
+	"testing"
+
+	"rebalance/workflow/internal/helper"
+	"rebalance/workflow/internal/onchain"
+	"rebalance/workflow/internal/protocol"
+
+	"github.com/stretchr/testify/require"
+)
+
+/*//////////////////////////////////////////////////////////////
+                         TEST HELPERS
+//////////////////////////////////////////////////////////////*/
+
+func ptr(v float64) *float64 {
+	return &v
+}
+
+func withAPY(protocolId [32]byte, chainSelector uint64, apy float64) onchain.StrategyWithAPY {
+	return onchain.StrategyWithAPY{
+		Strategy: onchain.Strategy{ProtocolId: protocolId, ChainSelector: chainSelector},
+		APY:      apy,
+	}
+}
+
+// usdc returns n whole USDC in raw 6-decimal units.
+func usdc(n int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(n), big.NewInt(1_000_000))
+}
+
+/*//////////////////////////////////////////////////////////////
+                       EVALUATE THRESHOLD
+//////////////////////////////////////////////////////////////*/
+
+func Test_EvaluateThreshold_builtinDefault(t *testing.T) {
+	current := withAPY(onchain.AaveV3ProtocolId, 1, 0.03)
+
+	allowed := EvaluateThreshold(helper.ThresholdConfig{}, current, withAPY(onchain.CompoundV3ProtocolId, 1, 0.04), usdc(1_000), 6)
+	require.True(t, allowed.Allowed, "1pp delta should meet the built-in 100 bps threshold")
+	require.Equal(t, RuleBuiltin, allowed.Rule)
+	require.Empty(t, allowed.Check)
+	require.InDelta(t, 0.01, allowed.MinAPYDelta, 1e-12)
+	require.InDelta(t, 10.0, allowed.AnnualGainUSD, 1e-9)
+
+	blocked := EvaluateThreshold(helper.ThresholdConfig{}, current, withAPY(onchain.CompoundV3ProtocolId, 1, 0.035), usdc(1_000), 6)
+	require.False(t, blocked.Allowed)
+	require.Equal(t, RuleBuiltin, blocked.Rule)
+	require.Equal(t, CheckAPYDelta, blocked.Check)
+}
+
+func Test_EvaluateThreshold_blockedByAnnualGain(t *testing.T) {
+	cfg := helper.ThresholdConfig{
+		MinAPYDeltaBps:   ptr(50),
+		MinAnnualGainUSD: ptr(1_000),
+	}
+	current := withAPY(onchain.AaveV3ProtocolId, 1, 0.03)
+	optimal := withAPY(onchain.CompoundV3ProtocolId, 1, 0.04)
+
+	// 10k USDC * 1pp = $100/yr < $1000
+	decision := EvaluateThreshold(cfg, current, optimal, usdc(10_000), 6)
+	require.False(t, decision.Allowed)
+	require.Equal(t, RuleDefault, decision.Rule)
+	require.Equal(t, CheckAnnualGain, decision.Check)
+	require.InDelta(t, 100.0, decision.AnnualGainUSD, 1e-6)
+
+	// 1M USDC * 1pp = $10k/yr >= $1000
+	decision = EvaluateThreshold(cfg, current, optimal, usdc(1_000_000), 6)
+	require.True(t, decision.Allowed)
+	require.Equal(t, RuleDefault, decision.Rule)
+}
+
+func Test_EvaluateThreshold_routeOverride(t *testing.T) {
+	cfg := helper.ThresholdConfig{
+		MinAPYDeltaBps: ptr(50),
+		SameChain:      &helper.ThresholdRule{MinAPYDeltaBps: ptr(25)},
+		CrossChain:     &helper.ThresholdRule{MinAPYDeltaBps: ptr(150)},
+	}
+	current := withAPY(onchain.AaveV3ProtocolId, 1, 0.03)
+
+	// 1pp same chain: 100 >= 25 bps
+	decision := EvaluateThreshold(cfg, current, withAPY(onchain.CompoundV3ProtocolId, 1, 0.04), usdc(1), 6)
+	require.True(t, decision.Allowed)
+	require.Equal(t, RuleSameChain, decision.Rule)
+
+	// 1pp cross chain: 100 < 150 bps
+	decision = EvaluateThreshold(cfg, current, withAPY(onchain.CompoundV3ProtocolId, 2, 0.04), usdc(1), 6)
+	require.False(t, decision.Allowed)
+	require.Equal(t, RuleCrossChain, decision.Rule)
+	require.Equal(t, CheckAPYDelta, decision.Check)
+}
+
+func Test_EvaluateThreshold_protocolOverrideTakesPrecedence(t *testing.T) {
+	cfg := helper.ThresholdConfig{
+		MinAPYDeltaBps: ptr(50),
+		CrossChain:     &helper.ThresholdRule{MinAPYDeltaBps: ptr(150)},
+		Protocols: map[string]helper.ThresholdRule{
+			"compound-v3": {MinAPYDeltaBps: ptr(75)},
+		},
+	}
+	current := withAPY(onchain.AaveV3ProtocolId, 1, 0.03)
+
+	decision := EvaluateThreshold(cfg, current, withAPY(onchain.CompoundV3ProtocolId, 2, 0.04), usdc(1), 6)
+	require.True(t, decision.Allowed, "protocol override (75 bps) should win over cross-chain (150 bps)")
+	require.Equal(t, "protocol:compound-v3", decision.Rule)
+
+	// Aave has no protocol override, so cross-chain applies.
+	decision = EvaluateThreshold(cfg, withAPY(onchain.CompoundV3ProtocolId, 1, 0.03), withAPY(onchain.AaveV3ProtocolId, 2, 0.04), usdc(1), 6)
+	require.False(t, decision.Allowed)
+	require.Equal(t, RuleCrossChain, decision.Rule)
+}
+
+func Test_EvaluateThreshold_protocolOverrideForConfigDefinedProtocol(t *testing.T) {
+	cfg := helper.ThresholdConfig{
+		MinAPYDeltaBps: ptr(150),
+		Protocols: map[string]helper.ThresholdRule{
+			"susds": {MinAPYDeltaBps: ptr(75)},
+		},
+	}
+	current := withAPY(onchain.AaveV3ProtocolId, 1, 0.03)
+
+	decision := EvaluateThreshold(cfg, current, withAPY(protocol.IDFromName("susds"), 1, 0.04), usdc(1), 6)
+	require.True(t, decision.Allowed)
+	require.Equal(t, "protocol:susds", decision.Rule)
+}
+
+func Test_EvaluateThreshold_overridesAreMergedPerField(t *testing.T) {
+	cfg := helper.ThresholdConfig{
+		MinAPYDeltaBps:   ptr(50),
+		MinAnnualGainUSD: ptr(1_000),
+		Protocols: map[string]helper.ThresholdRule{
+			// Only overrides the delta; the annual gain still comes from the default rule.
+			"compound-v3": {MinAPYDeltaBps: ptr(10)},
+		},
+	}
+	current := withAPY(onchain.AaveV3ProtocolId, 1, 0.03)
+
+	decision := EvaluateThreshold(cfg, current, withAPY(onchain.CompoundV3ProtocolId, 1, 0.04), usdc(1_000), 6)
+	require.False(t, decision.Allowed)
+	require.Equal(t, RuleDefault, decision.Rule)
+	require.Equal(t, CheckAnnualGain, decision.Check)
+	require.InDelta(t, 0.001, decision.MinAPYDelta, 1e-12)
+}
+
+func Test_EvaluateThreshold_nilTVL(t *testing.T) {
+	decision := EvaluateThreshold(
+		helper.ThresholdConfig{MinAnnualGainUSD: ptr(1)},
+		withAPY(onchain.AaveV3ProtocolId, 1, 0.01),
+		withAPY(onchain.CompoundV3ProtocolId, 1, 0.05),
+		nil,
+		6,
+	)
+	require.False(t, decision.Allowed)
+	require.Equal(t, CheckAnnualGain, decision.Check)
+	require.Zero(t, decision.AnnualGainUSD)
+}
+
+func Test_TVLToUSD_usesAssetDecimals(t *testing.T) {
+	require.Equal(t, 1_000.0, TVLToUSD(usdc(1_000), 6))
+
+	dai := new(big.Int).Mul(big.NewInt(1_000), new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil))
+	require.Equal(t, 1_000.0, TVLToUSD(dai, 18))
+}
@@ -0,0 +1,21 @@
+package price
+
+import (
+	"fmt"
+
+	"rebalance/contracts/evm/src/generated/aggregator_v3"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/cre-sdk-go/capabilities/blockchain/evm"
+)
+
+// newFeedBinding constructs the AggregatorV3 binding.
+// It validates the address and returns an interface for testability.
+func newFeedBinding(client *evm.Client, addr string) (FeedInterface, error) {
+	if !common.IsHexAddress(addr) {
+		return nil, fmt.Errorf("invalid price feed address: %s", addr)
+	}
+	feedAddr := common.HexToAddress(addr)
+
+	return aggregator_v3.NewAggregatorV3(client, feedAddr, nil)
+}
@@ -0,0 +1,6 @@
+package price
+
+// Dependency injection for price feeds.
+var (
+	newFeedBindingFunc = newFeedBinding
+)
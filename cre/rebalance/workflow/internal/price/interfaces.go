@@ -0,0 +1,15 @@
+package price
+
+import (
+	"math/big"
+
+	"rebalance/contracts/evm/src/generated/aggregator_v3"
+
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+)
+
+// FeedInterface abstracts a Chainlink AggregatorV3 price feed.
+type FeedInterface interface {
+	Decimals(runtime cre.Runtime, blockNumber *big.Int) cre.Promise[uint8]
+	LatestRoundData(runtime cre.Runtime, blockNumber *big.Int) cre.Promise[aggregator_v3.LatestRoundDataOutput]
+}
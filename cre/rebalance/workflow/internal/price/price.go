@@ -0,0 +1,53 @@
+// Package price prices reward tokens in USD.
+package price
+
+import (
+	"fmt"
+	"math/big"
+
+	"rebalance/contracts/evm/src/generated/aggregator_v3"
+	"rebalance/workflow/internal/helper"
+
+	"github.com/smartcontractkit/cre-sdk-go/capabilities/blockchain/evm"
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+)
+
+// GetUSDPricePromise returns a promise of the USD price of token on the chain
+// of evmCfg: the latest answer of its Chainlink feed at the configured block,
+// or its static priceUsd when it has no feed. A non-positive feed answer, or
+// one last updated more than the token's heartbeat ago, is an error.
+func GetUSDPricePromise(config *helper.Config, runtime cre.Runtime, evmCfg *helper.EvmConfig, token helper.RewardTokenConfig) cre.Promise[float64] {
+	if token.PriceFeedAddress == "" {
+		if token.PriceUSD <= 0 {
+			return cre.PromiseFromResult(0.0, fmt.Errorf("no price source for reward token %s on chain %s", token.Symbol, evmCfg.ChainName))
+		}
+		return cre.PromiseFromResult(token.PriceUSD, nil)
+	}
+
+	feed, err := newFeedBindingFunc(&evm.Client{ChainSelector: evmCfg.ChainSelector}, token.PriceFeedAddress)
+	if err != nil {
+		return cre.PromiseFromResult(0.0, fmt.Errorf("failed to create price feed binding for %s on chain %s: %w", token.Symbol, evmCfg.ChainName, err))
+	}
+
+	blockNumber := big.NewInt(config.BlockNumber)
+	now := runtime.Now().Unix()
+	decimalsPromise := feed.Decimals(runtime, blockNumber)
+	roundPromise := feed.LatestRoundData(runtime, blockNumber)
+
+	return cre.ThenPromise(decimalsPromise, func(decimals uint8) cre.Promise[float64] {
+		return cre.Then(roundPromise, func(round aggregator_v3.LatestRoundDataOutput) (float64, error) {
+			if round.Answer == nil || round.Answer.Sign() <= 0 {
+				return 0, fmt.Errorf("price feed for %s on chain %s returned non-positive answer %v", token.Symbol, evmCfg.ChainName, round.Answer)
+			}
+			if round.UpdatedAt == nil || !round.UpdatedAt.IsInt64() {
+				return 0, fmt.Errorf("price feed for %s on chain %s returned invalid updatedAt %v", token.Symbol, evmCfg.ChainName, round.UpdatedAt)
+			}
+			if age := now - round.UpdatedAt.Int64(); age > int64(token.PriceFeedHeartbeat()) {
+				return 0, fmt.Errorf("price feed for %s on chain %s is stale: updated %ds ago, heartbeat is %ds", token.Symbol, evmCfg.ChainName, age, token.PriceFeedHeartbeat())
+			}
+			unit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+			usd, _ := new(big.Rat).SetFrac(round.Answer, unit).Float64()
+			return usd, nil
+		})
+	})
+}
@@ -0,0 +1,92 @@
+package price
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"rebalance/contracts/evm/src/generated/aggregator_v3"
+	"rebalance/workflow/internal/helper"
+
+	"github.com/smartcontractkit/cre-sdk-go/capabilities/blockchain/evm"
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+	"github.com/smartcontractkit/cre-sdk-go/cre/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeFeed struct {
+	decimals  uint8
+	answer    *big.Int
+	updatedAt int64
+	err       error
+}
+
+func (f fakeFeed) Decimals(cre.Runtime, *big.Int) cre.Promise[uint8] {
+	return cre.PromiseFromResult(f.decimals, nil)
+}
+
+func (f fakeFeed) LatestRoundData(cre.Runtime, *big.Int) cre.Promise[aggregator_v3.LatestRoundDataOutput] {
+	return cre.PromiseFromResult(aggregator_v3.LatestRoundDataOutput{Answer: f.answer, UpdatedAt: big.NewInt(f.updatedAt)}, f.err)
+}
+
+func useFeed(t *testing.T, feed FeedInterface) {
+	orig := newFeedBindingFunc
+	newFeedBindingFunc = func(*evm.Client, string) (FeedInterface, error) { return feed, nil }
+	t.Cleanup(func() { newFeedBindingFunc = orig })
+}
+
+var testChain = &helper.EvmConfig{ChainName: "test-chain", ChainSelector: 1}
+
+func Test_GetUSDPricePromise_staticPrice(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+
+	price, err := GetUSDPricePromise(&helper.Config{}, runtime, testChain, helper.RewardTokenConfig{Symbol: "COMP", PriceUSD: 42.5}).Await()
+	require.NoError(t, err)
+	require.Equal(t, 42.5, price)
+
+	_, err = GetUSDPricePromise(&helper.Config{}, runtime, testChain, helper.RewardTokenConfig{Symbol: "COMP"}).Await()
+	require.ErrorContains(t, err, "no price source for reward token COMP on chain test-chain")
+}
+
+func Test_GetUSDPricePromise_feed(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+	useFeed(t, fakeFeed{decimals: 8, answer: big.NewInt(4_250_000_000), updatedAt: runtime.Now().Unix()})
+	token := helper.RewardTokenConfig{Symbol: "COMP", PriceUSD: 1, PriceFeedAddress: "0x0000000000000000000000000000000000000001"}
+
+	price, err := GetUSDPricePromise(&helper.Config{}, runtime, testChain, token).Await()
+	require.NoError(t, err)
+	require.Equal(t, 42.5, price, "the feed takes precedence over the static price")
+}
+
+func Test_GetUSDPricePromise_feedErrors(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+	token := helper.RewardTokenConfig{Symbol: "COMP", PriceFeedAddress: "0x0000000000000000000000000000000000000001"}
+
+	useFeed(t, fakeFeed{decimals: 8, answer: big.NewInt(0), updatedAt: runtime.Now().Unix()})
+	_, err := GetUSDPricePromise(&helper.Config{}, runtime, testChain, token).Await()
+	require.ErrorContains(t, err, "non-positive answer 0")
+
+	useFeed(t, fakeFeed{err: errors.New("rpc down")})
+	_, err = GetUSDPricePromise(&helper.Config{}, runtime, testChain, token).Await()
+	require.ErrorContains(t, err, "rpc down")
+}
+
+func Test_GetUSDPricePromise_staleFeed(t *testing.T) {
+	runtime := testutils.NewRuntime(t, nil)
+	now := runtime.Now().Unix()
+	token := helper.RewardTokenConfig{Symbol: "COMP", PriceFeedAddress: "0x0000000000000000000000000000000000000001", PriceFeedHeartbeatSeconds: 3600}
+
+	useFeed(t, fakeFeed{decimals: 8, answer: big.NewInt(4_250_000_000), updatedAt: now - 3600})
+	price, err := GetUSDPricePromise(&helper.Config{}, runtime, testChain, token).Await()
+	require.NoError(t, err)
+	require.Equal(t, 42.5, price, "an answer exactly one heartbeat old is still fresh")
+
+	useFeed(t, fakeFeed{decimals: 8, answer: big.NewInt(4_250_000_000), updatedAt: now - 3601})
+	_, err = GetUSDPricePromise(&helper.Config{}, runtime, testChain, token).Await()
+	require.ErrorContains(t, err, "price feed for COMP on chain test-chain is stale: updated 3601s ago, heartbeat is 3600s")
+
+	token.PriceFeedHeartbeatSeconds = 0
+	useFeed(t, fakeFeed{decimals: 8, answer: big.NewInt(4_250_000_000), updatedAt: now - helper.DefaultPriceFeedHeartbeatSeconds - 1})
+	_, err = GetUSDPricePromise(&helper.Config{}, runtime, testChain, token).Await()
+	require.ErrorContains(t, err, "heartbeat is 86400s")
+}
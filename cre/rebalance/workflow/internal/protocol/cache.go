@@ -0,0 +1,41 @@
+package protocol
+
+import (
+	"sync"
+
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+)
+
+// Cache holds the promises of the reads the candidates of one run share, so a
+// value a protocol reads once per run (e.g. a rate model) is read once however
+// many candidates use it. Each run makes its own with NewCache; a nil Cache
+// caches nothing.
+type Cache struct {
+	mu     sync.Mutex
+	values map[any]any
+}
+
+// NewCache returns an empty Cache for a run.
+func NewCache() *Cache {
+	return &Cache{values: make(map[any]any)}
+}
+
+// Cached returns the promise cache holds for key, calling read on a miss. key
+// must be comparable; keys of different types never match, so each protocol
+// keys its reads with a type of its own. A failed read stays cached for the
+// rest of the run.
+func Cached[V any](cache *Cache, key any, read func() cre.Promise[V]) cre.Promise[V] {
+	if cache == nil {
+		return read()
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if value, ok := cache.values[key]; ok {
+		return value.(cre.Promise[V])
+	}
+	value := read()
+	cache.values[key] = value
+	return value
+}
@@ -0,0 +1,30 @@
+package protocol
+
+import (
+	"testing"
+
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCached_readsOncePerKeyPerCache(t *testing.T) {
+	cache := NewCache()
+	reads := 0
+	read := func() cre.Promise[int] {
+		reads++
+		return cre.PromiseFromResult(reads, nil)
+	}
+
+	first, err := Cached(cache, 1, read).Await()
+	require.NoError(t, err)
+	again, err := Cached(cache, 1, read).Await()
+	require.NoError(t, err)
+	require.Equal(t, first, again)
+	Cached(cache, 2, read)
+	require.Equal(t, 2, reads)
+
+	Cached(NewCache(), 1, read)
+	require.Equal(t, 3, reads, "a new run reads again")
+	Cached(nil, 1, read)
+	require.Equal(t, 4, reads, "a nil cache caches nothing")
+}
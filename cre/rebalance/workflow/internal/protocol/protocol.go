@@ -0,0 +1,194 @@
+// Package protocol is the registry of yield sources the workflow can evaluate.
+//
+// Each yield source is a self-contained package that implements Protocol and
+// registers it from an init function:
+//
+//	func init() { protocol.Register(aaveV3Protocol{}) }
+//
+// Yield sources with one protocol per configured instance (e.g. one per
+// ERC-4626 vault listed in config) implement Family and register it with
+// RegisterFamily instead; Registry.ForConfig expands them for a run.
+//
+// The onchain package imports every yield source package for its side effect.
+package protocol
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"rebalance/workflow/internal/helper"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+)
+
+// Protocol is a yield source a strategy can allocate to.
+type Protocol interface {
+	// ID is the protocol ID used on-chain (keccak256 of Name).
+	ID() [32]byte
+	// Name is the human-readable protocol name, e.g. "aave-v3".
+	Name() string
+	// IsConfigured reports whether evm configures this protocol on its chain
+	// for asset, the vault asset on that chain.
+	IsConfigured(evm helper.EvmConfig, asset helper.AssetConfig) bool
+	// GetAPYPromise returns the APY on chainSelector after liquidityAdded is
+	// supplied (e.g. 0.0523 = 5.23%). Reads shared between the run's
+	// candidates go through cache.
+	GetAPYPromise(config *helper.Config, runtime cre.Runtime, cache *Cache, liquidityAdded *big.Int, chainSelector uint64) cre.Promise[float64]
+}
+
+// RewardSource is implemented by protocols whose suppliers also earn reward
+// emissions on top of the supply APY.
+type RewardSource interface {
+	// GetRewardAPRPromise returns the reward APR on chainSelector after
+	// liquidityAdded is supplied, with rewards priced in USD (e.g. 0.01 = 1%).
+	GetRewardAPRPromise(config *helper.Config, runtime cre.Runtime, liquidityAdded *big.Int, chainSelector uint64) cre.Promise[float64]
+}
+
+// HealthSource is implemented by protocols that can tell whether a market is
+// safe to hold funds in (not paused, frozen or carrying bad debt).
+type HealthSource interface {
+	// GetHealthPromise returns the market's health issues on chainSelector
+	// (e.g. "supply paused"); none means healthy.
+	GetHealthPromise(config *helper.Config, runtime cre.Runtime, chainSelector uint64) cre.Promise[[]string]
+}
+
+// LiquiditySource is implemented by protocols that can tell how much of the
+// supplied asset could be withdrawn right now.
+type LiquiditySource interface {
+	// GetAvailableLiquidityPromise returns the liquidity available to withdraw
+	// on chainSelector after liquidityAdded is supplied, in raw units of the
+	// chain's vault asset.
+	GetAvailableLiquidityPromise(config *helper.Config, runtime cre.Runtime, liquidityAdded *big.Int, chainSelector uint64) cre.Promise[*big.Int]
+}
+
+// ErrUnhealthy is wrapped by the errors of candidates whose market reported
+// health issues. Such candidates are reported but never selected.
+var ErrUnhealthy = errors.New("market is unhealthy")
+
+// ErrInfeasible is wrapped by APY errors of candidates that cannot absorb the
+// liquidity they are evaluated with (e.g. a full supply cap). Such candidates
+// are reported but never selected, whatever the evaluation error policy.
+var ErrInfeasible = errors.New("candidate cannot absorb the deposit")
+
+// Family is a yield source that contributes one Protocol per instance
+// configured in config.
+type Family interface {
+	// Name identifies the family, e.g. "erc4626".
+	Name() string
+	// Protocols returns a protocol for every instance configured in config.
+	Protocols(config *helper.Config) ([]Protocol, error)
+}
+
+// IDFromName returns the on-chain protocol ID for name: keccak256(name).
+func IDFromName(name string) [32]byte {
+	return [32]byte(crypto.Keccak256Hash([]byte(name)))
+}
+
+// Registry holds protocols by ID, and the families to expand per config.
+type Registry struct {
+	byID     map[[32]byte]Protocol
+	families []Family
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{byID: make(map[[32]byte]Protocol)}
+}
+
+// RegisterFamily adds f. A second family with the same name is an error.
+func (r *Registry) RegisterFamily(f Family) error {
+	for _, registered := range r.families {
+		if registered.Name() == f.Name() {
+			return fmt.Errorf("protocol family %s is already registered", f.Name())
+		}
+	}
+	r.families = append(r.families, f)
+	return nil
+}
+
+// ForConfig returns a new registry holding r's protocols plus every protocol
+// r's families configure in config. r itself is not modified.
+func (r *Registry) ForConfig(config *helper.Config) (*Registry, error) {
+	out := NewRegistry()
+	for id, p := range r.byID {
+		out.byID[id] = p
+	}
+	for _, f := range r.families {
+		protocols, err := f.Protocols(config)
+		if err != nil {
+			return nil, fmt.Errorf("protocol family %s: %w", f.Name(), err)
+		}
+		for _, p := range protocols {
+			if err := out.Register(p); err != nil {
+				return nil, fmt.Errorf("protocol family %s: %w", f.Name(), err)
+			}
+		}
+	}
+	return out, nil
+}
+
+// Register adds p. A second protocol with the same ID or name is an error.
+func (r *Registry) Register(p Protocol) error {
+	if _, ok := r.byID[p.ID()]; ok {
+		return fmt.Errorf("protocol %s (%x) is already registered", p.Name(), p.ID())
+	}
+	for _, registered := range r.byID {
+		if registered.Name() == p.Name() {
+			return fmt.Errorf("protocol name %s is already registered", p.Name())
+		}
+	}
+	r.byID[p.ID()] = p
+	return nil
+}
+
+// Lookup returns the protocol with the given ID. A nil registry holds none.
+func (r *Registry) Lookup(id [32]byte) (Protocol, bool) {
+	if r == nil {
+		return nil, false
+	}
+	p, ok := r.byID[id]
+	return p, ok
+}
+
+// All returns every registered protocol, ordered by name so that results do
+// not depend on package initialization order.
+func (r *Registry) All() []Protocol {
+	all := make([]Protocol, 0, len(r.byID))
+	for _, p := range r.byID {
+		all = append(all, p)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Name() < all[j].Name() })
+	return all
+}
+
+// Name returns the name of the protocol with the given ID, or
+// "unknown(<id>)" when none is registered. Config-defined protocols are only
+// named by the registry ForConfig returned for that config.
+func (r *Registry) Name(id [32]byte) string {
+	if p, ok := r.Lookup(id); ok {
+		return p.Name()
+	}
+	return fmt.Sprintf("unknown(%x)", id)
+}
+
+// Default is the registry yield source packages register into.
+var Default = NewRegistry()
+
+// Register adds p to Default. It panics on a duplicate, which is a
+// programming error caught at init.
+func Register(p Protocol) {
+	if err := Default.Register(p); err != nil {
+		panic(err)
+	}
+}
+
+// RegisterFamily adds f to Default. It panics on a duplicate, which is a
+// programming error caught at init.
+func RegisterFamily(f Family) {
+	if err := Default.RegisterFamily(f); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,117 @@
+package protocol
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"rebalance/workflow/internal/helper"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+	"github.com/stretchr/testify/require"
+)
+
+type stubProtocol struct{ name string }
+
+func (s stubProtocol) ID() [32]byte                                           { return IDFromName(s.name) }
+func (s stubProtocol) Name() string                                           { return s.name }
+func (s stubProtocol) IsConfigured(helper.EvmConfig, helper.AssetConfig) bool { return true }
+func (s stubProtocol) GetAPYPromise(*helper.Config, cre.Runtime, *Cache, *big.Int, uint64) cre.Promise[float64] {
+	return cre.PromiseFromResult(0.0, nil)
+}
+
+func Test_IDFromName_isKeccakOfName(t *testing.T) {
+	require.Equal(t,
+		[32]byte(common.HexToHash("0xbbbf88eb3aaea499bd8961e51ce38087d4dda7879001b87ead64f8a7a3d0b2da")),
+		IDFromName("aave-v3"),
+	)
+}
+
+func Test_Registry_lookupAndOrder(t *testing.T) {
+	r := NewRegistry()
+	require.NoError(t, r.Register(stubProtocol{name: "morpho"}))
+	require.NoError(t, r.Register(stubProtocol{name: "aave-v3"}))
+
+	p, ok := r.Lookup(IDFromName("morpho"))
+	require.True(t, ok)
+	require.Equal(t, "morpho", p.Name())
+
+	_, ok = r.Lookup(IDFromName("euler"))
+	require.False(t, ok)
+
+	var names []string
+	for _, p := range r.All() {
+		names = append(names, p.Name())
+	}
+	require.Equal(t, []string{"aave-v3", "morpho"}, names, "ordered by name, not registration")
+
+	require.Equal(t, "aave-v3", r.Name(IDFromName("aave-v3")))
+	require.Contains(t, r.Name([32]byte{}), "unknown(")
+}
+
+func Test_Registry_errorOnDuplicate(t *testing.T) {
+	r := NewRegistry()
+	require.NoError(t, r.Register(stubProtocol{name: "aave-v3"}))
+
+	err := r.Register(stubProtocol{name: "aave-v3"})
+	require.ErrorContains(t, err, "protocol aave-v3")
+	require.ErrorContains(t, err, "is already registered")
+}
+
+type stubFamily struct {
+	names []string
+	err   error
+}
+
+func (f stubFamily) Name() string { return "stub" }
+func (f stubFamily) Protocols(*helper.Config) ([]Protocol, error) {
+	var protocols []Protocol
+	for _, name := range f.names {
+		protocols = append(protocols, stubProtocol{name: name})
+	}
+	return protocols, f.err
+}
+
+func Test_Registry_ForConfig_expandsFamilies(t *testing.T) {
+	r := NewRegistry()
+	require.NoError(t, r.Register(stubProtocol{name: "aave-v3"}))
+	require.NoError(t, r.RegisterFamily(stubFamily{names: []string{"vault-b", "vault-a"}}))
+
+	expanded, err := r.ForConfig(&helper.Config{})
+	require.NoError(t, err)
+
+	var names []string
+	for _, p := range expanded.All() {
+		names = append(names, p.Name())
+	}
+	require.Equal(t, []string{"aave-v3", "vault-a", "vault-b"}, names)
+	require.Len(t, r.All(), 1, "the base registry is not modified")
+}
+
+func Test_Registry_ForConfig_errors(t *testing.T) {
+	r := NewRegistry()
+	require.NoError(t, r.Register(stubProtocol{name: "aave-v3"}))
+	require.NoError(t, r.RegisterFamily(stubFamily{names: []string{"aave-v3"}}))
+
+	_, err := r.ForConfig(&helper.Config{})
+	require.ErrorContains(t, err, "protocol family stub: protocol aave-v3")
+
+	r = NewRegistry()
+	require.NoError(t, r.RegisterFamily(stubFamily{err: errors.New("bad vault")}))
+	_, err = r.ForConfig(&helper.Config{})
+	require.ErrorContains(t, err, "protocol family stub: bad vault")
+
+	require.ErrorContains(t, r.RegisterFamily(stubFamily{}), "protocol family stub is already registered")
+}
+
+func Test_Registry_Name_onlyNamesRegisteredProtocols(t *testing.T) {
+	r := NewRegistry()
+	require.NoError(t, r.RegisterFamily(stubFamily{names: []string{"vault-from-config"}}))
+	id := IDFromName("vault-from-config")
+	require.Contains(t, r.Name(id), "unknown(", "families are only expanded by ForConfig")
+
+	forConfig, err := r.ForConfig(&helper.Config{})
+	require.NoError(t, err)
+	require.Equal(t, "vault-from-config", forConfig.Name(id))
+}
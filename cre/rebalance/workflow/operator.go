@@ -0,0 +1,275 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"rebalance/workflow/internal/helper"
+	"rebalance/workflow/internal/onchain"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/cre-sdk-go/capabilities/blockchain/evm"
+	"github.com/smartcontractkit/cre-sdk-go/capabilities/networking/http"
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+)
+
+/*//////////////////////////////////////////////////////////////
+                       OPERATOR REQUESTS
+//////////////////////////////////////////////////////////////*/
+
+// OperatorAction is what an operator request asks the workflow to do.
+type OperatorAction string
+
+const (
+	OperatorEvaluate OperatorAction = "evaluate" // run the pipeline without writing and return the candidate table
+	OperatorOverride OperatorAction = "override" // write Strategy, bypassing the decision policies
+)
+
+// OperatorRequest is the JSON body of a signed operator request:
+//
+//	{ "action": "evaluate" }
+//	{ "action": "override", "strategy": { "protocol": "aave-v3", "chainSelector": 1 } }
+//
+// The override strategy names its protocol, gives its protocolId, or both
+// (see onchain.StrategySet.Resolve).
+type OperatorRequest struct {
+	Action   OperatorAction         `json:"action"`
+	Strategy *onchain.NamedStrategy `json:"strategy,omitempty"` // override only
+}
+
+// parseOperatorRequest decodes and validates an operator request body.
+func parseOperatorRequest(input []byte) (OperatorRequest, error) {
+	var req OperatorRequest
+	if err := json.Unmarshal(input, &req); err != nil {
+		return OperatorRequest{}, fmt.Errorf("invalid operator request: %w", err)
+	}
+	switch req.Action {
+	case OperatorEvaluate:
+	case OperatorOverride:
+		if req.Strategy == nil {
+			return OperatorRequest{}, fmt.Errorf("override requires a strategy")
+		}
+	default:
+		return OperatorRequest{}, fmt.Errorf("unknown operator action %q", req.Action)
+	}
+	return req, nil
+}
+
+// authorizeOperator checks that key, the address that signed the request, is
+// one of config.Operator.AuthorizedKeys.
+func authorizeOperator(config *helper.Config, key string) error {
+	if !config.Operator.Enabled {
+		return fmt.Errorf("operator endpoint is disabled")
+	}
+	if !common.IsHexAddress(key) {
+		return fmt.Errorf("invalid operator key: %s", key)
+	}
+	signer := common.HexToAddress(key)
+	for _, authorized := range config.Operator.AuthorizedKeys {
+		if common.IsHexAddress(authorized) && common.HexToAddress(authorized) == signer {
+			return nil
+		}
+	}
+	return fmt.Errorf("operator key %s is not authorized", signer.Hex())
+}
+
+// validateOperatorConfig checks the authorized keys.
+func validateOperatorConfig(config *helper.Config) error {
+	if len(config.Operator.AuthorizedKeys) == 0 {
+		return fmt.Errorf("operator endpoint enabled without authorized keys")
+	}
+	for _, key := range config.Operator.AuthorizedKeys {
+		if !common.IsHexAddress(key) {
+			return fmt.Errorf("invalid authorized operator key: %s", key)
+		}
+	}
+	return nil
+}
+
+// operatorHandler returns the HTTP trigger handler serving operator requests.
+// The trigger only accepts requests signed by one of
+// config.Operator.AuthorizedKeys.
+func operatorHandler(config *helper.Config) (cre.ExecutionHandler[*helper.Config, cre.Runtime], error) {
+	if err := validateOperatorConfig(config); err != nil {
+		return nil, err
+	}
+
+	keys := make([]*http.AuthorizedKey, 0, len(config.Operator.AuthorizedKeys))
+	for _, key := range config.Operator.AuthorizedKeys {
+		keys = append(keys, &http.AuthorizedKey{
+			Type:      http.KeyType_KEY_TYPE_ECDSA_EVM,
+			PublicKey: common.HexToAddress(key).Hex(),
+		})
+	}
+	return cre.Handler(http.Trigger(&http.Config{AuthorizedKeys: keys}), onOperatorRequest), nil
+}
+
+func onOperatorRequest(config *helper.Config, runtime cre.Runtime, payload *http.Payload) (*StrategyResult, error) {
+	return onOperatorRequestWithDeps(config, runtime, payload, defaultOnCronDeps)
+}
+
+// operatorSigner returns the address the HTTP trigger verified the signature
+// of payload against.
+func operatorSigner(payload *http.Payload) (string, error) {
+	key := payload.GetKey()
+	if key == nil {
+		return "", fmt.Errorf("operator request carries no signing key")
+	}
+	if key.GetType() != http.KeyType_KEY_TYPE_ECDSA_EVM {
+		return "", fmt.Errorf("unsupported operator key type %s", key.GetType())
+	}
+	return key.GetPublicKey(), nil
+}
+
+// onOperatorRequestWithDeps serves a signed operator request. The signer is
+// taken from the trigger payload and checked against the authorized keys
+// again, so a trigger configured with stale keys cannot widen access.
+func onOperatorRequestWithDeps(config *helper.Config, runtime cre.Runtime, payload *http.Payload, deps OnCronDeps) (*StrategyResult, error) {
+	key, err := operatorSigner(payload)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeOperator(config, key); err != nil {
+		return nil, err
+	}
+	req, err := parseOperatorRequest(payload.GetInput())
+	if err != nil {
+		return nil, err
+	}
+
+	runtime.Logger().Info("Operator request received", "action", req.Action, "operator", key)
+
+	var result *StrategyResult
+	switch req.Action {
+	case OperatorEvaluate:
+		// Evaluate as a dry run so nothing is written.
+		dryRun := *config
+		dryRun.DryRun = true
+		result, err = evaluateStrategy(&dryRun, runtime, nil, deps)
+	case OperatorOverride:
+		result, err = overrideStrategy(config, runtime, *req.Strategy, deps)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result.Operator = common.HexToAddress(key).Hex()
+	return result, nil
+}
+
+// overrideStrategy writes the requested strategy without consulting the
+// decision policies. The strategy must still be in the supported strategy set
+// and pass the pre-flight checks, which include ParentPeer supporting the
+// protocol and allowing the chain.
+func overrideStrategy(config *helper.Config, runtime cre.Runtime, requested onchain.NamedStrategy, deps OnCronDeps) (*StrategyResult, error) {
+	logger := runtime.Logger()
+
+	strategies, err := deps.NewStrategySet(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build supported strategy set: %w", err)
+	}
+	strategy, err := strategies.Resolve(requested)
+	if err != nil {
+		return nil, fmt.Errorf("invalid override strategy: %w", err)
+	}
+	if !strategies.Contains(strategy) {
+		return nil, fmt.Errorf("override strategy 0x%x on chain %d is not a supported strategy", strategy.ProtocolId, strategy.ChainSelector)
+	}
+
+	if len(config.Evms) == 0 {
+		return nil, fmt.Errorf("no EVM configs provided")
+	}
+	parentCfg := config.Evms[0]
+	parentEvmClient := &evm.Client{ChainSelector: parentCfg.ChainSelector}
+
+	parentPeer, err := deps.NewParentPeerBinding(parentEvmClient, parentCfg.YieldPeerAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ParentPeer binding: %w", err)
+	}
+
+	parentBlock, err := deps.ReadBlock(config, runtime, parentEvmClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read parent block: %w", err)
+	}
+
+	currentStrategy, err := deps.ReadCurrentStrategy(config, runtime, parentPeer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read strategy from ParentPeer: %w", err)
+	}
+
+	result := &StrategyResult{
+		Current:     strategies.Named(currentStrategy),
+		Optimal:     strategies.Named(strategy),
+		BlockNumber: config.BlockNumber,
+		ParentBlock: parentBlock,
+	}
+
+	// An override must not be stacked on a rebalance that is still moving funds.
+	if config.InFlight.Enabled {
+		inFlight, err := checkInFlight(config, runtime, deps)
+		if err != nil {
+			return nil, err
+		}
+		result.InFlight = inFlight
+		if inFlight.InFlight {
+			result.Reason = ReasonInFlight
+			result.Optimal = strategies.Named(currentStrategy)
+			return result, nil
+		}
+	}
+
+	if strategy == currentStrategy {
+		logger.Info("Override matches the current strategy; no rebalance needed")
+		result.Reason = ReasonUnchanged
+		return result, nil
+	}
+
+	// The rebalance starts on the chain the funds currently sit on.
+	strategyChainCfg, err := helper.FindEvmConfigByChainSelector(config.Evms, currentStrategy.ChainSelector)
+	if err != nil {
+		return nil, fmt.Errorf("no EVM config found for strategy chainSelector %d: %w", currentStrategy.ChainSelector, err)
+	}
+
+	parentRebalancer, err := deps.NewRebalancerBinding(parentEvmClient, parentCfg.RebalancerAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create parent Rebalancer binding: %w", err)
+	}
+
+	// ParentPeer must support the protocol and allow the chain, among the
+	// other pre-flight checks.
+	preflight, err := runPreflight(config, runtime, deps, parentPeer, parentRebalancer, strategy)
+	if err != nil {
+		return nil, err
+	}
+	result.Preflight = &preflight
+	if !preflight.Passed {
+		logger.Warn("Pre-flight check failed; override not written", "check", preflight.Check)
+		result.Reason = ReasonPreflightFailed
+		return result, nil
+	}
+
+	if config.DryRun {
+		payload, err := deps.EncodeRebalance(strategy)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode rebalance: %w", err)
+		}
+		result.Reason = ReasonDryRun
+		result.Rebalance = &payload
+		return result, nil
+	}
+
+	txHash, err := deps.WriteRebalance(parentRebalancer, runtime, strategyChainCfg.GasLimit, strategy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rebalance: %w", err)
+	}
+
+	logger.Warn(
+		"Operator override written",
+		"protocolId", fmt.Sprintf("0x%x", strategy.ProtocolId),
+		"chainSelector", strategy.ChainSelector,
+	)
+	result.Reason = ReasonOverride
+	result.Updated = true
+	result.TxHash = txHash
+	return result, nil
+}
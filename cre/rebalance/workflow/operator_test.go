@@ -0,0 +1,246 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"rebalance/workflow/internal/helper"
+	"rebalance/workflow/internal/onchain"
+	"rebalance/workflow/internal/policy"
+	"rebalance/workflow/internal/protocol"
+
+	"github.com/smartcontractkit/cre-sdk-go/capabilities/networking/http"
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+	"github.com/smartcontractkit/cre-sdk-go/cre/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+/*//////////////////////////////////////////////////////////////
+                        SETUP / UTILITY
+//////////////////////////////////////////////////////////////*/
+
+const operatorKey = "0x00000000000000000000000000000000000000aa"
+
+func newOperatorTestConfig() *helper.Config {
+	config := newFlowTestConfig(0)
+	config.Operator = helper.OperatorConfig{Enabled: true, AuthorizedKeys: []string{operatorKey}}
+	return config
+}
+
+// overrideTarget is the strategy overrideBody asks for.
+var overrideTarget = onchain.Strategy{ProtocolId: [32]byte{3}, ChainSelector: 1}
+
+// newOperatorTestDeps extends the flow test deps with a supported strategy
+// set of the current strategy and overrideTarget.
+func newOperatorTestDeps(evaluations *int, written *onchain.Strategy) OnCronDeps {
+	deps := newFlowTestDeps(evaluations)
+	deps.NewStrategySet = func(_ *helper.Config) (onchain.StrategySet, error) {
+		return onchain.NewStrategySetOf(onchain.Strategy{ProtocolId: [32]byte{1}, ChainSelector: 1}, overrideTarget), nil
+	}
+	deps.EncodeRebalance = func(_ onchain.Strategy) (onchain.RebalancePayload, error) {
+		return onchain.RebalancePayload{Report: []byte{0x01}}, nil
+	}
+	deps.WriteRebalance = func(_ onchain.RebalancerInterface, _ cre.Runtime, _ uint64, strategy onchain.Strategy) ([]byte, error) {
+		*written = strategy
+		return []byte{0xbb}, nil
+	}
+	return deps
+}
+
+// operatorPayload is an HTTP trigger payload carrying body, signed by key.
+func operatorPayload(key string, body []byte) *http.Payload {
+	return &http.Payload{
+		Input: body,
+		Key:   &http.AuthorizedKey{Type: http.KeyType_KEY_TYPE_ECDSA_EVM, PublicKey: key},
+	}
+}
+
+var overrideBody = []byte(`{"action":"override","strategy":{"protocolId":"0x0300000000000000000000000000000000000000000000000000000000000000","chainSelector":1}}`)
+
+/*//////////////////////////////////////////////////////////////
+                             TESTS
+//////////////////////////////////////////////////////////////*/
+
+func Test_onOperatorRequestWithDeps_evaluateDoesNotWrite(t *testing.T) {
+	config := newOperatorTestConfig()
+	runtime := testutils.NewRuntime(t, nil)
+
+	evaluations := 0
+	var written onchain.Strategy
+	deps := newOperatorTestDeps(&evaluations, &written)
+
+	res, err := onOperatorRequestWithDeps(config, runtime, operatorPayload(operatorKey, []byte(`{"action":"evaluate"}`)), deps)
+
+	require.NoError(t, err)
+	require.Equal(t, 1, evaluations)
+	require.Equal(t, ReasonDryRun, res.Reason)
+	require.False(t, res.Updated)
+	require.Zero(t, written, "evaluate must not write")
+	require.NotNil(t, res.Rebalance)
+	require.Equal(t, "0x00000000000000000000000000000000000000AA", res.Operator)
+	require.False(t, config.DryRun, "config must not be mutated")
+}
+
+func Test_onOperatorRequestWithDeps_overrideWritesStrategy(t *testing.T) {
+	config := newOperatorTestConfig()
+	runtime := testutils.NewRuntime(t, nil)
+
+	evaluations := 0
+	var written onchain.Strategy
+	deps := newOperatorTestDeps(&evaluations, &written)
+
+	res, err := onOperatorRequestWithDeps(config, runtime, operatorPayload(operatorKey, overrideBody), deps)
+
+	require.NoError(t, err)
+	require.Zero(t, evaluations, "override must not evaluate strategies")
+	require.Equal(t, ReasonOverride, res.Reason)
+	require.True(t, res.Updated)
+	require.Equal(t, []byte{0xbb}, []byte(res.TxHash))
+	require.Equal(t, onchain.Strategy{ProtocolId: [32]byte{3}, ChainSelector: 1}, written)
+	require.Equal(t, written, res.Optimal.Strategy)
+}
+
+func Test_onOperatorRequestWithDeps_overrideResolvesProtocolName(t *testing.T) {
+	config := newOperatorTestConfig()
+	config.Evms[0].USDCAddress = "0x00000000000000000000000000000000000000dd"
+	config.Evms[0].AaveMarkets = []helper.AaveMarketConfig{
+		{Name: "spark", PoolAddressesProviderAddress: "0x00000000000000000000000000000000000000bb"},
+	}
+	runtime := testutils.NewRuntime(t, nil)
+
+	evaluations := 0
+	var written onchain.Strategy
+	deps := newOperatorTestDeps(&evaluations, &written)
+	deps.NewStrategySet = onchain.NewStrategySet
+
+	body := []byte(`{"action":"override","strategy":{"protocol":"spark","chainSelector":1}}`)
+	res, err := onOperatorRequestWithDeps(config, runtime, operatorPayload(operatorKey, body), deps)
+
+	require.NoError(t, err)
+	require.True(t, res.Updated)
+	require.Equal(t, onchain.Strategy{ProtocolId: protocol.IDFromName("spark"), ChainSelector: 1}, written)
+	require.Equal(t, "spark", res.Optimal.Protocol)
+
+	body = []byte(`{"action":"override","strategy":{"protocol":"prime","chainSelector":1}}`)
+	_, err = onOperatorRequestWithDeps(config, runtime, operatorPayload(operatorKey, body), deps)
+	require.ErrorContains(t, err, `unknown protocol "prime"`)
+}
+
+func Test_onOperatorRequestWithDeps_overrideUnchanged(t *testing.T) {
+	config := newOperatorTestConfig()
+	runtime := testutils.NewRuntime(t, nil)
+
+	evaluations := 0
+	var written onchain.Strategy
+	deps := newOperatorTestDeps(&evaluations, &written)
+
+	// {1} on chain 1 is the current strategy in the flow test deps.
+	body := []byte(`{"action":"override","strategy":{"protocolId":"0x0100000000000000000000000000000000000000000000000000000000000000","chainSelector":1}}`)
+	res, err := onOperatorRequestWithDeps(config, runtime, operatorPayload(operatorKey, body), deps)
+
+	require.NoError(t, err)
+	require.Equal(t, ReasonUnchanged, res.Reason)
+	require.Zero(t, written)
+}
+
+func Test_onOperatorRequestWithDeps_overrideRejectsUnsupportedStrategy(t *testing.T) {
+	config := newOperatorTestConfig()
+	runtime := testutils.NewRuntime(t, nil)
+
+	evaluations := 0
+	var written onchain.Strategy
+
+	deps := newOperatorTestDeps(&evaluations, &written)
+	deps.NewStrategySet = noopNewStrategySet
+	_, err := onOperatorRequestWithDeps(config, runtime, operatorPayload(operatorKey, overrideBody), deps)
+	require.ErrorContains(t, err, "is not a supported strategy")
+
+	deps = newOperatorTestDeps(&evaluations, &written)
+	deps.ReadPreflightState = func(config *helper.Config, runtime cre.Runtime, parentPeer onchain.ParentPeerInterface, targetPeer onchain.StrategyAdapterReaderInterface, rb onchain.RebalancerInterface, strategy onchain.Strategy) (onchain.PreflightState, error) {
+		state, err := passingReadPreflightState(config, runtime, parentPeer, targetPeer, rb, strategy)
+		state.ProtocolSupported = false
+		return state, err
+	}
+	res, err := onOperatorRequestWithDeps(config, runtime, operatorPayload(operatorKey, overrideBody), deps)
+	require.NoError(t, err)
+	require.Equal(t, ReasonPreflightFailed, res.Reason)
+	require.Equal(t, policy.PreflightProtocolUnsupported, res.Preflight.Check)
+	require.False(t, res.Updated)
+
+	deps = newOperatorTestDeps(&evaluations, &written)
+	deps.ReadPreflightState = func(_ *helper.Config, _ cre.Runtime, _ onchain.ParentPeerInterface, _ onchain.StrategyAdapterReaderInterface, _ onchain.RebalancerInterface, _ onchain.Strategy) (onchain.PreflightState, error) {
+		return onchain.PreflightState{}, fmt.Errorf("call-failed")
+	}
+	_, err = onOperatorRequestWithDeps(config, runtime, operatorPayload(operatorKey, overrideBody), deps)
+	require.ErrorContains(t, err, "failed to read pre-flight state")
+	require.ErrorContains(t, err, "call-failed")
+
+	require.Zero(t, written)
+}
+
+func Test_onOperatorRequestWithDeps_rejectsUnauthorizedKey(t *testing.T) {
+	config := newOperatorTestConfig()
+	runtime := testutils.NewRuntime(t, nil)
+
+	evaluations := 0
+	var written onchain.Strategy
+	deps := newOperatorTestDeps(&evaluations, &written)
+
+	_, err := onOperatorRequestWithDeps(config, runtime, operatorPayload("0x00000000000000000000000000000000000000bb", overrideBody), deps)
+	require.ErrorContains(t, err, "is not authorized")
+
+	config.Operator.Enabled = false
+	_, err = onOperatorRequestWithDeps(config, runtime, operatorPayload(operatorKey, overrideBody), deps)
+	require.ErrorContains(t, err, "operator endpoint is disabled")
+
+	require.Zero(t, written)
+}
+
+func Test_parseOperatorRequest_errors(t *testing.T) {
+	for body, want := range map[string]string{
+		`not json`:              "invalid operator request",
+		`{"action":"override"}`: "override requires a strategy",
+		`{"action":"withdraw"}`: "unknown operator action",
+	} {
+		_, err := parseOperatorRequest([]byte(body))
+		require.ErrorContains(t, err, want, body)
+	}
+}
+
+func Test_onOperatorRequestWithDeps_rejectsUnverifiedSigner(t *testing.T) {
+	config := newOperatorTestConfig()
+	runtime := testutils.NewRuntime(t, nil)
+
+	evaluations := 0
+	var written onchain.Strategy
+	deps := newOperatorTestDeps(&evaluations, &written)
+
+	_, err := onOperatorRequestWithDeps(config, runtime, &http.Payload{Input: overrideBody}, deps)
+	require.ErrorContains(t, err, "operator request carries no signing key")
+
+	payload := operatorPayload(operatorKey, overrideBody)
+	payload.Key.Type = http.KeyType_KEY_TYPE_UNSPECIFIED
+	_, err = onOperatorRequestWithDeps(config, runtime, payload, deps)
+	require.ErrorContains(t, err, "unsupported operator key type")
+
+	require.Zero(t, written)
+}
+
+func Test_InitWorkflow_registersOperatorHandler(t *testing.T) {
+	config := newOperatorTestConfig()
+	config.Schedule = "0 */1 * * * *"
+	config.FlowTrigger.Enabled = false
+	logger := testutils.NewRuntime(t, nil).Logger()
+
+	wf, err := InitWorkflow(config, logger, nil)
+	require.NoError(t, err)
+	require.Len(t, wf, 2)
+
+	config.Operator.AuthorizedKeys = nil
+	_, err = InitWorkflow(config, logger, nil)
+	require.ErrorContains(t, err, "operator endpoint enabled without authorized keys")
+
+	config.Operator.AuthorizedKeys = []string{"0xoperator"}
+	_, err = InitWorkflow(config, logger, nil)
+	require.ErrorContains(t, err, "invalid authorized operator key")
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"math/big"
+
+	"rebalance/workflow/internal/helper"
+	"rebalance/workflow/internal/onchain"
+	"rebalance/workflow/internal/policy"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+/*//////////////////////////////////////////////////////////////
+                            RESULT
+//////////////////////////////////////////////////////////////*/
+
+// Reason is a machine-readable code for the outcome of a run.
+type Reason string
+
+const (
+	ReasonInFlight        Reason = "rebalance-in-flight" // a previous cross-chain rebalance has not been delivered yet
+	ReasonSmallFlow       Reason = "small-flow"          // the triggering deposit/withdrawal is too small to re-evaluate
+	ReasonUnchanged       Reason = "unchanged"           // the current strategy is already optimal
+	ReasonBelowThreshold  Reason = "below-threshold"     // the threshold policy blocked the move
+	ReasonCostExceedsGain Reason = "cost-exceeds-gain"   // the projected gain does not cover the cost
+	ReasonCooldown        Reason = "cooldown"            // the cooldown or a rebalance budget blocked the move
+	ReasonPreflightFailed Reason = "preflight-failed"    // ParentPeer or the Rebalancer would reject the report
+	ReasonDryRun          Reason = "dry-run"             // the move was allowed but not written
+	ReasonRebalanced      Reason = "rebalanced"          // the rebalance report was written
+	ReasonOverride        Reason = "override"            // an operator override was written
+)
+
+// StrategyResult describes what a run evaluated and decided.
+type StrategyResult struct {
+	Reason  Reason                `json:"reason"`
+	Current onchain.NamedStrategy `json:"current"`
+	Optimal onchain.NamedStrategy `json:"optimal"`
+	Updated bool                  `json:"updated"`
+
+	TVL        *big.Int    `json:"tvl"` // raw vault asset units held by the current strategy
+	CurrentAPY float64     `json:"currentApy"`
+	OptimalAPY float64     `json:"optimalApy"`
+	APYDelta   float64     `json:"apyDelta"`
+	Candidates []Candidate `json:"candidates"`
+	// RewardsIncluded is set when the APYs above include reward APRs
+	// (rewards.includeInDecision).
+	RewardsIncluded bool `json:"rewardsIncluded,omitempty"`
+	// Emergency is set when the current strategy is unhealthy and is exited
+	// regardless of the threshold, cost and cooldown policies.
+	Emergency bool `json:"emergency,omitempty"`
+
+	BlockNumber int64                      `json:"blockNumber"` // configured block number or tag every read uses
+	ParentBlock onchain.Block              `json:"parentBlock"` // what BlockNumber resolved to on the parent chain
+	TxHash      hexutil.Bytes              `json:"txHash,omitempty"`
+	Flow        *FlowEvent                 `json:"flow,omitempty"`     // set when triggered by a deposit or withdrawal
+	Operator    string                     `json:"operator,omitempty"` // set when requested by an operator: the signing key
+	Excluded    []onchain.ExcludedStrategy `json:"excluded,omitempty"` // configured strategies on-chain discovery dropped
+
+	InFlight  *policy.InFlightDecision  `json:"inFlight,omitempty"`  // nil when the in-flight check is disabled
+	Threshold *policy.ThresholdDecision `json:"threshold,omitempty"` // nil when the strategy is unchanged
+	Cost      *policy.CostEstimate      `json:"cost,omitempty"`      // nil when the cost model is disabled or not reached
+	Cooldown  *policy.CooldownDecision  `json:"cooldown,omitempty"`  // nil when the cooldown is disabled or not reached
+	Preflight *policy.PreflightDecision `json:"preflight,omitempty"` // nil when not reached
+	Rebalance *onchain.RebalancePayload `json:"rebalance,omitempty"` // dry run only: the rebalance that would have been written
+}
+
+// Candidate is one evaluated strategy.
+type Candidate struct {
+	Strategy                onchain.NamedStrategy `json:"strategy"`
+	ChainName               string                `json:"chainName"`
+	APY                     float64               `json:"apy"`
+	BaseAPY                 float64               `json:"baseApy,omitempty"`         // supply APY, when rewards are enabled
+	RewardAPR               float64               `json:"rewardApr,omitempty"`       // reward emission APR, when rewards are enabled
+	ProjectedAnnualYieldUSD float64               `json:"projectedAnnualYieldUsd"`   // TVL * APY, were the TVL moved there
+	Error                   string                `json:"error,omitempty"`           // why the candidate was excluded, if it was
+	Infeasible              bool                  `json:"infeasible,omitempty"`      // it cannot absorb the TVL (e.g. a full supply cap)
+	Unhealthy               bool                  `json:"unhealthy,omitempty"`       // its market is paused, frozen or in deficit
+	LiquidityMargin         float64               `json:"liquidityMargin,omitempty"` // withdrawable liquidity as a multiple of the TVL, when checked
+}
+
+// newCandidates annotates every evaluated strategy with its protocol name as
+// strategies names it, its chain name and the annual yield the TVL would earn
+// there. Excluded candidates carry their error.
+func newCandidates(evms []helper.EvmConfig, strategies onchain.StrategySet, evaluated []onchain.StrategyWithAPY, tvl *big.Int, tvlDecimals uint8) []Candidate {
+	tvlUSD := policy.TVLToUSD(tvl, tvlDecimals)
+
+	candidates := make([]Candidate, 0, len(evaluated))
+	for _, c := range evaluated {
+		var chainName string
+		if evmCfg, err := helper.FindEvmConfigByChainSelector(evms, c.Strategy.ChainSelector); err == nil {
+			chainName = evmCfg.ChainName
+		}
+		candidate := Candidate{
+			Strategy:                strategies.Named(c.Strategy),
+			ChainName:               chainName,
+			APY:                     c.APY,
+			BaseAPY:                 c.BaseAPY,
+			RewardAPR:               c.RewardAPR,
+			LiquidityMargin:         c.LiquidityMargin,
+			ProjectedAnnualYieldUSD: tvlUSD * c.APY,
+		}
+		if c.Err != nil {
+			candidate.Error = c.Err.Error()
+			candidate.Infeasible = c.Infeasible
+			candidate.Unhealthy = c.Unhealthy
+		}
+		candidates = append(candidates, candidate)
+	}
+	return candidates
+}
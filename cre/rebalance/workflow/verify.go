@@ -0,0 +1,206 @@
+package main
+
+import (
+	"fmt"
+
+	"rebalance/contracts/evm/src/generated/parent_peer"
+	"rebalance/contracts/evm/src/generated/rebalancer"
+	"rebalance/workflow/internal/helper"
+	"rebalance/workflow/internal/onchain"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/smartcontractkit/chainlink-protos/cre/go/values/pb"
+	"github.com/smartcontractkit/cre-sdk-go/capabilities/blockchain/evm"
+	"github.com/smartcontractkit/cre-sdk-go/capabilities/blockchain/evm/bindings"
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+)
+
+/*//////////////////////////////////////////////////////////////
+                     REBALANCE VERIFICATION
+//////////////////////////////////////////////////////////////*/
+
+// VerificationEvent is the Rebalancer / ParentPeer log being verified.
+type VerificationEvent string
+
+const (
+	EventReportDecoded                VerificationEvent = "ReportDecoded"
+	EventInvalidProtocolIdInReport    VerificationEvent = "InvalidProtocolIdInReport"
+	EventInvalidChainSelectorInReport VerificationEvent = "InvalidChainSelectorInReport"
+	EventStrategyUpdated              VerificationEvent = "StrategyUpdated"
+)
+
+// VerificationOutcome classifies what became of a written report.
+type VerificationOutcome string
+
+const (
+	OutcomeApplied    VerificationOutcome = "applied"    // ParentPeer holds the reported strategy
+	OutcomeRejected   VerificationOutcome = "rejected"   // the Rebalancer refused the report
+	OutcomeSuperseded VerificationOutcome = "superseded" // a later update replaced the reported strategy
+)
+
+// verificationConfidence is the confidence level the verification log triggers fire at.
+const verificationConfidence = evm.ConfidenceLevel_CONFIDENCE_LEVEL_SAFE
+
+// VerificationResult is the outcome of a verification run. Reported holds the
+// strategy the event refers to; for the Invalid* events only the rejected
+// field is set. Mismatch is true whenever ParentPeer does not hold Reported.
+type VerificationResult struct {
+	Event       VerificationEvent     `json:"event"`
+	Outcome     VerificationOutcome   `json:"outcome"`
+	Reported    onchain.NamedStrategy `json:"reported"`
+	OnChain     onchain.NamedStrategy `json:"onChain"`
+	Mismatch    bool                  `json:"mismatch"`
+	TxHash      hexutil.Bytes         `json:"txHash,omitempty"`
+	BlockNumber uint64                `json:"blockNumber"`
+}
+
+// newVerificationResult builds a VerificationResult from a decoded log.
+func newVerificationResult(event VerificationEvent, reported onchain.Strategy, log *evm.Log) *VerificationResult {
+	result := &VerificationResult{Event: event, Reported: onchain.NamedStrategy{Strategy: reported}}
+	if log != nil {
+		result.TxHash = log.TxHash
+		if log.BlockNumber != nil {
+			result.BlockNumber = pb.NewIntFromBigInt(log.BlockNumber).Uint64()
+		}
+	}
+	return result
+}
+
+/*//////////////////////////////////////////////////////////////
+                    VERIFICATION LOG TRIGGERS
+//////////////////////////////////////////////////////////////*/
+
+// verificationHandlers registers the Rebalancer report events and the
+// ParentPeer StrategyUpdated event on the parent chain (evms[0]).
+func verificationHandlers(config *helper.Config) ([]cre.ExecutionHandler[*helper.Config, cre.Runtime], error) {
+	if len(config.Evms) == 0 {
+		return nil, fmt.Errorf("no EVM configs provided")
+	}
+	parentCfg := config.Evms[0]
+
+	if !common.IsHexAddress(parentCfg.RebalancerAddress) {
+		return nil, fmt.Errorf("invalid Rebalancer address for %s: %s", parentCfg.ChainName, parentCfg.RebalancerAddress)
+	}
+	if !common.IsHexAddress(parentCfg.YieldPeerAddress) {
+		return nil, fmt.Errorf("invalid YieldPeer address for %s: %s", parentCfg.ChainName, parentCfg.YieldPeerAddress)
+	}
+	client := &evm.Client{ChainSelector: parentCfg.ChainSelector}
+
+	rb, err := rebalancer.NewRebalancer(client, common.HexToAddress(parentCfg.RebalancerAddress), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Rebalancer binding: %w", err)
+	}
+	decoded, err := rb.LogTriggerReportDecodedLog(parentCfg.ChainSelector, verificationConfidence, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Rebalancer ReportDecoded trigger: %w", err)
+	}
+	invalidProtocol, err := rb.LogTriggerInvalidProtocolIdInReportLog(parentCfg.ChainSelector, verificationConfidence, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Rebalancer InvalidProtocolIdInReport trigger: %w", err)
+	}
+	invalidChain, err := rb.LogTriggerInvalidChainSelectorInReportLog(parentCfg.ChainSelector, verificationConfidence, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Rebalancer InvalidChainSelectorInReport trigger: %w", err)
+	}
+
+	peer, err := parent_peer.NewParentPeer(client, common.HexToAddress(parentCfg.YieldPeerAddress), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ParentPeer binding: %w", err)
+	}
+	updated, err := peer.LogTriggerStrategyUpdatedLog(parentCfg.ChainSelector, verificationConfidence, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ParentPeer StrategyUpdated trigger: %w", err)
+	}
+
+	return []cre.ExecutionHandler[*helper.Config, cre.Runtime]{
+		cre.Handler(decoded, onReportDecoded),
+		cre.Handler(invalidProtocol, onInvalidProtocolIdInReport),
+		cre.Handler(invalidChain, onInvalidChainSelectorInReport),
+		cre.Handler(updated, onStrategyUpdated),
+	}, nil
+}
+
+func onReportDecoded(config *helper.Config, runtime cre.Runtime, log *bindings.DecodedLog[rebalancer.ReportDecodedDecoded]) (*VerificationResult, error) {
+	reported := onchain.Strategy{ProtocolId: log.Data.ProtocolId, ChainSelector: log.Data.ChainSelector}
+	return verifyWithDeps(config, runtime, newVerificationResult(EventReportDecoded, reported, log.Log), defaultOnCronDeps)
+}
+
+func onInvalidProtocolIdInReport(config *helper.Config, runtime cre.Runtime, log *bindings.DecodedLog[rebalancer.InvalidProtocolIdInReportDecoded]) (*VerificationResult, error) {
+	reported := onchain.Strategy{ProtocolId: log.Data.ProtocolId}
+	return verifyWithDeps(config, runtime, newVerificationResult(EventInvalidProtocolIdInReport, reported, log.Log), defaultOnCronDeps)
+}
+
+func onInvalidChainSelectorInReport(config *helper.Config, runtime cre.Runtime, log *bindings.DecodedLog[rebalancer.InvalidChainSelectorInReportDecoded]) (*VerificationResult, error) {
+	reported := onchain.Strategy{ChainSelector: log.Data.ChainSelector}
+	return verifyWithDeps(config, runtime, newVerificationResult(EventInvalidChainSelectorInReport, reported, log.Log), defaultOnCronDeps)
+}
+
+func onStrategyUpdated(config *helper.Config, runtime cre.Runtime, log *bindings.DecodedLog[parent_peer.StrategyUpdatedDecoded]) (*VerificationResult, error) {
+	reported := onchain.Strategy{ProtocolId: log.Data.ProtocolId, ChainSelector: log.Data.ChainSelector}
+	return verifyWithDeps(config, runtime, newVerificationResult(EventStrategyUpdated, reported, log.Log), defaultOnCronDeps)
+}
+
+// verifyWithDeps classifies a report event against the strategy ParentPeer
+// holds at the latest block:
+//   - the Invalid* events are always rejected;
+//   - ReportDecoded / StrategyUpdated are applied when ParentPeer still holds
+//     the reported strategy, superseded otherwise.
+func verifyWithDeps(config *helper.Config, runtime cre.Runtime, result *VerificationResult, deps OnCronDeps) (*VerificationResult, error) {
+	logger := runtime.Logger()
+
+	if len(config.Evms) == 0 {
+		return nil, fmt.Errorf("no EVM configs provided")
+	}
+	parentCfg := config.Evms[0]
+
+	// The strategy set only names the strategies.
+	strategies, err := deps.NewStrategySet(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build supported strategy set: %w", err)
+	}
+
+	parentPeer, err := deps.NewParentPeerBinding(&evm.Client{ChainSelector: parentCfg.ChainSelector}, parentCfg.YieldPeerAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ParentPeer binding: %w", err)
+	}
+
+	// Read at the tip rather than config.BlockNumber, which may lag the
+	// triggering log.
+	latest := *config
+	latest.BlockNumber = onchain.LatestBlockNumber
+	onChain, err := deps.ReadCurrentStrategy(&latest, runtime, parentPeer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read strategy from ParentPeer: %w", err)
+	}
+	result.Reported = strategies.Named(result.Reported.Strategy)
+	result.OnChain = strategies.Named(onChain)
+	result.Mismatch = onChain != result.Reported.Strategy
+
+	switch {
+	case result.Event == EventInvalidProtocolIdInReport || result.Event == EventInvalidChainSelectorInReport:
+		result.Outcome = OutcomeRejected
+	case result.Mismatch:
+		result.Outcome = OutcomeSuperseded
+	default:
+		result.Outcome = OutcomeApplied
+	}
+
+	attrs := []any{
+		"event", result.Event,
+		"outcome", result.Outcome,
+		"reportedProtocolId", fmt.Sprintf("0x%x", result.Reported.ProtocolId),
+		"reportedChainSelector", result.Reported.ChainSelector,
+		"onChainProtocolId", fmt.Sprintf("0x%x", onChain.ProtocolId),
+		"onChainChainSelector", onChain.ChainSelector,
+		"txHash", result.TxHash.String(),
+		"blockNumber", result.BlockNumber,
+	}
+	if result.Outcome == OutcomeApplied {
+		logger.Info("Rebalance verified", attrs...)
+	} else {
+		logger.Warn("Rebalance not applied", attrs...)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"rebalance/workflow/internal/helper"
+	"rebalance/workflow/internal/onchain"
+
+	"github.com/smartcontractkit/chainlink-protos/cre/go/values/pb"
+	"github.com/smartcontractkit/cre-sdk-go/capabilities/blockchain/evm"
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+	"github.com/smartcontractkit/cre-sdk-go/cre/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+/*//////////////////////////////////////////////////////////////
+                        SETUP / UTILITY
+//////////////////////////////////////////////////////////////*/
+
+// newVerifyTestDeps returns deps whose ParentPeer holds onChain and records
+// the block number it was read at.
+func newVerifyTestDeps(onChain onchain.Strategy, readAt *int64) OnCronDeps {
+	return OnCronDeps{
+		NewStrategySet: func(*helper.Config) (onchain.StrategySet, error) {
+			return onchain.NewStrategySetOf(), nil
+		},
+		NewParentPeerBinding: func(_ *evm.Client, _ string) (onchain.ParentPeerInterface, error) {
+			return nil, nil
+		},
+		ReadCurrentStrategy: func(config *helper.Config, _ cre.Runtime, _ onchain.ParentPeerInterface) (onchain.Strategy, error) {
+			*readAt = config.BlockNumber
+			return onChain, nil
+		},
+	}
+}
+
+/*//////////////////////////////////////////////////////////////
+                             TESTS
+//////////////////////////////////////////////////////////////*/
+
+func Test_verifyWithDeps_applied(t *testing.T) {
+	config := newFlowTestConfig(0)
+	config.BlockNumber = -3
+	runtime := testutils.NewRuntime(t, nil)
+
+	reported := onchain.Strategy{ProtocolId: [32]byte{1}, ChainSelector: 1}
+	var readAt int64
+	deps := newVerifyTestDeps(reported, &readAt)
+
+	log := &evm.Log{TxHash: []byte{0xaa}, BlockNumber: pb.NewBigIntFromInt(big.NewInt(42))}
+	res, err := verifyWithDeps(config, runtime, newVerificationResult(EventReportDecoded, reported, log), deps)
+
+	require.NoError(t, err)
+	require.Equal(t, OutcomeApplied, res.Outcome)
+	require.False(t, res.Mismatch)
+	require.Equal(t, reported, res.OnChain.Strategy)
+	require.Equal(t, []byte{0xaa}, []byte(res.TxHash))
+	require.Equal(t, uint64(42), res.BlockNumber)
+	require.Equal(t, onchain.LatestBlockNumber, readAt, "should read at the latest block")
+	require.Equal(t, int64(-3), config.BlockNumber, "config must not be mutated")
+}
+
+func Test_verifyWithDeps_superseded(t *testing.T) {
+	config := newFlowTestConfig(0)
+	runtime := testutils.NewRuntime(t, nil)
+
+	reported := onchain.Strategy{ProtocolId: [32]byte{1}, ChainSelector: 1}
+	later := onchain.Strategy{ProtocolId: [32]byte{2}, ChainSelector: 2}
+	var readAt int64
+
+	res, err := verifyWithDeps(config, runtime, newVerificationResult(EventStrategyUpdated, reported, nil), newVerifyTestDeps(later, &readAt))
+
+	require.NoError(t, err)
+	require.Equal(t, OutcomeSuperseded, res.Outcome)
+	require.True(t, res.Mismatch)
+	require.Equal(t, reported, res.Reported.Strategy)
+	require.Equal(t, later, res.OnChain.Strategy)
+}
+
+func Test_verifyWithDeps_invalidEventsRejected(t *testing.T) {
+	config := newFlowTestConfig(0)
+	runtime := testutils.NewRuntime(t, nil)
+
+	onChain := onchain.Strategy{ProtocolId: [32]byte{1}, ChainSelector: 1}
+	var readAt int64
+
+	for _, tc := range []struct {
+		event    VerificationEvent
+		reported onchain.Strategy
+	}{
+		{EventInvalidProtocolIdInReport, onchain.Strategy{ProtocolId: [32]byte{9}}},
+		{EventInvalidChainSelectorInReport, onchain.Strategy{ChainSelector: 9}},
+	} {
+		res, err := verifyWithDeps(config, runtime, newVerificationResult(tc.event, tc.reported, nil), newVerifyTestDeps(onChain, &readAt))
+
+		require.NoError(t, err)
+		require.Equal(t, OutcomeRejected, res.Outcome, tc.event)
+		require.True(t, res.Mismatch, tc.event)
+		require.Equal(t, onChain, res.OnChain.Strategy, tc.event)
+	}
+}
+
+func Test_verifyWithDeps_errorWhen_ReadCurrentStrategyFails(t *testing.T) {
+	config := newFlowTestConfig(0)
+	runtime := testutils.NewRuntime(t, nil)
+
+	var readAt int64
+	deps := newVerifyTestDeps(onchain.Strategy{}, &readAt)
+	deps.ReadCurrentStrategy = func(_ *helper.Config, _ cre.Runtime, _ onchain.ParentPeerInterface) (onchain.Strategy, error) {
+		return onchain.Strategy{}, fmt.Errorf("read-failed")
+	}
+
+	res, err := verifyWithDeps(config, runtime, newVerificationResult(EventReportDecoded, onchain.Strategy{}, nil), deps)
+
+	require.Error(t, err)
+	require.Nil(t, res)
+	require.Contains(t, err.Error(), "failed to read strategy from ParentPeer")
+	require.Contains(t, err.Error(), "read-failed")
+}
+
+func Test_InitWorkflow_registersVerificationHandlers(t *testing.T) {
+	config := &helper.Config{
+		Schedule: "0 */1 * * * *",
+		Evms: []helper.EvmConfig{{
+			ChainName:         "parent-chain",
+			ChainSelector:     1,
+			YieldPeerAddress:  "0x0000000000000000000000000000000000000001",
+			RebalancerAddress: "0x0000000000000000000000000000000000000002",
+		}},
+		Verification: helper.VerificationConfig{Enabled: true},
+	}
+	logger := testutils.NewRuntime(t, nil).Logger()
+
+	wf, err := InitWorkflow(config, logger, nil)
+
+	require.NoError(t, err)
+	// cron + ReportDecoded, two Invalid* events, StrategyUpdated
+	require.Len(t, wf, 1+4)
+}
+
+func Test_InitWorkflow_errorWhen_rebalancerAddressInvalid(t *testing.T) {
+	config := &helper.Config{
+		Schedule: "0 */1 * * * *",
+		Evms: []helper.EvmConfig{{
+			ChainName:         "parent-chain",
+			ChainSelector:     1,
+			YieldPeerAddress:  "0x0000000000000000000000000000000000000001",
+			RebalancerAddress: "0xrebalancer",
+		}},
+		Verification: helper.VerificationConfig{Enabled: true},
+	}
+	logger := testutils.NewRuntime(t, nil).Logger()
+
+	wf, err := InitWorkflow(config, logger, nil)
+
+	require.Error(t, err)
+	require.Nil(t, wf)
+	require.Contains(t, err.Error(), "invalid Rebalancer address for parent-chain")
+}
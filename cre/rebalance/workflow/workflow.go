@@ -8,41 +8,61 @@ import (
 	"rebalance/workflow/internal/helper"
 	// "rebalance/workflow/internal/offchain"
 	"rebalance/workflow/internal/onchain"
+	"rebalance/workflow/internal/policy"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/smartcontractkit/cre-sdk-go/capabilities/blockchain/evm"
 	"github.com/smartcontractkit/cre-sdk-go/capabilities/scheduler/cron"
 	"github.com/smartcontractkit/cre-sdk-go/cre"
 )
 
-/*//////////////////////////////////////////////////////////////
-                           CONFIG
-//////////////////////////////////////////////////////////////*/
-
-// threshold is the minimum APY improvement (in the same units that
-// CalculateAPYForStrategy returns, e.g. 0.05 for 5% APY) required
-// before we rebalance.
-// @review TODO: set a sensible threshold and determine scaling. 0.01 = 1 percentage point.
-const threshold = 0.01
-
-// StrategyResult is primarily for debugging / testing.
-type StrategyResult struct {
-	Current onchain.Strategy `json:"current"`
-	Optimal onchain.Strategy `json:"optimal"`
-	Updated bool             `json:"updated"`
-}
-
 /*//////////////////////////////////////////////////////////////
                          INIT WORKFLOW
 //////////////////////////////////////////////////////////////*/
 
-// InitWorkflow registers the cron handler.
+// InitWorkflow registers the cron handler and, when enabled, the deposit /
+// withdrawal log handlers, the rebalance verification handlers and the
+// operator endpoint.
 func InitWorkflow(config *helper.Config, logger *slog.Logger, secretsProvider cre.SecretsProvider) (cre.Workflow[*helper.Config], error) {
-	return cre.Workflow[*helper.Config]{
+	if err := config.Cooldown.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid cooldown config: %w", err)
+	}
+	if err := config.Cost.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid cost config: %w", err)
+	}
+
+	workflow := cre.Workflow[*helper.Config]{
 		cre.Handler(
 			cron.Trigger(&cron.Config{Schedule: config.Schedule}),
 			onCronTrigger,
 		),
-	}, nil
+	}
+
+	if config.FlowTrigger.Enabled {
+		handlers, err := flowHandlers(config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to register flow handlers: %w", err)
+		}
+		workflow = append(workflow, handlers...)
+	}
+
+	if config.Verification.Enabled {
+		handlers, err := verificationHandlers(config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to register verification handlers: %w", err)
+		}
+		workflow = append(workflow, handlers...)
+	}
+
+	if config.Operator.Enabled {
+		handler, err := operatorHandler(config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to register operator handler: %w", err)
+		}
+		workflow = append(workflow, handler)
+	}
+
+	return workflow, nil
 }
 
 /*//////////////////////////////////////////////////////////////
@@ -55,9 +75,18 @@ type OnCronDeps struct {
 	NewRebalancerBinding                func(client *evm.Client, addr string) (onchain.RebalancerInterface, error)
 	ReadCurrentStrategy                 func(config *helper.Config, runtime cre.Runtime, peer onchain.ParentPeerInterface) (onchain.Strategy, error)
 	ReadTVL                             func(config *helper.Config, runtime cre.Runtime, peer onchain.YieldPeerInterface) (*big.Int, error)
-	WriteRebalance                      func(rb onchain.RebalancerInterface, runtime cre.Runtime, gasLimit uint64, optimal onchain.Strategy) error
-	GetOptimalAndCurrentStrategyWithAPY func(config *helper.Config, runtime cre.Runtime, currentStrategy onchain.Strategy, liquidityAdded *big.Int) (onchain.StrategyWithAPY, onchain.StrategyWithAPY, error)
-	InitSupportedStrategies             func(config *helper.Config) error
+	WriteRebalance                      func(rb onchain.RebalancerInterface, runtime cre.Runtime, gasLimit uint64, optimal onchain.Strategy) ([]byte, error)
+	GetOptimalAndCurrentStrategyWithAPY func(config *helper.Config, runtime cre.Runtime, strategies onchain.StrategySet, currentStrategy onchain.Strategy, liquidityAdded *big.Int) (onchain.StrategyWithAPY, onchain.StrategyWithAPY, []onchain.StrategyWithAPY, error)
+	NewStrategySet                      func(config *helper.Config) (onchain.StrategySet, error)
+	ReadStrategyHistory                 func(config *helper.Config, runtime cre.Runtime, headers onchain.HeaderReaderInterface, peer onchain.ParentPeerInterface) (onchain.StrategyHistory, error)
+	EncodeRebalance                     func(optimal onchain.Strategy) (onchain.RebalancePayload, error)
+	ReadBlock                           func(config *helper.Config, runtime cre.Runtime, headers onchain.HeaderReaderInterface) (onchain.Block, error)
+	ReadTotalShares                     func(config *helper.Config, runtime cre.Runtime, peer onchain.ParentPeerInterface) (*big.Int, error)
+	NewStrategyAdapterReaderBinding     func(client *evm.Client, addr string) (onchain.StrategyAdapterReaderInterface, error)
+	ReadPreflightState                  func(config *helper.Config, runtime cre.Runtime, parentPeer onchain.ParentPeerInterface, targetPeer onchain.StrategyAdapterReaderInterface, rb onchain.RebalancerInterface, strategy onchain.Strategy) (onchain.PreflightState, error)
+	NewCCIPLogReaderBinding             func(client *evm.Client, addr string) (onchain.CCIPLogReaderInterface, error)
+	ReadCCIPMessages                    func(runtime cre.Runtime, chainSelector uint64, lookbackBlocks uint64, headers onchain.HeaderReaderInterface, peer onchain.CCIPLogReaderInterface) (onchain.CCIPMessageLogs, error)
+	DiscoverStrategies                  func(config *helper.Config, runtime cre.Runtime, configured onchain.StrategySet, parentPeer onchain.ParentPeerInterface, peers map[uint64]onchain.StrategyAdapterReaderInterface) (onchain.StrategyDiscovery, error)
 }
 
 // defaultOnCronDeps are the real onchain/offchain implementations.
@@ -69,7 +98,16 @@ var defaultOnCronDeps = OnCronDeps{
 	ReadTVL:                             onchain.ReadTVL,
 	WriteRebalance:                      onchain.WriteRebalance,
 	GetOptimalAndCurrentStrategyWithAPY: onchain.GetOptimalAndCurrentStrategyWithAPY,
-	InitSupportedStrategies:             onchain.InitSupportedStrategies,
+	NewStrategySet:                      onchain.NewStrategySet,
+	ReadStrategyHistory:                 onchain.ReadStrategyHistory,
+	EncodeRebalance:                     onchain.EncodeRebalance,
+	ReadBlock:                           onchain.ReadBlock,
+	ReadTotalShares:                     onchain.ReadTotalShares,
+	NewStrategyAdapterReaderBinding:     onchain.NewStrategyAdapterReaderBinding,
+	ReadPreflightState:                  onchain.ReadPreflightState,
+	NewCCIPLogReaderBinding:             onchain.NewCCIPLogReaderBinding,
+	ReadCCIPMessages:                    onchain.ReadCCIPMessages,
+	DiscoverStrategies:                  onchain.DiscoverStrategies,
 }
 
 /*//////////////////////////////////////////////////////////////
@@ -81,12 +119,23 @@ func onCronTrigger(config *helper.Config, runtime cre.Runtime, trigger *cron.Pay
 }
 
 func onCronTriggerWithDeps(config *helper.Config, runtime cre.Runtime, trigger *cron.Payload, deps OnCronDeps) (*StrategyResult, error) {
+	return evaluateStrategy(config, runtime, nil, deps)
+}
+
+/*//////////////////////////////////////////////////////////////
+                      STRATEGY EVALUATION
+//////////////////////////////////////////////////////////////*/
+
+// evaluateStrategy is the decision pipeline shared by every trigger. When flow
+// is set, the run was triggered by a deposit or withdrawal and stops early if
+// that flow is below the configured fraction of TVL.
+func evaluateStrategy(config *helper.Config, runtime cre.Runtime, flow *FlowEvent, deps OnCronDeps) (*StrategyResult, error) {
 	logger := runtime.Logger()
 
-	// Initialize supported strategies
-	err := deps.InitSupportedStrategies(config)
+	// Build the strategies this run may evaluate.
+	strategies, err := deps.NewStrategySet(config)
 	if err != nil {
-		return nil, fmt.Errorf("failed to initialize supported strategies: %w", err)
+		return nil, fmt.Errorf("failed to build supported strategy set: %w", err)
 	}
 
 	// Ensure we have at least one EVM config and treat evms[0] as the parent chain.
@@ -106,6 +155,18 @@ func onCronTriggerWithDeps(config *helper.Config, runtime cre.Runtime, trigger *
 		return nil, fmt.Errorf("failed to create ParentPeer binding: %w", err)
 	}
 
+	// Resolve the block every read is anchored at on the parent chain.
+	parentBlock, err := deps.ReadBlock(config, runtime, parentEvmClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read parent block: %w", err)
+	}
+
+	// Drop configured strategies the contracts would reject.
+	strategies, excluded, err := discoverStrategies(config, runtime, deps, parentPeer, strategies)
+	if err != nil {
+		return nil, err
+	}
+
 	// Read current strategy from ParentPeer via deps.
 	currentStrategy, err := deps.ReadCurrentStrategy(config, runtime, parentPeer)
 	if err != nil {
@@ -117,6 +178,28 @@ func onCronTriggerWithDeps(config *helper.Config, runtime cre.Runtime, trigger *
 		"chainSelector", currentStrategy.ChainSelector,
 	)
 
+	// While a cross-chain rebalance is moving funds the TVL is misleading and
+	// another rebalance must not be stacked on it.
+	var inFlight *policy.InFlightDecision
+	if config.InFlight.Enabled {
+		inFlight, err = checkInFlight(config, runtime, deps)
+		if err != nil {
+			return nil, err
+		}
+		if inFlight.InFlight {
+			return &StrategyResult{
+				Reason:      ReasonInFlight,
+				Current:     strategies.Named(currentStrategy),
+				Optimal:     strategies.Named(currentStrategy),
+				BlockNumber: config.BlockNumber,
+				ParentBlock: parentBlock,
+				Flow:        flow,
+				Excluded:    excluded,
+				InFlight:    inFlight,
+			}, nil
+		}
+	}
+
 	// Decide which YieldPeer to use for TVL:
 	// - If the strategy lives on the parent chain, reuse parentPeer.
 	// - Otherwise, instantiate a YieldPeer on the strategy chain.
@@ -154,62 +237,309 @@ func onCronTriggerWithDeps(config *helper.Config, runtime cre.Runtime, trigger *
 		return nil, fmt.Errorf("failed to get total value from strategy YieldPeer: %w", err)
 	}
 
+	// The TVL is in the vault asset's units on the current strategy's chain.
+	tvlDecimals, err := config.VaultAssetDecimals(currentStrategy.ChainSelector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve vault asset decimals: %w", err)
+	}
+
+	result := &StrategyResult{
+		Current:     strategies.Named(currentStrategy),
+		TVL:         tvl,
+		BlockNumber: config.BlockNumber,
+		ParentBlock: parentBlock,
+		Flow:        flow,
+		Excluded:    excluded,
+		InFlight:    inFlight,
+	}
+
+	// A deposit or withdrawal only warrants a re-evaluation if it is large
+	// relative to the vault.
+	if flow != nil {
+		if err := measureFlow(config, runtime, deps, parentPeer, flow, tvl, tvlDecimals); err != nil {
+			return nil, fmt.Errorf("failed to measure %s: %w", flow.Kind, err)
+		}
+		if flow.TVLFraction < config.FlowTrigger.MinTVLFraction {
+			logger.Info(
+				"Flow below re-evaluation threshold; skipping",
+				"kind", flow.Kind,
+				"amount", flow.Amount.String(),
+				"tvlFraction", flow.TVLFraction,
+				"minTvlFraction", config.FlowTrigger.MinTVLFraction,
+			)
+			result.Optimal = strategies.Named(currentStrategy)
+			result.Reason = ReasonSmallFlow
+			return result, nil
+		}
+		logger.Info("Flow above re-evaluation threshold", "kind", flow.Kind, "tvlFraction", flow.TVLFraction)
+	}
+
 	// Get the optimal and current strategy with APY
-	optimal, current, err := deps.GetOptimalAndCurrentStrategyWithAPY(config, runtime, currentStrategy, tvl)
+	optimal, current, evaluated, err := deps.GetOptimalAndCurrentStrategyWithAPY(config, runtime, strategies, currentStrategy, tvl)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get optimal and current strategy with APY: %w", err)
 	}
 
+	result.Current = strategies.Named(current.Strategy)
+	result.Optimal = strategies.Named(optimal.Strategy)
+	result.CurrentAPY = current.APY
+	result.OptimalAPY = optimal.APY
+	result.APYDelta = optimal.APY - current.APY
+	result.Candidates = newCandidates(config.Evms, strategies, evaluated, tvl, tvlDecimals)
+	result.RewardsIncluded = config.Rewards.Enabled && config.Rewards.IncludeInDecision
+
 	// If the optimal and current strategy are the same, return without updating.
 	if optimal.Strategy == current.Strategy {
 		logger.Info("Strategy unchanged; no rebalance needed")
 		logger.Info("APY values", "optimalAPY", optimal.APY, "currentAPY", current.APY)
-		return &StrategyResult{
-			Current: current.Strategy,
-			Optimal: optimal.Strategy,
-			Updated: false,
-		}, nil
+		result.Reason = ReasonUnchanged
+		return result, nil
+	}
+
+	// An unhealthy current strategy (paused, frozen, in deficit) is exited
+	// whatever the gain: the threshold and cost policies are reported but do
+	// not block the move.
+	if current.Unhealthy {
+		logger.Warn("Current strategy is unhealthy; emergency exit", "reason", current.Err)
+		result.Emergency = true
 	}
 
-	// Compute delta := optimal - current as float64.
-	delta := optimal.APY - current.APY
+	// Evaluate the threshold policy (APY delta and projected annual gain).
+	decision := policy.EvaluateThreshold(config.Threshold, current, optimal, tvl, tvlDecimals)
+	result.Threshold = &decision
 
 	logger.Info(
 		"Computed APYs",
 		"tvl", tvl.String(),
 		"currentAPY", current.APY,
 		"optimalAPY", optimal.APY,
-		"delta", delta,
-		"threshold", threshold,
+		"delta", decision.APYDelta,
+		"minDelta", decision.MinAPYDelta,
+		"annualGainUSD", decision.AnnualGainUSD,
+		"minAnnualGainUSD", decision.MinAnnualGainUSD,
 	)
 
-	// If the delta is below the threshold, return without updating.
-	if delta < threshold {
-		logger.Info("Delta below threshold; no rebalance needed")
-		return &StrategyResult{
-			Current: current.Strategy,
-			Optimal: optimal.Strategy,
-			Updated: false,
-		}, nil
+	// If the threshold policy blocks the move, return without updating.
+	if !decision.Allowed && !result.Emergency {
+		logger.Info("Threshold not met; no rebalance needed", "rule", decision.Rule, "check", decision.Check)
+		result.Reason = ReasonBelowThreshold
+		return result, nil
 	}
 
-	// At this point:
-	// - optimal APY is strictly better than current
-	// - improvement meets or exceeds threshold
-	// so we go ahead and rebalance.
+	logger.Info("Threshold met", "rule", decision.Rule)
+
+	// Net out the cost of the move (gas, CCIP fees, bridging downtime).
+	if config.Cost.Enabled {
+		estimate, err := policy.EstimateRebalanceCost(config, current, optimal, rebalanceGasLimit, tvl, tvlDecimals)
+		if err != nil {
+			return nil, fmt.Errorf("failed to estimate rebalance cost: %w", err)
+		}
+		result.Cost = &estimate
+
+		logger.Info(
+			"Estimated rebalance cost",
+			"gasUSD", estimate.GasUSD,
+			"ccipMessages", estimate.CCIPMessages,
+			"ccipFeeUSD", estimate.CCIPFeeUSD,
+			"bridgeHops", estimate.BridgeHops,
+			"bridgeDowntimeUSD", estimate.BridgeDowntimeUSD,
+			"totalUSD", estimate.TotalUSD,
+			"horizonDays", estimate.HorizonDays,
+			"horizonGainUSD", estimate.HorizonGainUSD,
+			"netGainUSD", estimate.NetGainUSD,
+		)
+
+		if !estimate.Worthwhile && !result.Emergency {
+			logger.Info("Projected gain does not cover rebalance cost; no rebalance needed")
+			result.Reason = ReasonCostExceedsGain
+			return result, nil
+		}
+	}
+
+	// Rate-limit moves using the StrategyUpdated history on ParentPeer.
+	if config.Cooldown.Enabled {
+		history, err := deps.ReadStrategyHistory(config, runtime, parentEvmClient, parentPeer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read strategy history from ParentPeer: %w", err)
+		}
+		verdict := policy.EvaluateCooldown(config.Cooldown, history)
+		result.Cooldown = &verdict
+
+		logger.Info(
+			"Evaluated rebalance cooldown",
+			"fromBlock", history.FromBlock,
+			"headBlock", history.HeadBlock,
+			"recentUpdates", len(history.Updates),
+			"lastRebalanceAt", verdict.LastRebalanceAt,
+			"now", verdict.Now,
+		)
+
+		if !verdict.Allowed && !result.Emergency {
+			logger.Info(
+				"Rebalance cooldown active; no rebalance",
+				"rule", verdict.Rule,
+				"nextAllowedAt", verdict.NextAllowedAt,
+				"count", verdict.Count,
+				"maxRebalances", verdict.MaxRebalances,
+			)
+			result.Reason = ReasonCooldown
+			return result, nil
+		}
+	}
 
 	parentRebalancer, err := deps.NewRebalancerBinding(parentEvmClient, parentCfg.RebalancerAddress)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create parent Rebalancer binding: %w", err)
 	}
 
-	if err := deps.WriteRebalance(parentRebalancer, runtime, rebalanceGasLimit, optimal.Strategy); err != nil {
+	// Check the report would be accepted before spending gas on it.
+	preflight, err := runPreflight(config, runtime, deps, parentPeer, parentRebalancer, optimal.Strategy)
+	if err != nil {
+		return nil, err
+	}
+	result.Preflight = &preflight
+	if !preflight.Passed {
+		logger.Warn("Pre-flight check failed; rebalance not written", "check", preflight.Check)
+		result.Reason = ReasonPreflightFailed
+		return result, nil
+	}
+
+	// At this point:
+	// - optimal APY is strictly better than current
+	// - improvement meets or exceeds every threshold rule
+	// - projected gain covers the cost of the move (when enabled)
+	// - no cooldown or rebalance budget is exhausted (when enabled)
+	// - ParentPeer and the Rebalancer would accept the report
+	// so we go ahead and rebalance.
+
+	// In dry-run mode, return what would have been submitted instead of writing it.
+	if config.DryRun {
+		payload, err := deps.EncodeRebalance(optimal.Strategy)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode rebalance: %w", err)
+		}
+
+		logger.Info(
+			"Dry run; rebalance report not written",
+			"report", payload.Report.String(),
+			"calldata", payload.Calldata.String(),
+		)
+		result.Reason = ReasonDryRun
+		result.Rebalance = &payload
+		return result, nil
+	}
+
+	txHash, err := deps.WriteRebalance(parentRebalancer, runtime, rebalanceGasLimit, optimal.Strategy)
+	if err != nil {
 		return nil, fmt.Errorf("failed to rebalance: %w", err)
 	}
 
-	return &StrategyResult{
-		Current: current.Strategy,
-		Optimal: optimal.Strategy,
-		Updated: true,
-	}, nil
+	result.Reason = ReasonRebalanced
+	result.Updated = true
+	result.TxHash = txHash
+	return result, nil
+}
+
+// runPreflight reads the on-chain state a rebalance to strategy depends on and
+// evaluates the pre-flight checks against it.
+func runPreflight(
+	config *helper.Config,
+	runtime cre.Runtime,
+	deps OnCronDeps,
+	parentPeer onchain.ParentPeerInterface,
+	rb onchain.RebalancerInterface,
+	strategy onchain.Strategy,
+) (policy.PreflightDecision, error) {
+	targetCfg, err := helper.FindEvmConfigByChainSelector(config.Evms, strategy.ChainSelector)
+	if err != nil {
+		return policy.PreflightDecision{}, fmt.Errorf("no EVM config found for target chainSelector %d: %w", strategy.ChainSelector, err)
+	}
+
+	targetPeer, err := deps.NewStrategyAdapterReaderBinding(&evm.Client{ChainSelector: targetCfg.ChainSelector}, targetCfg.YieldPeerAddress)
+	if err != nil {
+		return policy.PreflightDecision{}, fmt.Errorf("failed to create target YieldPeer binding: %w", err)
+	}
+
+	state, err := deps.ReadPreflightState(config, runtime, parentPeer, targetPeer, rb, strategy)
+	if err != nil {
+		return policy.PreflightDecision{}, fmt.Errorf("failed to read pre-flight state: %w", err)
+	}
+
+	return policy.EvaluatePreflight(state, common.HexToAddress(config.Evms[0].YieldPeerAddress)), nil
+}
+
+// checkInFlight reads the CCIP messages every configured peer sent and
+// received recently and pairs them to find a rebalance still in transit.
+func checkInFlight(config *helper.Config, runtime cre.Runtime, deps OnCronDeps) (*policy.InFlightDecision, error) {
+	logs := make([]onchain.CCIPMessageLogs, 0, len(config.Evms))
+	for _, evmCfg := range config.Evms {
+		client := &evm.Client{ChainSelector: evmCfg.ChainSelector}
+
+		peer, err := deps.NewCCIPLogReaderBinding(client, evmCfg.YieldPeerAddress)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create YieldPeer binding for %s: %w", evmCfg.ChainName, err)
+		}
+
+		peerLogs, err := deps.ReadCCIPMessages(runtime, evmCfg.ChainSelector, config.InFlight.LookbackBlocksFor(evmCfg), client, peer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CCIP messages on %s: %w", evmCfg.ChainName, err)
+		}
+		logs = append(logs, peerLogs)
+	}
+
+	decision := policy.EvaluateInFlight(logs)
+	if decision.InFlight {
+		open := decision.Open[0]
+		runtime.Logger().Info(
+			"Rebalance in flight; skipping evaluation",
+			"open", len(decision.Open),
+			"messageId", open.MessageId.Hex(),
+			"txType", open.TxType,
+			"sentFrom", open.ChainSelector,
+		)
+	}
+	return &decision, nil
+}
+
+// discoverStrategies narrows the configured strategies to the ones ParentPeer
+// and the peers would accept when config.Discovery selects on-chain discovery,
+// and returns the ones it dropped.
+func discoverStrategies(
+	config *helper.Config,
+	runtime cre.Runtime,
+	deps OnCronDeps,
+	parentPeer onchain.ParentPeerInterface,
+	configured onchain.StrategySet,
+) (onchain.StrategySet, []onchain.ExcludedStrategy, error) {
+	switch config.Discovery.Mode {
+	case "", helper.DiscoveryModeConfig:
+		return configured, nil, nil
+	case helper.DiscoveryModeOnChain:
+	default:
+		return onchain.StrategySet{}, nil, fmt.Errorf("unknown strategy discovery mode %q", config.Discovery.Mode)
+	}
+
+	peers := make(map[uint64]onchain.StrategyAdapterReaderInterface, len(config.Evms))
+	for _, evmCfg := range config.Evms {
+		peer, err := deps.NewStrategyAdapterReaderBinding(&evm.Client{ChainSelector: evmCfg.ChainSelector}, evmCfg.YieldPeerAddress)
+		if err != nil {
+			return onchain.StrategySet{}, nil, fmt.Errorf("failed to create YieldPeer binding for %s: %w", evmCfg.ChainName, err)
+		}
+		peers[evmCfg.ChainSelector] = peer
+	}
+
+	discovery, err := deps.DiscoverStrategies(config, runtime, configured, parentPeer, peers)
+	if err != nil {
+		return onchain.StrategySet{}, nil, fmt.Errorf("failed to discover strategies on-chain: %w", err)
+	}
+
+	for _, e := range discovery.Excluded {
+		runtime.Logger().Warn(
+			"Excluding configured strategy the contracts would reject",
+			"protocolId", fmt.Sprintf("0x%x", e.Strategy.ProtocolId),
+			"chainSelector", e.Strategy.ChainSelector,
+			"reason", e.Reason,
+		)
+	}
+	return discovery.Strategies, discovery.Excluded, nil
 }
@@ -1,13 +1,13 @@
 package main
 
 import (
-	"log/slog"
 	"math/big"
 	"testing"
 
 	"rebalance/workflow/internal/helper"
 	"rebalance/workflow/internal/onchain"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/smartcontractkit/cre-sdk-go/capabilities/blockchain/evm"
 	"github.com/smartcontractkit/cre-sdk-go/cre"
 	"github.com/smartcontractkit/cre-sdk-go/cre/testutils"
@@ -26,6 +26,9 @@ const (
 	parentYieldAddr     = "0xparent"
 	parentRebalancer    = "0xrebalancer-parent"
 	childYieldAddr      = "0xchild"
+
+	// threshold is the built-in minimum APY delta as a decimal (100 bps = 0.01).
+	threshold = helper.DefaultMinAPYDeltaBps / 10_000.0
 )
 
 func newTestConfig() *helper.Config {
@@ -55,8 +58,26 @@ func newStrategy(id byte, chainSelector uint64) onchain.Strategy {
 	}
 }
 
-func noopInitSupportedStrategies(*helper.Config) error {
-	return nil
+func noopNewStrategySet(*helper.Config) (onchain.StrategySet, error) {
+	return onchain.StrategySet{}, nil
+}
+
+func noopReadBlock(*helper.Config, cre.Runtime, onchain.HeaderReaderInterface) (onchain.Block, error) {
+	return onchain.Block{}, nil
+}
+
+func noopNewStrategyAdapterReaderBinding(*evm.Client, string) (onchain.StrategyAdapterReaderInterface, error) {
+	return nil, nil
+}
+
+// passingReadPreflightState reports a state every pre-flight check accepts.
+func passingReadPreflightState(config *helper.Config, _ cre.Runtime, _ onchain.ParentPeerInterface, _ onchain.StrategyAdapterReaderInterface, _ onchain.RebalancerInterface, _ onchain.Strategy) (onchain.PreflightState, error) {
+	return onchain.PreflightState{
+		ProtocolSupported:    true,
+		ChainAllowed:         true,
+		StrategyAdapter:      common.HexToAddress("0x0000000000000000000000000000000000000001"),
+		RebalancerParentPeer: common.HexToAddress(config.Evms[0].YieldPeerAddress),
+	}, nil
 }
 
 /*//////////////////////////////////////////////////////////////
@@ -123,13 +144,13 @@ func Fuzz_onCronTriggerWithDeps_RebalanceThresholdAndGasLimit(f *testing.F) {
 				// TVL doesn't affect the rebalance decision in this model.
 				return big.NewInt(1_000), nil
 			},
-			WriteRebalance: func(_ onchain.RebalancerInterface, _ cre.Runtime, _ *slog.Logger, gasLimit uint64, optimal onchain.Strategy) error {
+			WriteRebalance: func(_ onchain.RebalancerInterface, _ cre.Runtime, gasLimit uint64, optimal onchain.Strategy) ([]byte, error) {
 				writeCalled = true
 				gotGasLimit = gasLimit
 				require.Equal(t, optimalStrategy, optimal, "WriteRebalance optimal mismatch")
-				return nil
+				return nil, nil
 			},
-			GetOptimalAndCurrentStrategyWithAPY: func(_ *helper.Config, _ cre.Runtime, cur onchain.Strategy, tvl *big.Int) (onchain.StrategyWithAPY, onchain.StrategyWithAPY, error) {
+			GetOptimalAndCurrentStrategyWithAPY: func(_ *helper.Config, _ cre.Runtime, _ onchain.StrategySet, cur onchain.Strategy, tvl *big.Int) (onchain.StrategyWithAPY, onchain.StrategyWithAPY, []onchain.StrategyWithAPY, error) {
 				require.Equal(t, currentStrategy, cur, "current strategy passed to GetOptimalAndCurrentStrategyWithAPY mismatch")
 				require.NotNil(t, tvl, "tvl should not be nil")
 				return onchain.StrategyWithAPY{
@@ -138,9 +159,12 @@ func Fuzz_onCronTriggerWithDeps_RebalanceThresholdAndGasLimit(f *testing.F) {
 					}, onchain.StrategyWithAPY{
 						Strategy: currentStrategy,
 						APY:      currentAPY,
-					}, nil
+					}, nil, nil
 			},
-			InitSupportedStrategies: noopInitSupportedStrategies,
+			ReadBlock:                       noopReadBlock,
+			NewStrategyAdapterReaderBinding: noopNewStrategyAdapterReaderBinding,
+			ReadPreflightState:              passingReadPreflightState,
+			NewStrategySet:                  noopNewStrategySet,
 		}
 
 		res, err := onCronTriggerWithDeps(cfg, runtime, nil, deps)
@@ -215,14 +239,14 @@ func Fuzz_onCronTriggerWithDeps_StrategyEqualityNoRebalance(f *testing.F) {
 			ReadTVL: func(_ *helper.Config, _ cre.Runtime, _ onchain.YieldPeerInterface) (*big.Int, error) {
 				return big.NewInt(1_000), nil
 			},
-			WriteRebalance: func(_ onchain.RebalancerInterface, _ cre.Runtime, _ *slog.Logger, _ uint64, _ onchain.Strategy) error {
+			WriteRebalance: func(_ onchain.RebalancerInterface, _ cre.Runtime, _ uint64, _ onchain.Strategy) ([]byte, error) {
 				writeCalled = true
 				if equal {
 					t.Fatalf("WriteRebalance should not be called when strategies are equal")
 				}
-				return nil
+				return nil, nil
 			},
-			GetOptimalAndCurrentStrategyWithAPY: func(_ *helper.Config, _ cre.Runtime, cur onchain.Strategy, tvl *big.Int) (onchain.StrategyWithAPY, onchain.StrategyWithAPY, error) {
+			GetOptimalAndCurrentStrategyWithAPY: func(_ *helper.Config, _ cre.Runtime, _ onchain.StrategySet, cur onchain.Strategy, tvl *big.Int) (onchain.StrategyWithAPY, onchain.StrategyWithAPY, []onchain.StrategyWithAPY, error) {
 				require.Equal(t, currentStrategy, cur)
 				require.NotNil(t, tvl)
 				return onchain.StrategyWithAPY{
@@ -231,9 +255,12 @@ func Fuzz_onCronTriggerWithDeps_StrategyEqualityNoRebalance(f *testing.F) {
 					}, onchain.StrategyWithAPY{
 						Strategy: currentStrategy,
 						APY:      currentAPY,
-					}, nil
+					}, nil, nil
 			},
-			InitSupportedStrategies: noopInitSupportedStrategies,
+			ReadBlock:                       noopReadBlock,
+			NewStrategyAdapterReaderBinding: noopNewStrategyAdapterReaderBinding,
+			ReadPreflightState:              passingReadPreflightState,
+			NewStrategySet:                  noopNewStrategySet,
 		}
 
 		res, err := onCronTriggerWithDeps(cfg, runtime, nil, deps)
@@ -242,8 +269,8 @@ func Fuzz_onCronTriggerWithDeps_StrategyEqualityNoRebalance(f *testing.F) {
 
 		if equal {
 			require.False(t, res.Updated, "expected Updated=false when strategies are equal")
-			require.Equal(t, currentStrategy, res.Current)
-			require.Equal(t, optimalStrategy, res.Optimal)
+			require.Equal(t, currentStrategy, res.Current.Strategy)
+			require.Equal(t, optimalStrategy, res.Optimal.Strategy)
 			require.False(t, writeCalled, "WriteRebalance should not be called when strategies are equal")
 		} else {
 			require.True(t, res.Updated, "expected Updated=true when strategies differ and delta >= threshold")
@@ -302,11 +329,11 @@ func Fuzz_onCronTriggerWithDeps_ChildPeerBindingUsage(f *testing.F) {
 				readTVLCalls++
 				return big.NewInt(1_000), nil
 			},
-			WriteRebalance: func(_ onchain.RebalancerInterface, _ cre.Runtime, _ *slog.Logger, _ uint64, _ onchain.Strategy) error {
+			WriteRebalance: func(_ onchain.RebalancerInterface, _ cre.Runtime, _ uint64, _ onchain.Strategy) ([]byte, error) {
 				writeCalled = true
-				return nil
+				return nil, nil
 			},
-			GetOptimalAndCurrentStrategyWithAPY: func(_ *helper.Config, _ cre.Runtime, cur onchain.Strategy, tvl *big.Int) (onchain.StrategyWithAPY, onchain.StrategyWithAPY, error) {
+			GetOptimalAndCurrentStrategyWithAPY: func(_ *helper.Config, _ cre.Runtime, _ onchain.StrategySet, cur onchain.Strategy, tvl *big.Int) (onchain.StrategyWithAPY, onchain.StrategyWithAPY, []onchain.StrategyWithAPY, error) {
 				require.Equal(t, currentStrategy, cur)
 				require.NotNil(t, tvl)
 				// Keep delta < threshold so rebalance never happens.
@@ -316,9 +343,12 @@ func Fuzz_onCronTriggerWithDeps_ChildPeerBindingUsage(f *testing.F) {
 					}, onchain.StrategyWithAPY{
 						Strategy: currentStrategy,
 						APY:      0.0,
-					}, nil
+					}, nil, nil
 			},
-			InitSupportedStrategies: noopInitSupportedStrategies,
+			ReadBlock:                       noopReadBlock,
+			NewStrategyAdapterReaderBinding: noopNewStrategyAdapterReaderBinding,
+			ReadPreflightState:              passingReadPreflightState,
+			NewStrategySet:                  noopNewStrategySet,
 		}
 
 		res, err := onCronTriggerWithDeps(cfg, runtime, nil, deps)
@@ -397,11 +427,11 @@ func Fuzz_onCronTriggerWithDeps_TVLWiring(f *testing.F) {
 				// Return a copy so mutations won't affect our tvl variable.
 				return new(big.Int).Set(tvl), nil
 			},
-			WriteRebalance: func(_ onchain.RebalancerInterface, _ cre.Runtime, _ *slog.Logger, _ uint64, _ onchain.Strategy) error {
+			WriteRebalance: func(_ onchain.RebalancerInterface, _ cre.Runtime, _ uint64, _ onchain.Strategy) ([]byte, error) {
 				writeCalled = true
-				return nil
+				return nil, nil
 			},
-			GetOptimalAndCurrentStrategyWithAPY: func(_ *helper.Config, _ cre.Runtime, cur onchain.Strategy, liquidityAdded *big.Int) (onchain.StrategyWithAPY, onchain.StrategyWithAPY, error) {
+			GetOptimalAndCurrentStrategyWithAPY: func(_ *helper.Config, _ cre.Runtime, _ onchain.StrategySet, cur onchain.Strategy, liquidityAdded *big.Int) (onchain.StrategyWithAPY, onchain.StrategyWithAPY, []onchain.StrategyWithAPY, error) {
 				require.Nil(t, gotLiquidity, "GetOptimalAndCurrentStrategyWithAPY should be called exactly once")
 				gotCurrent = cur
 				if liquidityAdded != nil {
@@ -414,9 +444,12 @@ func Fuzz_onCronTriggerWithDeps_TVLWiring(f *testing.F) {
 					}, onchain.StrategyWithAPY{
 						Strategy: currentStrategy,
 						APY:      0.0,
-					}, nil
+					}, nil, nil
 			},
-			InitSupportedStrategies: noopInitSupportedStrategies,
+			ReadBlock:                       noopReadBlock,
+			NewStrategyAdapterReaderBinding: noopNewStrategyAdapterReaderBinding,
+			ReadPreflightState:              passingReadPreflightState,
+			NewStrategySet:                  noopNewStrategySet,
 		}
 
 		res, err := onCronTriggerWithDeps(cfg, runtime, nil, deps)
@@ -492,11 +525,11 @@ func Fuzz_onCronTriggerWithDeps_DeltaTranslationInvariance(f *testing.F) {
 					// TVL is irrelevant for the APY model here.
 					return big.NewInt(1_000), nil
 				},
-				WriteRebalance: func(_ onchain.RebalancerInterface, _ cre.Runtime, _ *slog.Logger, _ uint64, _ onchain.Strategy) error {
+				WriteRebalance: func(_ onchain.RebalancerInterface, _ cre.Runtime, _ uint64, _ onchain.Strategy) ([]byte, error) {
 					writeCalled = true
-					return nil
+					return nil, nil
 				},
-				GetOptimalAndCurrentStrategyWithAPY: func(_ *helper.Config, _ cre.Runtime, cur onchain.Strategy, tvl *big.Int) (onchain.StrategyWithAPY, onchain.StrategyWithAPY, error) {
+				GetOptimalAndCurrentStrategyWithAPY: func(_ *helper.Config, _ cre.Runtime, _ onchain.StrategySet, cur onchain.Strategy, tvl *big.Int) (onchain.StrategyWithAPY, onchain.StrategyWithAPY, []onchain.StrategyWithAPY, error) {
 					require.Equal(t, currentStrategy, cur)
 					require.NotNil(t, tvl)
 					return onchain.StrategyWithAPY{
@@ -505,9 +538,12 @@ func Fuzz_onCronTriggerWithDeps_DeltaTranslationInvariance(f *testing.F) {
 						}, onchain.StrategyWithAPY{
 							Strategy: currentStrategy,
 							APY:      currentAPY,
-						}, nil
+						}, nil, nil
 				},
-				InitSupportedStrategies: noopInitSupportedStrategies,
+				ReadBlock:                       noopReadBlock,
+				NewStrategyAdapterReaderBinding: noopNewStrategyAdapterReaderBinding,
+				ReadPreflightState:              passingReadPreflightState,
+				NewStrategySet:                  noopNewStrategySet,
 			}
 
 			res, err := onCronTriggerWithDeps(cfg, runtime, nil, deps)
@@ -1,14 +1,16 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
-	"log/slog"
 	"math/big"
 	"strings"
 	"testing"
 
 	"rebalance/workflow/internal/helper"
 	"rebalance/workflow/internal/onchain"
+	"rebalance/workflow/internal/policy"
+	"rebalance/workflow/internal/protocol"
 
 	"github.com/smartcontractkit/cre-sdk-go/capabilities/blockchain/evm"
 	"github.com/smartcontractkit/cre-sdk-go/capabilities/scheduler/cron"
@@ -28,6 +30,11 @@ func newPayloadNow() *cron.Payload {
 	}
 }
 
+// named names strategy the way a set without a registry does.
+func named(strategy onchain.Strategy) onchain.NamedStrategy {
+	return onchain.StrategySet{}.Named(strategy)
+}
+
 /*//////////////////////////////////////////////////////////////
                      TESTS FOR ON CRON TRIGGER
 //////////////////////////////////////////////////////////////*/
@@ -44,7 +51,7 @@ func Test_onCronTrigger_errorWhen_noEvmConfigsProvided(t *testing.T) {
 		"unexpected error: %v", err)
 }
 
-func Test_onCronTriggerWithDeps_errorWhen_InitSupportedStrategiesFails(t *testing.T) {
+func Test_onCronTriggerWithDeps_errorWhen_NewStrategySetFails(t *testing.T) {
 	config := &helper.Config{
 		Evms: []helper.EvmConfig{{
 			ChainName:        "parent-chain",
@@ -57,15 +64,18 @@ func Test_onCronTriggerWithDeps_errorWhen_InitSupportedStrategiesFails(t *testin
 	expectedErr := fmt.Errorf("init-strategies-failed")
 
 	deps := OnCronDeps{
-		InitSupportedStrategies: func(_ *helper.Config) error {
-			return expectedErr
+		ReadBlock:                       noopReadBlock,
+		NewStrategyAdapterReaderBinding: noopNewStrategyAdapterReaderBinding,
+		ReadPreflightState:              passingReadPreflightState,
+		NewStrategySet: func(_ *helper.Config) (onchain.StrategySet, error) {
+			return onchain.StrategySet{}, expectedErr
 		},
 		NewParentPeerBinding: func(_ *evm.Client, _ string) (onchain.ParentPeerInterface, error) {
-			require.FailNow(t, "NewParentPeerBinding should not be called when InitSupportedStrategies fails")
+			require.FailNow(t, "NewParentPeerBinding should not be called when NewStrategySet fails")
 			return nil, nil
 		},
 		ReadCurrentStrategy: func(_ *helper.Config, _ cre.Runtime, _ onchain.ParentPeerInterface) (onchain.Strategy, error) {
-			require.FailNow(t, "ReadCurrentStrategy should not be called when InitSupportedStrategies fails")
+			require.FailNow(t, "ReadCurrentStrategy should not be called when NewStrategySet fails")
 			return onchain.Strategy{}, nil
 		},
 	}
@@ -74,7 +84,7 @@ func Test_onCronTriggerWithDeps_errorWhen_InitSupportedStrategiesFails(t *testin
 
 	require.Error(t, err)
 	require.Nil(t, res)
-	require.Contains(t, err.Error(), "failed to initialize supported strategies: init-strategies-failed")
+	require.Contains(t, err.Error(), "failed to build supported strategy set: init-strategies-failed")
 }
 
 func Test_onCronTriggerWithDeps_errorWhen_ParentPeerBindingFails(t *testing.T) {
@@ -88,8 +98,11 @@ func Test_onCronTriggerWithDeps_errorWhen_ParentPeerBindingFails(t *testing.T) {
 	runtime := testutils.NewRuntime(t, nil)
 
 	deps := OnCronDeps{
-		InitSupportedStrategies: func(_ *helper.Config) error {
-			return nil
+		ReadBlock:                       noopReadBlock,
+		NewStrategyAdapterReaderBinding: noopNewStrategyAdapterReaderBinding,
+		ReadPreflightState:              passingReadPreflightState,
+		NewStrategySet: func(_ *helper.Config) (onchain.StrategySet, error) {
+			return onchain.StrategySet{}, nil
 		},
 		NewParentPeerBinding: func(_ *evm.Client, _ string) (onchain.ParentPeerInterface, error) {
 			return nil, fmt.Errorf("parent-binding-failed")
@@ -103,6 +116,38 @@ func Test_onCronTriggerWithDeps_errorWhen_ParentPeerBindingFails(t *testing.T) {
 	require.Contains(t, err.Error(), "failed to create ParentPeer binding: parent-binding-failed")
 }
 
+func Test_onCronTriggerWithDeps_errorWhen_ReadBlockFails(t *testing.T) {
+	config := &helper.Config{
+		Evms: []helper.EvmConfig{{
+			ChainName:        "parent-chain",
+			ChainSelector:    1,
+			YieldPeerAddress: "0xparent",
+		}},
+	}
+	runtime := testutils.NewRuntime(t, nil)
+
+	deps := OnCronDeps{
+		NewStrategyAdapterReaderBinding: noopNewStrategyAdapterReaderBinding,
+		ReadPreflightState:              passingReadPreflightState,
+		ReadBlock: func(_ *helper.Config, _ cre.Runtime, _ onchain.HeaderReaderInterface) (onchain.Block, error) {
+			return onchain.Block{}, fmt.Errorf("read-block-failed")
+		},
+		NewStrategySet: func(_ *helper.Config) (onchain.StrategySet, error) {
+			return onchain.StrategySet{}, nil
+		},
+		NewParentPeerBinding: func(_ *evm.Client, _ string) (onchain.ParentPeerInterface, error) {
+			return nil, nil
+		},
+	}
+
+	res, err := onCronTriggerWithDeps(config, runtime, newPayloadNow(), deps)
+
+	require.Error(t, err)
+	require.Nil(t, res)
+	require.Contains(t, err.Error(), "failed to read parent block")
+	require.Contains(t, err.Error(), "read-block-failed")
+}
+
 func Test_onCronTriggerWithDeps_errorWhen_ReadCurrentStrategyFails(t *testing.T) {
 	config := &helper.Config{
 		Evms: []helper.EvmConfig{{
@@ -114,8 +159,11 @@ func Test_onCronTriggerWithDeps_errorWhen_ReadCurrentStrategyFails(t *testing.T)
 	runtime := testutils.NewRuntime(t, nil)
 
 	deps := OnCronDeps{
-		InitSupportedStrategies: func(_ *helper.Config) error {
-			return nil
+		ReadBlock:                       noopReadBlock,
+		NewStrategyAdapterReaderBinding: noopNewStrategyAdapterReaderBinding,
+		ReadPreflightState:              passingReadPreflightState,
+		NewStrategySet: func(_ *helper.Config) (onchain.StrategySet, error) {
+			return onchain.StrategySet{}, nil
 		},
 		NewParentPeerBinding: func(_ *evm.Client, _ string) (onchain.ParentPeerInterface, error) {
 			return nil, nil
@@ -145,8 +193,11 @@ func Test_onCronTriggerWithDeps_errorWhen_GetOptimalAndCurrentStrategyWithAPYFai
 	cur := onchain.Strategy{ChainSelector: 1}
 
 	deps := OnCronDeps{
-		InitSupportedStrategies: func(_ *helper.Config) error {
-			return nil
+		ReadBlock:                       noopReadBlock,
+		NewStrategyAdapterReaderBinding: noopNewStrategyAdapterReaderBinding,
+		ReadPreflightState:              passingReadPreflightState,
+		NewStrategySet: func(_ *helper.Config) (onchain.StrategySet, error) {
+			return onchain.StrategySet{}, nil
 		},
 		NewParentPeerBinding: func(_ *evm.Client, _ string) (onchain.ParentPeerInterface, error) {
 			return nil, nil
@@ -157,12 +208,12 @@ func Test_onCronTriggerWithDeps_errorWhen_GetOptimalAndCurrentStrategyWithAPYFai
 		ReadTVL: func(_ *helper.Config, _ cre.Runtime, _ onchain.YieldPeerInterface) (*big.Int, error) {
 			return big.NewInt(1000), nil
 		},
-		GetOptimalAndCurrentStrategyWithAPY: func(_ *helper.Config, _ cre.Runtime, _ onchain.Strategy, _ *big.Int) (onchain.StrategyWithAPY, onchain.StrategyWithAPY, error) {
-			return onchain.StrategyWithAPY{}, onchain.StrategyWithAPY{}, fmt.Errorf("optimal-failed")
+		GetOptimalAndCurrentStrategyWithAPY: func(_ *helper.Config, _ cre.Runtime, _ onchain.StrategySet, _ onchain.Strategy, _ *big.Int) (onchain.StrategyWithAPY, onchain.StrategyWithAPY, []onchain.StrategyWithAPY, error) {
+			return onchain.StrategyWithAPY{}, onchain.StrategyWithAPY{}, nil, fmt.Errorf("optimal-failed")
 		},
-		WriteRebalance: func(_ onchain.RebalancerInterface, _ cre.Runtime, _ *slog.Logger, _ uint64, _ onchain.Strategy) error {
+		WriteRebalance: func(_ onchain.RebalancerInterface, _ cre.Runtime, _ uint64, _ onchain.Strategy) ([]byte, error) {
 			require.FailNow(t, "WriteRebalance should not be called when GetOptimalAndCurrentStrategyWithAPY fails")
-			return nil
+			return nil, nil
 		},
 	}
 
@@ -186,8 +237,11 @@ func Test_onCronTriggerWithDeps_success_noRebalanceWhenStrategyUnchanged(t *test
 	strat := onchain.Strategy{ChainSelector: 1}
 
 	deps := OnCronDeps{
-		InitSupportedStrategies: func(_ *helper.Config) error {
-			return nil
+		ReadBlock:                       noopReadBlock,
+		NewStrategyAdapterReaderBinding: noopNewStrategyAdapterReaderBinding,
+		ReadPreflightState:              passingReadPreflightState,
+		NewStrategySet: func(_ *helper.Config) (onchain.StrategySet, error) {
+			return onchain.StrategySet{}, nil
 		},
 		NewParentPeerBinding: func(_ *evm.Client, _ string) (onchain.ParentPeerInterface, error) {
 			return nil, nil
@@ -198,13 +252,13 @@ func Test_onCronTriggerWithDeps_success_noRebalanceWhenStrategyUnchanged(t *test
 		ReadTVL: func(_ *helper.Config, _ cre.Runtime, _ onchain.YieldPeerInterface) (*big.Int, error) {
 			return big.NewInt(1000), nil
 		},
-		GetOptimalAndCurrentStrategyWithAPY: func(_ *helper.Config, _ cre.Runtime, _ onchain.Strategy, _ *big.Int) (onchain.StrategyWithAPY, onchain.StrategyWithAPY, error) {
+		GetOptimalAndCurrentStrategyWithAPY: func(_ *helper.Config, _ cre.Runtime, _ onchain.StrategySet, _ onchain.Strategy, _ *big.Int) (onchain.StrategyWithAPY, onchain.StrategyWithAPY, []onchain.StrategyWithAPY, error) {
 			// Return same strategy for both optimal and current
-			return onchain.StrategyWithAPY{Strategy: strat, APY: 0.05}, onchain.StrategyWithAPY{Strategy: strat, APY: 0.05}, nil
+			return onchain.StrategyWithAPY{Strategy: strat, APY: 0.05}, onchain.StrategyWithAPY{Strategy: strat, APY: 0.05}, nil, nil
 		},
-		WriteRebalance: func(_ onchain.RebalancerInterface, _ cre.Runtime, _ *slog.Logger, _ uint64, _ onchain.Strategy) error {
+		WriteRebalance: func(_ onchain.RebalancerInterface, _ cre.Runtime, _ uint64, _ onchain.Strategy) ([]byte, error) {
 			require.FailNow(t, "WriteRebalance should not be called when strategy is unchanged")
-			return nil
+			return nil, nil
 		},
 	}
 
@@ -213,8 +267,9 @@ func Test_onCronTriggerWithDeps_success_noRebalanceWhenStrategyUnchanged(t *test
 	require.NoError(t, err)
 	require.NotNil(t, res)
 	require.False(t, res.Updated)
-	require.Equal(t, strat, res.Current)
-	require.Equal(t, strat, res.Optimal)
+	require.Equal(t, ReasonUnchanged, res.Reason)
+	require.Equal(t, strat, res.Current.Strategy)
+	require.Equal(t, strat, res.Optimal.Strategy)
 }
 
 func Test_onCronTriggerWithDeps_errorWhen_NoConfigForStrategyChain(t *testing.T) {
@@ -233,8 +288,11 @@ func Test_onCronTriggerWithDeps_errorWhen_NoConfigForStrategyChain(t *testing.T)
 	}
 
 	deps := OnCronDeps{
-		InitSupportedStrategies: func(_ *helper.Config) error {
-			return nil
+		ReadBlock:                       noopReadBlock,
+		NewStrategyAdapterReaderBinding: noopNewStrategyAdapterReaderBinding,
+		ReadPreflightState:              passingReadPreflightState,
+		NewStrategySet: func(_ *helper.Config) (onchain.StrategySet, error) {
+			return onchain.StrategySet{}, nil
 		},
 		NewParentPeerBinding: func(_ *evm.Client, _ string) (onchain.ParentPeerInterface, error) {
 			return nil, nil
@@ -246,13 +304,13 @@ func Test_onCronTriggerWithDeps_errorWhen_NoConfigForStrategyChain(t *testing.T)
 			require.FailNow(t, "ReadTVL should not be called when no EVM config exists for strategy chain")
 			return nil, nil
 		},
-		GetOptimalAndCurrentStrategyWithAPY: func(_ *helper.Config, _ cre.Runtime, _ onchain.Strategy, _ *big.Int) (onchain.StrategyWithAPY, onchain.StrategyWithAPY, error) {
+		GetOptimalAndCurrentStrategyWithAPY: func(_ *helper.Config, _ cre.Runtime, _ onchain.StrategySet, _ onchain.Strategy, _ *big.Int) (onchain.StrategyWithAPY, onchain.StrategyWithAPY, []onchain.StrategyWithAPY, error) {
 			require.FailNow(t, "GetOptimalAndCurrentStrategyWithAPY should not be called when no EVM config exists for strategy chain")
-			return onchain.StrategyWithAPY{}, onchain.StrategyWithAPY{}, nil
+			return onchain.StrategyWithAPY{}, onchain.StrategyWithAPY{}, nil, nil
 		},
-		WriteRebalance: func(_ onchain.RebalancerInterface, _ cre.Runtime, _ *slog.Logger, _ uint64, _ onchain.Strategy) error {
+		WriteRebalance: func(_ onchain.RebalancerInterface, _ cre.Runtime, _ uint64, _ onchain.Strategy) ([]byte, error) {
 			require.FailNow(t, "WriteRebalance should not be called when no EVM config exists for strategy chain")
-			return nil
+			return nil, nil
 		},
 	}
 
@@ -286,8 +344,11 @@ func Test_onCronTriggerWithDeps_errorWhen_ChildPeerBindingFails(t *testing.T) {
 	}
 
 	deps := OnCronDeps{
-		InitSupportedStrategies: func(_ *helper.Config) error {
-			return nil
+		ReadBlock:                       noopReadBlock,
+		NewStrategyAdapterReaderBinding: noopNewStrategyAdapterReaderBinding,
+		ReadPreflightState:              passingReadPreflightState,
+		NewStrategySet: func(_ *helper.Config) (onchain.StrategySet, error) {
+			return onchain.StrategySet{}, nil
 		},
 		NewParentPeerBinding: func(_ *evm.Client, _ string) (onchain.ParentPeerInterface, error) {
 			return nil, nil
@@ -302,13 +363,13 @@ func Test_onCronTriggerWithDeps_errorWhen_ChildPeerBindingFails(t *testing.T) {
 			require.FailNow(t, "ReadTVL should not be called when ChildPeer binding fails")
 			return nil, nil
 		},
-		GetOptimalAndCurrentStrategyWithAPY: func(_ *helper.Config, _ cre.Runtime, _ onchain.Strategy, _ *big.Int) (onchain.StrategyWithAPY, onchain.StrategyWithAPY, error) {
+		GetOptimalAndCurrentStrategyWithAPY: func(_ *helper.Config, _ cre.Runtime, _ onchain.StrategySet, _ onchain.Strategy, _ *big.Int) (onchain.StrategyWithAPY, onchain.StrategyWithAPY, []onchain.StrategyWithAPY, error) {
 			require.FailNow(t, "GetOptimalAndCurrentStrategyWithAPY should not be called when ChildPeer binding fails")
-			return onchain.StrategyWithAPY{}, onchain.StrategyWithAPY{}, nil
+			return onchain.StrategyWithAPY{}, onchain.StrategyWithAPY{}, nil, nil
 		},
-		WriteRebalance: func(_ onchain.RebalancerInterface, _ cre.Runtime, _ *slog.Logger, _ uint64, _ onchain.Strategy) error {
+		WriteRebalance: func(_ onchain.RebalancerInterface, _ cre.Runtime, _ uint64, _ onchain.Strategy) ([]byte, error) {
 			require.FailNow(t, "WriteRebalance should not be called when ChildPeer binding fails")
-			return nil
+			return nil, nil
 		},
 	}
 
@@ -335,8 +396,11 @@ func Test_onCronTriggerWithDeps_errorWhen_ReadTVLFails_sameChain(t *testing.T) {
 	}
 
 	deps := OnCronDeps{
-		InitSupportedStrategies: func(_ *helper.Config) error {
-			return nil
+		ReadBlock:                       noopReadBlock,
+		NewStrategyAdapterReaderBinding: noopNewStrategyAdapterReaderBinding,
+		ReadPreflightState:              passingReadPreflightState,
+		NewStrategySet: func(_ *helper.Config) (onchain.StrategySet, error) {
+			return onchain.StrategySet{}, nil
 		},
 		NewParentPeerBinding: func(_ *evm.Client, _ string) (onchain.ParentPeerInterface, error) {
 			return nil, nil
@@ -347,13 +411,13 @@ func Test_onCronTriggerWithDeps_errorWhen_ReadTVLFails_sameChain(t *testing.T) {
 		ReadTVL: func(_ *helper.Config, _ cre.Runtime, _ onchain.YieldPeerInterface) (*big.Int, error) {
 			return nil, fmt.Errorf("tvl-failed")
 		},
-		GetOptimalAndCurrentStrategyWithAPY: func(_ *helper.Config, _ cre.Runtime, _ onchain.Strategy, _ *big.Int) (onchain.StrategyWithAPY, onchain.StrategyWithAPY, error) {
+		GetOptimalAndCurrentStrategyWithAPY: func(_ *helper.Config, _ cre.Runtime, _ onchain.StrategySet, _ onchain.Strategy, _ *big.Int) (onchain.StrategyWithAPY, onchain.StrategyWithAPY, []onchain.StrategyWithAPY, error) {
 			require.FailNow(t, "GetOptimalAndCurrentStrategyWithAPY should not be called when ReadTVL fails")
-			return onchain.StrategyWithAPY{}, onchain.StrategyWithAPY{}, nil
+			return onchain.StrategyWithAPY{}, onchain.StrategyWithAPY{}, nil, nil
 		},
-		WriteRebalance: func(_ onchain.RebalancerInterface, _ cre.Runtime, _ *slog.Logger, _ uint64, _ onchain.Strategy) error {
+		WriteRebalance: func(_ onchain.RebalancerInterface, _ cre.Runtime, _ uint64, _ onchain.Strategy) ([]byte, error) {
 			require.FailNow(t, "WriteRebalance should not be called when ReadTVL fails")
-			return nil
+			return nil, nil
 		},
 	}
 
@@ -377,8 +441,11 @@ func Test_onCronTriggerWithDeps_errorWhen_GetOptimalAndCurrentStrategyWithAPYFai
 	cur := onchain.Strategy{ProtocolId: [32]byte{1}, ChainSelector: 1}
 
 	deps := OnCronDeps{
-		InitSupportedStrategies: func(_ *helper.Config) error {
-			return nil
+		ReadBlock:                       noopReadBlock,
+		NewStrategyAdapterReaderBinding: noopNewStrategyAdapterReaderBinding,
+		ReadPreflightState:              passingReadPreflightState,
+		NewStrategySet: func(_ *helper.Config) (onchain.StrategySet, error) {
+			return onchain.StrategySet{}, nil
 		},
 		NewParentPeerBinding: func(_ *evm.Client, _ string) (onchain.ParentPeerInterface, error) {
 			return nil, nil
@@ -389,12 +456,12 @@ func Test_onCronTriggerWithDeps_errorWhen_GetOptimalAndCurrentStrategyWithAPYFai
 		ReadTVL: func(_ *helper.Config, _ cre.Runtime, _ onchain.YieldPeerInterface) (*big.Int, error) {
 			return big.NewInt(123), nil
 		},
-		GetOptimalAndCurrentStrategyWithAPY: func(_ *helper.Config, _ cre.Runtime, _ onchain.Strategy, _ *big.Int) (onchain.StrategyWithAPY, onchain.StrategyWithAPY, error) {
-			return onchain.StrategyWithAPY{}, onchain.StrategyWithAPY{}, fmt.Errorf("apy-calculation-failed")
+		GetOptimalAndCurrentStrategyWithAPY: func(_ *helper.Config, _ cre.Runtime, _ onchain.StrategySet, _ onchain.Strategy, _ *big.Int) (onchain.StrategyWithAPY, onchain.StrategyWithAPY, []onchain.StrategyWithAPY, error) {
+			return onchain.StrategyWithAPY{}, onchain.StrategyWithAPY{}, nil, fmt.Errorf("apy-calculation-failed")
 		},
-		WriteRebalance: func(_ onchain.RebalancerInterface, _ cre.Runtime, _ *slog.Logger, _ uint64, _ onchain.Strategy) error {
+		WriteRebalance: func(_ onchain.RebalancerInterface, _ cre.Runtime, _ uint64, _ onchain.Strategy) ([]byte, error) {
 			require.FailNow(t, "WriteRebalance should not be called when APY calculation fails")
-			return nil
+			return nil, nil
 		},
 	}
 
@@ -422,8 +489,11 @@ func Test_onCronTriggerWithDeps_success_noRebalanceWhenDeltaBelowThreshold(t *te
 	writeCalled := false
 
 	deps := OnCronDeps{
-		InitSupportedStrategies: func(_ *helper.Config) error {
-			return nil
+		ReadBlock:                       noopReadBlock,
+		NewStrategyAdapterReaderBinding: noopNewStrategyAdapterReaderBinding,
+		ReadPreflightState:              passingReadPreflightState,
+		NewStrategySet: func(_ *helper.Config) (onchain.StrategySet, error) {
+			return onchain.StrategySet{}, nil
 		},
 		NewParentPeerBinding: func(_ *evm.Client, _ string) (onchain.ParentPeerInterface, error) {
 			return nil, nil
@@ -435,16 +505,16 @@ func Test_onCronTriggerWithDeps_success_noRebalanceWhenDeltaBelowThreshold(t *te
 			return big.NewInt(1000), nil
 		},
 		// delta = 0.01 - 0.02 = -0.01 < threshold(0.01)
-		GetOptimalAndCurrentStrategyWithAPY: func(_ *helper.Config, _ cre.Runtime, _ onchain.Strategy, _ *big.Int) (onchain.StrategyWithAPY, onchain.StrategyWithAPY, error) {
-			return onchain.StrategyWithAPY{Strategy: opt, APY: 0.01}, onchain.StrategyWithAPY{Strategy: cur, APY: 0.02}, nil
+		GetOptimalAndCurrentStrategyWithAPY: func(_ *helper.Config, _ cre.Runtime, _ onchain.StrategySet, _ onchain.Strategy, _ *big.Int) (onchain.StrategyWithAPY, onchain.StrategyWithAPY, []onchain.StrategyWithAPY, error) {
+			return onchain.StrategyWithAPY{Strategy: opt, APY: 0.01}, onchain.StrategyWithAPY{Strategy: cur, APY: 0.02}, nil, nil
 		},
 		NewRebalancerBinding: func(_ *evm.Client, _ string) (onchain.RebalancerInterface, error) {
 			require.FailNow(t, "NewRebalancerBinding should not be called when delta < threshold")
 			return nil, nil
 		},
-		WriteRebalance: func(_ onchain.RebalancerInterface, _ cre.Runtime, _ *slog.Logger, _ uint64, _ onchain.Strategy) error {
+		WriteRebalance: func(_ onchain.RebalancerInterface, _ cre.Runtime, _ uint64, _ onchain.Strategy) ([]byte, error) {
 			writeCalled = true
-			return nil
+			return nil, nil
 		},
 	}
 
@@ -453,9 +523,434 @@ func Test_onCronTriggerWithDeps_success_noRebalanceWhenDeltaBelowThreshold(t *te
 	require.NoError(t, err)
 	require.NotNil(t, res)
 	require.False(t, res.Updated)
+	require.Equal(t, ReasonBelowThreshold, res.Reason)
 	require.False(t, writeCalled, "WriteRebalance should not be called when delta < threshold")
-	require.Equal(t, cur, res.Current)
-	require.Equal(t, opt, res.Optimal)
+	require.Equal(t, cur, res.Current.Strategy)
+	require.Equal(t, opt, res.Optimal.Strategy)
+}
+
+func Test_onCronTriggerWithDeps_success_noRebalanceWhenThresholdPolicyBlocks(t *testing.T) {
+	minGain := 1_000.0
+	config := &helper.Config{
+		Evms: []helper.EvmConfig{{
+			ChainName:        "parent-chain",
+			ChainSelector:    1,
+			YieldPeerAddress: "0xparent",
+			GasLimit:         500000,
+		}},
+		Threshold: helper.ThresholdConfig{
+			MinAnnualGainUSD: &minGain,
+		},
+	}
+	runtime := testutils.NewRuntime(t, nil)
+
+	cur := onchain.Strategy{ProtocolId: [32]byte{1}, ChainSelector: 1}
+	opt := onchain.Strategy{ProtocolId: [32]byte{2}, ChainSelector: 1}
+
+	deps := OnCronDeps{
+		ReadBlock:                       noopReadBlock,
+		NewStrategyAdapterReaderBinding: noopNewStrategyAdapterReaderBinding,
+		ReadPreflightState:              passingReadPreflightState,
+		NewStrategySet: func(_ *helper.Config) (onchain.StrategySet, error) {
+			return onchain.StrategySet{}, nil
+		},
+		NewParentPeerBinding: func(_ *evm.Client, _ string) (onchain.ParentPeerInterface, error) {
+			return nil, nil
+		},
+		ReadCurrentStrategy: func(_ *helper.Config, _ cre.Runtime, _ onchain.ParentPeerInterface) (onchain.Strategy, error) {
+			return cur, nil
+		},
+		// 10k USDC
+		ReadTVL: func(_ *helper.Config, _ cre.Runtime, _ onchain.YieldPeerInterface) (*big.Int, error) {
+			return big.NewInt(10_000_000_000), nil
+		},
+		// delta = 0.05 clears the APY threshold, but 10k * 0.05 = $500/yr < $1000
+		GetOptimalAndCurrentStrategyWithAPY: func(_ *helper.Config, _ cre.Runtime, _ onchain.StrategySet, _ onchain.Strategy, _ *big.Int) (onchain.StrategyWithAPY, onchain.StrategyWithAPY, []onchain.StrategyWithAPY, error) {
+			return onchain.StrategyWithAPY{Strategy: opt, APY: 0.06}, onchain.StrategyWithAPY{Strategy: cur, APY: 0.01}, nil, nil
+		},
+		NewRebalancerBinding: func(_ *evm.Client, _ string) (onchain.RebalancerInterface, error) {
+			require.FailNow(t, "NewRebalancerBinding should not be called when the threshold policy blocks")
+			return nil, nil
+		},
+	}
+
+	res, err := onCronTriggerWithDeps(config, runtime, newPayloadNow(), deps)
+
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	require.False(t, res.Updated)
+	require.NotNil(t, res.Threshold)
+	require.False(t, res.Threshold.Allowed)
+	require.Equal(t, policy.RuleDefault, res.Threshold.Rule)
+	require.Equal(t, policy.CheckAnnualGain, res.Threshold.Check)
+	require.InDelta(t, 500.0, res.Threshold.AnnualGainUSD, 1e-6)
+}
+
+func Test_onCronTriggerWithDeps_success_noRebalanceWhenCostExceedsGain(t *testing.T) {
+	config := &helper.Config{
+		Evms: []helper.EvmConfig{
+			{
+				ChainName:           "parent-chain",
+				ChainSelector:       1,
+				YieldPeerAddress:    "0xparent",
+				GasLimit:            500000,
+				GasPriceGwei:        10,
+				NativeTokenPriceUSD: 2_000,
+				CCIPFeeUSD:          5,
+			},
+			{
+				ChainName:        "child-chain",
+				ChainSelector:    2,
+				YieldPeerAddress: "0xchild",
+				GasLimit:         777000,
+			},
+		},
+		Cost: helper.CostConfig{Enabled: true, HorizonDays: 7},
+	}
+	runtime := testutils.NewRuntime(t, nil)
+
+	cur := onchain.Strategy{ProtocolId: [32]byte{1}, ChainSelector: 1}
+	opt := onchain.Strategy{ProtocolId: [32]byte{2}, ChainSelector: 2}
+
+	deps := OnCronDeps{
+		ReadBlock:                       noopReadBlock,
+		NewStrategyAdapterReaderBinding: noopNewStrategyAdapterReaderBinding,
+		ReadPreflightState:              passingReadPreflightState,
+		NewStrategySet: func(_ *helper.Config) (onchain.StrategySet, error) {
+			return onchain.StrategySet{}, nil
+		},
+		NewParentPeerBinding: func(_ *evm.Client, _ string) (onchain.ParentPeerInterface, error) {
+			return nil, nil
+		},
+		ReadCurrentStrategy: func(_ *helper.Config, _ cre.Runtime, _ onchain.ParentPeerInterface) (onchain.Strategy, error) {
+			return cur, nil
+		},
+		// 1k USDC
+		ReadTVL: func(_ *helper.Config, _ cre.Runtime, _ onchain.YieldPeerInterface) (*big.Int, error) {
+			return big.NewInt(1_000_000_000), nil
+		},
+		// 1k * 2pp * 7/365 = ~$0.38 of gain vs $10 gas + $5 CCIP
+		GetOptimalAndCurrentStrategyWithAPY: func(_ *helper.Config, _ cre.Runtime, _ onchain.StrategySet, _ onchain.Strategy, _ *big.Int) (onchain.StrategyWithAPY, onchain.StrategyWithAPY, []onchain.StrategyWithAPY, error) {
+			return onchain.StrategyWithAPY{Strategy: opt, APY: 0.05}, onchain.StrategyWithAPY{Strategy: cur, APY: 0.03}, nil, nil
+		},
+		NewRebalancerBinding: func(_ *evm.Client, _ string) (onchain.RebalancerInterface, error) {
+			require.FailNow(t, "NewRebalancerBinding should not be called when cost exceeds gain")
+			return nil, nil
+		},
+	}
+
+	res, err := onCronTriggerWithDeps(config, runtime, newPayloadNow(), deps)
+
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	require.False(t, res.Updated)
+	require.Equal(t, ReasonCostExceedsGain, res.Reason)
+	require.True(t, res.Threshold.Allowed)
+	require.NotNil(t, res.Cost)
+	require.False(t, res.Cost.Worthwhile)
+	require.Equal(t, 1, res.Cost.CCIPMessages)
+	require.InDelta(t, 15.0, res.Cost.TotalUSD, 1e-9)
+}
+
+func Test_onCronTriggerWithDeps_success_noRebalanceWhenCooldownActive(t *testing.T) {
+	config := &helper.Config{
+		Evms: []helper.EvmConfig{
+			{
+				ChainName:        "parent-chain",
+				ChainSelector:    1,
+				YieldPeerAddress: "0xparent",
+				GasLimit:         500000,
+			},
+		},
+		Cooldown: helper.CooldownConfig{
+			Enabled:            true,
+			MinIntervalSeconds: 12 * 60 * 60,
+			LookbackBlocks:     100,
+		},
+	}
+	runtime := testutils.NewRuntime(t, nil)
+
+	cur := onchain.Strategy{ProtocolId: [32]byte{1}, ChainSelector: 1}
+	opt := onchain.Strategy{ProtocolId: [32]byte{2}, ChainSelector: 1}
+
+	deps := OnCronDeps{
+		ReadBlock:                       noopReadBlock,
+		NewStrategyAdapterReaderBinding: noopNewStrategyAdapterReaderBinding,
+		ReadPreflightState:              passingReadPreflightState,
+		NewStrategySet: func(_ *helper.Config) (onchain.StrategySet, error) {
+			return onchain.StrategySet{}, nil
+		},
+		NewParentPeerBinding: func(_ *evm.Client, _ string) (onchain.ParentPeerInterface, error) {
+			return nil, nil
+		},
+		ReadCurrentStrategy: func(_ *helper.Config, _ cre.Runtime, _ onchain.ParentPeerInterface) (onchain.Strategy, error) {
+			return cur, nil
+		},
+		ReadTVL: func(_ *helper.Config, _ cre.Runtime, _ onchain.YieldPeerInterface) (*big.Int, error) {
+			return big.NewInt(1_000_000), nil
+		},
+		GetOptimalAndCurrentStrategyWithAPY: func(_ *helper.Config, _ cre.Runtime, _ onchain.StrategySet, _ onchain.Strategy, _ *big.Int) (onchain.StrategyWithAPY, onchain.StrategyWithAPY, []onchain.StrategyWithAPY, error) {
+			return onchain.StrategyWithAPY{Strategy: opt, APY: 0.10}, onchain.StrategyWithAPY{Strategy: cur, APY: 0.03}, nil, nil
+		},
+		// Last move was one hour before the head block.
+		ReadStrategyHistory: func(_ *helper.Config, _ cre.Runtime, _ onchain.HeaderReaderInterface, _ onchain.ParentPeerInterface) (onchain.StrategyHistory, error) {
+			return onchain.StrategyHistory{
+				Updates:       []onchain.StrategyUpdate{{Strategy: cur, Timestamp: 100_000 - 3_600}},
+				HeadTimestamp: 100_000,
+			}, nil
+		},
+		NewRebalancerBinding: func(_ *evm.Client, _ string) (onchain.RebalancerInterface, error) {
+			require.FailNow(t, "NewRebalancerBinding should not be called during cooldown")
+			return nil, nil
+		},
+	}
+
+	res, err := onCronTriggerWithDeps(config, runtime, newPayloadNow(), deps)
+
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	require.False(t, res.Updated)
+	require.Equal(t, ReasonCooldown, res.Reason)
+	require.True(t, res.Threshold.Allowed)
+	require.NotNil(t, res.Cooldown)
+	require.False(t, res.Cooldown.Allowed)
+	require.Equal(t, policy.CooldownMinInterval, res.Cooldown.Rule)
+	require.Equal(t, uint64(100_000-3_600+12*60*60), res.Cooldown.NextAllowedAt)
+}
+
+func Test_onCronTriggerWithDeps_success_emergencyExitIgnoresCooldown(t *testing.T) {
+	config := &helper.Config{
+		Evms: []helper.EvmConfig{
+			{
+				ChainName:         "parent-chain",
+				ChainSelector:     1,
+				YieldPeerAddress:  "0xparent",
+				RebalancerAddress: "0xrebalancer",
+				GasLimit:          500000,
+			},
+		},
+		Cooldown: helper.CooldownConfig{
+			Enabled:            true,
+			MinIntervalSeconds: 12 * 60 * 60,
+			LookbackBlocks:     100,
+		},
+	}
+	runtime := testutils.NewRuntime(t, nil)
+
+	cur := onchain.Strategy{ProtocolId: [32]byte{1}, ChainSelector: 1}
+	opt := onchain.Strategy{ProtocolId: [32]byte{2}, ChainSelector: 1}
+
+	writeCalls := 0
+	deps := OnCronDeps{
+		ReadBlock:                       noopReadBlock,
+		NewStrategyAdapterReaderBinding: noopNewStrategyAdapterReaderBinding,
+		ReadPreflightState:              passingReadPreflightState,
+		NewStrategySet: func(_ *helper.Config) (onchain.StrategySet, error) {
+			return onchain.StrategySet{}, nil
+		},
+		NewParentPeerBinding: func(_ *evm.Client, _ string) (onchain.ParentPeerInterface, error) {
+			return nil, nil
+		},
+		ReadCurrentStrategy: func(_ *helper.Config, _ cre.Runtime, _ onchain.ParentPeerInterface) (onchain.Strategy, error) {
+			return cur, nil
+		},
+		ReadTVL: func(_ *helper.Config, _ cre.Runtime, _ onchain.YieldPeerInterface) (*big.Int, error) {
+			return big.NewInt(1_000_000), nil
+		},
+		GetOptimalAndCurrentStrategyWithAPY: func(_ *helper.Config, _ cre.Runtime, _ onchain.StrategySet, _ onchain.Strategy, _ *big.Int) (onchain.StrategyWithAPY, onchain.StrategyWithAPY, []onchain.StrategyWithAPY, error) {
+			current := onchain.StrategyWithAPY{Strategy: cur, APY: 0.03, Err: fmt.Errorf("reserve deficit of 1"), Unhealthy: true}
+			return onchain.StrategyWithAPY{Strategy: opt, APY: 0.10}, current, nil, nil
+		},
+		// Last move was one hour before the head block.
+		ReadStrategyHistory: func(_ *helper.Config, _ cre.Runtime, _ onchain.HeaderReaderInterface, _ onchain.ParentPeerInterface) (onchain.StrategyHistory, error) {
+			return onchain.StrategyHistory{
+				Updates:       []onchain.StrategyUpdate{{Strategy: cur, Timestamp: 100_000 - 3_600}},
+				HeadTimestamp: 100_000,
+			}, nil
+		},
+		NewRebalancerBinding: func(_ *evm.Client, _ string) (onchain.RebalancerInterface, error) {
+			return nil, nil
+		},
+		WriteRebalance: func(_ onchain.RebalancerInterface, _ cre.Runtime, _ uint64, _ onchain.Strategy) ([]byte, error) {
+			writeCalls++
+			return []byte{0xab}, nil
+		},
+	}
+
+	res, err := onCronTriggerWithDeps(config, runtime, newPayloadNow(), deps)
+
+	require.NoError(t, err)
+	require.True(t, res.Emergency)
+	require.True(t, res.Updated)
+	require.Equal(t, ReasonRebalanced, res.Reason)
+	require.Equal(t, 1, writeCalls)
+	require.NotNil(t, res.Cooldown)
+	require.False(t, res.Cooldown.Allowed, "the cooldown is reported even though it is not applied")
+}
+
+func Test_onCronTriggerWithDeps_errorWhen_ReadStrategyHistoryFails(t *testing.T) {
+	config := &helper.Config{
+		Evms: []helper.EvmConfig{
+			{
+				ChainName:        "parent-chain",
+				ChainSelector:    1,
+				YieldPeerAddress: "0xparent",
+				GasLimit:         500000,
+			},
+		},
+		Cooldown: helper.CooldownConfig{
+			Enabled:        true,
+			Budgets:        []helper.RebalanceBudget{{WindowSeconds: 7 * 24 * 60 * 60, MaxRebalances: 3}},
+			LookbackBlocks: 100,
+		},
+	}
+	runtime := testutils.NewRuntime(t, nil)
+
+	cur := onchain.Strategy{ProtocolId: [32]byte{1}, ChainSelector: 1}
+	opt := onchain.Strategy{ProtocolId: [32]byte{2}, ChainSelector: 1}
+
+	deps := OnCronDeps{
+		ReadBlock:                       noopReadBlock,
+		NewStrategyAdapterReaderBinding: noopNewStrategyAdapterReaderBinding,
+		ReadPreflightState:              passingReadPreflightState,
+		NewStrategySet: func(_ *helper.Config) (onchain.StrategySet, error) {
+			return onchain.StrategySet{}, nil
+		},
+		NewParentPeerBinding: func(_ *evm.Client, _ string) (onchain.ParentPeerInterface, error) {
+			return nil, nil
+		},
+		ReadCurrentStrategy: func(_ *helper.Config, _ cre.Runtime, _ onchain.ParentPeerInterface) (onchain.Strategy, error) {
+			return cur, nil
+		},
+		ReadTVL: func(_ *helper.Config, _ cre.Runtime, _ onchain.YieldPeerInterface) (*big.Int, error) {
+			return big.NewInt(1_000_000), nil
+		},
+		GetOptimalAndCurrentStrategyWithAPY: func(_ *helper.Config, _ cre.Runtime, _ onchain.StrategySet, _ onchain.Strategy, _ *big.Int) (onchain.StrategyWithAPY, onchain.StrategyWithAPY, []onchain.StrategyWithAPY, error) {
+			return onchain.StrategyWithAPY{Strategy: opt, APY: 0.10}, onchain.StrategyWithAPY{Strategy: cur, APY: 0.03}, nil, nil
+		},
+		ReadStrategyHistory: func(_ *helper.Config, _ cre.Runtime, _ onchain.HeaderReaderInterface, _ onchain.ParentPeerInterface) (onchain.StrategyHistory, error) {
+			return onchain.StrategyHistory{}, fmt.Errorf("filter logs failed")
+		},
+	}
+
+	res, err := onCronTriggerWithDeps(config, runtime, newPayloadNow(), deps)
+
+	require.Error(t, err)
+	require.Nil(t, res)
+	require.Contains(t, err.Error(), "failed to read strategy history from ParentPeer")
+}
+
+func Test_onCronTriggerWithDeps_success_dryRunReturnsPayloadWithoutWriting(t *testing.T) {
+	config := &helper.Config{
+		DryRun: true,
+		Evms: []helper.EvmConfig{
+			{
+				ChainName:        "parent-chain",
+				ChainSelector:    1,
+				YieldPeerAddress: "0xparent",
+				GasLimit:         500000,
+			},
+		},
+	}
+	runtime := testutils.NewRuntime(t, nil)
+
+	cur := onchain.Strategy{ProtocolId: onchain.AaveV3ProtocolId, ChainSelector: 1}
+	opt := onchain.Strategy{ProtocolId: onchain.CompoundV3ProtocolId, ChainSelector: 1}
+
+	deps := OnCronDeps{
+		ReadBlock:                       noopReadBlock,
+		NewStrategyAdapterReaderBinding: noopNewStrategyAdapterReaderBinding,
+		ReadPreflightState:              passingReadPreflightState,
+		NewStrategySet: func(_ *helper.Config) (onchain.StrategySet, error) {
+			return onchain.StrategySet{}, nil
+		},
+		NewParentPeerBinding: func(_ *evm.Client, _ string) (onchain.ParentPeerInterface, error) {
+			return nil, nil
+		},
+		ReadCurrentStrategy: func(_ *helper.Config, _ cre.Runtime, _ onchain.ParentPeerInterface) (onchain.Strategy, error) {
+			return cur, nil
+		},
+		ReadTVL: func(_ *helper.Config, _ cre.Runtime, _ onchain.YieldPeerInterface) (*big.Int, error) {
+			return big.NewInt(1_000_000), nil
+		},
+		GetOptimalAndCurrentStrategyWithAPY: func(_ *helper.Config, _ cre.Runtime, _ onchain.StrategySet, _ onchain.Strategy, _ *big.Int) (onchain.StrategyWithAPY, onchain.StrategyWithAPY, []onchain.StrategyWithAPY, error) {
+			return onchain.StrategyWithAPY{Strategy: opt, APY: 0.10}, onchain.StrategyWithAPY{Strategy: cur, APY: 0.03}, nil, nil
+		},
+		EncodeRebalance: onchain.EncodeRebalance,
+		// The Rebalancer binding is still needed for the pre-flight read.
+		NewRebalancerBinding: func(_ *evm.Client, _ string) (onchain.RebalancerInterface, error) {
+			return nil, nil
+		},
+		WriteRebalance: func(_ onchain.RebalancerInterface, _ cre.Runtime, _ uint64, _ onchain.Strategy) ([]byte, error) {
+			require.FailNow(t, "WriteRebalance should not be called in dry-run mode")
+			return nil, nil
+		},
+	}
+
+	res, err := onCronTriggerWithDeps(config, runtime, newPayloadNow(), deps)
+
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	require.False(t, res.Updated)
+	require.Equal(t, ReasonDryRun, res.Reason)
+	require.True(t, res.Threshold.Allowed)
+	require.Equal(t, opt, res.Optimal.Strategy)
+
+	expected, err := onchain.EncodeRebalance(opt)
+	require.NoError(t, err)
+	require.NotNil(t, res.Rebalance)
+	require.Equal(t, expected, *res.Rebalance)
+}
+
+func Test_onCronTriggerWithDeps_errorWhen_EncodeRebalanceFails(t *testing.T) {
+	config := &helper.Config{
+		DryRun: true,
+		Evms: []helper.EvmConfig{
+			{
+				ChainName:        "parent-chain",
+				ChainSelector:    1,
+				YieldPeerAddress: "0xparent",
+				GasLimit:         500000,
+			},
+		},
+	}
+	runtime := testutils.NewRuntime(t, nil)
+
+	cur := onchain.Strategy{ProtocolId: [32]byte{1}, ChainSelector: 1}
+	opt := onchain.Strategy{ProtocolId: [32]byte{2}, ChainSelector: 1}
+
+	deps := OnCronDeps{
+		ReadBlock:                       noopReadBlock,
+		NewStrategyAdapterReaderBinding: noopNewStrategyAdapterReaderBinding,
+		ReadPreflightState:              passingReadPreflightState,
+		NewStrategySet: func(_ *helper.Config) (onchain.StrategySet, error) {
+			return onchain.StrategySet{}, nil
+		},
+		NewParentPeerBinding: func(_ *evm.Client, _ string) (onchain.ParentPeerInterface, error) {
+			return nil, nil
+		},
+		ReadCurrentStrategy: func(_ *helper.Config, _ cre.Runtime, _ onchain.ParentPeerInterface) (onchain.Strategy, error) {
+			return cur, nil
+		},
+		ReadTVL: func(_ *helper.Config, _ cre.Runtime, _ onchain.YieldPeerInterface) (*big.Int, error) {
+			return big.NewInt(1_000_000), nil
+		},
+		GetOptimalAndCurrentStrategyWithAPY: func(_ *helper.Config, _ cre.Runtime, _ onchain.StrategySet, _ onchain.Strategy, _ *big.Int) (onchain.StrategyWithAPY, onchain.StrategyWithAPY, []onchain.StrategyWithAPY, error) {
+			return onchain.StrategyWithAPY{Strategy: opt, APY: 0.10}, onchain.StrategyWithAPY{Strategy: cur, APY: 0.03}, nil, nil
+		},
+		NewRebalancerBinding: func(_ *evm.Client, _ string) (onchain.RebalancerInterface, error) {
+			return nil, nil
+		},
+		EncodeRebalance: func(_ onchain.Strategy) (onchain.RebalancePayload, error) {
+			return onchain.RebalancePayload{}, fmt.Errorf("encode-failed")
+		},
+	}
+
+	res, err := onCronTriggerWithDeps(config, runtime, newPayloadNow(), deps)
+
+	require.Error(t, err)
+	require.Nil(t, res)
+	require.Contains(t, err.Error(), "failed to encode rebalance")
 }
 
 func Test_onCronTriggerWithDeps_errorWhen_RebalancerBindingFails(t *testing.T) {
@@ -474,8 +969,11 @@ func Test_onCronTriggerWithDeps_errorWhen_RebalancerBindingFails(t *testing.T) {
 	opt := onchain.Strategy{ProtocolId: [32]byte{2}, ChainSelector: 1}
 
 	deps := OnCronDeps{
-		InitSupportedStrategies: func(_ *helper.Config) error {
-			return nil
+		ReadBlock:                       noopReadBlock,
+		NewStrategyAdapterReaderBinding: noopNewStrategyAdapterReaderBinding,
+		ReadPreflightState:              passingReadPreflightState,
+		NewStrategySet: func(_ *helper.Config) (onchain.StrategySet, error) {
+			return onchain.StrategySet{}, nil
 		},
 		NewParentPeerBinding: func(_ *evm.Client, _ string) (onchain.ParentPeerInterface, error) {
 			return nil, nil
@@ -487,15 +985,15 @@ func Test_onCronTriggerWithDeps_errorWhen_RebalancerBindingFails(t *testing.T) {
 			return big.NewInt(1000), nil
 		},
 		// delta = 0.02 - 0.01 = 0.01 >= threshold(0.01)
-		GetOptimalAndCurrentStrategyWithAPY: func(_ *helper.Config, _ cre.Runtime, _ onchain.Strategy, _ *big.Int) (onchain.StrategyWithAPY, onchain.StrategyWithAPY, error) {
-			return onchain.StrategyWithAPY{Strategy: opt, APY: 0.02}, onchain.StrategyWithAPY{Strategy: cur, APY: 0.01}, nil
+		GetOptimalAndCurrentStrategyWithAPY: func(_ *helper.Config, _ cre.Runtime, _ onchain.StrategySet, _ onchain.Strategy, _ *big.Int) (onchain.StrategyWithAPY, onchain.StrategyWithAPY, []onchain.StrategyWithAPY, error) {
+			return onchain.StrategyWithAPY{Strategy: opt, APY: 0.02}, onchain.StrategyWithAPY{Strategy: cur, APY: 0.01}, nil, nil
 		},
 		NewRebalancerBinding: func(_ *evm.Client, _ string) (onchain.RebalancerInterface, error) {
 			return nil, fmt.Errorf("rebalancer-binding-failed")
 		},
-		WriteRebalance: func(_ onchain.RebalancerInterface, _ cre.Runtime, _ *slog.Logger, _ uint64, _ onchain.Strategy) error {
+		WriteRebalance: func(_ onchain.RebalancerInterface, _ cre.Runtime, _ uint64, _ onchain.Strategy) ([]byte, error) {
 			require.FailNow(t, "WriteRebalance should not be called when Rebalancer binding fails")
-			return nil
+			return nil, nil
 		},
 	}
 
@@ -522,8 +1020,11 @@ func Test_onCronTriggerWithDeps_errorWhen_WriteRebalanceFails(t *testing.T) {
 	opt := onchain.Strategy{ProtocolId: [32]byte{2}, ChainSelector: 1}
 
 	deps := OnCronDeps{
-		InitSupportedStrategies: func(_ *helper.Config) error {
-			return nil
+		ReadBlock:                       noopReadBlock,
+		NewStrategyAdapterReaderBinding: noopNewStrategyAdapterReaderBinding,
+		ReadPreflightState:              passingReadPreflightState,
+		NewStrategySet: func(_ *helper.Config) (onchain.StrategySet, error) {
+			return onchain.StrategySet{}, nil
 		},
 		NewParentPeerBinding: func(_ *evm.Client, _ string) (onchain.ParentPeerInterface, error) {
 			return nil, nil
@@ -535,14 +1036,14 @@ func Test_onCronTriggerWithDeps_errorWhen_WriteRebalanceFails(t *testing.T) {
 			return big.NewInt(1000), nil
 		},
 		// delta = 0.02 - 0.01 = 0.01 >= threshold(0.01)
-		GetOptimalAndCurrentStrategyWithAPY: func(_ *helper.Config, _ cre.Runtime, _ onchain.Strategy, _ *big.Int) (onchain.StrategyWithAPY, onchain.StrategyWithAPY, error) {
-			return onchain.StrategyWithAPY{Strategy: opt, APY: 0.02}, onchain.StrategyWithAPY{Strategy: cur, APY: 0.01}, nil
+		GetOptimalAndCurrentStrategyWithAPY: func(_ *helper.Config, _ cre.Runtime, _ onchain.StrategySet, _ onchain.Strategy, _ *big.Int) (onchain.StrategyWithAPY, onchain.StrategyWithAPY, []onchain.StrategyWithAPY, error) {
+			return onchain.StrategyWithAPY{Strategy: opt, APY: 0.02}, onchain.StrategyWithAPY{Strategy: cur, APY: 0.01}, nil, nil
 		},
 		NewRebalancerBinding: func(_ *evm.Client, _ string) (onchain.RebalancerInterface, error) {
 			return nil, nil
 		},
-		WriteRebalance: func(_ onchain.RebalancerInterface, _ cre.Runtime, _ *slog.Logger, _ uint64, _ onchain.Strategy) error {
-			return fmt.Errorf("rebalance-failed")
+		WriteRebalance: func(_ onchain.RebalancerInterface, _ cre.Runtime, _ uint64, _ onchain.Strategy) ([]byte, error) {
+			return nil, fmt.Errorf("rebalance-failed")
 		},
 	}
 
@@ -573,8 +1074,13 @@ func Test_onCronTriggerWithDeps_success_rebalanceWhenStrategyChanges_sameChain(t
 	var lastGasLimit uint64
 
 	deps := OnCronDeps{
-		InitSupportedStrategies: func(_ *helper.Config) error {
-			return nil
+		NewStrategyAdapterReaderBinding: noopNewStrategyAdapterReaderBinding,
+		ReadPreflightState:              passingReadPreflightState,
+		ReadBlock: func(_ *helper.Config, _ cre.Runtime, _ onchain.HeaderReaderInterface) (onchain.Block, error) {
+			return onchain.Block{Number: 123, Timestamp: 456}, nil
+		},
+		NewStrategySet: func(_ *helper.Config) (onchain.StrategySet, error) {
+			return onchain.StrategySet{}, nil
 		},
 		NewParentPeerBinding: func(_ *evm.Client, _ string) (onchain.ParentPeerInterface, error) {
 			return nil, nil
@@ -586,17 +1092,20 @@ func Test_onCronTriggerWithDeps_success_rebalanceWhenStrategyChanges_sameChain(t
 			return big.NewInt(1000), nil
 		},
 		// delta = 0.02 - 0.01 = 0.01 >= threshold(0.01)
-		GetOptimalAndCurrentStrategyWithAPY: func(_ *helper.Config, _ cre.Runtime, _ onchain.Strategy, _ *big.Int) (onchain.StrategyWithAPY, onchain.StrategyWithAPY, error) {
-			return onchain.StrategyWithAPY{Strategy: opt, APY: 0.02}, onchain.StrategyWithAPY{Strategy: cur, APY: 0.01}, nil
+		GetOptimalAndCurrentStrategyWithAPY: func(_ *helper.Config, _ cre.Runtime, _ onchain.StrategySet, _ onchain.Strategy, _ *big.Int) (onchain.StrategyWithAPY, onchain.StrategyWithAPY, []onchain.StrategyWithAPY, error) {
+			return onchain.StrategyWithAPY{Strategy: opt, APY: 0.02}, onchain.StrategyWithAPY{Strategy: cur, APY: 0.01}, []onchain.StrategyWithAPY{
+				{Strategy: cur, APY: 0.01},
+				{Strategy: opt, APY: 0.02},
+			}, nil
 		},
 		NewRebalancerBinding: func(_ *evm.Client, _ string) (onchain.RebalancerInterface, error) {
 			return nil, nil
 		},
-		WriteRebalance: func(_ onchain.RebalancerInterface, _ cre.Runtime, _ *slog.Logger, gasLimit uint64, optimal onchain.Strategy) error {
+		WriteRebalance: func(_ onchain.RebalancerInterface, _ cre.Runtime, gasLimit uint64, optimal onchain.Strategy) ([]byte, error) {
 			writeCalls++
 			lastGasLimit = gasLimit
 			lastOptimal = optimal
-			return nil
+			return []byte{0xab, 0xcd}, nil
 		},
 	}
 
@@ -608,8 +1117,79 @@ func Test_onCronTriggerWithDeps_success_rebalanceWhenStrategyChanges_sameChain(t
 	require.Equal(t, 1, writeCalls)
 	require.Equal(t, opt, lastOptimal)
 	require.Equal(t, config.Evms[0].GasLimit, lastGasLimit)
-	require.Equal(t, cur, res.Current)
-	require.Equal(t, opt, res.Optimal)
+	require.Equal(t, cur, res.Current.Strategy)
+	require.Equal(t, opt, res.Optimal.Strategy)
+	require.NotNil(t, res.Threshold)
+	require.True(t, res.Threshold.Allowed)
+	require.Equal(t, policy.RuleBuiltin, res.Threshold.Rule)
+
+	require.Equal(t, ReasonRebalanced, res.Reason)
+	require.Equal(t, []byte{0xab, 0xcd}, []byte(res.TxHash))
+	require.Equal(t, big.NewInt(1000), res.TVL)
+	require.InDelta(t, 0.01, res.APYDelta, 1e-12)
+	require.Equal(t, onchain.Block{Number: 123, Timestamp: 456}, res.ParentBlock)
+	require.Equal(t, []Candidate{
+		{Strategy: named(cur), ChainName: "parent-chain", APY: 0.01, ProjectedAnnualYieldUSD: 0.001 * 0.01},
+		{Strategy: named(opt), ChainName: "parent-chain", APY: 0.02, ProjectedAnnualYieldUSD: 0.001 * 0.02},
+	}, res.Candidates)
+}
+
+func Test_onCronTriggerWithDeps_success_emergencyExitIgnoresThreshold(t *testing.T) {
+	config := &helper.Config{
+		Evms: []helper.EvmConfig{{
+			ChainName:         "parent-chain",
+			ChainSelector:     1,
+			YieldPeerAddress:  "0xparent",
+			RebalancerAddress: "0xrebalancer",
+			GasLimit:          500000,
+		}},
+		Cost: helper.CostConfig{Enabled: true, HorizonDays: 1},
+	}
+	runtime := testutils.NewRuntime(t, nil)
+
+	cur := onchain.Strategy{ProtocolId: [32]byte{1}, ChainSelector: 1}
+	opt := onchain.Strategy{ProtocolId: [32]byte{2}, ChainSelector: 1}
+
+	writeCalls := 0
+	deps := OnCronDeps{
+		ReadBlock:                       noopReadBlock,
+		NewStrategyAdapterReaderBinding: noopNewStrategyAdapterReaderBinding,
+		ReadPreflightState:              passingReadPreflightState,
+		NewStrategySet: func(_ *helper.Config) (onchain.StrategySet, error) {
+			return onchain.StrategySet{}, nil
+		},
+		NewParentPeerBinding: func(_ *evm.Client, _ string) (onchain.ParentPeerInterface, error) {
+			return nil, nil
+		},
+		ReadCurrentStrategy: func(_ *helper.Config, _ cre.Runtime, _ onchain.ParentPeerInterface) (onchain.Strategy, error) {
+			return cur, nil
+		},
+		ReadTVL: func(_ *helper.Config, _ cre.Runtime, _ onchain.YieldPeerInterface) (*big.Int, error) {
+			return big.NewInt(1000), nil
+		},
+		// The optimal is worse than the current strategy, which is paused.
+		GetOptimalAndCurrentStrategyWithAPY: func(_ *helper.Config, _ cre.Runtime, _ onchain.StrategySet, _ onchain.Strategy, _ *big.Int) (onchain.StrategyWithAPY, onchain.StrategyWithAPY, []onchain.StrategyWithAPY, error) {
+			current := onchain.StrategyWithAPY{Strategy: cur, APY: 0.05, Err: fmt.Errorf("reserve paused"), Unhealthy: true}
+			return onchain.StrategyWithAPY{Strategy: opt, APY: 0.02}, current, nil, nil
+		},
+		NewRebalancerBinding: func(_ *evm.Client, _ string) (onchain.RebalancerInterface, error) {
+			return nil, nil
+		},
+		WriteRebalance: func(_ onchain.RebalancerInterface, _ cre.Runtime, _ uint64, _ onchain.Strategy) ([]byte, error) {
+			writeCalls++
+			return []byte{0xab}, nil
+		},
+	}
+
+	res, err := onCronTriggerWithDeps(config, runtime, newPayloadNow(), deps)
+
+	require.NoError(t, err)
+	require.True(t, res.Emergency)
+	require.True(t, res.Updated)
+	require.Equal(t, 1, writeCalls)
+	require.Equal(t, ReasonRebalanced, res.Reason)
+	require.False(t, res.Threshold.Allowed, "the threshold is reported even though it is not applied")
+	require.False(t, res.Cost.Worthwhile, "the cost estimate is reported even though it is not applied")
 }
 
 func Test_onCronTriggerWithDeps_success_rebalanceWhenStrategyChanges_differentChain(t *testing.T) {
@@ -640,8 +1220,11 @@ func Test_onCronTriggerWithDeps_success_rebalanceWhenStrategyChanges_differentCh
 	var lastGasLimit uint64
 
 	deps := OnCronDeps{
-		InitSupportedStrategies: func(_ *helper.Config) error {
-			return nil
+		ReadBlock:                       noopReadBlock,
+		NewStrategyAdapterReaderBinding: noopNewStrategyAdapterReaderBinding,
+		ReadPreflightState:              passingReadPreflightState,
+		NewStrategySet: func(_ *helper.Config) (onchain.StrategySet, error) {
+			return onchain.StrategySet{}, nil
 		},
 		NewParentPeerBinding: func(_ *evm.Client, _ string) (onchain.ParentPeerInterface, error) {
 			return nil, nil
@@ -656,17 +1239,17 @@ func Test_onCronTriggerWithDeps_success_rebalanceWhenStrategyChanges_differentCh
 			return big.NewInt(1000), nil
 		},
 		// delta = 0.03 - 0.01 = 0.02 >= threshold(0.01)
-		GetOptimalAndCurrentStrategyWithAPY: func(_ *helper.Config, _ cre.Runtime, _ onchain.Strategy, _ *big.Int) (onchain.StrategyWithAPY, onchain.StrategyWithAPY, error) {
-			return onchain.StrategyWithAPY{Strategy: opt, APY: 0.03}, onchain.StrategyWithAPY{Strategy: cur, APY: 0.01}, nil
+		GetOptimalAndCurrentStrategyWithAPY: func(_ *helper.Config, _ cre.Runtime, _ onchain.StrategySet, _ onchain.Strategy, _ *big.Int) (onchain.StrategyWithAPY, onchain.StrategyWithAPY, []onchain.StrategyWithAPY, error) {
+			return onchain.StrategyWithAPY{Strategy: opt, APY: 0.03}, onchain.StrategyWithAPY{Strategy: cur, APY: 0.01}, nil, nil
 		},
 		NewRebalancerBinding: func(_ *evm.Client, _ string) (onchain.RebalancerInterface, error) {
 			return nil, nil
 		},
-		WriteRebalance: func(_ onchain.RebalancerInterface, _ cre.Runtime, _ *slog.Logger, gasLimit uint64, optimal onchain.Strategy) error {
+		WriteRebalance: func(_ onchain.RebalancerInterface, _ cre.Runtime, gasLimit uint64, optimal onchain.Strategy) ([]byte, error) {
 			writeCalls++
 			lastGasLimit = gasLimit
 			lastOptimal = optimal
-			return nil
+			return nil, nil
 		},
 	}
 
@@ -678,8 +1261,76 @@ func Test_onCronTriggerWithDeps_success_rebalanceWhenStrategyChanges_differentCh
 	require.Equal(t, 1, writeCalls)
 	require.Equal(t, opt, lastOptimal)
 	require.Equal(t, config.Evms[1].GasLimit, lastGasLimit)
-	require.Equal(t, cur, res.Current)
-	require.Equal(t, opt, res.Optimal)
+	require.Equal(t, cur, res.Current.Strategy)
+	require.Equal(t, opt, res.Optimal.Strategy)
+}
+
+func Test_onCronTriggerWithDeps_success_costsTheGasLimitTheReportIsWrittenWith(t *testing.T) {
+	config := &helper.Config{
+		Evms: []helper.EvmConfig{
+			{
+				ChainName:           "parent-chain",
+				ChainSelector:       1,
+				YieldPeerAddress:    "0xparent",
+				RebalancerAddress:   "0xrebalancer",
+				GasLimit:            500000,
+				GasPriceGwei:        10,
+				NativeTokenPriceUSD: 2_000,
+			},
+			{
+				ChainName:        "child-chain",
+				ChainSelector:    2,
+				YieldPeerAddress: "0xchild",
+				GasLimit:         2_000_000,
+			},
+		},
+		Cost: helper.CostConfig{Enabled: true, HorizonDays: 365},
+	}
+	runtime := testutils.NewRuntime(t, nil)
+
+	cur := onchain.Strategy{ProtocolId: [32]byte{1}, ChainSelector: 2}
+	opt := onchain.Strategy{ProtocolId: [32]byte{2}, ChainSelector: 1}
+
+	var lastGasLimit uint64
+	deps := OnCronDeps{
+		ReadBlock:                       noopReadBlock,
+		NewStrategyAdapterReaderBinding: noopNewStrategyAdapterReaderBinding,
+		ReadPreflightState:              passingReadPreflightState,
+		NewStrategySet: func(_ *helper.Config) (onchain.StrategySet, error) {
+			return onchain.StrategySet{}, nil
+		},
+		NewParentPeerBinding: func(_ *evm.Client, _ string) (onchain.ParentPeerInterface, error) {
+			return nil, nil
+		},
+		NewChildPeerBinding: func(_ *evm.Client, _ string) (onchain.YieldPeerInterface, error) {
+			return nil, nil
+		},
+		ReadCurrentStrategy: func(_ *helper.Config, _ cre.Runtime, _ onchain.ParentPeerInterface) (onchain.Strategy, error) {
+			return cur, nil
+		},
+		// 1M USDC
+		ReadTVL: func(_ *helper.Config, _ cre.Runtime, _ onchain.YieldPeerInterface) (*big.Int, error) {
+			return big.NewInt(1_000_000_000_000), nil
+		},
+		GetOptimalAndCurrentStrategyWithAPY: func(_ *helper.Config, _ cre.Runtime, _ onchain.StrategySet, _ onchain.Strategy, _ *big.Int) (onchain.StrategyWithAPY, onchain.StrategyWithAPY, []onchain.StrategyWithAPY, error) {
+			return onchain.StrategyWithAPY{Strategy: opt, APY: 0.03}, onchain.StrategyWithAPY{Strategy: cur, APY: 0.01}, nil, nil
+		},
+		NewRebalancerBinding: func(_ *evm.Client, _ string) (onchain.RebalancerInterface, error) {
+			return nil, nil
+		},
+		WriteRebalance: func(_ onchain.RebalancerInterface, _ cre.Runtime, gasLimit uint64, _ onchain.Strategy) ([]byte, error) {
+			lastGasLimit = gasLimit
+			return nil, nil
+		},
+	}
+
+	res, err := onCronTriggerWithDeps(config, runtime, newPayloadNow(), deps)
+
+	require.NoError(t, err)
+	require.True(t, res.Updated)
+	require.Equal(t, config.Evms[1].GasLimit, lastGasLimit)
+	// The child's 2M gas limit at the parent's prices: 2M * 10 gwei * $2000 = $40.
+	require.InDelta(t, 40.0, res.Cost.GasUSD, 1e-9)
 }
 
 /*//////////////////////////////////////////////////////////////
@@ -697,3 +1348,249 @@ func Test_InitWorkflow_setsUpCronHandler(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, wf, 1)
 }
+
+func Test_InitWorkflow_errorWhen_cooldownLookbackTooShort(t *testing.T) {
+	config := &helper.Config{
+		Schedule: "0 */1 * * * *",
+		Cooldown: helper.CooldownConfig{
+			Enabled:          true,
+			Budgets:          []helper.RebalanceBudget{{WindowSeconds: 604800, MaxRebalances: 3}},
+			LookbackBlocks:   7200,
+			BlockTimeSeconds: 12,
+		},
+	}
+	logger := testutils.NewRuntime(t, nil).Logger()
+
+	wf, err := InitWorkflow(config, logger, nil)
+
+	require.Nil(t, wf)
+	require.ErrorContains(t, err, "invalid cooldown config")
+}
+
+func Test_InitWorkflow_errorWhen_costHorizonNotPositive(t *testing.T) {
+	config := &helper.Config{
+		Schedule: "0 */1 * * * *",
+		Cost:     helper.CostConfig{Enabled: true},
+	}
+	logger := testutils.NewRuntime(t, nil).Logger()
+
+	wf, err := InitWorkflow(config, logger, nil)
+
+	require.Nil(t, wf)
+	require.ErrorContains(t, err, "invalid cost config: cost horizonDays must be greater than zero")
+}
+
+func Test_newCandidates_reportsExcludedCandidateError(t *testing.T) {
+	evms := []helper.EvmConfig{{ChainName: "parent-chain", ChainSelector: 1}}
+	ok := onchain.Strategy{ProtocolId: [32]byte{1}, ChainSelector: 1}
+	failed := onchain.Strategy{ProtocolId: [32]byte{2}, ChainSelector: 1}
+
+	candidates := newCandidates(evms, onchain.StrategySet{}, []onchain.StrategyWithAPY{
+		{Strategy: ok, APY: 0.05},
+		{Strategy: failed, Err: fmt.Errorf("rpc unavailable")},
+	}, big.NewInt(1_000_000), 6)
+
+	require.Equal(t, []Candidate{
+		{Strategy: named(ok), ChainName: "parent-chain", APY: 0.05, ProjectedAnnualYieldUSD: 0.05},
+		{Strategy: named(failed), ChainName: "parent-chain", Error: "rpc unavailable"},
+	}, candidates)
+}
+
+func Test_newCandidates_reportsInfeasibleCandidate(t *testing.T) {
+	evms := []helper.EvmConfig{{ChainName: "parent-chain", ChainSelector: 1}}
+	strategy := onchain.Strategy{ProtocolId: [32]byte{1}, ChainSelector: 1}
+
+	candidates := newCandidates(evms, onchain.StrategySet{}, []onchain.StrategyWithAPY{
+		{Strategy: strategy, Err: fmt.Errorf("supply cap reached"), Infeasible: true},
+	}, big.NewInt(1_000_000), 6)
+
+	require.Equal(t, []Candidate{
+		{Strategy: named(strategy), ChainName: "parent-chain", Error: "supply cap reached", Infeasible: true},
+	}, candidates)
+}
+
+func Test_newCandidates_reportsLiquidityMargin(t *testing.T) {
+	evms := []helper.EvmConfig{{ChainName: "parent-chain", ChainSelector: 1}}
+	strategy := onchain.Strategy{ProtocolId: [32]byte{1}, ChainSelector: 1}
+
+	candidates := newCandidates(evms, onchain.StrategySet{}, []onchain.StrategyWithAPY{
+		{Strategy: strategy, APY: 0.05, LiquidityMargin: 3.5},
+	}, big.NewInt(1_000_000), 6)
+
+	require.Equal(t, 3.5, candidates[0].LiquidityMargin)
+}
+
+func Test_newCandidates_reportsUnhealthyCandidate(t *testing.T) {
+	evms := []helper.EvmConfig{{ChainName: "parent-chain", ChainSelector: 1}}
+	strategy := onchain.Strategy{ProtocolId: [32]byte{1}, ChainSelector: 1}
+
+	candidates := newCandidates(evms, onchain.StrategySet{}, []onchain.StrategyWithAPY{
+		{Strategy: strategy, APY: 0.05, Err: fmt.Errorf("reserve frozen"), Unhealthy: true},
+	}, big.NewInt(1_000_000), 6)
+
+	require.Equal(t, []Candidate{
+		{Strategy: named(strategy), ChainName: "parent-chain", APY: 0.05, ProjectedAnnualYieldUSD: 0.05, Error: "reserve frozen", Unhealthy: true},
+	}, candidates)
+}
+
+func Test_newCandidates_reportsRewardAPRSeparately(t *testing.T) {
+	evms := []helper.EvmConfig{{ChainName: "parent-chain", ChainSelector: 1}}
+	strategy := onchain.Strategy{ProtocolId: [32]byte{1}, ChainSelector: 1}
+
+	candidates := newCandidates(evms, onchain.StrategySet{}, []onchain.StrategyWithAPY{
+		{Strategy: strategy, APY: 0.05, BaseAPY: 0.03, RewardAPR: 0.02},
+	}, big.NewInt(1_000_000), 6)
+
+	require.Equal(t, []Candidate{
+		{Strategy: named(strategy), ChainName: "parent-chain", APY: 0.05, BaseAPY: 0.03, RewardAPR: 0.02, ProjectedAnnualYieldUSD: 0.05},
+	}, candidates)
+}
+
+func Test_onCronTriggerWithDeps_preflightFailureSkipsWrite(t *testing.T) {
+	config := newFlowTestConfig(0)
+	runtime := testutils.NewRuntime(t, nil)
+
+	evaluations := 0
+	deps := newFlowTestDeps(&evaluations)
+	deps.ReadPreflightState = func(config *helper.Config, runtime cre.Runtime, parentPeer onchain.ParentPeerInterface, targetPeer onchain.StrategyAdapterReaderInterface, rb onchain.RebalancerInterface, strategy onchain.Strategy) (onchain.PreflightState, error) {
+		state, err := passingReadPreflightState(config, runtime, parentPeer, targetPeer, rb, strategy)
+		state.ParentPaused = true
+		return state, err
+	}
+	deps.WriteRebalance = func(_ onchain.RebalancerInterface, _ cre.Runtime, _ uint64, _ onchain.Strategy) ([]byte, error) {
+		require.FailNow(t, "WriteRebalance should not be called when a pre-flight check fails")
+		return nil, nil
+	}
+
+	res, err := onCronTriggerWithDeps(config, runtime, newPayloadNow(), deps)
+
+	require.NoError(t, err)
+	require.Equal(t, ReasonPreflightFailed, res.Reason)
+	require.False(t, res.Updated)
+	require.NotNil(t, res.Preflight)
+	require.False(t, res.Preflight.Passed)
+	require.Equal(t, policy.PreflightParentPaused, res.Preflight.Check)
+}
+
+func Test_onCronTriggerWithDeps_errorWhen_ReadPreflightStateFails(t *testing.T) {
+	config := newFlowTestConfig(0)
+	runtime := testutils.NewRuntime(t, nil)
+
+	evaluations := 0
+	deps := newFlowTestDeps(&evaluations)
+	deps.ReadPreflightState = func(_ *helper.Config, _ cre.Runtime, _ onchain.ParentPeerInterface, _ onchain.StrategyAdapterReaderInterface, _ onchain.RebalancerInterface, _ onchain.Strategy) (onchain.PreflightState, error) {
+		return onchain.PreflightState{}, fmt.Errorf("preflight-failed")
+	}
+
+	res, err := onCronTriggerWithDeps(config, runtime, newPayloadNow(), deps)
+
+	require.Error(t, err)
+	require.Nil(t, res)
+	require.Contains(t, err.Error(), "failed to read pre-flight state: preflight-failed")
+}
+
+func Test_onCronTriggerWithDeps_buildsStrategySetEveryRun(t *testing.T) {
+	config := newFlowTestConfig(0)
+	config.Evms[0].AaveV3PoolAddressesProviderAddress = "0xaave"
+	runtime := testutils.NewRuntime(t, nil)
+
+	evaluations := 0
+	deps := newFlowTestDeps(&evaluations)
+	deps.NewStrategySet = onchain.NewStrategySet
+
+	var got [][]onchain.Strategy
+	evaluate := deps.GetOptimalAndCurrentStrategyWithAPY
+	deps.GetOptimalAndCurrentStrategyWithAPY = func(config *helper.Config, runtime cre.Runtime, strategies onchain.StrategySet, current onchain.Strategy, tvl *big.Int) (onchain.StrategyWithAPY, onchain.StrategyWithAPY, []onchain.StrategyWithAPY, error) {
+		got = append(got, strategies.Strategies())
+		return evaluate(config, runtime, strategies, current, tvl)
+	}
+
+	for range 2 {
+		_, err := onCronTriggerWithDeps(config, runtime, newPayloadNow(), deps)
+		require.NoError(t, err)
+	}
+
+	want := []onchain.Strategy{{ProtocolId: onchain.AaveV3ProtocolId, ChainSelector: 1}}
+	require.Equal(t, [][]onchain.Strategy{want, want}, got)
+}
+
+func Test_onCronTriggerWithDeps_namesConfigDefinedProtocols(t *testing.T) {
+	config := newFlowTestConfig(0)
+	config.Evms[0].AaveV3PoolAddressesProviderAddress = "0xaave"
+	config.Evms[0].Erc4626Vaults = []helper.Erc4626VaultConfig{
+		{Name: "susds", Address: "0x00000000000000000000000000000000000000aa", LookbackBlocks: 7200},
+	}
+	runtime := testutils.NewRuntime(t, nil)
+
+	susds := onchain.Strategy{ProtocolId: protocol.IDFromName("susds"), ChainSelector: 1}
+	aave := onchain.Strategy{ProtocolId: onchain.AaveV3ProtocolId, ChainSelector: 1}
+
+	evaluations := 0
+	deps := newFlowTestDeps(&evaluations)
+	deps.NewStrategySet = onchain.NewStrategySet
+	deps.ReadCurrentStrategy = func(_ *helper.Config, _ cre.Runtime, _ onchain.ParentPeerInterface) (onchain.Strategy, error) {
+		return susds, nil
+	}
+	deps.GetOptimalAndCurrentStrategyWithAPY = func(_ *helper.Config, _ cre.Runtime, _ onchain.StrategySet, _ onchain.Strategy, _ *big.Int) (onchain.StrategyWithAPY, onchain.StrategyWithAPY, []onchain.StrategyWithAPY, error) {
+		current := onchain.StrategyWithAPY{Strategy: susds, APY: 0.03}
+		optimal := onchain.StrategyWithAPY{Strategy: aave, APY: 0.08}
+		return optimal, current, []onchain.StrategyWithAPY{optimal, current}, nil
+	}
+
+	res, err := onCronTriggerWithDeps(config, runtime, newPayloadNow(), deps)
+	require.NoError(t, err)
+
+	require.Equal(t, "susds", res.Current.Protocol)
+	require.Equal(t, "aave-v3", res.Optimal.Protocol)
+	require.Equal(t, "susds", res.Candidates[1].Strategy.Protocol)
+
+	data, err := json.Marshal(res)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"protocol":"susds"`)
+}
+
+func Test_onCronTriggerWithDeps_onChainDiscoveryNarrowsCandidates(t *testing.T) {
+	config := newFlowTestConfig(0)
+	config.Discovery.Mode = helper.DiscoveryModeOnChain
+	runtime := testutils.NewRuntime(t, nil)
+
+	kept := onchain.Strategy{ProtocolId: [32]byte{1}, ChainSelector: 1}
+	dropped := onchain.ExcludedStrategy{
+		Strategy: named(onchain.Strategy{ProtocolId: [32]byte{2}, ChainSelector: 1}),
+		Reason:   onchain.ExcludedProtocolUnsupported,
+	}
+
+	evaluations := 0
+	deps := newFlowTestDeps(&evaluations)
+	deps.NewStrategySet = func(_ *helper.Config) (onchain.StrategySet, error) {
+		return onchain.NewStrategySetOf(kept, dropped.Strategy.Strategy), nil
+	}
+	deps.DiscoverStrategies = func(_ *helper.Config, _ cre.Runtime, configured onchain.StrategySet, _ onchain.ParentPeerInterface, peers map[uint64]onchain.StrategyAdapterReaderInterface) (onchain.StrategyDiscovery, error) {
+		require.Equal(t, 2, configured.Len())
+		require.Contains(t, peers, uint64(1))
+		return onchain.StrategyDiscovery{Strategies: onchain.NewStrategySetOf(kept), Excluded: []onchain.ExcludedStrategy{dropped}}, nil
+	}
+	deps.GetOptimalAndCurrentStrategyWithAPY = func(_ *helper.Config, _ cre.Runtime, strategies onchain.StrategySet, current onchain.Strategy, _ *big.Int) (onchain.StrategyWithAPY, onchain.StrategyWithAPY, []onchain.StrategyWithAPY, error) {
+		require.Equal(t, []onchain.Strategy{kept}, strategies.Strategies())
+		return onchain.StrategyWithAPY{Strategy: current, APY: 0.05}, onchain.StrategyWithAPY{Strategy: current, APY: 0.05}, nil, nil
+	}
+
+	res, err := onCronTriggerWithDeps(config, runtime, newPayloadNow(), deps)
+
+	require.NoError(t, err)
+	require.Equal(t, ReasonUnchanged, res.Reason)
+	require.Equal(t, []onchain.ExcludedStrategy{dropped}, res.Excluded)
+}
+
+func Test_onCronTriggerWithDeps_errorWhen_discoveryModeUnknown(t *testing.T) {
+	config := newFlowTestConfig(0)
+	config.Discovery.Mode = "registry"
+	runtime := testutils.NewRuntime(t, nil)
+
+	evaluations := 0
+	res, err := onCronTriggerWithDeps(config, runtime, newPayloadNow(), newFlowTestDeps(&evaluations))
+
+	require.Error(t, err)
+	require.Nil(t, res)
+	require.Contains(t, err.Error(), `unknown strategy discovery mode "registry"`)
+}